@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/anthonyme00/gomarkdoc/format"
+)
+
+// changelogPrefix marks the comments summarizing the changes to the symbol
+// sections of a regenerated file, so they can be replaced on the next run.
+const changelogPrefix = "gomarkdoc-changes:"
+
+var changelogRegex = regexp.MustCompile(fmt.Sprintf(`\n*[^\n]*%s[^\n]*`, regexp.QuoteMeta(changelogPrefix)))
+
+// stripChangelog removes the lines holding a changelog from the text, along
+// with any blank lines preceding them.
+func stripChangelog(text string) string {
+	return changelogRegex.ReplaceAllString(text, "")
+}
+
+// section holds the text of the documentation of a single symbol, starting at
+// its anchor and ending at the next one.
+type section struct {
+	anchor string
+	text   string
+}
+
+// appendChangelog compares the text about to be written to the file with its
+// current contents and appends comments listing the symbols whose sections
+// were added, removed or modified, to make large diffs of the documentation
+// easier to review. Sections are found using the anchors of the format, so
+// formats without anchors or comments get no changelog. The changelog of the
+// previous run is replaced, and it is left out entirely if the file doesn't
+// exist yet or no sections changed.
+func appendChangelog(f format.Format, fileName, text string) (string, error) {
+	commenter, ok := f.(format.CommentRenderer)
+	if !ok {
+		return text, nil
+	}
+
+	prev, err := os.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return text, nil
+	} else if err != nil {
+		return "", fmt.Errorf("gomarkdoc: failed to read previous contents of %s: %w", fileName, err)
+	}
+
+	anchorRegex, ok := anchorPattern(f)
+	if !ok {
+		return text, nil
+	}
+
+	oldSections := splitSections(anchorRegex, stripMetadata(stripChangelog(string(prev))))
+	newSections := splitSections(anchorRegex, stripMetadata(text))
+
+	oldText := make(map[string]string, len(oldSections))
+	for _, s := range oldSections {
+		oldText[s.anchor] = s.text
+	}
+
+	newText := make(map[string]string, len(newSections))
+	for _, s := range newSections {
+		newText[s.anchor] = s.text
+	}
+
+	var added, removed, modified []string
+	for _, s := range newSections {
+		old, ok := oldText[s.anchor]
+		switch {
+		case !ok:
+			added = append(added, s.anchor)
+		case old != s.text:
+			modified = append(modified, s.anchor)
+		}
+	}
+
+	for _, s := range oldSections {
+		if _, ok := newText[s.anchor]; !ok {
+			removed = append(removed, s.anchor)
+		}
+	}
+
+	var lines []string
+	for _, change := range []struct {
+		kind    string
+		symbols []string
+	}{
+		{"added", added},
+		{"removed", removed},
+		{"modified", modified},
+	} {
+		if len(change.symbols) == 0 {
+			continue
+		}
+
+		line, err := commenter.Comment(fmt.Sprintf("%s %s %s", changelogPrefix, change.kind, strings.Join(change.symbols, ", ")))
+		if err != nil {
+			return "", err
+		}
+
+		if line == "" {
+			return text, nil
+		}
+
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 {
+		return text, nil
+	}
+
+	return fmt.Sprintf("%s\n\n%s\n", strings.TrimRight(text, "\n"), strings.Join(lines, "\n")), nil
+}
+
+// anchorPattern builds a regular expression matching the anchors of the
+// format, capturing the name of the anchor. The second return value is false
+// if the format doesn't produce anchors.
+func anchorPattern(f format.Format) (*regexp.Regexp, bool) {
+	const placeholder = "GOMARKDOCANCHOR"
+
+	before, after, ok := strings.Cut(f.Anchor(placeholder), placeholder)
+	if !ok {
+		return nil, false
+	}
+
+	return regexp.MustCompile(fmt.Sprintf(`%s(.+?)%s`, regexp.QuoteMeta(before), regexp.QuoteMeta(after))), true
+}
+
+// splitSections splits the text into the sections of each anchor in the
+// order they appear. Text before the first anchor is left out.
+func splitSections(anchorRegex *regexp.Regexp, text string) []section {
+	matches := anchorRegex.FindAllStringSubmatchIndex(text, -1)
+
+	sections := make([]section, len(matches))
+	for i, m := range matches {
+		end := len(text)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+
+		sections[i] = section{html.UnescapeString(text[m[2]:m[3]]), text[m[0]:end]}
+	}
+
+	return sections
+}
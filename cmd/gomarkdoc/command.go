@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"container/list"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"path/filepath"
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -41,12 +43,15 @@ type PackageSpec struct {
 	isWildcard bool
 	isLocal    bool
 	outputFile string
+	symbol     string
+	routes     map[lang.Section]string
 	pkg        *lang.Package
 }
 
 type commandOptions struct {
 	repository            lang.Repo
 	output                string
+	routes                map[string]string
 	header                string
 	headerFile            string
 	footer                string
@@ -56,18 +61,115 @@ type commandOptions struct {
 	excludeDirs           []string
 	templateOverrides     map[string]string
 	templateFileOverrides map[string]string
+	headings              map[string]string
+	numberedHeadings      bool
+	deepHeadings          string
 	verbosity             int
 	includeUnexported     bool
+	includeTestHelpers    bool
+	includeXTestHelpers   bool
 	check                 bool
 	embed                 bool
 	version               bool
 	fileOnly              bool
 	file                  string
 	overrideImportPath    string
+	badges                bool
+	internal              string
+	statsOutput           string
+	hideDeprecated        bool
+	includeSymbols        []string
+	excludeSymbols        []string
+	hideMethods           []string
+	symbolFilter          *lang.SymbolFilter
+	deprecatedOutput      string
+	gettingStartedOutput  string
+	noTypeLinks           bool
+	indexFields           bool
+	changelog             bool
+	outputTar             string
+	recursive             bool
+	indexOutput           string
+	importGraph           string
+	importGraphOutput     string
+	manifest              string
+	outputHashes          map[string]string
+	redirects             string
+	redirectsFormat       string
+	toc                   bool
+	watch                 bool
+	regenerate            map[string]bool
+	tar                   *tarOutput
+	exampleTitles         string
+	exampleOrder          string
+	symbolOrder           string
+	checkExamples         bool
+	strictExamples        bool
+	runExamples           bool
+	cAPI                  bool
+	buildInfo             bool
+	benchmarks            bool
+	fuzzTargets           bool
+	classDiagram          bool
+	iotaValues            bool
+	computedValues        bool
+	funcBodies            bool
+	pinCommit             bool
+	buildTargets          bool
+	platforms             []string
+	safeTemplates         bool
+	frontMatter           map[string]string
+	frontMatterSyntax     string
+	metadata              bool
+	metadataTimestamp     bool
+	extractStrings        string
+	translations          string
+	translationStrings    map[string]string
+	stringCatalog         *lang.StringCatalog
+	symbolIndex           *lang.SymbolIndex
+	symbolAliases         string
+	symbolAliasMap        map[string]string
+	importURLs            map[string]string
+	importURLResolver     *lang.ImportURLResolver
+	report                string
+	checkDiff             string
+	postprocess           []string
+	postprocessFailure    string
+	redact                []string
+	redactionReport       string
+	redactor              *redactor
+	flattenEmbedded       bool
+	promotedMethods       bool
+	implementers          bool
+	implements            bool
+	importers             bool
+	offline               bool
+	networkTimeout        time.Duration
+	networkRetries        int
+	networkRate           float64
+	network               *networkClient
+	publish               bool
+	playgroundLinks       bool
+	playgroundSharer      lang.PlaygroundSharer
+	publishTargets        []publishTarget
 }
 
 var version = "v1.0.1"
 
+// Valid modes for handling internal packages.
+const (
+	internalInclude = "include"
+	internalExclude = "exclude"
+	internalWarn    = "warn"
+	internalOnly    = "only"
+)
+
+// Valid formats for the check mode report.
+const (
+	reportJSON  = "json"
+	reportSARIF = "sarif"
+)
+
 const configFilePrefix = ".gomarkdoc"
 
 func buildCommand() *cobra.Command {
@@ -79,6 +181,7 @@ func buildCommand() *cobra.Command {
 	var command = &cobra.Command{
 		Use:   "gomarkdoc [package ...]",
 		Short: "generate markdown documentation for golang code",
+		Args:  cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if opts.version {
 				printVersion()
@@ -89,12 +192,18 @@ func buildCommand() *cobra.Command {
 
 			// Load configuration from viper
 			opts.includeUnexported = viper.GetBool("includeUnexported")
+			opts.includeTestHelpers = viper.GetBool("includeTestHelpers")
+			opts.includeXTestHelpers = viper.GetBool("includeExternalTestHelpers")
 			opts.output = viper.GetString("output")
+			opts.routes = viper.GetStringMapString("route")
 			opts.check = viper.GetBool("check")
 			opts.embed = viper.GetBool("embed")
 			opts.format = viper.GetString("format")
 			opts.templateOverrides = viper.GetStringMapString("template")
 			opts.templateFileOverrides = viper.GetStringMapString("templateFile")
+			opts.headings = viper.GetStringMapString("headings")
+			opts.numberedHeadings = viper.GetBool("numberedHeadings")
+			opts.deepHeadings = viper.GetString("deepHeadings")
 			opts.header = viper.GetString("header")
 			opts.headerFile = viper.GetString("headerFile")
 			opts.footer = viper.GetString("footer")
@@ -104,13 +213,198 @@ func buildCommand() *cobra.Command {
 			opts.repository.Remote = viper.GetString("repository.url")
 			opts.repository.DefaultBranch = viper.GetString("repository.defaultBranch")
 			opts.repository.PathFromRoot = viper.GetString("repository.path")
+			opts.repository.Commit = viper.GetString("repository.commit")
+			opts.repository.Forge = viper.GetString("repository.forge")
+			opts.pinCommit = viper.GetBool("repository.pin")
 			opts.fileOnly = viper.GetBool("fileOnly")
-			opts.overrideImportPath = viper.GetString("overrideImportPath")
+			opts.overrideImportPath = viper.GetString("importPath")
+			if opts.overrideImportPath == "" {
+				opts.overrideImportPath = viper.GetString("overrideImportPath")
+			}
+			opts.badges = viper.GetBool("badges")
+			opts.internal = viper.GetString("internal")
+			opts.statsOutput = viper.GetString("statsOutput")
+			opts.hideDeprecated = viper.GetBool("hideDeprecated")
+			opts.includeSymbols = viper.GetStringSlice("includeSymbols")
+			opts.excludeSymbols = viper.GetStringSlice("excludeSymbols")
+			opts.hideMethods = viper.GetStringSlice("hideMethods")
+			opts.deprecatedOutput = viper.GetString("deprecatedOutput")
+			opts.gettingStartedOutput = viper.GetString("gettingStartedOutput")
+			opts.noTypeLinks = viper.GetBool("noTypeLinks")
+			opts.indexFields = viper.GetBool("indexFields")
+			opts.changelog = viper.GetBool("changelog")
+			opts.outputTar = viper.GetString("outputTar")
+			opts.recursive = viper.GetBool("recursive")
+			opts.indexOutput = viper.GetString("indexOutput")
+			opts.importGraph = viper.GetString("importGraph")
+			opts.importGraphOutput = viper.GetString("importGraphOutput")
+			opts.manifest = viper.GetString("manifest")
+			opts.redirects = viper.GetString("redirects")
+			opts.redirectsFormat = viper.GetString("redirectsFormat")
+			opts.toc = viper.GetBool("toc")
+			opts.watch = viper.GetBool("watch")
+			opts.exampleTitles = viper.GetString("exampleTitles")
+			opts.exampleOrder = viper.GetString("exampleOrder")
+			opts.symbolOrder = viper.GetString("symbolOrder")
+			opts.checkExamples = viper.GetBool("checkExamples")
+			opts.playgroundLinks = viper.GetBool("playgroundLinks")
+			opts.strictExamples = viper.GetBool("strictExamples")
+			opts.runExamples = viper.GetBool("runExamples")
+			opts.cAPI = viper.GetBool("cAPI")
+			opts.buildInfo = viper.GetBool("buildInfo")
+			opts.benchmarks = viper.GetBool("benchmarks")
+			opts.fuzzTargets = viper.GetBool("fuzzTargets")
+			opts.classDiagram = viper.GetBool("classDiagram")
+			opts.iotaValues = viper.GetBool("iotaValues")
+			opts.computedValues = viper.GetBool("computedValues")
+			opts.funcBodies = viper.GetBool("funcBodies")
+			opts.flattenEmbedded = viper.GetBool("flattenEmbedded")
+			opts.promotedMethods = viper.GetBool("promotedMethods")
+			opts.implementers = viper.GetBool("implementers")
+			opts.implements = viper.GetBool("implements")
+			opts.importers = viper.GetBool("importers")
+			opts.buildTargets = viper.GetBool("buildTargets")
+			opts.platforms = viper.GetStringSlice("platforms")
+			opts.safeTemplates = viper.GetBool("safeTemplates")
+			opts.frontMatter = viper.GetStringMapString("frontMatter")
+			opts.frontMatterSyntax = viper.GetString("frontMatterSyntax")
+			opts.metadata = viper.GetBool("metadata")
+			opts.metadataTimestamp = viper.GetBool("metadataTimestamp")
+			opts.extractStrings = viper.GetString("extractStrings")
+			opts.translations = viper.GetString("translations")
+			opts.symbolAliases = viper.GetString("symbolAliases")
+			opts.importURLs = viper.GetStringMapString("importURLs")
+			opts.report = viper.GetString("report")
+			opts.checkDiff = viper.GetString("checkDiff")
+			opts.postprocess = viper.GetStringSlice("postprocess")
+			opts.postprocessFailure = viper.GetString("postprocessFailure")
+			opts.redact = viper.GetStringSlice("redact")
+			opts.redactionReport = viper.GetString("redactionReport")
+			opts.offline = viper.GetBool("offline")
+			opts.networkTimeout = viper.GetDuration("networkTimeout")
+			opts.networkRetries = viper.GetInt("networkRetries")
+			opts.networkRate = viper.GetFloat64("networkRate")
+
+			if err := viper.UnmarshalKey("publish", &opts.publishTargets); err != nil {
+				return fmt.Errorf("gomarkdoc: invalid publish targets: %w", err)
+			}
 
 			if opts.check && opts.output == "" {
 				return errors.New("gomarkdoc: check mode cannot be run without an output set")
 			}
 
+			if len(opts.routes) > 0 && opts.output == "" {
+				return errors.New("gomarkdoc: sections cannot be routed to other files without an output set")
+			}
+
+			if opts.importGraph != "" && opts.indexOutput == "" && opts.importGraphOutput == "" {
+				return errors.New("gomarkdoc: an import graph can only be written with an index output or import graph output set")
+			}
+
+			if opts.importGraphOutput != "" && opts.importGraph == "" {
+				return errors.New("gomarkdoc: an import graph output can only be written with an import graph format set")
+			}
+
+			if opts.outputTar != "" && opts.output == "" {
+				return errors.New("gomarkdoc: tar output cannot be written without an output set")
+			}
+
+			if opts.outputTar != "" && opts.check {
+				return errors.New("gomarkdoc: check mode cannot be run with tar output")
+			}
+
+			if opts.redactionReport != "" && len(opts.redact) == 0 {
+				return errors.New("gomarkdoc: a redaction report can only be written with redaction patterns set")
+			}
+
+			if opts.outputTar != "" && len(opts.postprocess) > 0 {
+				return errors.New("gomarkdoc: postprocess commands cannot be run with tar output")
+			}
+
+			if opts.safeTemplates && len(opts.postprocess) > 0 {
+				return errors.New("gomarkdoc: postprocess commands cannot be run in safe template mode")
+			}
+
+			if opts.safeTemplates && opts.runExamples {
+				return errors.New("gomarkdoc: examples cannot be run in safe template mode")
+			}
+
+			if opts.safeTemplates && opts.publish {
+				return errors.New("gomarkdoc: documentation cannot be published in safe template mode")
+			}
+
+			if opts.watch && opts.output == "" {
+				return errors.New("gomarkdoc: watch mode cannot be run without an output set")
+			}
+
+			if opts.watch && (opts.check || opts.outputTar != "" || opts.extractStrings != "") {
+				return errors.New("gomarkdoc: watch mode cannot be run with check mode, tar output or string extraction")
+			}
+
+			if opts.publish && (opts.output == "" || opts.check || opts.watch || opts.outputTar != "" || opts.extractStrings != "") {
+				return errors.New("gomarkdoc: publishing requires an output set and cannot be run with check mode, watch mode, tar output or string extraction")
+			}
+
+			if opts.publish {
+				if err := validatePublishTargets(opts); err != nil {
+					return err
+				}
+			}
+
+			if opts.report != "" && opts.report != reportJSON && opts.report != reportSARIF {
+				return fmt.Errorf("gomarkdoc: invalid report format: %s", opts.report)
+			}
+
+			switch opts.checkDiff {
+			case checkDiffAuto, checkDiffUnified, checkDiffTerminal:
+			default:
+				return fmt.Errorf("gomarkdoc: invalid check diff style: %s", opts.checkDiff)
+			}
+
+			if opts.report != "" && !opts.check {
+				return errors.New("gomarkdoc: a report can only be produced in check mode")
+			}
+
+			if opts.check && opts.extractStrings != "" {
+				return errors.New("gomarkdoc: check mode cannot be run while extracting strings")
+			}
+
+			if opts.embed && (opts.format == "asciidoc" || opts.format == "confluence" || opts.format == "rst" || opts.format == "man") {
+				return fmt.Errorf("gomarkdoc: embed mode is not supported for the %s format", opts.format)
+			}
+
+			if _, ok := defaultFrontMatter[opts.format]; opts.embed && (ok || len(opts.frontMatter) > 0) {
+				return errors.New("gomarkdoc: embed mode cannot be used with front matter")
+			}
+
+			switch lang.FrontMatterSyntax(opts.frontMatterSyntax) {
+			case lang.YAMLFrontMatter, lang.TOMLFrontMatter:
+			default:
+				return fmt.Errorf("gomarkdoc: invalid front matter syntax: %s", opts.frontMatterSyntax)
+			}
+
+			switch opts.internal {
+			case internalInclude, internalExclude, internalWarn, internalOnly:
+			default:
+				return fmt.Errorf("gomarkdoc: invalid internal package mode: %s", opts.internal)
+			}
+
+			switch opts.postprocessFailure {
+			case postprocessFail, postprocessWarn, postprocessIgnore:
+			default:
+				return fmt.Errorf("gomarkdoc: invalid postprocess failure mode: %s", opts.postprocessFailure)
+			}
+
+			if opts.redirects != "" && opts.manifest == "" {
+				return errors.New("gomarkdoc: redirects cannot be written without a manifest set")
+			}
+
+			switch opts.redirectsFormat {
+			case redirectsNetlify, redirectsNginx:
+			default:
+				return fmt.Errorf("gomarkdoc: invalid redirects format: %s", opts.redirectsFormat)
+			}
+
 			if opts.fileOnly {
 				if len(args) == 0 {
 					return errors.New("gomarkdoc: if file-only flag is set, then a valid file must be passed")
@@ -133,8 +427,22 @@ func buildCommand() *cobra.Command {
 			}
 
 			if len(args) == 0 {
-				// Default to current directory
-				args = []string{"."}
+				// Default to the modules of the workspace when run at its
+				// root, or to the current directory otherwise
+				workspace, ok, err := workspacePaths(".")
+				if err != nil {
+					return err
+				}
+
+				if ok {
+					args = workspace
+				} else {
+					args = []string{"."}
+				}
+			}
+
+			if opts.recursive {
+				args = recursivePaths(args)
 			}
 
 			return runCommand(args, opts)
@@ -153,6 +461,18 @@ func buildCommand() *cobra.Command {
 		false,
 		"Output documentation for unexported symbols, methods and fields in addition to exported ones.",
 	)
+	command.Flags().BoolVar(
+		&opts.includeTestHelpers,
+		"include-test-helpers",
+		false,
+		"Output documentation for the symbols declared in the _test.go files of each package (e.g. test doubles exported through export_test.go), marked as test only. Tests, benchmarks, examples and fuzz targets are left out.",
+	)
+	command.Flags().BoolVar(
+		&opts.includeXTestHelpers,
+		"include-external-test-helpers",
+		false,
+		"Output documentation for the symbols declared in the _test.go files of each package's external test package (e.g. pkg_test), marked as test only. Tests, benchmarks, examples and fuzz targets are left out.",
+	)
 	command.Flags().StringVarP(
 		&opts.output,
 		"output",
@@ -160,6 +480,12 @@ func buildCommand() *cobra.Command {
 		"",
 		"File or pattern specifying where to write documentation output. Defaults to printing to stdout.",
 	)
+	command.Flags().StringToStringVar(
+		&opts.routes,
+		"route",
+		map[string]string{},
+		"File or pattern specifying where to write the documentation of a kind of symbol instead of the --output file (e.g. types=docs/{{.ImportPath}}/types.md). Valid kinds: consts, vars, funcs, types, examples. The --output file links to the routed files.",
+	)
 	command.Flags().BoolVarP(
 		&opts.check,
 		"check",
@@ -167,6 +493,72 @@ func buildCommand() *cobra.Command {
 		false,
 		"Check the output to see if it matches the generated documentation. --output must be specified to use this.",
 	)
+	command.Flags().StringVar(
+		&opts.report,
+		"report",
+		"",
+		"Format of a report of the status (ok, stale, missing or error) of each file to write to stdout in check mode, along with the regions of the file which differ. Valid options: json, sarif (SARIF 2.1.0 for code scanning tools)",
+	)
+	command.Flags().StringVar(
+		&opts.checkDiff,
+		"check-diff",
+		checkDiffAuto,
+		"Style of the diff printed to stderr for files which are out of date in check mode. Valid options: auto (default, unified unless stderr is a terminal), unified (a unified diff naming the symbols changed by each hunk), terminal (a colored diff)",
+	)
+	command.Flags().StringArrayVar(
+		&opts.postprocess,
+		"postprocess",
+		nil,
+		"Command to run on each written output file, which receives the path of the file as its final argument. Can be provided multiple times to run several commands in order. In check mode, the commands are run on a temporary copy of the output before comparing it with the file.",
+	)
+	command.Flags().StringVar(
+		&opts.postprocessFailure,
+		"postprocess-failure",
+		postprocessFail,
+		"How to handle a failing postprocess command. Valid options: fail (default), warn (log and continue), ignore",
+	)
+	command.Flags().StringArrayVar(
+		&opts.redact,
+		"redact",
+		nil,
+		"Text to replace with [REDACTED] wherever it appears in the output, such as internal hostnames or tokens. Patterns enclosed in slashes are regular expressions (e.g. /ghp_[A-Za-z0-9]{36}/). Can be provided multiple times.",
+	)
+	command.Flags().StringVar(
+		&opts.redactionReport,
+		"redaction-report",
+		"",
+		"File to write a JSON report of the redactions made to, listing the file, line and pattern of each redaction.",
+	)
+	command.Flags().BoolVar(
+		&opts.offline,
+		"offline",
+		false,
+		"Never access the network. Features which need it are skipped with a warning or fall back to working without it.",
+	)
+	command.Flags().DurationVar(
+		&opts.networkTimeout,
+		"network-timeout",
+		defaultNetworkTimeout,
+		"Maximum time each network request can take, including reading the response.",
+	)
+	command.Flags().IntVar(
+		&opts.networkRetries,
+		"network-retries",
+		defaultNetworkRetries,
+		"Number of times a network request failing with a network error, a 429 or a 5xx status is retried, with exponential backoff between attempts.",
+	)
+	command.Flags().Float64Var(
+		&opts.networkRate,
+		"network-rate",
+		defaultNetworkRate,
+		"Maximum number of network requests sent per second across all features.",
+	)
+	command.Flags().BoolVar(
+		&opts.publish,
+		"publish",
+		false,
+		"Publish the written documentation to each of the targets in the publish list of the configuration file (git branches, s3 or gcs buckets and confluence spaces).",
+	)
 	command.Flags().BoolVarP(
 		&opts.embed,
 		"embed",
@@ -179,7 +571,7 @@ func buildCommand() *cobra.Command {
 		"format",
 		"f",
 		"github",
-		"Format to use for writing output data. Valid options: github (default), azure-devops, plain",
+		"Format to use for writing output data. Valid options: github (default), azure-devops, gitlab, plain, json, asciidoc, html, docusaurus, hugo, confluence, rst, man",
 	)
 	command.Flags().StringToStringVarP(
 		&opts.templateOverrides,
@@ -194,29 +586,47 @@ func buildCommand() *cobra.Command {
 		map[string]string{},
 		"Custom template file to use for the provided template name instead of the default template.",
 	)
+	command.Flags().StringToStringVar(
+		&opts.headings,
+		"heading",
+		map[string]string{},
+		"Text to use in place of the provided built-in section heading (e.g. Index=Contents). Valid headings: Index, Constants, Variables, Output, C API, Documentation Statistics, Coverage by Package, Largest Undocumented Surfaces, Deprecated, Packages, Contents, Build Configuration, Linker Variables, Dependencies, Imported By, Benchmarks, Fuzzing, Class Diagram, Import Graph, Getting Started, Implementation",
+	)
+	command.Flags().BoolVar(
+		&opts.numberedHeadings,
+		"numbered-headings",
+		false,
+		"Prefix section and symbol headings with hierarchical numbers (e.g. 2.3.1). The title of each package is not numbered.",
+	)
+	command.Flags().StringVar(
+		&opts.deepHeadings,
+		"deep-headings",
+		"clamp",
+		"How headings nested deeper than the format supports (e.g. H6 in markdown) are rendered. Options are clamp, bold, definition and reroot. Only clamp is supported by the confluence, rst, man and json formats.",
+	)
 	command.Flags().StringVar(
 		&opts.header,
 		"header",
 		"",
-		"Additional content to inject at the beginning of each output file.",
+		"Additional content to inject at the beginning of each output file. It is rendered as a template with the same data as --front-matter.",
 	)
 	command.Flags().StringVar(
 		&opts.headerFile,
 		"header-file",
 		"",
-		"File containing additional content to inject at the beginning of each output file.",
+		"File containing additional content to inject at the beginning of each output file. It is rendered as a template with the same data as --front-matter.",
 	)
 	command.Flags().StringVar(
 		&opts.footer,
 		"footer",
 		"",
-		"Additional content to inject at the end of each output file.",
+		"Additional content to inject at the end of each output file. It is rendered as a template with the same data as --front-matter.",
 	)
 	command.Flags().StringVar(
 		&opts.footerFile,
 		"footer-file",
 		"",
-		"File containing additional content to inject at the end of each output file.",
+		"File containing additional content to inject at the end of each output file. It is rendered as a template with the same data as --front-matter.",
 	)
 	command.Flags().StringSliceVar(
 		&opts.tags,
@@ -254,6 +664,24 @@ func buildCommand() *cobra.Command {
 		"",
 		"Manual override for the path from the root of the git repository used in place of automatic detection.",
 	)
+	command.Flags().StringVar(
+		&opts.repository.Commit,
+		"repository.commit",
+		"",
+		"Commit to pin links to the source code to in place of the default branch.",
+	)
+	command.Flags().StringVar(
+		&opts.repository.Forge,
+		"repository.forge",
+		"",
+		"Hint for the software hosting the git repository when it can't be detected from the URL, such as a self-hosted instance. The only option is gitea, which covers Forgejo as well.",
+	)
+	command.Flags().BoolVar(
+		&opts.pinCommit,
+		"repository.pin",
+		false,
+		"Pin links to the source code to the commit checked out in the git repository (HEAD) in place of the default branch, so that their line anchors keep pointing to the same code as the branch moves on.",
+	)
 	command.Flags().BoolVar(
 		&opts.version,
 		"version",
@@ -272,15 +700,352 @@ func buildCommand() *cobra.Command {
 		"",
 		"Override the import path of the package. This is useful when the package is not in the GOPATH.",
 	)
+	command.Flags().StringVar(
+		&opts.overrideImportPath,
+		"import-path",
+		"",
+		"Import path to document the package with when it is not in a Go module. Without it, the import statement and links to the package's documentation are left out. Same as --override-import-path.",
+	)
+	command.Flags().BoolVar(
+		&opts.badges,
+		"badges",
+		false,
+		"Generate standard badges (reference, version, documentation coverage) at the top of each package's documentation.",
+	)
+	command.Flags().StringVar(
+		&opts.internal,
+		"internal",
+		internalInclude,
+		"How to handle internal packages. Valid options: include (default), exclude, warn (include with a warning banner), only (document only internal packages)",
+	)
+	command.Flags().StringVar(
+		&opts.statsOutput,
+		"stats-output",
+		"",
+		"File to write an aggregate documentation statistics page for all documented packages to.",
+	)
+	command.Flags().BoolVar(
+		&opts.hideDeprecated,
+		"hide-deprecated",
+		false,
+		"Leave functions, types and methods with a \"Deprecated:\" note out of the generated documentation.",
+	)
+	command.Flags().StringArrayVar(
+		&opts.includeSymbols,
+		"include-symbols",
+		nil,
+		"Pattern matching the names of the symbols to include in the generated documentation, such as Client.* for the methods of Client. Patterns are globs, or regular expressions when enclosed in slashes (e.g. /^New/). Can be provided multiple times to include the symbols matching any of them.",
+	)
+	command.Flags().StringArrayVar(
+		&opts.excludeSymbols,
+		"exclude-symbols",
+		nil,
+		"Pattern matching the names of the symbols to leave out of the generated documentation, such as /^Mock/. Uses the same syntax as --include-symbols and takes precedence over it. Can be provided multiple times.",
+	)
+	command.Flags().StringArrayVar(
+		&opts.hideMethods,
+		"hide-methods",
+		nil,
+		"Pattern matching the names of the types whose methods to leave out of the generated documentation while still documenting the types, such as '*Server'. Uses the same syntax as --include-symbols. Can be provided multiple times.",
+	)
+	command.Flags().StringVar(
+		&opts.deprecatedOutput,
+		"deprecated-output",
+		"",
+		"File to write an appendix of the deprecated functions, types and methods of all documented packages to. Usually combined with --hide-deprecated.",
+	)
+	command.Flags().StringVar(
+		&opts.gettingStartedOutput,
+		"getting-started-output",
+		"",
+		"File to write a Getting Started walkthrough to, made up of the package-level examples of all documented packages which have a //gomarkdoc:order N directive, in order of N.",
+	)
+	command.Flags().BoolVar(
+		&opts.noTypeLinks,
+		"no-type-links",
+		false,
+		"Don't link the types from other packages used in signatures and declarations to their documentation. Use --import-url to point the links at a private documentation server instead.",
+	)
+	command.Flags().BoolVar(
+		&opts.indexFields,
+		"index-fields",
+		false,
+		"List the documented fields of struct types under their types in the index and table of contents, along with their functions and methods.",
+	)
+	command.Flags().BoolVar(
+		&opts.changelog,
+		"changelog",
+		false,
+		"When overwriting an existing file, add comments at the bottom listing the symbols whose sections were added, removed or modified since its previous contents.",
+	)
+	command.Flags().BoolVar(
+		&opts.toc,
+		"toc",
+		false,
+		"Add a table of contents at the top of each output file listing the constants, variables, functions and types of its packages with links to their documentation.",
+	)
+	command.Flags().BoolVar(
+		&opts.watch,
+		"watch",
+		false,
+		"After generating the documentation, keep watching the directories of the packages for changes to their Go files and regenerate the output files of the changed packages until interrupted.",
+	)
+	command.Flags().BoolVar(
+		&opts.recursive,
+		"recursive",
+		false,
+		"Document the packages nested within each provided directory as well, as if each path ended with /...",
+	)
+	command.Flags().StringVar(
+		&opts.indexOutput,
+		"index-output",
+		"",
+		"File to write an index of all documented packages to, nested by directory, with the summary of each package and a link to its generated documentation.",
+	)
+	command.Flags().StringVar(
+		&opts.importGraph,
+		"import-graph",
+		"",
+		"Format of a graph of the imports among the documented packages to add to the --index-output file, or to write to the --import-graph-output file. Valid options: mermaid, dot",
+	)
+	command.Flags().StringVar(
+		&opts.importGraphOutput,
+		"import-graph-output",
+		"",
+		"File to write the source of the import graph to instead of adding it to the --index-output file.",
+	)
+	command.Flags().StringVar(
+		&opts.manifest,
+		"manifest",
+		"",
+		"File to write a JSON manifest of the generated files to, conventionally gomarkdoc-manifest.json. It lists the packages documented in each file, the hash of its contents and the options it was generated with. Not written in check mode or when extracting strings.",
+	)
+	command.Flags().StringVar(
+		&opts.redirects,
+		"redirects",
+		"",
+		"File to write redirects from the former paths of output files to their current paths to, computed from the previous contents of the --manifest file, so links to renamed outputs keep working.",
+	)
+	command.Flags().StringVar(
+		&opts.redirectsFormat,
+		"redirects-format",
+		redirectsNetlify,
+		"Format of the --redirects file. Valid options: netlify (default, a _redirects file), nginx (entries of a map block from $uri to the new path)",
+	)
+	command.Flags().StringVar(
+		&opts.outputTar,
+		"output-tar",
+		"",
+		"File to write all of the generated files to as a tar archive instead of writing them to the filesystem, or - for stdout. Entries are named by the paths from --output relative to the working directory.",
+	)
+	command.Flags().StringVar(
+		&opts.exampleTitles,
+		"example-titles",
+		string(lang.DefaultExampleTitles),
+		"Style of example titles. Valid options: default (Example (With Retry)), humanized (Example (With retry)), short (With retry)",
+	)
+	command.Flags().StringVar(
+		&opts.exampleOrder,
+		"example-order",
+		string(lang.AlphabeticalExampleOrder),
+		"Order in which examples are listed. Valid options: alphabetical (default), source",
+	)
+	command.Flags().StringVar(
+		&opts.symbolOrder,
+		"symbol-order",
+		string(lang.AlphabeticalSymbolOrder),
+		"Order in which functions and types are listed. Valid options: alphabetical (default), popularity (most referenced within the module first)",
+	)
+	command.Flags().BoolVar(
+		&opts.checkExamples,
+		"check-examples",
+		false,
+		"Type check the examples of each package and log a warning for each example which doesn't compile.",
+	)
+	command.Flags().BoolVar(
+		&opts.strictExamples,
+		"strict-examples",
+		false,
+		"Type check the examples of each package and fail if any of them don't compile.",
+	)
+	command.Flags().BoolVar(
+		&opts.runExamples,
+		"run-examples",
+		false,
+		"Run the examples of each package with go test and show the output they actually print, even for examples without an output comment. Not allowed with --safe-templates.",
+	)
+	command.Flags().BoolVar(
+		&opts.playgroundLinks,
+		"playground-links",
+		false,
+		"Add a link to run each self-contained example in the Go Playground, sharing its code through the playground's share api. Skipped in offline mode.",
+	)
+	command.Flags().BoolVar(
+		&opts.cAPI,
+		"c-api",
+		false,
+		"Document the functions exported to C with //export directives and the documented declarations of the cgo preamble in a C API section.",
+	)
+	command.Flags().BoolVar(
+		&opts.buildInfo,
+		"build-info",
+		false,
+		"Add a build configuration appendix to the documentation of main packages, listing the string variables which can be set with -ldflags \"-X\" and the packages they import along with their module versions. Nothing is built.",
+	)
+	command.Flags().BoolVar(
+		&opts.benchmarks,
+		"benchmarks",
+		false,
+		"Add a Benchmarks section listing the benchmark functions declared in the _test.go files of each package, along with their documentation and code.",
+	)
+	command.Flags().BoolVar(
+		&opts.fuzzTargets,
+		"fuzz-targets",
+		false,
+		"Add a Fuzzing section listing the fuzz targets declared in the _test.go files of each package, along with their documentation and seed corpus.",
+	)
+	command.Flags().BoolVar(
+		&opts.classDiagram,
+		"class-diagram",
+		false,
+		"Add a Class Diagram section after the index of each package, holding a Mermaid class diagram of its exported types, the types they embed and the interfaces they implement.",
+	)
+	command.Flags().BoolVar(
+		&opts.iotaValues,
+		"iota-values",
+		false,
+		"Follow const declarations using iota with a table of the computed value of each of their constants.",
+	)
+	command.Flags().BoolVar(
+		&opts.computedValues,
+		"computed-values",
+		false,
+		"Follow const declarations whose values are expressions rather than literals, including those using iota, with a table of the computed value of each of their constants.",
+	)
+	command.Flags().BoolVar(
+		&opts.funcBodies,
+		"func-bodies",
+		false,
+		"Include the full source of each function and method, including its body, in a collapsible Implementation block. Individual functions can be included with a //gomarkdoc:body directive instead.",
+	)
+	command.Flags().BoolVar(
+		&opts.flattenEmbedded,
+		"flatten-embedded",
+		false,
+		"List the fields promoted to struct types from the structs they embed, along with the embedded type each field comes from.",
+	)
+	command.Flags().BoolVar(
+		&opts.promotedMethods,
+		"promoted-methods",
+		false,
+		"List the methods promoted to types from the types they embed, along with the embedded type each method comes from, so that the full method set of each type is documented.",
+	)
+	command.Flags().BoolVar(
+		&opts.implementers,
+		"implementers",
+		false,
+		"List the types within the module which implement each interface, with links to their documentation.",
+	)
+	command.Flags().BoolVar(
+		&opts.implements,
+		"implements",
+		false,
+		"List the interfaces within the module which each type implements, with links to their documentation.",
+	)
+	command.Flags().BoolVar(
+		&opts.importers,
+		"importers",
+		false,
+		"List the other packages documented in the same run (e.g. with ./...) which import each package, with links to their documentation.",
+	)
+	command.Flags().BoolVar(
+		&opts.buildTargets,
+		"build-targets",
+		false,
+		"Annotate symbols declared in files with build constraints (e.g. js && wasm) with badges showing the targets they are available for.",
+	)
+	command.Flags().StringSliceVar(
+		&opts.platforms,
+		"platforms",
+		nil,
+		"List of GOOS/GOARCH platforms (e.g. linux/amd64,windows/amd64) to document each package for at once. The symbols of all of the platforms are documented together, and those which aren't available on every platform get a badge listing the platforms they are available on.",
+	)
+	command.Flags().BoolVar(
+		&opts.safeTemplates,
+		"safe-templates",
+		false,
+		"Restrict custom templates for use with untrusted sources: template functions which can run arbitrary code are disabled, postprocess commands, running examples and publishing are rejected and template, header, footer and included files must be within the working directory.",
+	)
+	command.Flags().StringToStringVar(
+		&opts.frontMatter,
+		"front-matter",
+		map[string]string{},
+		"Template for the value of the provided front matter field, rendered with the same data as --output plus the package's Name, Slug, Summary, generation Date and Package. The docusaurus format includes id, title and slug fields by default and the hugo format includes title and description fields. An empty value removes the field.",
+	)
+	command.Flags().StringVar(
+		&opts.frontMatterSyntax,
+		"front-matter-syntax",
+		string(lang.YAMLFrontMatter),
+		"Language to write front matter in. Valid options: yaml (default), toml",
+	)
+	command.Flags().BoolVar(
+		&opts.metadata,
+		"metadata",
+		false,
+		"Add comments with generation metadata (gomarkdoc version, module version and commit) to the end of each output file. The metadata is ignored in check mode.",
+	)
+	command.Flags().BoolVar(
+		&opts.metadataTimestamp,
+		"metadata-timestamp",
+		false,
+		"Include the UTC time of generation in the metadata added by --metadata. This makes the output non-reproducible.",
+	)
+	command.Flags().StringVar(
+		&opts.extractStrings,
+		"extract-strings",
+		"",
+		"File to write the prose of the documentation to as JSON, keyed by stable ids, for translation. No documentation is written when this is set.",
+	)
+	command.Flags().StringVar(
+		&opts.translations,
+		"translations",
+		"",
+		"JSON file with translated prose keyed by the ids produced by --extract-strings, which is used in place of the original documentation text.",
+	)
+	command.Flags().StringVar(
+		&opts.symbolAliases,
+		"symbol-aliases",
+		"",
+		"JSON file mapping symbol names (e.g. clientImpl or Client.doRequest) to the names to present them as in the documentation. Symbols mapped to an empty name are left out.",
+	)
+	command.Flags().StringToStringVar(
+		&opts.importURLs,
+		"import-url",
+		map[string]string{},
+		"Template for the documentation url of packages with the provided import path prefix, used when linking to other packages (e.g. github.com/org/repo=https://docs.example.com/{{.Path}}). The template can use the package's ImportPath, the matching Prefix and the Path after the prefix. Use * as the prefix to replace the default pkg.go.dev url.",
+	)
+
+	command.AddCommand(buildServeCommand())
+	command.AddCommand(buildCoverageCommand())
+	command.AddCommand(buildLintCommand())
+	command.AddCommand(buildSyncCommand())
 
 	// We ignore the errors here because they only happen if the specified flag doesn't exist
 	_ = viper.BindPFlag("includeUnexported", command.Flags().Lookup("include-unexported"))
+	_ = viper.BindPFlag("includeTestHelpers", command.Flags().Lookup("include-test-helpers"))
+	_ = viper.BindPFlag("includeExternalTestHelpers", command.Flags().Lookup("include-external-test-helpers"))
 	_ = viper.BindPFlag("output", command.Flags().Lookup("output"))
+	_ = viper.BindPFlag("route", command.Flags().Lookup("route"))
 	_ = viper.BindPFlag("check", command.Flags().Lookup("check"))
+	_ = viper.BindPFlag("report", command.Flags().Lookup("report"))
+	_ = viper.BindPFlag("checkDiff", command.Flags().Lookup("check-diff"))
 	_ = viper.BindPFlag("embed", command.Flags().Lookup("embed"))
 	_ = viper.BindPFlag("format", command.Flags().Lookup("format"))
 	_ = viper.BindPFlag("template", command.Flags().Lookup("template"))
 	_ = viper.BindPFlag("templateFile", command.Flags().Lookup("template-file"))
+	_ = viper.BindPFlag("headings", command.Flags().Lookup("heading"))
+	_ = viper.BindPFlag("numberedHeadings", command.Flags().Lookup("numbered-headings"))
+	_ = viper.BindPFlag("deepHeadings", command.Flags().Lookup("deep-headings"))
 	_ = viper.BindPFlag("header", command.Flags().Lookup("header"))
 	_ = viper.BindPFlag("headerFile", command.Flags().Lookup("header-file"))
 	_ = viper.BindPFlag("footer", command.Flags().Lookup("footer"))
@@ -290,8 +1055,73 @@ func buildCommand() *cobra.Command {
 	_ = viper.BindPFlag("repository.url", command.Flags().Lookup("repository.url"))
 	_ = viper.BindPFlag("repository.defaultBranch", command.Flags().Lookup("repository.default-branch"))
 	_ = viper.BindPFlag("repository.path", command.Flags().Lookup("repository.path"))
+	_ = viper.BindPFlag("repository.commit", command.Flags().Lookup("repository.commit"))
+	_ = viper.BindPFlag("repository.forge", command.Flags().Lookup("repository.forge"))
+	_ = viper.BindPFlag("repository.pin", command.Flags().Lookup("repository.pin"))
 	_ = viper.BindPFlag("fileOnly", command.Flags().Lookup("file-only"))
 	_ = viper.BindPFlag("overrideImportPath", command.Flags().Lookup("override-import-path"))
+	_ = viper.BindPFlag("importPath", command.Flags().Lookup("import-path"))
+	_ = viper.BindPFlag("badges", command.Flags().Lookup("badges"))
+	_ = viper.BindPFlag("internal", command.Flags().Lookup("internal"))
+	_ = viper.BindPFlag("statsOutput", command.Flags().Lookup("stats-output"))
+	_ = viper.BindPFlag("hideDeprecated", command.Flags().Lookup("hide-deprecated"))
+	_ = viper.BindPFlag("includeSymbols", command.Flags().Lookup("include-symbols"))
+	_ = viper.BindPFlag("excludeSymbols", command.Flags().Lookup("exclude-symbols"))
+	_ = viper.BindPFlag("hideMethods", command.Flags().Lookup("hide-methods"))
+	_ = viper.BindPFlag("deprecatedOutput", command.Flags().Lookup("deprecated-output"))
+	_ = viper.BindPFlag("gettingStartedOutput", command.Flags().Lookup("getting-started-output"))
+	_ = viper.BindPFlag("noTypeLinks", command.Flags().Lookup("no-type-links"))
+	_ = viper.BindPFlag("indexFields", command.Flags().Lookup("index-fields"))
+	_ = viper.BindPFlag("changelog", command.Flags().Lookup("changelog"))
+	_ = viper.BindPFlag("outputTar", command.Flags().Lookup("output-tar"))
+	_ = viper.BindPFlag("recursive", command.Flags().Lookup("recursive"))
+	_ = viper.BindPFlag("toc", command.Flags().Lookup("toc"))
+	_ = viper.BindPFlag("watch", command.Flags().Lookup("watch"))
+	_ = viper.BindPFlag("indexOutput", command.Flags().Lookup("index-output"))
+	_ = viper.BindPFlag("importGraph", command.Flags().Lookup("import-graph"))
+	_ = viper.BindPFlag("importGraphOutput", command.Flags().Lookup("import-graph-output"))
+	_ = viper.BindPFlag("manifest", command.Flags().Lookup("manifest"))
+	_ = viper.BindPFlag("redirects", command.Flags().Lookup("redirects"))
+	_ = viper.BindPFlag("redirectsFormat", command.Flags().Lookup("redirects-format"))
+	_ = viper.BindPFlag("exampleTitles", command.Flags().Lookup("example-titles"))
+	_ = viper.BindPFlag("exampleOrder", command.Flags().Lookup("example-order"))
+	_ = viper.BindPFlag("symbolOrder", command.Flags().Lookup("symbol-order"))
+	_ = viper.BindPFlag("checkExamples", command.Flags().Lookup("check-examples"))
+	_ = viper.BindPFlag("strictExamples", command.Flags().Lookup("strict-examples"))
+	_ = viper.BindPFlag("runExamples", command.Flags().Lookup("run-examples"))
+	_ = viper.BindPFlag("playgroundLinks", command.Flags().Lookup("playground-links"))
+	_ = viper.BindPFlag("cAPI", command.Flags().Lookup("c-api"))
+	_ = viper.BindPFlag("buildInfo", command.Flags().Lookup("build-info"))
+	_ = viper.BindPFlag("benchmarks", command.Flags().Lookup("benchmarks"))
+	_ = viper.BindPFlag("fuzzTargets", command.Flags().Lookup("fuzz-targets"))
+	_ = viper.BindPFlag("classDiagram", command.Flags().Lookup("class-diagram"))
+	_ = viper.BindPFlag("iotaValues", command.Flags().Lookup("iota-values"))
+	_ = viper.BindPFlag("computedValues", command.Flags().Lookup("computed-values"))
+	_ = viper.BindPFlag("funcBodies", command.Flags().Lookup("func-bodies"))
+	_ = viper.BindPFlag("flattenEmbedded", command.Flags().Lookup("flatten-embedded"))
+	_ = viper.BindPFlag("promotedMethods", command.Flags().Lookup("promoted-methods"))
+	_ = viper.BindPFlag("implementers", command.Flags().Lookup("implementers"))
+	_ = viper.BindPFlag("implements", command.Flags().Lookup("implements"))
+	_ = viper.BindPFlag("importers", command.Flags().Lookup("importers"))
+	_ = viper.BindPFlag("buildTargets", command.Flags().Lookup("build-targets"))
+	_ = viper.BindPFlag("platforms", command.Flags().Lookup("platforms"))
+	_ = viper.BindPFlag("safeTemplates", command.Flags().Lookup("safe-templates"))
+	_ = viper.BindPFlag("frontMatter", command.Flags().Lookup("front-matter"))
+	_ = viper.BindPFlag("frontMatterSyntax", command.Flags().Lookup("front-matter-syntax"))
+	_ = viper.BindPFlag("metadata", command.Flags().Lookup("metadata"))
+	_ = viper.BindPFlag("metadataTimestamp", command.Flags().Lookup("metadata-timestamp"))
+	_ = viper.BindPFlag("extractStrings", command.Flags().Lookup("extract-strings"))
+	_ = viper.BindPFlag("translations", command.Flags().Lookup("translations"))
+	_ = viper.BindPFlag("symbolAliases", command.Flags().Lookup("symbol-aliases"))
+	_ = viper.BindPFlag("importURLs", command.Flags().Lookup("import-url"))
+	_ = viper.BindPFlag("postprocess", command.Flags().Lookup("postprocess"))
+	_ = viper.BindPFlag("postprocessFailure", command.Flags().Lookup("postprocess-failure"))
+	_ = viper.BindPFlag("redact", command.Flags().Lookup("redact"))
+	_ = viper.BindPFlag("redactionReport", command.Flags().Lookup("redaction-report"))
+	_ = viper.BindPFlag("offline", command.Flags().Lookup("offline"))
+	_ = viper.BindPFlag("networkTimeout", command.Flags().Lookup("network-timeout"))
+	_ = viper.BindPFlag("networkRetries", command.Flags().Lookup("network-retries"))
+	_ = viper.BindPFlag("networkRate", command.Flags().Lookup("network-rate"))
 
 	return command
 }
@@ -340,6 +1170,26 @@ func runCommand(paths []string, opts commandOptions) error {
 		return fmt.Errorf("gomarkdoc: invalid output template: %w", err)
 	}
 
+	routeTmpls := make(map[lang.Section]*template.Template, len(opts.routes))
+	for section, route := range opts.routes {
+		routeTmpls[lang.Section(section)], err = template.New("route").Parse(route)
+		if err != nil {
+			return fmt.Errorf("gomarkdoc: invalid route template for %s: %w", section, err)
+		}
+	}
+
+	log := logger.New(getLogLevel(opts.verbosity))
+	opts.network, err = newNetworkClient(log, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.playgroundLinks && opts.offline {
+		log.Warn("skipping playground links for examples in offline mode")
+	} else if opts.playgroundLinks {
+		opts.playgroundSharer = newPlaygroundSharer(opts.network, playgroundShareURL)
+	}
+
 	specs := getSpecs(paths...)
 
 	excluded := getSpecs(opts.excludeDirs...)
@@ -349,15 +1199,89 @@ func runCommand(paths []string, opts commandOptions) error {
 
 	specs = removeExcludes(specs, excluded)
 
+	if err := validateSymbolQueries(specs); err != nil {
+		return err
+	}
+
 	if err := resolveOutput(specs, outputTmpl); err != nil {
 		return err
 	}
 
+	if err := resolveRoutes(specs, routeTmpls); err != nil {
+		return err
+	}
+
+	if opts.translations != "" {
+		opts.translationStrings, err = readStringMap(opts.translations, "translations")
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.symbolAliases != "" {
+		opts.symbolAliasMap, err = readStringMap(opts.symbolAliases, "symbol aliases")
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.extractStrings != "" {
+		opts.stringCatalog = lang.NewStringCatalog()
+	}
+
+	opts.importURLResolver, err = lang.NewImportURLResolver(opts.importURLs)
+	if err != nil {
+		return err
+	}
+
+	if len(opts.includeSymbols) > 0 || len(opts.excludeSymbols) > 0 {
+		opts.symbolFilter, err = lang.NewSymbolFilter(opts.includeSymbols, opts.excludeSymbols)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(opts.redact) > 0 {
+		opts.redactor, err = newRedactor(opts.redact)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Packages documented together link to each other's documentation
+	opts.symbolIndex = lang.NewSymbolIndex()
+
 	if err := loadPackages(specs, opts); err != nil {
 		return err
 	}
 
-	return writeOutput(specs, opts)
+	if opts.outputTar != "" {
+		opts.tar, err = newTarOutput(opts.outputTar)
+		if err != nil {
+			return err
+		}
+
+		if err := writeOutput(specs, opts); err != nil {
+			_ = opts.tar.close()
+			return err
+		}
+
+		return opts.tar.close()
+	}
+
+	if err := writeOutput(specs, opts); err != nil {
+		return err
+	}
+
+	if opts.publish {
+		return publishOutput(specs, opts)
+	}
+
+	if opts.watch {
+		return watch(specs, opts)
+	}
+
+	return nil
 }
 
 func resolveOutput(specs []*PackageSpec, outputTmpl *template.Template) error {
@@ -380,6 +1304,31 @@ func resolveOutput(specs []*PackageSpec, outputTmpl *template.Template) error {
 	return nil
 }
 
+// resolveRoutes computes the files the documentation of each kind of symbol of
+// the packages is routed to. Routes resolving to the package's own output file
+// are left out.
+func resolveRoutes(specs []*PackageSpec, routeTmpls map[lang.Section]*template.Template) error {
+	if len(routeTmpls) == 0 {
+		return nil
+	}
+
+	for _, spec := range specs {
+		spec.routes = make(map[lang.Section]string, len(routeTmpls))
+		for section, tmpl := range routeTmpls {
+			var file strings.Builder
+			if err := tmpl.Execute(&file, spec); err != nil {
+				return err
+			}
+
+			if route := filepath.Clean(file.String()); file.Len() > 0 && route != spec.outputFile {
+				spec.routes[section] = route
+			}
+		}
+	}
+
+	return nil
+}
+
 func resolveOverrides(opts commandOptions) ([]gomarkdoc.RendererOption, error) {
 	var overrides []gomarkdoc.RendererOption
 
@@ -395,7 +1344,7 @@ func resolveOverrides(opts commandOptions) ([]gomarkdoc.RendererOption, error) {
 			continue
 		}
 
-		b, err := ioutil.ReadFile(f)
+		b, err := readInputFile(f, opts.safeTemplates)
 		if err != nil {
 			return nil, fmt.Errorf("gomarkdoc: couldn't resolve template for %s: %w", name, err)
 		}
@@ -403,20 +1352,33 @@ func resolveOverrides(opts commandOptions) ([]gomarkdoc.RendererOption, error) {
 		overrides = append(overrides, gomarkdoc.WithTemplateOverride(name, string(b)))
 	}
 
-	var f format.Format
-	switch opts.format {
-	case "github":
-		f = &format.GitHubFlavoredMarkdown{}
-	case "azure-devops":
-		f = &format.AzureDevOpsMarkdown{}
-	case "plain":
-		f = &format.PlainMarkdown{}
-	default:
-		return nil, fmt.Errorf("gomarkdoc: invalid format: %s", opts.format)
+	f, err := format.ByName(opts.format)
+	if err != nil {
+		return nil, err
 	}
 
 	overrides = append(overrides, gomarkdoc.WithFormat(f))
 
+	if len(opts.headings) > 0 {
+		overrides = append(overrides, gomarkdoc.WithHeadings(opts.headings))
+	}
+
+	if opts.numberedHeadings {
+		overrides = append(overrides, gomarkdoc.WithNumberedHeadings())
+	}
+
+	if opts.deepHeadings != "" {
+		overrides = append(overrides, gomarkdoc.WithDeepHeadings(gomarkdoc.DeepHeadingStrategy(opts.deepHeadings)))
+	}
+
+	if opts.importURLResolver != nil {
+		overrides = append(overrides, gomarkdoc.WithImportURLResolver(opts.importURLResolver))
+	}
+
+	if opts.safeTemplates {
+		overrides = append(overrides, gomarkdoc.WithSafeTemplates())
+	}
+
 	return overrides, nil
 }
 
@@ -426,7 +1388,7 @@ func resolveHeader(opts commandOptions) (string, error) {
 	}
 
 	if opts.headerFile != "" {
-		b, err := ioutil.ReadFile(opts.headerFile)
+		b, err := readInputFile(opts.headerFile, opts.safeTemplates)
 		if err != nil {
 			return "", fmt.Errorf("gomarkdoc: couldn't resolve header file: %w", err)
 		}
@@ -443,7 +1405,7 @@ func resolveFooter(opts commandOptions) (string, error) {
 	}
 
 	if opts.footerFile != "" {
-		b, err := ioutil.ReadFile(opts.footerFile)
+		b, err := readInputFile(opts.footerFile, opts.safeTemplates)
 		if err != nil {
 			return "", fmt.Errorf("gomarkdoc: couldn't resolve footer file: %w", err)
 		}
@@ -454,7 +1416,96 @@ func resolveFooter(opts commandOptions) (string, error) {
 	return "", nil
 }
 
+// readInputFile reads a file providing content for the documentation. When
+// restricted, the file must be located within the working directory (after
+// resolving symlinks) so that untrusted configuration can't read arbitrary
+// files.
+func readInputFile(name string, restricted bool) ([]byte, error) {
+	if !restricted {
+		return ioutil.ReadFile(name)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	wd, err = filepath.EvalSymlinks(wd)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := filepath.Abs(name)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err = filepath.EvalSymlinks(p)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := filepath.Rel(wd, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("gomarkdoc: file %s is outside of the working directory, which is not allowed in safe template mode", name)
+	}
+
+	return ioutil.ReadFile(p)
+}
+
+// readStringMap reads a JSON object of strings from the named file. The kind
+// describes the contents of the file in errors.
+func readStringMap(name, kind string) (map[string]string, error) {
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: couldn't read %s file: %w", kind, err)
+	}
+
+	var m map[string]string
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("gomarkdoc: invalid %s file %s: %w", kind, name, err)
+	}
+
+	return m, nil
+}
+
+// subcommandOptions fills in the defaults of the options for loading packages
+// which subcommands don't provide flags for.
+func subcommandOptions(opts commandOptions) (commandOptions, error) {
+	opts.internal = internalInclude
+	opts.exampleTitles = string(lang.DefaultExampleTitles)
+	opts.exampleOrder = string(lang.AlphabeticalExampleOrder)
+	opts.symbolOrder = string(lang.AlphabeticalSymbolOrder)
+	opts.symbolIndex = lang.NewSymbolIndex()
+	opts.networkTimeout = defaultNetworkTimeout
+	opts.networkRetries = defaultNetworkRetries
+	opts.networkRate = defaultNetworkRate
+
+	var err error
+	opts.importURLResolver, err = lang.NewImportURLResolver(nil)
+	if err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
+
 func loadPackages(specs []*PackageSpec, opts commandOptions) error {
+	// Imported packages are only type checked once for all of the packages
+	imp := lang.NewSourceImporter(opts.tags...)
+
+	// Modules are only type checked once for all of their packages
+	var implementers *lang.ImplementerIndex
+	if opts.implementers || opts.implements {
+		implementers = lang.NewImplementerIndex(imp)
+	}
+
+	// Importers are found among all of the packages once they are loaded
+	var importers *lang.ImporterIndex
+	if opts.importers {
+		importers = lang.NewImporterIndex()
+	}
+
 	for _, spec := range specs {
 		log := logger.New(getLogLevel(opts.verbosity), logger.WithField("dir", spec.Dir))
 
@@ -470,12 +1521,20 @@ func loadPackages(specs []*PackageSpec, opts commandOptions) error {
 		}
 
 		var pkgOpts []lang.PackageOption
-		pkgOpts = append(pkgOpts, lang.PackageWithRepositoryOverrides(&opts.repository))
+		pkgOpts = append(pkgOpts, lang.PackageWithRepositoryOverrides(&opts.repository), lang.PackageWithSourceImporter(imp))
 
 		if opts.includeUnexported {
 			pkgOpts = append(pkgOpts, lang.PackageWithUnexportedIncluded())
 		}
 
+		if opts.includeTestHelpers {
+			pkgOpts = append(pkgOpts, lang.PackageWithTestHelpers())
+		}
+
+		if opts.includeXTestHelpers {
+			pkgOpts = append(pkgOpts, lang.PackageWithExternalTestHelpers())
+		}
+
 		if opts.fileOnly {
 			pkgOpts = append(pkgOpts, lang.PackageWithFileFilter(opts.file))
 		}
@@ -484,17 +1543,186 @@ func loadPackages(specs []*PackageSpec, opts commandOptions) error {
 			pkgOpts = append(pkgOpts, lang.PackageWithOverrideImport(opts.overrideImportPath))
 		}
 
+		if opts.badges {
+			pkgOpts = append(pkgOpts, lang.PackageWithBadges())
+		}
+
+		if opts.cAPI {
+			pkgOpts = append(pkgOpts, lang.PackageWithCAPI())
+		}
+
+		if opts.buildInfo {
+			pkgOpts = append(pkgOpts, lang.PackageWithBuildInfo())
+		}
+
+		if opts.benchmarks {
+			pkgOpts = append(pkgOpts, lang.PackageWithBenchmarks())
+		}
+
+		if opts.fuzzTargets {
+			pkgOpts = append(pkgOpts, lang.PackageWithFuzzTargets())
+		}
+
+		if opts.classDiagram {
+			pkgOpts = append(pkgOpts, lang.PackageWithClassDiagram())
+		}
+
+		if opts.iotaValues {
+			pkgOpts = append(pkgOpts, lang.PackageWithIotaValues())
+		}
+
+		if opts.computedValues {
+			pkgOpts = append(pkgOpts, lang.PackageWithComputedValues())
+		}
+
+		if opts.funcBodies {
+			pkgOpts = append(pkgOpts, lang.PackageWithFuncBodies())
+		}
+
+		if opts.pinCommit {
+			pkgOpts = append(pkgOpts, lang.PackageWithPinnedCommit())
+		}
+
+		if opts.buildTargets {
+			pkgOpts = append(pkgOpts, lang.PackageWithBuildTargets())
+		}
+
+		if opts.playgroundSharer != nil {
+			pkgOpts = append(pkgOpts, lang.PackageWithPlaygroundLinks(opts.playgroundSharer))
+		}
+
+		if len(opts.tags) > 0 {
+			pkgOpts = append(pkgOpts, lang.PackageWithBuildTags(opts.tags...))
+		}
+
+		if len(opts.platforms) > 0 {
+			pkgOpts = append(pkgOpts, lang.PackageWithPlatforms(opts.platforms...))
+		}
+
+		if opts.flattenEmbedded {
+			pkgOpts = append(pkgOpts, lang.PackageWithFlattenedEmbedding())
+		}
+
+		if opts.promotedMethods {
+			pkgOpts = append(pkgOpts, lang.PackageWithPromotedMethods())
+		}
+
+		if opts.implementers {
+			pkgOpts = append(pkgOpts, lang.PackageWithImplementers(implementers))
+		}
+
+		if opts.implements {
+			pkgOpts = append(pkgOpts, lang.PackageWithImplementedInterfaces(implementers))
+		}
+
+		if importers != nil {
+			pkgOpts = append(pkgOpts, lang.PackageWithImporters(importers))
+		}
+
+		if opts.hideDeprecated {
+			pkgOpts = append(pkgOpts, lang.PackageWithDeprecatedHidden())
+		}
+
+		if opts.noTypeLinks {
+			pkgOpts = append(pkgOpts, lang.PackageWithoutTypeLinks())
+		}
+
+		if opts.indexFields {
+			pkgOpts = append(pkgOpts, lang.PackageWithIndexedFields())
+		}
+
+		if opts.checkExamples {
+			pkgOpts = append(pkgOpts, lang.PackageWithExampleCheck())
+		}
+
+		if opts.strictExamples {
+			pkgOpts = append(pkgOpts, lang.PackageWithStrictExamples())
+		}
+
+		if opts.runExamples {
+			pkgOpts = append(pkgOpts, lang.PackageWithExampleExecution())
+		}
+
+		if opts.translationStrings != nil {
+			pkgOpts = append(pkgOpts, lang.PackageWithTranslations(opts.translationStrings))
+		}
+
+		if len(spec.routes) > 0 {
+			pkgOpts = append(pkgOpts, lang.PackageWithRoutes(spec.routes))
+		}
+
+		if opts.symbolIndex != nil {
+			pkgOpts = append(pkgOpts, lang.PackageWithSymbolIndex(opts.symbolIndex, spec.outputFile))
+		}
+
+		if opts.stringCatalog != nil {
+			pkgOpts = append(pkgOpts, lang.PackageWithStringCatalog(opts.stringCatalog))
+		}
+
+		if opts.symbolAliasMap != nil {
+			pkgOpts = append(pkgOpts, lang.PackageWithSymbolAliases(opts.symbolAliasMap))
+		}
+
+		if opts.importURLResolver != nil {
+			pkgOpts = append(pkgOpts, lang.PackageWithImportURLResolver(opts.importURLResolver))
+		}
+
+		if opts.symbolFilter != nil {
+			pkgOpts = append(pkgOpts, lang.PackageWithSymbolFilter(opts.symbolFilter))
+		}
+
+		if len(opts.hideMethods) > 0 {
+			pkgOpts = append(pkgOpts, lang.PackageWithHiddenMethods(opts.hideMethods...))
+		}
+
+		pkgOpts = append(
+			pkgOpts,
+			lang.PackageWithExampleTitles(lang.ExampleTitleStyle(opts.exampleTitles)),
+			lang.PackageWithExampleOrder(lang.ExampleOrder(opts.exampleOrder)),
+			lang.PackageWithSymbolOrder(lang.SymbolOrder(opts.symbolOrder)),
+		)
+
+		if opts.internal == internalWarn {
+			pkgOpts = append(pkgOpts, lang.PackageWithInternalWarning())
+		}
+
 		pkg, err := lang.NewPackageFromBuild(log, buildPkg, pkgOpts...)
 		if err != nil {
 			return err
 		}
 
+		if skipInternal(pkg, opts.internal) {
+			log.Debugf("skipping package %s due to internal package mode %s", pkg.ImportPath(), opts.internal)
+			continue
+		}
+
+		if opts.symbolIndex != nil {
+			opts.symbolIndex.Add(pkg, spec.outputFile)
+		}
+
+		if importers != nil {
+			importers.Add(pkg)
+		}
+
 		spec.pkg = pkg
 	}
 
 	return nil
 }
 
+// skipInternal identifies whether the package should be left out of the
+// documentation based on the provided internal package mode.
+func skipInternal(pkg *lang.Package, mode string) bool {
+	switch mode {
+	case internalExclude:
+		return pkg.Internal()
+	case internalOnly:
+		return !pkg.Internal()
+	default:
+		return false
+	}
+}
+
 func getBuildPackage(path string, tags []string) (*build.Package, error) {
 	ctx := build.Default
 	ctx.BuildTags = tags
@@ -521,6 +1749,22 @@ func getBuildPackage(path string, tags []string) (*build.Package, error) {
 	return pkg, nil
 }
 
+// recursivePaths adds the recursive marker to each of the local paths which
+// don't already have it, so the packages nested within them are documented.
+func recursivePaths(paths []string) []string {
+	recursive := make([]string, len(paths))
+	for i, p := range paths {
+		if !isLocalPath(p) || strings.HasSuffix(p, "...") {
+			recursive[i] = p
+			continue
+		}
+
+		recursive[i] = strings.TrimRight(p, "/"+string(os.PathSeparator)) + "/..."
+	}
+
+	return recursive
+}
+
 func getSpecs(paths ...string) []*PackageSpec {
 	var expanded []*PackageSpec
 	for _, path := range paths {
@@ -530,6 +1774,11 @@ func getSpecs(paths ...string) []*PackageSpec {
 
 		// Not a recursive path
 		if !strings.HasSuffix(path, fmt.Sprintf("%s...", string(os.PathSeparator))) {
+			var symbol string
+			if pkgPath, sym, ok := splitSymbolQuery(path); ok {
+				path, symbol = pkgPath, sym
+			}
+
 			isLocal := isLocalPath(path)
 			var dir string
 			if isLocal {
@@ -542,6 +1791,7 @@ func getSpecs(paths ...string) []*PackageSpec {
 				ImportPath: path,
 				isWildcard: false,
 				isLocal:    isLocal,
+				symbol:     symbol,
 			})
 			continue
 		}
@@ -701,14 +1951,17 @@ func getLogLevel(verbosity int) logger.Level {
 }
 
 func printVersion() {
+	fmt.Println(toolVersion())
+}
+
+func toolVersion() string {
 	if version != "" {
-		fmt.Println(version)
-		return
+		return version
 	}
 
 	if info, ok := debug.ReadBuildInfo(); ok {
-		fmt.Println(info.Main.Version)
-	} else {
-		fmt.Println("<unknown>")
+		return info.Main.Version
 	}
+
+	return "<unknown>"
 }
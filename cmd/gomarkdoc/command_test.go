@@ -1,15 +1,32 @@
 package main
 
 import (
+	"archive/tar"
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	goformat "go/format"
+	"go/parser"
+	"go/token"
 	"io"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
 	"github.com/matryer/is"
 )
 
@@ -21,6 +38,7 @@ func TestCommand(t *testing.T) {
 		"./lang/function",
 		"./docs",
 		"./untagged",
+		"./generics",
 	}
 
 	for _, test := range tests {
@@ -141,29 +159,39 @@ func TestCommand_invalidCheck(t *testing.T) {
 	is.Equal(err.Error(), "gomarkdoc: check mode cannot be run without an output set")
 }
 
-func TestCommand_defaultDirectory(t *testing.T) {
+func TestCommand_safeTemplatesOutsideFile(t *testing.T) {
 	is := is.New(t)
 
-	err := os.Chdir(filepath.Join(wd, "../../testData/simple"))
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
-	harness(t, ".", []string{
-		"gomarkdoc",
+	os.Args = []string{
+		"gomarkdoc", "./simple",
+		"--safe-templates",
+		"--header-file", "../go.mod",
 		"--repository.url", "https://github.com/princjef/gomarkdoc",
 		"--repository.default-branch", "master",
-		"--repository.path", "/testData/simple/",
-	})
+		"--repository.path", "/testData/",
+	}
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.True(err != nil)
+	is.Equal(err.Error(), "gomarkdoc: couldn't resolve header file: gomarkdoc: file ../go.mod is outside of the working directory, which is not allowed in safe template mode")
 }
 
-func TestCommand_nonexistant(t *testing.T) {
+func TestCommand_safeTemplatesPostprocess(t *testing.T) {
 	is := is.New(t)
 
 	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
+	out := filepath.Join(t.TempDir(), "README.md")
 	os.Args = []string{
-		"gomarkdoc", "./nonexistant",
-		"-o", "{{.Dir}}/README-test.md",
+		"gomarkdoc", "./simple",
+		"--safe-templates",
+		"--postprocess", "touch " + out + ".ran",
+		"-o", out,
 		"--repository.url", "https://github.com/princjef/gomarkdoc",
 		"--repository.default-branch", "master",
 		"--repository.path", "/testData/",
@@ -171,256 +199,1482 @@ func TestCommand_nonexistant(t *testing.T) {
 
 	cmd := buildCommand()
 	err = cmd.Execute()
-	t.Log(err.Error())
-	is.Equal(err.Error(), fmt.Sprintf("gomarkdoc: invalid package in directory: .%snonexistant", string(filepath.Separator)))
+	is.True(err != nil)
+	is.Equal(err.Error(), "gomarkdoc: postprocess commands cannot be run in safe template mode")
+
+	_, err = os.Stat(out + ".ran")
+	is.True(os.IsNotExist(err)) // Postprocess command ran
 }
 
-func TestCommand_tags(t *testing.T) {
+func TestCommand_safeTemplatesRunExamples(t *testing.T) {
 	is := is.New(t)
 
 	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
-	harness(t, "tags", []string{
-		"gomarkdoc", "./tags",
-		"--tags", "tagged",
+	os.Args = []string{
+		"gomarkdoc", "./simple",
+		"--safe-templates",
+		"--run-examples",
+		"-o", filepath.Join(t.TempDir(), "README.md"),
 		"--repository.url", "https://github.com/princjef/gomarkdoc",
 		"--repository.default-branch", "master",
 		"--repository.path", "/testData/",
-	})
+	}
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.True(err != nil)
+	is.Equal(err.Error(), "gomarkdoc: examples cannot be run in safe template mode")
 }
 
-func TestCommand_tagsWithGOFLAGS(t *testing.T) {
+func TestCommand_safeTemplatesPublish(t *testing.T) {
 	is := is.New(t)
 
 	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
-	os.Setenv("GOFLAGS", "-tags=tagged")
 	os.Args = []string{
-		"gomarkdoc", "./tags",
-		"--config", "../.gomarkdoc-empty.yml",
-		"-o", "{{.Dir}}/README-github-test.md",
+		"gomarkdoc", "./simple",
+		"--safe-templates",
+		"--publish",
+		"-o", filepath.Join(t.TempDir(), "README.md"),
 		"--repository.url", "https://github.com/princjef/gomarkdoc",
 		"--repository.default-branch", "master",
 		"--repository.path", "/testData/",
 	}
-	cleanup(t, "tags")
 
 	cmd := buildCommand()
 	err = cmd.Execute()
-	is.NoErr(err)
-
-	verify(t, "./tags", "github")
+	is.True(err != nil)
+	is.Equal(err.Error(), "gomarkdoc: documentation cannot be published in safe template mode")
 }
 
-func TestCommand_tagsWithGOFLAGSNoTags(t *testing.T) {
+func TestCommand_docusaurusFrontMatter(t *testing.T) {
 	is := is.New(t)
 
 	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
-	err = os.Setenv("GOFLAGS", "-other=foo")
-	is.NoErr(err)
-
+	outFile := filepath.Join(t.TempDir(), "simple.mdx")
 	os.Args = []string{
-		"gomarkdoc", "./tags",
-		"--config", "../.gomarkdoc-empty.yml",
-		"-o", "{{.Dir}}/README-github-test.md",
+		"gomarkdoc", "./simple",
+		"--format", "docusaurus",
+		"--front-matter", "sidebar_position=2",
+		"--front-matter", "title={{.Name}} package",
+		"--front-matter", "id=",
+		"-o", outFile,
 		"--repository.url", "https://github.com/princjef/gomarkdoc",
 		"--repository.default-branch", "master",
 		"--repository.path", "/testData/",
 	}
-	cleanup(t, "tags")
 
 	cmd := buildCommand()
 	err = cmd.Execute()
 	is.NoErr(err)
 
-	verifyNotEqual(t, "./tags", "github")
+	data, err := os.ReadFile(outFile)
+	is.NoErr(err)
+	is.True(strings.HasPrefix(string(data), `---
+title: "simple package"
+slug: "/simple"
+sidebar_position: 2
+---
+{/* Code generated by gomarkdoc. DO NOT EDIT */}
+`))
 }
 
-func TestCommand_tagsWithGOFLAGSNoParse(t *testing.T) {
+func TestCommand_hugoFrontMatter(t *testing.T) {
 	is := is.New(t)
 
 	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
-	err = os.Setenv("GOFLAGS", "invalid")
-	is.NoErr(err)
-
+	outFile := filepath.Join(t.TempDir(), "_index.md")
 	os.Args = []string{
-		"gomarkdoc", "./tags",
-		"--config", "../.gomarkdoc-empty.yml",
-		"-o", "{{.Dir}}/README-github-test.md",
+		"gomarkdoc", "./simple",
+		"--format", "hugo",
+		"--front-matter-syntax", "toml",
+		"--front-matter", "weight=1",
+		"-o", outFile,
 		"--repository.url", "https://github.com/princjef/gomarkdoc",
 		"--repository.default-branch", "master",
 		"--repository.path", "/testData/",
 	}
-	cleanup(t, "tags")
 
 	cmd := buildCommand()
 	err = cmd.Execute()
 	is.NoErr(err)
 
-	verifyNotEqual(t, "./tags", "github")
+	data, err := os.ReadFile(outFile)
+	is.NoErr(err)
+	is.True(strings.HasPrefix(string(data), `+++
+title = "simple"
+description = "Package simple contains, some simple code to exercise basic scenarios for documentation purposes."
+weight = 1
++++
+`))
 }
 
-func TestCommand_embed(t *testing.T) {
+func TestCommand_headerTemplate(t *testing.T) {
 	is := is.New(t)
 
 	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
+	outFile := filepath.Join(t.TempDir(), "README.md")
 	os.Args = []string{
-		"gomarkdoc", "./embed",
-		"--embed",
-		"-o", "{{.Dir}}/README-github-test.md",
-		"--repository.url", "https://github.com/princjef/gomarkdoc",
-		"--repository.default-branch", "master",
-		"--repository.path", "/testData/",
+		"gomarkdoc", "./simple",
+		"--header", `{{.Name}} {{semverLatest "v1.2.0" "1.10.0" "junk"}}`,
+		"--footer", "{{< ref \"other.md\" >}}",
+		"-o", outFile,
 	}
-	cleanup(t, "embed")
 
-	data, err := os.ReadFile("./embed/README-template.md")
+	cmd := buildCommand()
+	err = cmd.Execute()
 	is.NoErr(err)
 
-	err = os.WriteFile("./embed/README-github-test.md", data, 0664)
+	data, err := os.ReadFile(outFile)
 	is.NoErr(err)
+	is.True(strings.Contains(string(data), "\n\nsimple 1.10.0\n\n"))
+	is.True(strings.Contains(string(data), "\n\n{{< ref \"other.md\" >}}\n\n")) // not a valid template
+}
 
-	main()
+func TestCommand_headerBadges(t *testing.T) {
+	is := is.New(t)
 
-	verify(t, "./embed", "github")
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	outFile := filepath.Join(t.TempDir(), "README.md")
+	os.Args = []string{
+		"gomarkdoc", "./simple",
+		"--badges",
+		"--header", `{{range .Package.Badges}}{{badge .Text .Image .URL}} {{end}}`,
+		"-o", outFile,
+	}
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+
+	data, err := os.ReadFile(outFile)
+	is.NoErr(err)
+	is.True(strings.Contains(string(data), "\n\n[![Go Reference](<https://pkg.go.dev/badge/github.com/anthonyme00/gomarkdoc/testData/simple.svg>)](<https://pkg.go.dev/github.com/anthonyme00/gomarkdoc/testData/simple>) ![Docs Coverage]("))
 }
 
-func TestCommand_embed_check(t *testing.T) {
+func TestCommand_safeTemplatesHeaderCall(t *testing.T) {
 	is := is.New(t)
 
 	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
 	os.Args = []string{
-		"gomarkdoc", "./embed",
-		"--embed",
-		"--check",
-		"-o", "{{.Dir}}/README-github-invalid.md",
-		"--repository.url", "https://github.com/princjef/gomarkdoc",
-		"--repository.default-branch", "master",
-		"--repository.path", "/testData/",
+		"gomarkdoc", "./simple",
+		"--safe-templates",
+		"--header", `{{ call "print" }}`,
+		"-o", filepath.Join(t.TempDir(), "README.md"),
 	}
-	cleanup(t, "embed")
-
-	log.SetFlags(0)
 
 	cmd := buildCommand()
-
 	err = cmd.Execute()
-	is.True(err != nil) // Should fail
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "gomarkdoc: template function call is not allowed in safe template mode"))
+}
 
-	os.Args = []string{
-		"gomarkdoc", "./embed",
-		"--embed",
-		"--check",
-		"-o", "{{.Dir}}/README-github.md",
+func TestCommand_metadataCheck(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	outFile := filepath.Join(t.TempDir(), "README.md")
+	args := []string{
+		"gomarkdoc", "./simple",
+		"-o", outFile,
 		"--repository.url", "https://github.com/princjef/gomarkdoc",
 		"--repository.default-branch", "master",
 		"--repository.path", "/testData/",
 	}
-	cleanup(t, "embed")
 
-	log.SetFlags(0)
+	os.Args = append(args, "--metadata", "--metadata-timestamp")
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
 
-	cmd = buildCommand()
+	data, err := os.ReadFile(outFile)
+	is.NoErr(err)
+	is.True(strings.Contains(string(data), "\n\n<!-- gomarkdoc-metadata: tool="))
 
+	// The metadata should not cause the check to fail
+	os.Args = append(args, "--check")
+	cmd = buildCommand()
 	err = cmd.Execute()
-	is.NoErr(err) // Should pass
+	is.NoErr(err)
 }
 
-func TestCompare(t *testing.T) {
-	tests := []struct {
-		b1, b2 []byte
-		equal  bool
-	}{
-		{[]byte("abc"), []byte("abc"), true},
-		{[]byte("abc"), []byte("def"), false},
-		{[]byte{}, []byte{}, true},
-		{[]byte("abc"), []byte{}, false},
-		{[]byte{}, []byte("abc"), false},
+func TestCommand_changelog(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	outFile := filepath.Join(t.TempDir(), "README.md")
+	args := []string{
+		"gomarkdoc", "./simple",
+		"-o", outFile,
+		"--changelog",
 	}
 
-	for _, test := range tests {
-		name := fmt.Sprintf(`"%s" == "%s"`, string(test.b1), string(test.b2))
-		if !test.equal {
-			name = fmt.Sprintf(`"%s" != "%s"`, string(test.b1), string(test.b2))
-		}
+	// There is nothing to compare against on the first run
+	os.Args = args
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
 
-		t.Run(name, func(t *testing.T) {
-			is := is.New(t)
+	data, err := os.ReadFile(outFile)
+	is.NoErr(err)
+	is.True(!strings.Contains(string(data), "gomarkdoc-changes:"))
 
-			eq, err := compare(bytes.NewBuffer(test.b1), bytes.NewBuffer(test.b2))
-			is.NoErr(err)
+	previous := strings.Replace(string(data), "AddNums adds two Nums together.", "AddNums adds.", 1)
+	previous = strings.Replace(previous, `<a name="Num"></a>`, "<a name=\"Old\"></a>\n## func Old\n\n<a name=\"Num\"></a>", 1)
+	err = os.WriteFile(outFile, []byte(previous), 0664)
+	is.NoErr(err)
 
-			is.Equal(eq, test.equal)
-		})
-	}
+	cmd = buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+
+	data, err = os.ReadFile(outFile)
+	is.NoErr(err)
+	is.True(strings.HasSuffix(string(data), "\n\n<!-- gomarkdoc-changes: removed Old -->\n<!-- gomarkdoc-changes: modified AddNums -->\n"))
+
+	// The changelog should not cause the check to fail
+	os.Args = append(args, "--check")
+	cmd = buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
 }
 
-func verify(t *testing.T, dir, format string) {
+func TestCommand_outputTar(t *testing.T) {
 	is := is.New(t)
 
-	data, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("README-%s.md", format)))
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
-	data2, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("README-%s-test.md", format)))
+	tarFile := filepath.Join(t.TempDir(), "docs.tar")
+	os.Args = []string{
+		"gomarkdoc", "./simple", "./lang/function",
+		"-o", "tarred/{{.Dir}}/README.md",
+		"--output-tar", tarFile,
+	}
+
+	cmd := buildCommand()
+	err = cmd.Execute()
 	is.NoErr(err)
 
-	is.Equal(string(data), string(data2))
+	_, err = os.Stat("tarred")
+	is.True(errors.Is(err, os.ErrNotExist)) // nothing is written to the filesystem
+
+	f, err := os.Open(tarFile)
+	is.NoErr(err)
+	defer f.Close()
+
+	var names []string
+	r := tar.NewReader(f)
+	for {
+		hdr, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		is.NoErr(err)
+
+		names = append(names, hdr.Name)
+	}
+
+	sort.Strings(names)
+	is.Equal(names, []string{"tarred/lang/function/README.md", "tarred/simple/README.md"})
 }
 
-func verifyNotEqual(t *testing.T, dir, format string) {
+func TestCommand_outputTarRedactionReport(t *testing.T) {
 	is := is.New(t)
 
-	data, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("README-%s.md", format)))
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
 	is.NoErr(err)
 
-	data2, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("README-%s-test.md", format)))
+	tarFile := filepath.Join(t.TempDir(), "docs.tar")
+	os.Args = []string{
+		"gomarkdoc", "./simple",
+		"-o", "tarred/README.md",
+		"--redact", "test type",
+		"--redaction-report", "tarred/redactions.json",
+		"--output-tar", tarFile,
+	}
+
+	cmd := buildCommand()
+	err = cmd.Execute()
 	is.NoErr(err)
 
-	is.True(string(data) != string(data2))
+	_, err = os.Stat("tarred")
+	is.True(errors.Is(err, os.ErrNotExist)) // nothing is written to the filesystem
+
+	f, err := os.Open(tarFile)
+	is.NoErr(err)
+	defer f.Close()
+
+	var names []string
+	r := tar.NewReader(f)
+	for {
+		hdr, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		is.NoErr(err)
+
+		names = append(names, hdr.Name)
+	}
+
+	sort.Strings(names)
+	is.Equal(names, []string{"tarred/README.md", "tarred/redactions.json"})
 }
 
-func cleanup(t *testing.T, dir string) {
-	f, err := os.ReadDir(dir)
-	if err != nil {
-		t.Fatal(err)
+func TestCommand_checkReport(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	outDir := t.TempDir()
+	args := []string{
+		"gomarkdoc", "./simple", "./lang/function",
+		"-o", filepath.Join(outDir, "{{.Dir}}", "README.md"),
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
 	}
 
-	for _, n := range f {
-		if n.IsDir() {
-			continue
-		}
+	os.Args = args
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
 
-		if !strings.HasPrefix(n.Name(), "README") || !strings.HasSuffix(n.Name(), "-test.md") {
-			continue
-		}
+	simpleFile := filepath.Join(outDir, "simple", "README.md")
+	data, err := os.ReadFile(simpleFile)
+	is.NoErr(err)
+	is.NoErr(os.WriteFile(simpleFile, append([]byte("Outdated line\n"), data...), 0664))
+	is.NoErr(os.Remove(filepath.Join(outDir, "lang", "function", "README.md")))
 
-		os.Remove(filepath.Join(dir, n.Name()))
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	defer func() { os.Stdout = oldStdout }()
 
+	os.Args = append(args, "--check", "--report", "json")
+	cmd = buildCommand()
+	err = cmd.Execute()
+	is.True(err != nil) // Should fail
+	w.Close()
+
+	var report struct {
+		OK    bool
+		Files []struct {
+			File   string
+			Status string
+			Hunks  int
+		}
 	}
+	data, err = io.ReadAll(r)
+	is.NoErr(err)
+	is.NoErr(json.Unmarshal(data, &report))
+
+	is.Equal(report.OK, false)
+	is.Equal(len(report.Files), 2)
+	is.Equal(report.Files[0].File, filepath.Join(outDir, "lang", "function", "README.md"))
+	is.Equal(report.Files[0].Status, "missing")
+	is.Equal(report.Files[1].File, simpleFile)
+	is.Equal(report.Files[1].Status, "stale")
+	is.Equal(report.Files[1].Hunks, 1)
 }
 
-// harness runs the test for all formats. Omit the --output and --format args to
-// the command when running this as it will fill them in for you
-func harness(t *testing.T, dir string, args []string) {
-	for _, format := range []string{"plain", "github", "azure-devops"} {
-		os.Args = args
-		os.Args = append(os.Args, "-o", fmt.Sprintf("{{.Dir}}/README-%s-test.md", format))
-		os.Args = append(os.Args, "--format", format)
-
-		cleanup(t, dir)
+func TestCommand_checkReportSARIF(t *testing.T) {
+	is := is.New(t)
 
-		main()
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
 
-		verify(t, dir, format)
+	outDir := t.TempDir()
+	args := []string{
+		"gomarkdoc", "./simple",
+		"-o", filepath.Join(outDir, "{{.Dir}}", "README.md"),
 	}
+
+	os.Args = args
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+
+	simpleFile := filepath.Join(outDir, "simple", "README.md")
+	data, err := os.ReadFile(simpleFile)
+	is.NoErr(err)
+	is.NoErr(os.WriteFile(simpleFile, []byte(strings.Replace(string(data), "Num is a number.", "Num is a numeral.", 1)), 0664))
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	defer func() { os.Stdout = oldStdout }()
+
+	os.Args = append(args, "--check", "--report", "sarif")
+	cmd = buildCommand()
+	err = cmd.Execute()
+	is.True(err != nil) // Should fail
+	w.Close()
+
+	var log sarifLog
+	data, err = io.ReadAll(r)
+	is.NoErr(err)
+	is.NoErr(json.Unmarshal(data, &log))
+
+	is.Equal(log.Version, "2.1.0")
+	is.Equal(len(log.Runs), 1)
+	is.Equal(len(log.Runs[0].Results), 1)
+
+	res := log.Runs[0].Results[0]
+	is.Equal(res.RuleID, sarifStaleRule)
+	is.True(strings.HasPrefix(res.Message.Text, "Documentation of Num is out of date."))
+	is.Equal(res.Locations[0].PhysicalLocation.ArtifactLocation.URI, filepath.ToSlash(simpleFile))
+	is.True(res.Locations[0].PhysicalLocation.Region.StartLine > 1)
+}
+
+func TestCommand_reportWithoutCheck(t *testing.T) {
+	is := is.New(t)
+
+	os.Args = []string{"gomarkdoc", "./simple", "--report", "json"}
+	cmd := buildCommand()
+	err := cmd.Execute()
+	is.Equal(err.Error(), "gomarkdoc: a report can only be produced in check mode")
+}
+
+func TestCommand_postprocess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("postprocess commands in this test require a posix shell")
+	}
+
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	outFile := filepath.Join(t.TempDir(), "README.md")
+	os.Args = []string{
+		"gomarkdoc", "./simple",
+		"-o", outFile,
+		"--postprocess", "echo first >>",
+		"--postprocess", "echo second >>",
+	}
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+
+	data, err := os.ReadFile(outFile)
+	is.NoErr(err)
+	is.True(strings.HasSuffix(string(data), "\nfirst\nsecond\n")) // commands run in order
+}
+
+func TestCommand_postprocessCheck(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("postprocess commands in this test require a posix shell")
+	}
+
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	outFile := filepath.Join(t.TempDir(), "README.md")
+	args := []string{
+		"gomarkdoc", "./simple",
+		"-o", outFile,
+		"--postprocess", "echo formatted >>",
+	}
+
+	os.Args = args
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+
+	// The check compares the file with the postprocessed output
+	os.Args = append(args, "--check")
+	cmd = buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+
+	data, err := os.ReadFile(outFile)
+	is.NoErr(err)
+	is.Equal(strings.Count(string(data), "formatted"), 1) // file postprocessed again
+}
+
+func TestCommand_redact(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	dir := t.TempDir()
+	outFile := filepath.Join(dir, "README.md")
+	reportFile := filepath.Join(dir, "redactions.json")
+	os.Args = []string{
+		"gomarkdoc", "./simple",
+		"-o", outFile,
+		"--redact", "test type",
+		"--redact", "/[Nn]ums? together/",
+		"--redaction-report", reportFile,
+	}
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+
+	data, err := os.ReadFile(outFile)
+	is.NoErr(err)
+	is.True(!strings.Contains(string(data), "test type"))
+	is.True(!strings.Contains(string(data), "Nums together"))
+	is.True(strings.Contains(string(data), "It is just a [REDACTED] so"))
+
+	data, err = os.ReadFile(reportFile)
+	is.NoErr(err)
+
+	var report []redaction
+	is.NoErr(json.Unmarshal(data, &report))
+	is.Equal(len(report), 2)
+	is.Equal(report[0].File, outFile)
+	is.Equal(report[0].Pattern, "test type")
+	is.Equal(report[1].Pattern, "/[Nn]ums? together/")
+	is.True(report[0].Line < report[1].Line) // sorted by line
+}
+
+func TestNewRedactor(t *testing.T) {
+	is := is.New(t)
+
+	_, err := newRedactor([]string{"/[/"})
+	is.True(err != nil) // invalid regular expression
+
+	_, err = newRedactor([]string{"/a*/"})
+	is.True(err != nil) // matches empty text
+
+	r, err := newRedactor([]string{"a.b"})
+	is.NoErr(err)
+	is.Equal(r.redact(logger.New(logger.ErrorLevel), "", "a.b axb"), "[REDACTED] axb") // plain patterns are literal
+}
+
+func TestCommand_postprocessFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("postprocess commands in this test require a posix shell")
+	}
+
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	args := []string{
+		"gomarkdoc", "./simple",
+		"-o", filepath.Join(t.TempDir(), "README.md"),
+		"--postprocess", "false",
+	}
+
+	os.Args = args
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.True(err != nil) // Should fail
+
+	os.Args = append(args, "--postprocess-failure", "warn")
+	cmd = buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+}
+
+func TestCommand_defaultDirectory(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData/simple"))
+	is.NoErr(err)
+
+	harness(t, ".", []string{
+		"gomarkdoc",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/simple/",
+	})
+}
+
+func TestCommand_nonexistant(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./nonexistant",
+		"-o", "{{.Dir}}/README-test.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	t.Log(err.Error())
+	is.Equal(err.Error(), fmt.Sprintf("gomarkdoc: invalid package in directory: .%snonexistant", string(filepath.Separator)))
+}
+
+func TestCommand_tags(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	harness(t, "tags", []string{
+		"gomarkdoc", "./tags",
+		"--tags", "tagged",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	})
+}
+
+func TestCommand_tagsWithGOFLAGS(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Setenv("GOFLAGS", "-tags=tagged")
+	os.Args = []string{
+		"gomarkdoc", "./tags",
+		"--config", "../.gomarkdoc-empty.yml",
+		"-o", "{{.Dir}}/README-github-test.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "tags")
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+
+	verify(t, "./tags", "github")
+}
+
+func TestCommand_tagsWithGOFLAGSNoTags(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	err = os.Setenv("GOFLAGS", "-other=foo")
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./tags",
+		"--config", "../.gomarkdoc-empty.yml",
+		"-o", "{{.Dir}}/README-github-test.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "tags")
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+
+	verifyNotEqual(t, "./tags", "github")
+}
+
+func TestCommand_tagsWithGOFLAGSNoParse(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	err = os.Setenv("GOFLAGS", "invalid")
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./tags",
+		"--config", "../.gomarkdoc-empty.yml",
+		"-o", "{{.Dir}}/README-github-test.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "tags")
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+
+	verifyNotEqual(t, "./tags", "github")
+}
+
+func TestCommand_embed(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./embed",
+		"--embed",
+		"-o", "{{.Dir}}/README-github-test.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "embed")
+
+	data, err := os.ReadFile("./embed/README-template.md")
+	is.NoErr(err)
+
+	err = os.WriteFile("./embed/README-github-test.md", data, 0664)
+	is.NoErr(err)
+
+	main()
+
+	verify(t, "./embed", "github")
+}
+
+func TestCommand_embedGo(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	fileName := filepath.Join(t.TempDir(), "doc.go")
+	err = os.WriteFile(fileName, []byte("/* gomarkdoc:embed:start */\n\n// Outdated documentation.\n/* gomarkdoc:embed:end */\npackage simple\n"), 0664)
+	is.NoErr(err)
+
+	os.Args = []string{"gomarkdoc", "./simple", "--embed", "--format", "plain", "-o", fileName}
+	is.NoErr(buildCommand().Execute())
+
+	data, err := os.ReadFile(fileName)
+	is.NoErr(err)
+
+	formatted, err := goformat.Source(data)
+	is.NoErr(err)
+	is.Equal(string(data), string(formatted)) // not gofmt-clean
+
+	f, err := parser.ParseFile(token.NewFileSet(), fileName, data, parser.ParseComments)
+	is.NoErr(err)
+
+	doc := f.Doc.Text()
+	is.True(strings.Contains(doc, "Package simple contains"))
+	is.True(!strings.Contains(doc, "Outdated"))
+	is.True(!strings.Contains(doc, "gomarkdoc:embed"))
+
+	for _, line := range strings.Split(string(data), "\n") {
+		is.True(len(line) <= goCommentWidth || !strings.Contains(line, " ")) // line not wrapped
+	}
+
+	// Embedding again leaves the file unchanged
+	is.NoErr(buildCommand().Execute())
+
+	again, err := os.ReadFile(fileName)
+	is.NoErr(err)
+	is.Equal(string(again), string(data))
+}
+
+func TestCommand_sync(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "widget.go"), []byte("package widget\n"), 0664)
+	is.NoErr(err)
+
+	readme := "# widget\n\n<!-- gomarkdoc:sync:start -->\nPackage widget draws **widgets** with the [Widget API](https://example.com/api).\n\n## Usage\n\n- Create a `Widget`\n- Draw it\n\nFor example:\n\n```go\nw := widget.New()\n```\n<!-- gomarkdoc:sync:end -->\n\nMore text.\n"
+	err = os.WriteFile(filepath.Join(dir, "README.md"), []byte(readme), 0664)
+	is.NoErr(err)
+
+	os.Args = []string{"gomarkdoc", "sync", "--check", dir}
+	is.True(buildCommand().Execute() != nil) // doc.go doesn't exist yet
+
+	os.Args = []string{"gomarkdoc", "sync", dir}
+	is.NoErr(buildCommand().Execute())
+
+	data, err := os.ReadFile(filepath.Join(dir, "doc.go"))
+	is.NoErr(err)
+	is.Equal(string(data), `// Package widget draws widgets with the [Widget API].
+//
+// # Usage
+//
+//   - Create a Widget
+//   - Draw it
+//
+// For example:
+//
+//	w := widget.New()
+//
+// [Widget API]: https://example.com/api
+package widget
+`)
+
+	os.Args = []string{"gomarkdoc", "sync", "--check", dir}
+	is.NoErr(buildCommand().Execute())
+
+	// The README is generated from the package comment in the other direction
+	os.Args = []string{"gomarkdoc", "sync", "--to", "readme", dir}
+	is.NoErr(buildCommand().Execute())
+
+	data, err = os.ReadFile(filepath.Join(dir, "README.md"))
+	is.NoErr(err)
+	is.Equal(string(data), "# widget\n\n<!-- gomarkdoc:sync:start -->\n\nPackage widget draws widgets with the [Widget API](https://example.com/api).\n\n## Usage\n\n  - Create a Widget\n  - Draw it\n\nFor example:\n\n\tw := widget.New()\n\n<!-- gomarkdoc:sync:end -->\n\nMore text.\n")
+}
+
+func TestCommand_embed_check(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./embed",
+		"--embed",
+		"--check",
+		"-o", "{{.Dir}}/README-github-invalid.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "embed")
+
+	log.SetFlags(0)
+
+	cmd := buildCommand()
+
+	err = cmd.Execute()
+	is.True(err != nil) // Should fail
+
+	os.Args = []string{
+		"gomarkdoc", "./embed",
+		"--embed",
+		"--check",
+		"-o", "{{.Dir}}/README-github.md",
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+	cleanup(t, "embed")
+
+	log.SetFlags(0)
+
+	cmd = buildCommand()
+
+	err = cmd.Execute()
+	is.NoErr(err) // Should pass
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		b1, b2 []byte
+		equal  bool
+	}{
+		{[]byte("abc"), []byte("abc"), true},
+		{[]byte("abc"), []byte("def"), false},
+		{[]byte{}, []byte{}, true},
+		{[]byte("abc"), []byte{}, false},
+		{[]byte{}, []byte("abc"), false},
+	}
+
+	for _, test := range tests {
+		name := fmt.Sprintf(`"%s" == "%s"`, string(test.b1), string(test.b2))
+		if !test.equal {
+			name = fmt.Sprintf(`"%s" != "%s"`, string(test.b1), string(test.b2))
+		}
+
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+
+			eq, err := compare(bytes.NewBuffer(test.b1), bytes.NewBuffer(test.b2))
+			is.NoErr(err)
+
+			is.Equal(eq, test.equal)
+		})
+	}
+}
+
+func verify(t *testing.T, dir, format string) {
+	is := is.New(t)
+
+	data, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("README-%s.md", format)))
+	is.NoErr(err)
+
+	data2, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("README-%s-test.md", format)))
+	is.NoErr(err)
+
+	is.Equal(string(data), string(data2))
+}
+
+func verifyNotEqual(t *testing.T, dir, format string) {
+	is := is.New(t)
+
+	data, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("README-%s.md", format)))
+	is.NoErr(err)
+
+	data2, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("README-%s-test.md", format)))
+	is.NoErr(err)
+
+	is.True(string(data) != string(data2))
+}
+
+func cleanup(t *testing.T, dir string) {
+	f, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, n := range f {
+		if n.IsDir() {
+			continue
+		}
+
+		if !strings.HasPrefix(n.Name(), "README") || !strings.HasSuffix(n.Name(), "-test.md") {
+			continue
+		}
+
+		os.Remove(filepath.Join(dir, n.Name()))
+
+	}
+}
+
+// harness runs the test for all formats. Omit the --output and --format args to
+// the command when running this as it will fill them in for you
+func harness(t *testing.T, dir string, args []string) {
+	for _, format := range []string{"plain", "github", "azure-devops"} {
+		os.Args = args
+		os.Args = append(os.Args, "-o", fmt.Sprintf("{{.Dir}}/README-%s-test.md", format))
+		os.Args = append(os.Args, "--format", format)
+
+		cleanup(t, dir)
+
+		main()
+
+		verify(t, dir, format)
+	}
+}
+
+func TestAffectedSpecs(t *testing.T) {
+	is := is.New(t)
+
+	a := &PackageSpec{Dir: "./a", isLocal: true, outputFile: "a.md"}
+	b := &PackageSpec{Dir: "./b", isLocal: true, outputFile: "shared.md"}
+	c := &PackageSpec{Dir: "./c", isLocal: true, outputFile: "shared.md"}
+	d := &PackageSpec{Dir: "./d", isLocal: true, outputFile: "d.md"}
+	specs := []*PackageSpec{a, b, c, d}
+
+	is.Equal(affectedSpecs(specs, map[string]bool{"a": true}), []*PackageSpec{a})
+	is.Equal(affectedSpecs(specs, map[string]bool{"c": true}), []*PackageSpec{b, c})
+	is.Equal(len(affectedSpecs(specs, map[string]bool{"e": true})), 0)
+}
+
+func TestPreviewServer(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	s, err := newPreviewServer(logger.New(logger.ErrorLevel), []string{"./simple", "./lang/function"}, commandOptions{})
+	is.NoErr(err)
+
+	get := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w
+	}
+
+	index := get("/")
+	is.Equal(index.Code, http.StatusOK)
+	is.True(strings.Contains(index.Body.String(), `<a href="pkg/simple/index.html">simple</a>`))
+
+	page := get("/pkg/simple/index.html").Body.String()
+	is.True(strings.Contains(page, `<li class="current"><a href="/pkg/simple/index.html">`))
+	is.True(strings.Contains(page, `new EventSource("/_gomarkdoc/events")`))
+	is.True(strings.Contains(page, `<a href="#Num">type Num</a>`))
+
+	is.Equal(get("/pkg/missing/index.html").Code, http.StatusNotFound)
+
+	// Rendering again tells the open pages to reload
+	rendered := s.rendered
+	is.NoErr(s.render())
+	<-rendered
+}
+
+func TestPreviewServer_reload(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	s, err := newPreviewServer(logger.New(logger.ErrorLevel), []string{"./simple"}, commandOptions{})
+	is.NoErr(err)
+
+	spec := s.specs[0]
+	pkg := spec.pkg
+	pages := s.pages
+
+	// The loaded packages and pages are kept if any of the packages fail
+	missing := &PackageSpec{Dir: "./missing", ImportPath: "./missing", isLocal: true}
+	is.True(s.reload([]*PackageSpec{spec, missing}) != nil)
+	is.Equal(spec.pkg, pkg)
+	is.Equal(s.pages, pages)
+
+	is.NoErr(s.reload([]*PackageSpec{spec}))
+	is.True(spec.pkg != pkg)
+	is.Equal(spec.pkg.ImportPath(), pkg.ImportPath())
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	is := is.New(t)
+
+	current := "# pkg\n\n<a name=\"A\"></a>\n## func A\n\na\nb\nc\nd\ne\nf\ng\nh\n"
+	generated := "# pkg\n\n<a name=\"A\"></a>\n## func A\n\na\nb\nc\nd\nE\nf\ng\nh\n"
+
+	diff := unifiedDiff("README.md", current, generated, regexp.MustCompile(`<a name="(.+?)"></a>`))
+	is.Equal(diff, `--- README.md (current)
++++ README.md (generated)
+@@ -7,7 +7,7 @@ A
+ b
+ c
+ d
+-e
++E
+ f
+ g
+ h
+`)
+
+	is.Equal(unifiedDiff("README.md", current, current, nil), "")
+	changes := diffChanges(current, generated, regexp.MustCompile(`<a name="(.+?)"></a>`))
+	is.Equal(len(changes), 1)
+	is.Equal(*changes[0], checkChange{Line: 10, EndLine: 10, Symbol: "A", Diff: "-e\n+E\n"})
+}
+
+func TestCommand_siblingPackages(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	outDir := t.TempDir()
+	os.Args = []string{
+		"gomarkdoc", "./simple", "./lang/function",
+		"-o", filepath.Join(outDir, "{{.Dir}}", "README.md"),
+		"--header", "{{range .Packages}}{{if .Current}}*{{end}}{{.Name}} {{end}}{{with .Prev}}Prev: [{{.Name}}]({{.Href}}){{end}}{{with .Next}}Next: [{{.Name}}]({{.Href}}){{end}}",
+	}
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+
+	data, err := os.ReadFile(filepath.Join(outDir, "simple", "README.md"))
+	is.NoErr(err)
+	is.True(strings.Contains(string(data), "*simple function Next: [function](../lang/function/README.md)"))
+
+	data, err = os.ReadFile(filepath.Join(outDir, "lang", "function", "README.md"))
+	is.NoErr(err)
+	is.True(strings.Contains(string(data), "simple *function Prev: [simple](../../simple/README.md)"))
+}
+
+func TestCommand_coverage(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{"gomarkdoc", "coverage", "--min-coverage", "100", "./simple", "./lang/function"}
+	is.NoErr(buildCommand().Execute())
+
+	os.Args = []string{"gomarkdoc", "coverage", "--min-coverage", "100", "./simple", "./lang/cgo"}
+	err = buildCommand().Execute()
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "below the minimum of 100%"))
+}
+
+func TestNewCoverageReport(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	specs := getSpecs("./lang/cgo")
+	opts, err := subcommandOptions(commandOptions{})
+	is.NoErr(err)
+	is.NoErr(loadPackages(specs, opts))
+
+	cov := newCoverageReport(lang.NewStats([]*lang.Package{specs[0].pkg}), 80)
+	is.Equal(cov.Total, 3)
+	is.Equal(cov.Documented, 2)
+	is.True(cov.BelowMinimum)
+	is.Equal(cov.Packages[0].Undocumented, []string{"Origin"})
+}
+
+func TestCommand_lint(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{"gomarkdoc", "lint", "./simple"}
+	is.NoErr(buildCommand().Execute())
+
+	os.Args = []string{"gomarkdoc", "lint", "./simple", "./lang/lint"}
+	err = buildCommand().Execute()
+	is.True(err != nil)
+	is.Equal(err.Error(), "gomarkdoc: found 6 problems in documentation comments")
+}
+
+func TestCommand_manifest(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	dir := t.TempDir()
+	manifestFile := filepath.Join(dir, "gomarkdoc-manifest.json")
+	os.Args = []string{
+		"gomarkdoc", "./simple", "./tags",
+		"--manifest", manifestFile,
+		"--stats-output", filepath.Join(dir, "STATS.md"),
+		"-o", filepath.Join(dir, "{{.Dir}}.md"),
+	}
+	is.NoErr(buildCommand().Execute())
+
+	b, err := os.ReadFile(manifestFile)
+	is.NoErr(err)
+
+	var m outputManifest
+	is.NoErr(json.Unmarshal(b, &m))
+	is.Equal(m.Options.Format, "github")
+	is.Equal(len(m.Files), 3)
+
+	is.Equal(m.Files[0].File, filepath.ToSlash(filepath.Join(dir, "STATS.md")))
+	is.Equal(len(m.Files[0].Packages), 0)
+
+	is.Equal(m.Files[1].File, filepath.ToSlash(filepath.Join(dir, "simple.md")))
+	is.Equal(m.Files[1].Packages, []string{"github.com/anthonyme00/gomarkdoc/testData/simple"})
+
+	data, err := os.ReadFile(filepath.Join(dir, "simple.md"))
+	is.NoErr(err)
+
+	sum := sha256.Sum256(data)
+	is.Equal(m.Files[1].Hash, "sha256:"+hex.EncodeToString(sum[:]))
+}
+
+func TestCommand_workspace(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData/workspace"))
+	is.NoErr(err)
+
+	dir := t.TempDir()
+	manifestFile := filepath.Join(dir, "gomarkdoc-manifest.json")
+	os.Args = []string{
+		"gomarkdoc",
+		"--manifest", manifestFile,
+		"-o", filepath.Join(dir, "{{.Dir}}", "README.md"),
+	}
+	is.NoErr(buildCommand().Execute())
+
+	b, err := os.ReadFile(manifestFile)
+	is.NoErr(err)
+
+	var m outputManifest
+	is.NoErr(json.Unmarshal(b, &m))
+
+	var pkgs []string
+	for _, f := range m.Files {
+		pkgs = append(pkgs, f.Packages...)
+	}
+
+	is.Equal(pkgs, []string{"example.com/api", "example.com/api/client", "example.com/tools"})
+}
+
+func TestWorkspacePaths(t *testing.T) {
+	is := is.New(t)
+
+	paths, ok, err := workspacePaths(filepath.Join("..", "..", "testData", "workspace"))
+	is.NoErr(err)
+	is.True(ok)
+	is.Equal(paths, []string{
+		filepath.Join("..", "..", "testData", "workspace", "api") + string(os.PathSeparator) + "...",
+		filepath.Join("..", "..", "testData", "workspace", "tools") + string(os.PathSeparator) + "...",
+	})
+
+	_, ok, err = workspacePaths(filepath.Join("..", "..", "testData", "simple"))
+	is.NoErr(err)
+	is.True(!ok)
+}
+
+func TestOutputManifest_updateRedirects(t *testing.T) {
+	is := is.New(t)
+
+	prev := &outputManifest{
+		Files: []*manifestFile{
+			{File: "a/README.md", Packages: []string{"example.com/a"}},
+			{File: "b/README.md", Packages: []string{"example.com/b"}},
+			{File: "old/README.md", Packages: []string{"example.com/old"}},
+		},
+		Redirects: map[string]string{
+			"a/index.md":     "a/README.md",
+			"b/index.md":     "b/README.md",
+			"gone/README.md": "old/README.md",
+		},
+	}
+
+	m := &outputManifest{
+		Files: []*manifestFile{
+			{File: "a/README.md", Packages: []string{"example.com/a"}},
+			{File: "docs/b.md", Packages: []string{"example.com/b"}},
+		},
+	}
+
+	m.updateRedirects(prev)
+	is.Equal(m.Redirects, map[string]string{
+		"b/README.md": "docs/b.md",
+		"a/index.md":  "a/README.md",
+		"b/index.md":  "docs/b.md",
+	})
+
+	is.Equal(formatRedirects(m.Redirects, redirectsNetlify), "/a/index.md /a/README.md 301\n/b/README.md /docs/b.md 301\n/b/index.md /docs/b.md 301\n")
+	is.Equal(formatRedirects(m.Redirects, redirectsNginx), "/a/index.md /a/README.md;\n/b/README.md /docs/b.md;\n/b/index.md /docs/b.md;\n")
+}
+
+func TestNetworkClient(t *testing.T) {
+	is := is.New(t)
+
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		switch len(bodies) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = io.WriteString(w, "ok")
+		}
+	}))
+	defer srv.Close()
+
+	opts, err := subcommandOptions(commandOptions{})
+	is.NoErr(err)
+
+	c, err := newNetworkClient(logger.New(logger.ErrorLevel), opts)
+	is.NoErr(err)
+	c.backoff = time.Millisecond
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("code"))
+	is.NoErr(err)
+
+	resp, err := c.do(req)
+	is.NoErr(err)
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	is.NoErr(err)
+	is.Equal(string(data), "ok")
+	is.Equal(bodies, []string{"code", "code", "code"}) // the body is sent again with each retry
+
+	// The last response is returned once the retries run out
+	bodies = nil
+	c.retries = 0
+	resp, err = c.get(context.Background(), srv.URL)
+	is.NoErr(err)
+	resp.Body.Close()
+	is.Equal(resp.StatusCode, http.StatusServiceUnavailable)
+
+	opts.offline = true
+	c, err = newNetworkClient(logger.New(logger.ErrorLevel), opts)
+	is.NoErr(err)
+
+	_, err = c.get(context.Background(), srv.URL)
+	is.True(errors.Is(err, errOffline))
+	is.Equal(len(bodies), 1) // no requests are sent offline
+
+	opts.offline = false
+	opts.networkRate = 0
+	_, err = newNetworkClient(logger.New(logger.ErrorLevel), opts)
+	is.True(err != nil)
+}
+
+func TestPlaygroundSharer(t *testing.T) {
+	is := is.New(t)
+
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost || string(body) != "package main\n" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_, _ = io.WriteString(w, "abc123\n")
+	}))
+	defer srv.Close()
+
+	opts, err := subcommandOptions(commandOptions{})
+	is.NoErr(err)
+
+	client, err := newNetworkClient(logger.New(logger.ErrorLevel), opts)
+	is.NoErr(err)
+
+	share := newPlaygroundSharer(client, srv.URL)
+
+	u, err := share("package main\n")
+	is.NoErr(err)
+	is.Equal(u, "https://go.dev/play/p/abc123")
+
+	u, err = share("package main\n")
+	is.NoErr(err)
+	is.Equal(u, "https://go.dev/play/p/abc123")
+	is.Equal(requests, 1) // shared code is cached
+
+	_, err = share("package other\n")
+	is.True(err != nil)
+}
+
+func TestCommand_symbolQuery(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	outFile := filepath.Join(t.TempDir(), "symbols.md")
+	os.Args = []string{
+		"gomarkdoc",
+		"./lang/function.Receiver.WithReceiver",
+		"./lang/function.Standalone",
+		"-o", outFile,
+	}
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+
+	data, err := os.ReadFile(outFile)
+	is.NoErr(err)
+	is.True(strings.HasPrefix(string(data), `<a name="Receiver.WithReceiver"></a>`))
+	is.True(strings.Contains(string(data), "func Standalone(p1 int, p2 string) (int, error)"))
+	is.True(!strings.Contains(string(data), "type Receiver")) // only the queried symbols
+
+	os.Args = []string{"gomarkdoc", "./lang/function.Missing", "-o", outFile}
+	cmd = buildCommand()
+	is.True(cmd.Execute() != nil)
+
+	os.Args = []string{"gomarkdoc", "./lang/function.Standalone", "./simple", "-o", outFile}
+	cmd = buildCommand()
+	is.True(cmd.Execute() != nil) // queries can't be mixed with packages
+}
+
+func TestSplitSymbolQuery(t *testing.T) {
+	tests := []struct {
+		path   string
+		pkg    string
+		symbol string
+		ok     bool
+	}{
+		{"net/http.Client.Do", "net/http", "Client.Do", true},
+		{"bytes.Buffer", "bytes", "Buffer", true},
+		{filepath.FromSlash("./lang/function.New"), filepath.FromSlash("./lang/function"), "New", true},
+		{"github.com", "", "", false},
+		{"gopkg.in/yaml.v3", "", "", false},
+		{"net/http.Client.Do.More", "", "", false},
+		{".", "", "", false},
+		{filepath.FromSlash("./simple"), "", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			is := is.New(t)
+
+			pkg, symbol, ok := splitSymbolQuery(test.path)
+			is.Equal(ok, test.ok)
+			is.Equal(pkg, test.pkg)
+			is.Equal(symbol, test.symbol)
+		})
+	}
+}
+
+func TestCommand_includeFooter(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	outFile := filepath.Join(t.TempDir(), "README.md")
+	os.Args = []string{
+		"gomarkdoc", "./simple",
+		"--footer", `{{include "testData/fragments/support.md"}}`,
+		"-o", outFile,
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.NoErr(err)
+
+	data, err := os.ReadFile(outFile)
+	is.NoErr(err)
+	is.True(strings.Contains(string(data), "\n| Platform | Supported |\n| --- | --- |\n| linux | yes |\n"))
+}
+
+func TestCommand_includeOutsideRepository(t *testing.T) {
+	is := is.New(t)
+
+	err := os.Chdir(filepath.Join(wd, "../../testData"))
+	is.NoErr(err)
+
+	os.Args = []string{
+		"gomarkdoc", "./simple",
+		"--footer", `{{include "../go.mod"}}`,
+		"-o", filepath.Join(t.TempDir(), "README.md"),
+		"--repository.url", "https://github.com/princjef/gomarkdoc",
+		"--repository.default-branch", "master",
+		"--repository.path", "/testData/",
+	}
+
+	cmd := buildCommand()
+	err = cmd.Execute()
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "gomarkdoc: couldn't include file ../go.mod"))
+}
+
+func TestConfinedPath(t *testing.T) {
+	is := is.New(t)
+
+	root := t.TempDir()
+	outside := t.TempDir()
+	is.NoErr(os.WriteFile(filepath.Join(root, "inside.md"), []byte("inside"), 0o644))
+	is.NoErr(os.WriteFile(filepath.Join(outside, "secret.md"), []byte("secret"), 0o644))
+
+	if err := os.Symlink(outside, filepath.Join(root, "link")); err != nil {
+		t.Skipf("unable to create symlink: %s", err)
+	}
+
+	p, err := confinedPath(root, "inside.md")
+	is.NoErr(err)
+	is.Equal(filepath.Base(p), "inside.md")
+
+	_, err = confinedPath(root, filepath.Join("link", "secret.md"))
+	is.True(err != nil) // Symlink out of the root allowed
+
+	_, err = confinedPath(root, filepath.Join("..", filepath.Base(outside), "secret.md"))
+	is.True(err != nil) // Relative path out of the root allowed
+
+	_, err = confinedPath(root, filepath.Join(outside, "secret.md"))
+	is.True(err != nil) // Absolute path allowed
 }
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/spf13/cobra"
+)
+
+type (
+	// coverageReport is the report of the documentation coverage of the
+	// packages written to stdout by the coverage command in json.
+	coverageReport struct {
+		Total        int                `json:"total"`
+		Documented   int                `json:"documented"`
+		Coverage     int                `json:"coverage"`
+		MinCoverage  int                `json:"minCoverage"`
+		Packages     []*coveragePackage `json:"packages"`
+		BelowMinimum bool               `json:"belowMinimum"`
+	}
+
+	coveragePackage struct {
+		ImportPath   string          `json:"importPath"`
+		Total        int             `json:"total"`
+		Documented   int             `json:"documented"`
+		Coverage     int             `json:"coverage"`
+		Undocumented []string        `json:"undocumented"`
+		Types        []*coverageType `json:"types"`
+	}
+
+	coverageType struct {
+		Name         string   `json:"name"`
+		Total        int      `json:"total"`
+		Documented   int      `json:"documented"`
+		Coverage     int      `json:"coverage"`
+		Undocumented []string `json:"undocumented"`
+	}
+)
+
+func buildCoverageCommand() *cobra.Command {
+	var (
+		opts        commandOptions
+		minCoverage int
+		report      string
+	)
+
+	command := &cobra.Command{
+		Use:   "coverage [package ...]",
+		Short: "report the documentation coverage of packages",
+		Long:  "Report the percentage of exported symbols with a documentation comment for each package, along with each type and its constructors and methods, listing the symbols without one. The command fails if the total coverage is below the minimum.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = []string{"./..."}
+			}
+
+			if minCoverage < 0 || minCoverage > 100 {
+				return fmt.Errorf("gomarkdoc: minimum coverage must be between 0 and 100: %d", minCoverage)
+			}
+
+			if report != "" && report != reportJSON {
+				return fmt.Errorf("gomarkdoc: invalid report format: %s", report)
+			}
+
+			// Failing to meet the minimum isn't a usage error
+			cmd.SilenceUsage = true
+
+			log := logger.New(getLogLevel(opts.verbosity))
+
+			return runCoverage(log, args, opts, minCoverage, report)
+		},
+	}
+
+	command.Flags().IntVar(
+		&minCoverage,
+		"min-coverage",
+		0,
+		"Minimum percentage of symbols across all of the packages which must have a documentation comment. The command fails if the coverage is below it.",
+	)
+	command.Flags().StringVar(
+		&report,
+		"report",
+		"",
+		"Format of the report of the coverage to write to stdout instead of text. Valid options: json",
+	)
+	command.Flags().BoolVarP(
+		&opts.includeUnexported,
+		"include-unexported",
+		"u",
+		false,
+		"Include unexported symbols and methods in the coverage in addition to exported ones.",
+	)
+	command.Flags().StringSliceVar(
+		&opts.tags,
+		"tags",
+		defaultTags(),
+		"Set of build tags to apply when choosing which files to include for documentation generation.",
+	)
+	command.Flags().CountVarP(
+		&opts.verbosity,
+		"verbose",
+		"v",
+		"Log additional output from the execution of the command. Can be chained for additional verbosity.",
+	)
+
+	return command
+}
+
+// runCoverage loads the packages at the provided paths and writes the report
+// of their documentation coverage to stdout.
+func runCoverage(log logger.Logger, paths []string, opts commandOptions, minCoverage int, report string) error {
+	opts, err := subcommandOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	specs := getSpecs(paths...)
+	if err := loadPackages(specs, opts); err != nil {
+		return err
+	}
+
+	var pkgs []*lang.Package
+	for _, spec := range specs {
+		if spec.pkg != nil {
+			pkgs = append(pkgs, spec.pkg)
+		}
+	}
+
+	stats := lang.NewStats(pkgs)
+	cov := newCoverageReport(stats, minCoverage)
+
+	if report == reportJSON {
+		b, err := json.MarshalIndent(cov, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stdout, string(b))
+	} else {
+		writeCoverage(os.Stdout, cov)
+	}
+
+	if cov.BelowMinimum {
+		return fmt.Errorf("gomarkdoc: documentation coverage of %d%% is below the minimum of %d%%", cov.Coverage, minCoverage)
+	}
+
+	return nil
+}
+
+// newCoverageReport converts the statistics of the packages to a report of
+// their documentation coverage.
+func newCoverageReport(stats *lang.Stats, minCoverage int) *coverageReport {
+	cov := &coverageReport{
+		Total:        stats.Total(),
+		Documented:   stats.Documented(),
+		Coverage:     stats.Coverage(),
+		MinCoverage:  minCoverage,
+		Packages:     []*coveragePackage{},
+		BelowMinimum: stats.Coverage() < minCoverage,
+	}
+
+	for _, p := range stats.Packages() {
+		pkg := &coveragePackage{
+			ImportPath:   p.ImportPath(),
+			Total:        p.Total(),
+			Documented:   p.Documented(),
+			Coverage:     p.Coverage(),
+			Undocumented: nonNil(p.Undocumented()),
+			Types:        []*coverageType{},
+		}
+
+		for _, t := range p.TypeStats() {
+			pkg.Types = append(pkg.Types, &coverageType{
+				Name:         t.Name(),
+				Total:        t.Total(),
+				Documented:   t.Documented(),
+				Coverage:     t.Coverage(),
+				Undocumented: nonNil(t.Undocumented()),
+			})
+		}
+
+		cov.Packages = append(cov.Packages, pkg)
+	}
+
+	return cov
+}
+
+// writeCoverage writes the report of the documentation coverage as text, with
+// a line for each package followed by lines for each of its types which are
+// missing documentation.
+func writeCoverage(w io.Writer, cov *coverageReport) {
+	for _, pkg := range cov.Packages {
+		fmt.Fprintf(w, "%3d%%  %s (%d/%d)\n", pkg.Coverage, pkg.ImportPath, pkg.Documented, pkg.Total)
+
+		for _, t := range pkg.Types {
+			if t.Documented == t.Total {
+				continue
+			}
+
+			fmt.Fprintf(w, "%3d%%    type %s (%d/%d): %s\n", t.Coverage, t.Name, t.Documented, t.Total, strings.Join(t.Undocumented, ", "))
+		}
+
+		if len(pkg.Undocumented) > 0 {
+			fmt.Fprintf(w, "        undocumented: %s\n", strings.Join(pkg.Undocumented, ", "))
+		}
+	}
+
+	fmt.Fprintf(w, "%3d%%  total (%d/%d)\n", cov.Coverage, cov.Documented, cov.Total)
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+
+	return s
+}
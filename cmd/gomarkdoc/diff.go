@@ -0,0 +1,278 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// Valid styles of the diff printed for stale files in check mode.
+const (
+	checkDiffAuto     = "auto"
+	checkDiffUnified  = "unified"
+	checkDiffTerminal = "terminal"
+)
+
+// diffContext is the number of unchanged lines shown around each change in a
+// unified diff.
+const diffContext = 3
+
+// diffLine is a single line of a line-by-line diff, with its operation (' ',
+// '-' or '+') and the number of lines of each side of the diff before it.
+type diffLine struct {
+	op     byte
+	text   string
+	before int
+	after  int
+}
+
+// resolveCheckDiff decides on the style of the diff printed for stale files.
+// In auto mode, a unified diff is used unless stderr is a terminal, so that
+// logs in CI show plain text which can be read without color.
+func resolveCheckDiff(style string) string {
+	if style != checkDiffAuto {
+		return style
+	}
+
+	if info, err := os.Stderr.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		return checkDiffTerminal
+	}
+
+	return checkDiffUnified
+}
+
+// unifiedDiff produces a unified diff between the current contents of the file
+// and the contents generated for it, or an empty string if they match. If an
+// anchor pattern is provided, the header of each hunk names the symbol whose
+// section the hunk starts in, the same way git names the enclosing function.
+func unifiedDiff(path, current, generated string, anchorRegex *regexp.Regexp) string {
+	lines := diffLines(current, generated)
+
+	// Each changed line brings the lines around it into a hunk, which merges
+	// changes close enough to share context
+	inHunk := make([]bool, len(lines))
+	changed := false
+	for i, line := range lines {
+		if line.op == ' ' {
+			continue
+		}
+
+		changed = true
+		for j := i - diffContext; j <= i+diffContext; j++ {
+			if j >= 0 && j < len(lines) {
+				inHunk[j] = true
+			}
+		}
+	}
+
+	if !changed {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s (current)\n", path)
+	fmt.Fprintf(&b, "+++ %s (generated)\n", path)
+
+	for start := 0; start < len(lines); start++ {
+		if !inHunk[start] {
+			continue
+		}
+
+		end := start
+		for end < len(lines) && inHunk[end] {
+			end++
+		}
+
+		writeHunk(&b, lines, start, end, anchorRegex)
+		start = end
+	}
+
+	return b.String()
+}
+
+// writeHunk writes the hunk covering lines[start:end] to the builder.
+func writeHunk(b *strings.Builder, lines []diffLine, start, end int, anchorRegex *regexp.Regexp) {
+	var beforeCount, afterCount int
+	for _, line := range lines[start:end] {
+		if line.op != '+' {
+			beforeCount++
+		}
+
+		if line.op != '-' {
+			afterCount++
+		}
+	}
+
+	// Empty ranges start at the line before them
+	beforeStart := lines[start].before
+	if beforeCount > 0 {
+		beforeStart++
+	}
+
+	afterStart := lines[start].after
+	if afterCount > 0 {
+		afterStart++
+	}
+
+	fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@", beforeStart, beforeCount, afterStart, afterCount)
+	if symbol := hunkSymbol(lines[:start+1], anchorRegex); symbol != "" {
+		fmt.Fprintf(b, " %s", symbol)
+	}
+	b.WriteString("\n")
+
+	for _, line := range lines[start:end] {
+		b.WriteByte(line.op)
+		b.WriteString(line.text)
+		if !strings.HasSuffix(line.text, "\n") {
+			b.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkSymbol finds the name of the last anchor in the generated side of the
+// lines, which is the symbol whose section a hunk starting after them is in.
+func hunkSymbol(lines []diffLine, anchorRegex *regexp.Regexp) string {
+	if anchorRegex == nil {
+		return ""
+	}
+
+	for i := len(lines) - 1; i >= 0; i-- {
+		if lines[i].op == '-' {
+			continue
+		}
+
+		if m := anchorRegex.FindStringSubmatch(lines[i].text); m != nil {
+			return html.UnescapeString(m[1])
+		}
+	}
+
+	return ""
+}
+
+// diffChanges finds the regions of lines which differ between the current
+// contents of the file and the contents generated for it. If an anchor pattern
+// is provided, each change names the symbol whose section it is in.
+func diffChanges(current, generated string, anchorRegex *regexp.Regexp) []*checkChange {
+	lines := diffLines(current, generated)
+
+	var changes []*checkChange
+	for start := 0; start < len(lines); start++ {
+		if lines[start].op == ' ' {
+			continue
+		}
+
+		end := start
+		var (
+			removed int
+			diff    strings.Builder
+		)
+		for ; end < len(lines) && lines[end].op != ' '; end++ {
+			if lines[end].op == '-' {
+				removed++
+			}
+
+			diff.WriteByte(lines[end].op)
+			diff.WriteString(lines[end].text)
+			if !strings.HasSuffix(lines[end].text, "\n") {
+				diff.WriteString("\n")
+			}
+		}
+
+		// Added lines belong after the line before them, or at the start of
+		// the file
+		line := lines[start].before + 1
+		endLine := line + removed - 1
+		if removed == 0 {
+			line = lines[start].before
+			if line == 0 {
+				line = 1
+			}
+
+			endLine = line
+		}
+
+		changes = append(changes, &checkChange{
+			Line:    line,
+			EndLine: endLine,
+			Symbol:  hunkSymbol(lines[:start+1], anchorRegex),
+			Diff:    diff.String(),
+		})
+
+		start = end
+	}
+
+	return changes
+}
+
+// diffLines computes a line-by-line diff between the two texts.
+func diffLines(before, after string) []diffLine {
+	var (
+		lineRunes = make(map[string]rune)
+		runeLines = make(map[rune]string)
+	)
+
+	// Each distinct line is diffed as a single rune. The line mode of
+	// diffmatchpatch isn't used since it mangles texts with more than a few
+	// distinct lines.
+	encode := func(text string) []rune {
+		var runes []rune
+		for _, line := range strings.SplitAfter(text, "\n") {
+			if line == "" {
+				continue
+			}
+
+			r, ok := lineRunes[line]
+			if !ok {
+				r = rune(len(lineRunes) + 1)
+				if r >= 0xD800 {
+					// Surrogates aren't valid runes
+					r += 0x800
+				}
+
+				lineRunes[line] = r
+				runeLines[r] = line
+			}
+
+			runes = append(runes, r)
+		}
+
+		return runes
+	}
+
+	differ := diffmatchpatch.New()
+	diffs := differ.DiffMainRunes(encode(before), encode(after), false)
+
+	var (
+		lines               []diffLine
+		beforeNum, afterNum int
+	)
+	for _, d := range diffs {
+		var op byte
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			op = ' '
+		case diffmatchpatch.DiffDelete:
+			op = '-'
+		case diffmatchpatch.DiffInsert:
+			op = '+'
+		}
+
+		for _, r := range d.Text {
+			lines = append(lines, diffLine{op, runeLines[r], beforeNum, afterNum})
+
+			if op != '+' {
+				beforeNum++
+			}
+
+			if op != '-' {
+				afterNum++
+			}
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// goCommentWidth is the width that the lines of documentation embedded in Go
+// files are wrapped to, including the comment marker.
+const goCommentWidth = 80
+
+var (
+	goEmbedStandaloneRegex = regexp.MustCompile(`(?m:^[ \t]*)/\*\s*gomarkdoc:embed\s*\*/(?m:[ \t]*$)`)
+	goEmbedStartRegex      = regexp.MustCompile(
+		`(?m:^[ \t]*)/\*\s*gomarkdoc:embed:start\s*\*/(?s:.*?)(?:/\*\s*gomarkdoc:embed:end\s*\*/|//gomarkdoc:embed:end)(?m:[ \t]*$)`,
+	)
+	goFenceRegex     = regexp.MustCompile("^\\s*(```|~~~)")
+	orderedListRegex = regexp.MustCompile(`^\d+[.)] `)
+)
+
+// embedGoContents embeds the text into the Go file between its embed markers
+// as line comments, such as to keep the package comment of a doc.go file in
+// sync with the generated documentation. The end marker is written as a
+// directive (//gomarkdoc:embed:end) so that it can be placed right above the
+// package clause without becoming part of the package comment. The file is
+// formatted afterwards so that it stays gofmt-clean.
+func embedGoContents(fileName string, text string) (string, error) {
+	embedText := fmt.Sprintf("/* gomarkdoc:embed:start */\n\n%s\n//gomarkdoc:embed:end", goComment(text))
+
+	// Unlike other files, Go files can't be created from the documentation
+	// alone since they need a package clause
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: unable to embed documentation in Go file %s: %w", fileName, err)
+	}
+
+	var replacements int
+	data = goEmbedStandaloneRegex.ReplaceAllFunc(data, func(_ []byte) []byte {
+		replacements++
+		return []byte(embedText)
+	})
+
+	data = goEmbedStartRegex.ReplaceAllFunc(data, func(_ []byte) []byte {
+		replacements++
+		return []byte(embedText)
+	})
+
+	if replacements == 0 {
+		return "", fmt.Errorf("gomarkdoc: no embed markers found in Go file %s", fileName)
+	}
+
+	formatted, err := format.Source(data)
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: failed to format Go file %s after embedding documentation: %w", fileName, err)
+	}
+
+	return string(formatted), nil
+}
+
+// goComment turns the text into line comments, wrapping the lines of its
+// paragraphs to goCommentWidth. Lines which can't be wrapped without changing
+// their meaning, such as those of code blocks, tables, headings and indented
+// text, are kept as they are.
+func goComment(text string) string {
+	var (
+		b     strings.Builder
+		fence bool
+	)
+
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if goFenceRegex.MatchString(line) {
+			fence = !fence
+			writeGoCommentLine(&b, line)
+			continue
+		}
+
+		if fence || !isWrappable(line) {
+			writeGoCommentLine(&b, line)
+			continue
+		}
+
+		for _, wrapped := range wrapLine(line, goCommentWidth-len("// ")) {
+			writeGoCommentLine(&b, wrapped)
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func writeGoCommentLine(b *strings.Builder, line string) {
+	line = strings.TrimRight(line, " \t")
+	if line == "" {
+		b.WriteString("//\n")
+		return
+	}
+
+	fmt.Fprintf(b, "// %s\n", line)
+}
+
+// isWrappable checks whether the line is text of a paragraph, which can be
+// wrapped onto several lines without changing its meaning.
+func isWrappable(line string) bool {
+	if line == "" || line[0] == ' ' || line[0] == '\t' {
+		return false
+	}
+
+	for _, prefix := range []string{"#", "|", "<", ">", "- ", "* ", "+ "} {
+		if strings.HasPrefix(line, prefix) {
+			return false
+		}
+	}
+
+	return !orderedListRegex.MatchString(line)
+}
+
+// wrapLine splits the line at spaces into lines no wider than the width where
+// possible. Words wider than the width are kept on lines of their own.
+func wrapLine(line string, width int) []string {
+	var (
+		lines   []string
+		current string
+	)
+
+	for _, word := range strings.Fields(line) {
+		switch {
+		case current == "":
+			current = word
+		case len(current)+1+len(word) > width:
+			lines = append(lines, current)
+			current = word
+		default:
+			current += " " + word
+		}
+	}
+
+	return append(lines, current)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/spf13/cobra"
+)
+
+// lintFinding is a problem found by the lint command, as written to stdout in
+// json.
+type lintFinding struct {
+	Package string `json:"package"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Rule    string `json:"rule"`
+	Symbol  string `json:"symbol,omitempty"`
+	Message string `json:"message"`
+}
+
+func buildLintCommand() *cobra.Command {
+	var (
+		opts   commandOptions
+		report string
+	)
+
+	command := &cobra.Command{
+		Use:   "lint [package ...]",
+		Short: "check the documentation comments of packages",
+		Long:  "Check the documentation comments of the packages for a missing package comment, comments which don't start with the name of the symbol they document, doc links to symbols which don't exist and deprecation notices which tools won't recognize. Each finding is printed with the file and line of the comment, and the command fails if there are any.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = []string{"./..."}
+			}
+
+			if report != "" && report != reportJSON {
+				return fmt.Errorf("gomarkdoc: invalid report format: %s", report)
+			}
+
+			// Findings aren't a usage error
+			cmd.SilenceUsage = true
+
+			log := logger.New(getLogLevel(opts.verbosity))
+
+			return runLint(log, args, opts, report)
+		},
+	}
+
+	command.Flags().StringVar(
+		&report,
+		"report",
+		"",
+		"Format of the report of the findings to write to stdout instead of text. Valid options: json",
+	)
+	command.Flags().BoolVarP(
+		&opts.includeUnexported,
+		"include-unexported",
+		"u",
+		false,
+		"Check the documentation comments of unexported symbols and methods in addition to exported ones.",
+	)
+	command.Flags().StringSliceVar(
+		&opts.tags,
+		"tags",
+		defaultTags(),
+		"Set of build tags to apply when choosing which files to include for documentation generation.",
+	)
+	command.Flags().CountVarP(
+		&opts.verbosity,
+		"verbose",
+		"v",
+		"Log additional output from the execution of the command. Can be chained for additional verbosity.",
+	)
+
+	return command
+}
+
+// runLint loads the packages at the provided paths and writes the problems
+// found in their documentation comments to stdout.
+func runLint(log logger.Logger, paths []string, opts commandOptions, report string) error {
+	opts, err := subcommandOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	specs := getSpecs(paths...)
+	if err := loadPackages(specs, opts); err != nil {
+		return err
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	findings := []*lintFinding{}
+	for _, spec := range specs {
+		if spec.pkg == nil {
+			continue
+		}
+
+		for _, f := range spec.pkg.Lint() {
+			findings = append(findings, newLintFinding(wd, spec.pkg, f))
+		}
+	}
+
+	if report == reportJSON {
+		b, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stdout, string(b))
+	} else {
+		writeLint(os.Stdout, findings)
+	}
+
+	if len(findings) > 0 {
+		return fmt.Errorf("gomarkdoc: found %d problems in documentation comments", len(findings))
+	}
+
+	return nil
+}
+
+// newLintFinding converts a finding of a package, naming its file relative to
+// the working directory.
+func newLintFinding(wd string, pkg *lang.Package, f *lang.LintFinding) *lintFinding {
+	loc := f.Location()
+
+	file := loc.Filepath
+	if rel, err := filepath.Rel(wd, file); err == nil {
+		file = rel
+	}
+
+	return &lintFinding{
+		Package: pkg.ImportPath(),
+		File:    filepath.ToSlash(file),
+		Line:    loc.Start.Line,
+		Column:  loc.Start.Col,
+		Rule:    string(f.Rule()),
+		Symbol:  f.Symbol(),
+		Message: f.Message(),
+	}
+}
+
+// writeLint writes the findings in the same file:line:column form as the go
+// vet command, so editors can jump to them.
+func writeLint(w io.Writer, findings []*lintFinding) {
+	for _, f := range findings {
+		fmt.Fprintf(w, "%s:%d:%d: %s (%s)\n", f.File, f.Line, f.Column, f.Message, f.Rule)
+	}
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Valid formats of the redirects file.
+const (
+	redirectsNetlify = "netlify"
+	redirectsNginx   = "nginx"
+)
+
+// outputManifest records the output files written by a run, the packages
+// documented in each of them and the options they were generated with. It is
+// read by deploy steps to find the generated files and their content hashes,
+// and by the next run to tell which packages were moved to a different file.
+type outputManifest struct {
+	// Version holds the version of gomarkdoc which wrote the files.
+	Version string `json:"version"`
+
+	// Options holds the options which affect the contents of the files.
+	Options manifestOptions `json:"options"`
+
+	// Files holds the output files, sorted by path.
+	Files []*manifestFile `json:"files"`
+
+	// Redirects maps the former paths of output files to the paths the
+	// documentation they held was moved to. Redirects are kept across runs
+	// so that links to any earlier path keep working.
+	Redirects map[string]string `json:"redirects,omitempty"`
+}
+
+// manifestFile holds a single output file of the manifest.
+type manifestFile struct {
+	// File holds the path of the output file, as resolved from --output.
+	File string `json:"file"`
+
+	// Packages holds the import paths of the packages documented in the
+	// file. Files which don't document packages, such as the --stats-output
+	// file, have none.
+	Packages []string `json:"packages,omitempty"`
+
+	// Hash holds the SHA-256 hash of the contents of the file, prefixed with
+	// "sha256:".
+	Hash string `json:"hash"`
+}
+
+// manifestOptions holds the options of a run which affect the contents of its
+// output files.
+type manifestOptions struct {
+	Format                     string   `json:"format"`
+	Output                     string   `json:"output"`
+	Tags                       []string `json:"tags,omitempty"`
+	Platforms                  []string `json:"platforms,omitempty"`
+	IncludeUnexported          bool     `json:"includeUnexported"`
+	IncludeTestHelpers         bool     `json:"includeTestHelpers"`
+	IncludeExternalTestHelpers bool     `json:"includeExternalTestHelpers"`
+	Embed                      bool     `json:"embed"`
+	Templates                  []string `json:"templates,omitempty"`
+}
+
+// newOutputManifest creates the manifest of the files written with the
+// provided options, along with the packages documented in each of them. Files
+// which were not rewritten (e.g. in watch mode) keep their hash from the
+// previous manifest, if there is one.
+func newOutputManifest(specs []*PackageSpec, opts commandOptions, prev *outputManifest) *outputManifest {
+	byFile := make(map[string]*manifestFile)
+	for name, hash := range opts.outputHashes {
+		byFile[name] = &manifestFile{File: name, Hash: hash}
+	}
+
+	if prev != nil {
+		for _, f := range prev.Files {
+			if _, ok := byFile[f.File]; !ok && specsFile(specs, f.File) {
+				byFile[f.File] = &manifestFile{File: f.File, Hash: f.Hash}
+			}
+		}
+	}
+
+	for _, spec := range specs {
+		if spec.pkg == nil || spec.outputFile == "" {
+			continue
+		}
+
+		if f, ok := byFile[filepath.ToSlash(spec.outputFile)]; ok {
+			f.Packages = append(f.Packages, spec.pkg.ImportPath())
+		}
+	}
+
+	m := &outputManifest{
+		Version: toolVersion(),
+		Options: newManifestOptions(opts),
+		Files:   []*manifestFile{},
+	}
+
+	for _, f := range byFile {
+		sort.Strings(f.Packages)
+		m.Files = append(m.Files, f)
+	}
+
+	sort.Slice(m.Files, func(i, j int) bool {
+		return m.Files[i].File < m.Files[j].File
+	})
+
+	return m
+}
+
+func newManifestOptions(opts commandOptions) manifestOptions {
+	templates := make(map[string]bool)
+	for name := range opts.templateOverrides {
+		templates[name] = true
+	}
+
+	for name := range opts.templateFileOverrides {
+		templates[name] = true
+	}
+
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return manifestOptions{
+		Format:                     opts.format,
+		Output:                     opts.output,
+		Tags:                       opts.tags,
+		Platforms:                  opts.platforms,
+		IncludeUnexported:          opts.includeUnexported,
+		IncludeTestHelpers:         opts.includeTestHelpers,
+		IncludeExternalTestHelpers: opts.includeXTestHelpers,
+		Embed:                      opts.embed,
+		Templates:                  names,
+	}
+}
+
+// specsFile checks whether the file is the output file of any of the specs.
+func specsFile(specs []*PackageSpec, file string) bool {
+	for _, spec := range specs {
+		if spec.pkg != nil && filepath.ToSlash(spec.outputFile) == file {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordOutput records the hash of the contents of a file which has been
+// written, for the manifest. Files which have been postprocessed are read back
+// so that the hash matches their final contents.
+func recordOutput(fileName string, text string, opts commandOptions) error {
+	if opts.outputHashes == nil {
+		return nil
+	}
+
+	if len(opts.postprocess) > 0 && opts.tar == nil {
+		b, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return err
+		}
+
+		text = string(b)
+	}
+
+	sum := sha256.Sum256([]byte(text))
+	opts.outputHashes[filepath.ToSlash(fileName)] = "sha256:" + hex.EncodeToString(sum[:])
+	return nil
+}
+
+// readManifest reads the manifest written by a previous run, or provides nil if
+// there isn't one.
+func readManifest(fileName string) (*outputManifest, error) {
+	b, err := ioutil.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var m outputManifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("gomarkdoc: invalid manifest file %s: %w", fileName, err)
+	}
+
+	return &m, nil
+}
+
+// updateRedirects adds redirects from the files of the previous manifest to the
+// files their packages are now documented in, and carries over the redirects of
+// the previous manifest. Redirects always point at a current file, and paths
+// which are written again are no longer redirected.
+func (m *outputManifest) updateRedirects(prev *outputManifest) {
+	current := make(map[string]bool)
+	pkgFiles := make(map[string]string)
+	for _, f := range m.Files {
+		current[f.File] = true
+		for _, pkg := range f.Packages {
+			pkgFiles[pkg] = f.File
+		}
+	}
+
+	redirects := make(map[string]string)
+	for _, f := range prev.Files {
+		if current[f.File] {
+			continue
+		}
+
+		// A file split into several files is redirected to the first of them
+		for _, pkg := range f.Packages {
+			if to, ok := pkgFiles[pkg]; ok {
+				redirects[f.File] = to
+				break
+			}
+		}
+	}
+
+	for from, to := range prev.Redirects {
+		if current[from] {
+			continue
+		}
+
+		if !current[to] {
+			var ok bool
+			if to, ok = redirects[to]; !ok {
+				continue
+			}
+		}
+
+		if _, ok := redirects[from]; !ok {
+			redirects[from] = to
+		}
+	}
+
+	if len(redirects) > 0 {
+		m.Redirects = redirects
+	}
+}
+
+// writeManifest writes the manifest of the output files of the specs. The
+// manifest of the previous run is used to redirect the paths of files which
+// have been moved, and the redirects are written in the requested format if a
+// redirects file is requested.
+func writeManifest(specs []*PackageSpec, opts commandOptions) error {
+	prev, err := readManifest(opts.manifest)
+	if err != nil {
+		return err
+	}
+
+	m := newOutputManifest(specs, opts, prev)
+
+	if prev != nil {
+		m.updateRedirects(prev)
+	}
+
+	if opts.redirects != "" {
+		if err := writeOutputFile(opts.redirects, formatRedirects(m.Redirects, opts.redirectsFormat), opts.tar); err != nil {
+			return err
+		}
+	}
+
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeOutputFile(opts.manifest, string(b)+"\n", opts.tar)
+}
+
+// formatRedirects writes the redirects as a Netlify _redirects file, or as the
+// entries of an nginx map block mapping $uri to the new path. Paths are
+// written as absolute urls from the root of the site.
+func formatRedirects(redirects map[string]string, format string) string {
+	from := make([]string, 0, len(redirects))
+	for f := range redirects {
+		from = append(from, f)
+	}
+
+	sort.Strings(from)
+
+	var b strings.Builder
+	for _, f := range from {
+		switch format {
+		case redirectsNginx:
+			fmt.Fprintf(&b, "%s %s;\n", redirectURL(f), redirectURL(redirects[f]))
+		default:
+			fmt.Fprintf(&b, "%s %s 301\n", redirectURL(f), redirectURL(redirects[f]))
+		}
+	}
+
+	return b.String()
+}
+
+func redirectURL(file string) string {
+	return path.Join("/", file)
+}
+
+// writeOutputFile writes a file produced alongside the documentation, adding
+// it to the tar output instead if there is one.
+func writeOutputFile(fileName string, text string, t *tarOutput) error {
+	if t != nil {
+		return t.add(fileName, text)
+	}
+
+	return writeFile(fileName, text)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/anthonyme00/gomarkdoc/logger"
+)
+
+// Defaults for the network flags.
+const (
+	defaultNetworkTimeout = 30 * time.Second
+	defaultNetworkRetries = 3
+	defaultNetworkRate    = 5
+)
+
+// networkBackoff is the delay before the first retry of a failed request,
+// which doubles with each subsequent retry.
+const networkBackoff = 500 * time.Millisecond
+
+// maxRetryAfter caps the delay requested by a server through the Retry-After
+// header, so that a misbehaving server can't stall generation.
+const maxRetryAfter = time.Minute
+
+// errOffline is returned for every request made while the network is disabled
+// with the --offline flag. Features using the network check for it with
+// errors.Is so that they can fall back to working without it.
+var errOffline = errors.New("gomarkdoc: network access is disabled in offline mode")
+
+// networkClient is the http client shared by the features which use the
+// network. Each request is bounded by a timeout, requests are spaced out to
+// stay under a rate limit across all of the features, and requests failing
+// with transient errors are retried with exponential backoff.
+type networkClient struct {
+	log      logger.Logger
+	client   *http.Client
+	offline  bool
+	retries  int
+	interval time.Duration
+	backoff  time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// newNetworkClient creates the network client for the network options of the
+// command.
+func newNetworkClient(log logger.Logger, opts commandOptions) (*networkClient, error) {
+	if opts.networkTimeout <= 0 {
+		return nil, fmt.Errorf("gomarkdoc: invalid network timeout: %s", opts.networkTimeout)
+	}
+
+	if opts.networkRetries < 0 {
+		return nil, fmt.Errorf("gomarkdoc: invalid number of network retries: %d", opts.networkRetries)
+	}
+
+	if opts.networkRate <= 0 {
+		return nil, fmt.Errorf("gomarkdoc: invalid network rate limit: %g", opts.networkRate)
+	}
+
+	return &networkClient{
+		log:      log,
+		client:   &http.Client{Timeout: opts.networkTimeout},
+		offline:  opts.offline,
+		retries:  opts.networkRetries,
+		interval: time.Duration(float64(time.Second) / opts.networkRate),
+		backoff:  networkBackoff,
+	}, nil
+}
+
+// do sends the request, retrying it when it fails with a network error, a 429
+// Too Many Requests response or a 5xx response. The last response is returned
+// when all of the retries fail with an error status, so its status must still
+// be checked by the caller. Requests with a body can only be retried if the
+// body can be provided again through the GetBody field of the request, which
+// http.NewRequest sets for in-memory bodies.
+func (c *networkClient) do(req *http.Request) (*http.Response, error) {
+	if c.offline {
+		return nil, fmt.Errorf("%w: %s %s", errOffline, req.Method, req.URL.Redacted())
+	}
+
+	ctx := req.Context()
+	delay := c.backoff
+	for attempt := 0; ; attempt++ {
+		if err := c.wait(ctx); err != nil {
+			return nil, err
+		}
+
+		attemptReq := req
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return nil, fmt.Errorf("gomarkdoc: unable to retry request %s %s", req.Method, req.URL.Redacted())
+			}
+
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("gomarkdoc: unable to retry request %s %s: %w", req.Method, req.URL.Redacted(), err)
+			}
+
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := c.client.Do(attemptReq)
+		if err == nil && !retryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		if attempt >= c.retries || ctx.Err() != nil {
+			if err != nil {
+				return nil, fmt.Errorf("gomarkdoc: request %s %s failed: %w", req.Method, req.URL.Redacted(), err)
+			}
+
+			return resp, nil
+		}
+
+		wait := delay
+		if err != nil {
+			c.log.Debugf("retrying request %s %s in %s: %s", req.Method, req.URL.Redacted(), wait, err)
+		} else {
+			if after, ok := retryAfter(resp); ok {
+				wait = after
+			}
+
+			resp.Body.Close()
+			c.log.Debugf("retrying request %s %s in %s: %s", req.Method, req.URL.Redacted(), wait, resp.Status)
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+
+		delay *= 2
+	}
+}
+
+// get sends a GET request for the url with the network client.
+func (c *networkClient) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: invalid request url %s: %w", url, err)
+	}
+
+	return c.do(req)
+}
+
+// wait blocks until the rate limit allows another request to be sent,
+// reserving the slot for it.
+func (c *networkClient) wait(ctx context.Context) error {
+	c.mu.Lock()
+	now := time.Now()
+	at := c.next
+	if at.Before(now) {
+		at = now
+	}
+
+	c.next = at.Add(c.interval)
+	c.mu.Unlock()
+
+	return sleep(ctx, at.Sub(now))
+}
+
+// retryableStatus checks whether a request which failed with the status code
+// may succeed if it is sent again.
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// retryAfter provides the delay requested by the Retry-After header of the
+// response, which is either a number of seconds or an http date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	var after time.Duration
+	if seconds, err := strconv.Atoi(header); err == nil {
+		after = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		after = time.Until(date)
+	} else {
+		return 0, false
+	}
+
+	if after < 0 {
+		after = 0
+	}
+
+	if after > maxRetryAfter {
+		after = maxRetryAfter
+	}
+
+	return after, true
+}
+
+// sleep waits for the duration, returning early with the error of the context
+// if it is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -2,21 +2,39 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/anthonyme00/gomarkdoc"
+	"github.com/anthonyme00/gomarkdoc/format"
 	"github.com/anthonyme00/gomarkdoc/lang"
 	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/go-git/go-git/v5"
 	"github.com/princjef/termdiff"
 	"github.com/sergi/go-diff/diffmatchpatch"
 )
 
+// routedSections lists the sections which can be routed to other files in the
+// order they are rendered in when several are routed to the same file.
+var routedSections = []lang.Section{
+	lang.ConstSection,
+	lang.VarSection,
+	lang.FuncSection,
+	lang.TypeSection,
+	lang.ExampleSection,
+}
+
 func writeOutput(specs []*PackageSpec, opts commandOptions) error {
 	log := logger.New(getLogLevel(opts.verbosity))
 
@@ -25,6 +43,8 @@ func writeOutput(specs []*PackageSpec, opts commandOptions) error {
 		return err
 	}
 
+	overrides = append(overrides, gomarkdoc.WithLogger(log))
+
 	out, err := gomarkdoc.NewRenderer(overrides...)
 	if err != nil {
 		return err
@@ -40,40 +60,737 @@ func writeOutput(specs []*PackageSpec, opts commandOptions) error {
 		return err
 	}
 
+	// Only the queried symbols are written for go doc style queries
+	if len(specs) > 0 && specs[0].symbol != "" {
+		return writeSymbols(log, out, specs, opts)
+	}
+
+	headerTmpl := parseContentTemplate(log, out, "header", header, opts)
+	footerTmpl := parseContentTemplate(log, out, "footer", footer, opts)
+
+	frontMatter, err := resolveFrontMatterTemplates(out, opts)
+	if err != nil {
+		return err
+	}
+
 	filePkgs := make(map[string][]*lang.Package)
+	fileSpecs := make(map[string]*PackageSpec)
+	var allPkgs []*lang.Package
 
 	for _, spec := range specs {
 		if spec.pkg == nil {
 			continue
 		}
 
+		if _, ok := fileSpecs[spec.outputFile]; !ok {
+			fileSpecs[spec.outputFile] = spec
+		}
+
 		filePkgs[spec.outputFile] = append(filePkgs[spec.outputFile], spec.pkg)
+		allPkgs = append(allPkgs, spec.pkg)
+
+		// Routed sections are rendered from views of the package in their
+		// own files
+		for _, section := range routedSections {
+			file, ok := spec.routes[section]
+			if !ok {
+				continue
+			}
+
+			if _, ok := fileSpecs[file]; !ok {
+				fileSpecs[file] = spec
+			}
+
+			filePkgs[file] = append(filePkgs[file], spec.pkg.ForSection(section))
+		}
 	}
 
-	var checkErr error
+	if opts.manifest != "" && !opts.check && opts.stringCatalog == nil {
+		opts.outputHashes = make(map[string]string)
+	}
+
+	now := time.Now()
+
+	var timestamp time.Time
+	if opts.metadataTimestamp {
+		timestamp = now
+	}
+
+	var results []*checkResult
 	for fileName, pkgs := range filePkgs {
-		file := lang.NewFile(header, footer, pkgs)
+		// Only the files of changed packages are rewritten in watch mode
+		if opts.regenerate != nil && !opts.regenerate[fileName] {
+			continue
+		}
+
+		// Templates describe the first package in the file
+		data := newTemplateData(fileSpecs[fileName], specs, now)
+
+		fileHeader, err := renderContent(headerTmpl, header, data)
+		if err != nil {
+			return err
+		}
+
+		fileFooter, err := renderContent(footerTmpl, footer, data)
+		if err != nil {
+			return err
+		}
+
+		file := lang.NewFile(fileHeader, fileFooter, pkgs)
+		file.TableOfContents = opts.toc
+
+		if len(frontMatter) > 0 {
+			file.FrontMatter, err = renderFrontMatter(frontMatter, data, lang.FrontMatterSyntax(opts.frontMatterSyntax))
+			if err != nil {
+				return err
+			}
+		}
+
+		if opts.metadata {
+			file.Metadata = lang.NewMetadata(toolVersion(), fileSpecs[fileName].pkg, timestamp)
+		}
 
 		text, err := out.File(file)
 		if err != nil {
 			return err
 		}
 
-		checkErr, err = handleFile(log, fileName, text, opts)
+		// Rendering the file records its prose in the catalog, but nothing
+		// else is written when extracting strings
+		if opts.stringCatalog != nil {
+			continue
+		}
+
+		res, err := handleFile(log, fileName, text, opts)
+		if err != nil {
+			return err
+		}
+
+		if res != nil {
+			for _, pkg := range pkgs {
+				res.Packages = append(res.Packages, pkg.ImportPath())
+			}
+
+			results = append(results, res)
+		}
+	}
+
+	if opts.stringCatalog != nil {
+		return writeStrings(opts.extractStrings, opts.stringCatalog, opts.tar)
+	}
+
+	if opts.statsOutput != "" {
+		text, err := out.Stats(lang.NewStats(allPkgs))
+		if err != nil {
+			return err
+		}
+
+		res, err := handleFile(log, opts.statsOutput, text, opts)
+		if err != nil {
+			return err
+		}
+
+		if res != nil {
+			results = append(results, res)
+		}
+	}
+
+	var importGraph *lang.ImportGraph
+	if opts.importGraph != "" {
+		importGraph, err = lang.NewImportGraph(lang.ImportGraphFormat(opts.importGraph), allPkgs)
 		if err != nil {
 			return err
 		}
 	}
 
-	if checkErr != nil {
-		return checkErr
+	if opts.importGraphOutput != "" {
+		res, err := handleFile(log, opts.importGraphOutput, importGraph.Source()+"\n", opts)
+		if err != nil {
+			return err
+		}
+
+		if res != nil {
+			results = append(results, res)
+		}
 	}
 
-	return nil
+	if opts.indexOutput != "" {
+		tree := lang.NewPackageTree(opts.indexOutput, allPkgs)
+
+		// The import graph is only embedded when it isn't written separately
+		if opts.importGraphOutput == "" {
+			tree.ImportGraph = importGraph
+		}
+
+		text, err := out.Packages(tree)
+		if err != nil {
+			return err
+		}
+
+		res, err := handleFile(log, opts.indexOutput, text, opts)
+		if err != nil {
+			return err
+		}
+
+		if res != nil {
+			results = append(results, res)
+		}
+	}
+
+	if opts.deprecatedOutput != "" {
+		text, err := out.Deprecated(allPkgs)
+		if err != nil {
+			return err
+		}
+
+		res, err := handleFile(log, opts.deprecatedOutput, text, opts)
+		if err != nil {
+			return err
+		}
+
+		if res != nil {
+			results = append(results, res)
+		}
+	}
+
+	if opts.gettingStartedOutput != "" {
+		guide, err := lang.NewGettingStarted(allPkgs)
+		if err != nil {
+			return err
+		}
+
+		text, err := out.GettingStarted(guide)
+		if err != nil {
+			return err
+		}
+
+		res, err := handleFile(log, opts.gettingStartedOutput, text, opts)
+		if err != nil {
+			return err
+		}
+
+		if res != nil {
+			results = append(results, res)
+		}
+	}
+
+	if opts.outputHashes != nil {
+		if err := writeManifest(specs, opts); err != nil {
+			return err
+		}
+	}
+
+	if opts.redactionReport != "" {
+		if err := opts.redactor.writeReport(opts.redactionReport, opts.tar); err != nil {
+			return err
+		}
+	}
+
+	return reportCheck(results, opts)
+}
+
+// Statuses of the files checked in check mode.
+const (
+	checkOK      = "ok"
+	checkStale   = "stale"
+	checkMissing = "missing"
+	checkError   = "error"
+)
+
+// checkResult holds the result of checking a single output file, as included
+// in the check report.
+type checkResult struct {
+	// File holds the path of the output file.
+	File string `json:"file"`
+
+	// Packages holds the import paths of the packages documented in the
+	// file, if it documents packages.
+	Packages []string `json:"packages,omitempty"`
+
+	// Status holds whether the file is up to date (ok), has different
+	// contents (stale), doesn't exist (missing) or couldn't be checked
+	// (error).
+	Status string `json:"status"`
+
+	// Hunks holds the number of separate regions of lines which differ from
+	// the expected output.
+	Hunks int `json:"hunks"`
+
+	// Changes holds each of the regions of lines which differ from the
+	// expected output.
+	Changes []*checkChange `json:"changes,omitempty"`
+
+	// Error holds the reason the file couldn't be checked.
+	Error string `json:"error,omitempty"`
+
+	err error
+}
+
+// checkChange describes a single region of lines of an output file which
+// differs from the expected output, as included in the check report.
+type checkChange struct {
+	// Line holds the first line of the region in the current file. For lines
+	// which are missing from the file, it holds the line they belong after.
+	Line int `json:"line"`
+
+	// EndLine holds the last line of the region in the current file.
+	EndLine int `json:"endLine"`
+
+	// Symbol holds the anchor of the symbol whose documentation the region is
+	// in, if the format has anchors.
+	Symbol string `json:"symbol,omitempty"`
+
+	// Diff holds the lines removed from and added to the region, in the
+	// style of a unified diff.
+	Diff string `json:"diff"`
+}
+
+// checkReport holds the results of check mode in the format written by the
+// --report option.
+type checkReport struct {
+	OK    bool           `json:"ok"`
+	Files []*checkResult `json:"files"`
+}
+
+// reportCheck writes the report of the check results if requested and provides
+// the error of the first file which failed the check, if any.
+func reportCheck(results []*checkResult, opts commandOptions) error {
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].File < results[j].File
+	})
+
+	report := checkReport{OK: true, Files: results}
+	var checkErr error
+	for _, res := range results {
+		if res.err != nil {
+			report.OK = false
+			if checkErr == nil {
+				checkErr = res.err
+			}
+		}
+	}
+
+	var data interface{}
+	switch opts.report {
+	case reportJSON:
+		if report.Files == nil {
+			report.Files = []*checkResult{}
+		}
+
+		data = report
+	case reportSARIF:
+		data = newSARIFLog(results)
+	}
+
+	if data != nil {
+		b, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stdout, string(b))
+	}
+
+	return checkErr
+}
+
+func writeStrings(fileName string, catalog *lang.StringCatalog, t *tarOutput) error {
+	b, err := json.MarshalIndent(catalog.Strings(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeOutputFile(fileName, string(b)+"\n", t)
+}
+
+// frontMatterTemplate holds the template for the value of a single front matter
+// field.
+type frontMatterTemplate struct {
+	key  string
+	tmpl *template.Template
+}
+
+// templateData defines the data available to the --front-matter option's
+// templates and to header and footer templates.
+type templateData struct {
+	*PackageSpec
+
+	// Name holds the name of the package, or the name of its directory for
+	// main packages.
+	Name string
+
+	// Slug holds a url path for the package's documentation derived from its
+	// location (e.g. "/lang" for the ./lang directory).
+	Slug string
+
+	// Summary holds the first sentence of the package's documentation.
+	Summary string
+
+	// Date holds the UTC time at which the documentation was generated in
+	// RFC 3339 format.
+	Date string
+
+	// Package holds the package itself, which provides additional information
+	// such as its ImportPath.
+	Package *lang.Package
+
+	// Packages lists all of the packages documented in the run in order,
+	// including this one, for rendering navigation between them.
+	Packages []*siblingPackage
+
+	// Prev and Next hold the packages before and after this one in Packages,
+	// or nil at the start and end of the list.
+	Prev *siblingPackage
+	Next *siblingPackage
+
+	// Parents lists the packages documented in the run whose directories
+	// contain this package's directory, outermost first, for rendering
+	// breadcrumbs.
+	Parents []*siblingPackage
+}
+
+// siblingPackage describes one of the packages documented in the same run, as
+// provided to templates.
+type siblingPackage struct {
+	// Name holds the name of the package, or the name of its directory for
+	// main packages.
+	Name string
+
+	// ImportPath holds the import path of the package.
+	ImportPath string
+
+	// Summary holds the first sentence of the package's documentation.
+	Summary string
+
+	// OutputFile holds the path of the file the package's documentation is
+	// written to.
+	OutputFile string
+
+	// Href holds the path of the package's documentation file relative to the
+	// file being rendered, or an empty string if the package is documented in
+	// the same file.
+	Href string
+
+	// Current is true for the package the template is rendered for.
+	Current bool
+
+	dir string
+}
+
+func newTemplateData(spec *PackageSpec, specs []*PackageSpec, date time.Time) templateData {
+	slug := spec.ImportPath
+	if spec.isLocal {
+		slug = filepath.ToSlash(spec.Dir)
+	}
+
+	data := templateData{
+		PackageSpec: spec,
+		Name:        packageDisplayName(spec.pkg),
+		Slug:        path.Join("/", slug),
+		Summary:     spec.pkg.Summary(),
+		Date:        date.UTC().Format(time.RFC3339),
+		Package:     spec.pkg,
+	}
+
+	current := -1
+	for _, s := range specs {
+		if s.pkg == nil {
+			continue
+		}
+
+		if s == spec {
+			current = len(data.Packages)
+		}
+
+		data.Packages = append(data.Packages, newSiblingPackage(s, spec))
+	}
+
+	if current > 0 {
+		data.Prev = data.Packages[current-1]
+	}
+
+	if current >= 0 && current < len(data.Packages)-1 {
+		data.Next = data.Packages[current+1]
+	}
+
+	if spec.isLocal {
+		dir := filepath.Clean(spec.Dir)
+		for _, sibling := range data.Packages {
+			if sibling.dir == "" || sibling.Current {
+				continue
+			}
+
+			if rel, err := filepath.Rel(sibling.dir, dir); err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				data.Parents = append(data.Parents, sibling)
+			}
+		}
+
+		sort.SliceStable(data.Parents, func(i, j int) bool {
+			return len(data.Parents[i].dir) < len(data.Parents[j].dir)
+		})
+	}
+
+	return data
+}
+
+// newSiblingPackage describes the package of the spec for templates rendered
+// for the current package.
+func newSiblingPackage(spec, current *PackageSpec) *siblingPackage {
+	sibling := &siblingPackage{
+		Name:       packageDisplayName(spec.pkg),
+		ImportPath: spec.pkg.ImportPath(),
+		Summary:    spec.pkg.Summary(),
+		OutputFile: spec.outputFile,
+		Current:    spec == current,
+	}
+
+	if spec.isLocal {
+		sibling.dir = filepath.Clean(spec.Dir)
+	}
+
+	if spec.outputFile != "" && current.outputFile != "" && spec.outputFile != current.outputFile {
+		if rel, err := filepath.Rel(filepath.Dir(current.outputFile), spec.outputFile); err == nil {
+			sibling.Href = filepath.ToSlash(rel)
+		}
+	}
+
+	return sibling
+}
+
+// packageDisplayName provides the name of the package, or the name of its
+// directory for main packages.
+func packageDisplayName(pkg *lang.Package) string {
+	if pkg.Name() == "main" {
+		return pkg.Dirname()
+	}
+
+	return pkg.Name()
+}
+
+// ModuleVersion provides the tag of the package's repository which points at
+// the current commit (e.g. v1.2.0), or an empty string if there is no such tag.
+// It is only looked up when a template uses it.
+func (d templateData) ModuleVersion() string {
+	return lang.NewMetadata(toolVersion(), d.Package, time.Time{}).ModuleVersion
+}
+
+// frontMatterDefault holds the default template for a front matter field.
+type frontMatterDefault struct {
+	key  string
+	tmpl string
+}
+
+// Front matter fields included by default for each format.
+var defaultFrontMatter = map[string][]frontMatterDefault{
+	"docusaurus": {
+		{"id", "{{.Name}}"},
+		{"title", "{{.Name}}"},
+		{"slug", "{{.Slug}}"},
+	},
+	"hugo": {
+		{"title", "{{.Name}}"},
+		{"description", "{{.Summary}}"},
+	},
+}
+
+func resolveFrontMatterTemplates(out *gomarkdoc.Renderer, opts commandOptions) ([]frontMatterTemplate, error) {
+	var keys []string
+	values := make(map[string]string)
+
+	for _, d := range defaultFrontMatter[opts.format] {
+		keys = append(keys, d.key)
+		values[d.key] = d.tmpl
+	}
+
+	var custom []string
+	for key, value := range opts.frontMatter {
+		if _, ok := values[key]; !ok {
+			custom = append(custom, key)
+		}
+
+		values[key] = value
+	}
+
+	sort.Strings(custom)
+	keys = append(keys, custom...)
+
+	tmpls := make([]frontMatterTemplate, 0, len(keys))
+	for _, key := range keys {
+		tmpl, err := template.New(key).Funcs(contentTemplateFuncs(out, opts)).Parse(values[key])
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: invalid front matter template for %s: %w", key, err)
+		}
+
+		tmpls = append(tmpls, frontMatterTemplate{key, tmpl})
+	}
+
+	return tmpls, nil
+}
+
+func renderFrontMatter(tmpls []frontMatterTemplate, data templateData, syntax lang.FrontMatterSyntax) (*lang.FrontMatter, error) {
+	fm := lang.NewFrontMatter()
+	fm.Syntax = syntax
+	for _, t := range tmpls {
+		var b strings.Builder
+		if err := t.tmpl.Execute(&b, data); err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to render front matter for %s: %w", t.key, err)
+		}
+
+		// Fields with empty values are left out entirely
+		if b.Len() == 0 {
+			continue
+		}
+
+		fm.Set(t.key, frontMatterValue(b.String()))
+	}
+
+	return fm, nil
+}
+
+// parseContentTemplate parses header or footer content as a template with the
+// same data and helper functions as front matter templates. Content which isn't
+// a valid template, such as content holding the shortcodes of a static site
+// generator, is included as is, so nil is returned for it.
+func parseContentTemplate(log logger.Logger, out *gomarkdoc.Renderer, name, content string, opts commandOptions) *template.Template {
+	if !strings.Contains(content, "{{") {
+		return nil
+	}
+
+	tmpl, err := template.New(name).Funcs(contentTemplateFuncs(out, opts)).Parse(content)
+	if err != nil {
+		log.Debugf("including %s as is since it is not a valid template: %s", name, err)
+		return nil
+	}
+
+	return tmpl
+}
+
+// contentTemplateFuncs provides the functions available to header, footer and
+// front matter templates, which are the helper functions along with include
+// and badge. The include function provides the contents of the file at the
+// provided slash-separated path relative to the root of the repository (e.g.
+// {{ include "docs/support-matrix.md" }}), so that shared fragments can be
+// maintained outside of the templates. The badge function renders a badge in
+// the output format, such as each of the badges of .Package.Badges. In safe
+// template mode, the functions disabled for the package templates are disabled
+// here as well.
+func contentTemplateFuncs(out *gomarkdoc.Renderer, opts commandOptions) template.FuncMap {
+	funcs := gomarkdoc.HelperFuncs()
+	if opts.safeTemplates {
+		funcs = gomarkdoc.SafeHelperFuncs()
+	}
+
+	funcs["badge"] = out.Badge
+	funcs["include"] = func(name string) (string, error) {
+		return includeFile(name, opts.safeTemplates)
+	}
+
+	return funcs
+}
+
+// includeFile reads the file at the provided slash-separated path relative to
+// the root of the git repository holding the working directory, or relative to
+// the working directory if it isn't within a repository or when restricted.
+// Paths leading outside of the root, including through symlinks, are rejected.
+func includeFile(name string, restricted bool) (string, error) {
+	root, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	if !restricted {
+		if repo, err := git.PlainOpenWithOptions(root, &git.PlainOpenOptions{DetectDotGit: true}); err == nil {
+			if t, err := repo.Worktree(); err == nil {
+				root = t.Filesystem.Root()
+			}
+		}
+	}
+
+	p, err := confinedPath(root, filepath.FromSlash(name))
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: couldn't include file %s: %w", name, err)
+	}
+
+	b, err := ioutil.ReadFile(p)
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: couldn't include file %s: %w", name, err)
+	}
+
+	return string(b), nil
+}
+
+// confinedPath resolves the relative path within the root directory, following
+// any symlinks, and returns an error if the resolved path is outside of the
+// root.
+func confinedPath(root, name string) (string, error) {
+	if filepath.IsAbs(name) {
+		return "", fmt.Errorf("path %s is not relative", name)
+	}
+
+	root, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", err
+	}
+
+	p, err := filepath.EvalSymlinks(filepath.Join(root, filepath.Clean(name)))
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(root, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s escapes %s", name, root)
+	}
+
+	return p, nil
+}
+
+func renderContent(tmpl *template.Template, content string, data templateData) (string, error) {
+	if tmpl == nil {
+		return content, nil
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("gomarkdoc: failed to render %s: %w", tmpl.Name(), err)
+	}
+
+	return b.String(), nil
+}
+
+// frontMatterValue converts rendered values which look like numbers or booleans
+// (e.g. sidebar_position) to their typed equivalents.
+func frontMatterValue(value string) interface{} {
+	if i, err := strconv.Atoi(value); err == nil {
+		return i
+	}
+
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	return value
 }
 
-func handleFile(log logger.Logger, fileName string, text string, opts commandOptions) (error, error) {
-	if opts.embed && fileName != "" {
+// handleFile writes the text to the file, or to stdout if there is no file, and
+// runs the postprocess commands on the written file. In check mode, the file is
+// compared with the text instead and the result of the check is provided. The
+// redaction patterns are applied to the text before anything else, so that
+// redacted text never reaches the file or the files it is embedded in.
+func handleFile(log logger.Logger, fileName string, text string, opts commandOptions) (*checkResult, error) {
+	if opts.redactor != nil {
+		text = opts.redactor.redact(log, fileName, text)
+	}
+
+	if opts.embed && filepath.Ext(fileName) == ".go" {
+		var err error
+		if text, err = embedGoContents(fileName, text); err != nil {
+			return nil, err
+		}
+	} else if opts.embed && fileName != "" {
 		text = embedContents(log, fileName, text)
 	}
 
@@ -81,15 +798,51 @@ func handleFile(log logger.Logger, fileName string, text string, opts commandOpt
 	case fileName == "":
 		fmt.Fprint(os.Stdout, text)
 	case opts.check:
+		if len(opts.postprocess) > 0 {
+			var err error
+			if text, err = postprocessText(log, fileName, text, opts); err != nil {
+				return nil, err
+			}
+		}
+
 		var b bytes.Buffer
 		fmt.Fprint(&b, text)
-		if err := checkFile(&b, fileName); err != nil {
-			return err, nil
-		}
+		return checkFile(&b, fileName, opts), nil
 	default:
+		if opts.changelog {
+			f, err := format.ByName(opts.format)
+			if err != nil {
+				return nil, err
+			}
+
+			text, err = appendChangelog(f, fileName, text)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if opts.tar != nil {
+			if err := opts.tar.add(fileName, text); err != nil {
+				return nil, err
+			}
+
+			logger.ReportProgress(log, logger.Progress{Event: logger.FileWrittenEvent, File: fileName})
+			return nil, recordOutput(fileName, text, opts)
+		}
+
 		if err := writeFile(fileName, text); err != nil {
 			return nil, fmt.Errorf("failed to write output file %s: %w", fileName, err)
 		}
+
+		logger.ReportProgress(log, logger.Progress{Event: logger.FileWrittenEvent, File: fileName})
+
+		if err := postprocessFile(log, fileName, opts); err != nil {
+			return nil, err
+		}
+
+		if err := recordOutput(fileName, text, opts); err != nil {
+			return nil, err
+		}
 	}
 	return nil, nil
 }
@@ -110,18 +863,35 @@ func writeFile(fileName string, text string) error {
 	return nil
 }
 
-func checkFile(b *bytes.Buffer, path string) error {
-	checkErr := errors.New("output does not match current files. Did you forget to run gomarkdoc?")
+var metadataRegex = regexp.MustCompile(fmt.Sprintf(`\n*[^\n]*%s[^\n]*`, regexp.QuoteMeta(lang.MetadataPrefix)))
+
+// stripMetadata removes the lines holding generation metadata from the text,
+// along with any blank lines preceding them.
+func stripMetadata(text string) string {
+	return metadataRegex.ReplaceAllString(text, "")
+}
+
+func checkFile(b *bytes.Buffer, path string, opts commandOptions) *checkResult {
+	res := &checkResult{File: path, Status: checkOK}
 
 	fileContents, err := os.ReadFile(path)
-	if err == os.ErrNotExist {
+	if errors.Is(err, os.ErrNotExist) {
+		res.Status = checkMissing
 		fileContents = []byte{}
 	} else if err != nil {
-		return fmt.Errorf("failed to open file %s for checking: %w", path, err)
+		res.Status = checkError
+		res.err = fmt.Errorf("failed to open file %s for checking: %w", path, err)
+		res.Error = res.err.Error()
+		return res
 	}
 
+	// Generation metadata and changelogs change between runs, so they're left
+	// out of the check
+	expected := stripMetadata(b.String())
+	actual := stripMetadata(stripChangelog(string(fileContents)))
+
 	differ := diffmatchpatch.New()
-	diff := differ.DiffBisect(b.String(), string(fileContents), time.Now().Add(time.Second))
+	diff := differ.DiffBisect(expected, actual, time.Now().Add(time.Second))
 
 	// Remove equal diffs
 	var filtered = make([]diffmatchpatch.Diff, 0, len(diff))
@@ -134,19 +904,36 @@ func checkFile(b *bytes.Buffer, path string) error {
 	}
 
 	if len(filtered) != 0 {
-		diffs := termdiff.DiffsFromDiffMatchPatch(diff)
+		// Changes name the symbols they're in when the format has anchors
+		var anchorRegex *regexp.Regexp
+		if f, err := format.ByName(opts.format); err == nil {
+			anchorRegex, _ = anchorPattern(f)
+		}
+
 		fmt.Fprintln(os.Stderr)
-		termdiff.Fprint(
-			os.Stderr,
-			path,
-			diffs,
-			termdiff.WithBeforeText("(expected)"),
-			termdiff.WithAfterText("(actual)"),
-		)
-		return checkErr
+		if resolveCheckDiff(opts.checkDiff) == checkDiffUnified {
+			fmt.Fprint(os.Stderr, unifiedDiff(path, actual, expected, anchorRegex))
+		} else {
+			diffs := termdiff.DiffsFromDiffMatchPatch(diff)
+			termdiff.Fprint(
+				os.Stderr,
+				path,
+				diffs,
+				termdiff.WithBeforeText("(expected)"),
+				termdiff.WithAfterText("(actual)"),
+			)
+		}
+
+		if res.Status == checkOK {
+			res.Status = checkStale
+		}
+
+		res.Changes = diffChanges(actual, expected, anchorRegex)
+		res.Hunks = len(res.Changes)
+		res.err = errors.New("output does not match current files. Did you forget to run gomarkdoc?")
 	}
 
-	return nil
+	return res
 }
 
 var (
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+)
+
+// Endpoints of the Go Playground.
+const (
+	playgroundShareURL = "https://go.dev/_/share"
+	playgroundURL      = "https://go.dev/play/p/"
+)
+
+// newPlaygroundSharer creates a function sharing examples on the Go Playground
+// at the endpoint by posting their code to its share api with the network
+// client. Shared code is cached, so examples rendered several times (e.g. in
+// watch mode) are only shared once.
+func newPlaygroundSharer(client *networkClient, endpoint string) lang.PlaygroundSharer {
+	var (
+		mu   sync.Mutex
+		urls = make(map[string]string)
+	)
+
+	return func(code string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if u, ok := urls[code]; ok {
+			return u, nil
+		}
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, endpoint, strings.NewReader(code))
+		if err != nil {
+			return "", fmt.Errorf("gomarkdoc: invalid playground share url: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+		resp, err := client.do(req)
+		if err != nil {
+			return "", err
+		}
+
+		defer resp.Body.Close()
+
+		if err := checkStatus(resp); err != nil {
+			return "", err
+		}
+
+		id, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		if err != nil {
+			return "", fmt.Errorf("gomarkdoc: failed to read playground share response: %w", err)
+		}
+
+		u := playgroundURL + strings.TrimSpace(string(id))
+		urls[code] = u
+		return u, nil
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/anthonyme00/gomarkdoc/logger"
+)
+
+// Valid modes for handling failures of postprocess commands.
+const (
+	postprocessFail   = "fail"
+	postprocessWarn   = "warn"
+	postprocessIgnore = "ignore"
+)
+
+// postprocessFile runs each of the postprocess commands in order on the file
+// which was just written. The commands are run by the shell with the path of
+// the file as their final argument, so formatters, link checkers and uploaders
+// can be chained after gomarkdoc. Depending on the failure mode, a failing
+// command either stops gomarkdoc or is logged and skipped over.
+func postprocessFile(log logger.Logger, fileName string, opts commandOptions) error {
+	for _, command := range opts.postprocess {
+		log.Debugf("running postprocess command %q on %s", command, fileName)
+
+		cmd := postprocessCommand(command, fileName)
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+
+		if err := cmd.Run(); err != nil {
+			switch opts.postprocessFailure {
+			case postprocessWarn:
+				log.Warnf("postprocess command %q failed for %s: %s", command, fileName, err)
+			case postprocessIgnore:
+				log.Debugf("ignoring failure of postprocess command %q for %s: %s", command, fileName, err)
+			default:
+				return fmt.Errorf("gomarkdoc: postprocess command %q failed for %s: %w", command, fileName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// postprocessText runs the postprocess commands on the text which would be
+// written to the file, so that the output of the commands can be checked
+// against the file without writing it. The commands are run on a temporary
+// file with the same extension, since formatters often depend on it.
+func postprocessText(log logger.Logger, fileName string, text string, opts commandOptions) (string, error) {
+	f, err := os.CreateTemp("", "gomarkdoc-*"+filepath.Ext(fileName))
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: failed to create file to postprocess: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(text)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: failed to write file to postprocess: %w", err)
+	}
+
+	if err := postprocessFile(log, f.Name(), opts); err != nil {
+		return "", err
+	}
+
+	b, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: failed to read postprocessed file: %w", err)
+	}
+
+	return string(b), nil
+}
+
+func postprocessCommand(command, fileName string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", fmt.Sprintf(`%s "%s"`, command, fileName))
+	}
+
+	// The file name is passed as a positional parameter so it doesn't need to
+	// be quoted for the shell
+	return exec.Command("sh", "-c", fmt.Sprintf(`%s "$1"`, command), "sh", fileName)
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/anthonyme00/gomarkdoc/logger"
+)
+
+// Valid types of publish targets.
+const (
+	publishGit        = "git"
+	publishS3         = "s3"
+	publishGCS        = "gcs"
+	publishConfluence = "confluence"
+)
+
+// Defaults for the settings of publish targets.
+const (
+	defaultPublishRemote  = "origin"
+	defaultPublishBranch  = "gh-pages"
+	defaultPublishMessage = "Update documentation"
+)
+
+// publishTarget is a destination the written documentation is published to,
+// as configured in the publish list of the configuration file. Which of the
+// settings apply depends on the type of the target.
+type publishTarget struct {
+	// Type is the kind of destination: git, s3, gcs or confluence.
+	Type string `mapstructure:"type"`
+
+	// Remote, Branch and Message configure git targets, which commit the
+	// files to the branch (gh-pages by default) and push it to the remote
+	// (origin by default).
+	Remote  string `mapstructure:"remote"`
+	Branch  string `mapstructure:"branch"`
+	Message string `mapstructure:"message"`
+
+	// Bucket, Region and Endpoint configure s3 and gcs targets. The endpoint
+	// can point s3 targets at compatible storage services.
+	Bucket   string `mapstructure:"bucket"`
+	Region   string `mapstructure:"region"`
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Prefix is the directory the files are published under, for every type
+	// of target except confluence.
+	Prefix string `mapstructure:"prefix"`
+
+	// URL, Space and Parent configure confluence targets, which create or
+	// update a page in the space for each file. The URL is the base url of
+	// the confluence site (e.g. https://example.atlassian.net/wiki), and the
+	// parent is the id of the page the pages are created under.
+	URL    string `mapstructure:"url"`
+	Space  string `mapstructure:"space"`
+	Parent string `mapstructure:"parent"`
+}
+
+// publishFile is a written file to publish.
+type publishFile struct {
+	// name is the path of the file relative to the working directory, using
+	// forward slashes.
+	name string
+
+	// title names the contents of the file, for targets which need one.
+	title string
+
+	contents []byte
+}
+
+// validatePublishTargets checks the configuration of the publish targets for
+// the options of the command.
+func validatePublishTargets(opts commandOptions) error {
+	if len(opts.publishTargets) == 0 {
+		return errors.New("gomarkdoc: no publish targets are configured in the configuration file")
+	}
+
+	for i, t := range opts.publishTargets {
+		switch t.Type {
+		case publishGit:
+		case publishS3, publishGCS:
+			if t.Bucket == "" {
+				return fmt.Errorf("gomarkdoc: publish target %d (%s) has no bucket set", i+1, t.Type)
+			}
+		case publishConfluence:
+			if t.URL == "" || t.Space == "" {
+				return fmt.Errorf("gomarkdoc: publish target %d (%s) needs both a url and a space set", i+1, t.Type)
+			}
+
+			if opts.format != "confluence" {
+				return fmt.Errorf("gomarkdoc: publish target %d (%s) can only be used with the confluence format", i+1, t.Type)
+			}
+		default:
+			return fmt.Errorf("gomarkdoc: invalid publish target type: %s", t.Type)
+		}
+	}
+
+	return nil
+}
+
+// publishOutput publishes the files written for the packages to each of the
+// configured targets in order. Targets need the network, so they are skipped
+// in offline mode.
+func publishOutput(specs []*PackageSpec, opts commandOptions) error {
+	log := logger.New(getLogLevel(opts.verbosity))
+
+	files, err := publishFiles(specs, opts)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	for _, t := range opts.publishTargets {
+		if opts.offline {
+			log.Warnf("skipping %s publish target in offline mode", t.Type)
+			continue
+		}
+
+		var err error
+		switch t.Type {
+		case publishGit:
+			err = publishToGit(log, t, files)
+		case publishS3:
+			err = publishToS3(ctx, opts.network, t, files)
+		case publishGCS:
+			err = publishToGCS(ctx, opts.network, t, files)
+		case publishConfluence:
+			err = publishToConfluence(ctx, opts.network, t, files)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("published %d files to %s target", len(files), t.Type)
+	}
+
+	return nil
+}
+
+// publishFiles reads the files written for the packages, along with the
+// stats, index and deprecation pages. Files written for packages are titled
+// with the import path of their first package, and the others with their
+// path. Files outside of the working directory can't be published.
+func publishFiles(specs []*PackageSpec, opts commandOptions) ([]*publishFile, error) {
+	titles := make(map[string]string)
+	for _, spec := range specs {
+		if spec.pkg == nil || spec.outputFile == "" {
+			continue
+		}
+
+		if _, ok := titles[spec.outputFile]; !ok {
+			titles[spec.outputFile] = spec.pkg.ImportPath()
+		}
+	}
+
+	for _, fileName := range []string{opts.statsOutput, opts.indexOutput, opts.deprecatedOutput, opts.gettingStartedOutput} {
+		if fileName != "" {
+			titles[fileName] = ""
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*publishFile, 0, len(titles))
+	for fileName, title := range titles {
+		abs, err := filepath.Abs(fileName)
+		if err != nil {
+			return nil, err
+		}
+
+		rel, err := filepath.Rel(wd, abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("gomarkdoc: %s is outside of the working directory, so it can't be published", fileName)
+		}
+
+		b, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to read %s for publishing: %w", fileName, err)
+		}
+
+		name := filepath.ToSlash(rel)
+		if title == "" {
+			title = strings.TrimSuffix(name, path.Ext(name))
+		}
+
+		files = append(files, &publishFile{name: name, title: title, contents: b})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].name < files[j].name
+	})
+
+	return files, nil
+}
+
+// publishedName provides the name of the file under the prefix of the target.
+func publishedName(t publishTarget, f *publishFile) string {
+	prefix := strings.Trim(t.Prefix, "/")
+	if prefix == "" {
+		return f.name
+	}
+
+	return prefix + "/" + f.name
+}
+
+// publishContentType provides the media type a file is served with.
+func publishContentType(name string) string {
+	switch path.Ext(name) {
+	case ".md":
+		return "text/markdown; charset=utf-8"
+	case ".adoc", ".rst", ".txt", "":
+		return "text/plain; charset=utf-8"
+	}
+
+	if typ := mime.TypeByExtension(path.Ext(name)); typ != "" {
+		return typ
+	}
+
+	return "application/octet-stream"
+}
+
+// expectSuccess checks that a request sent with the network client succeeded
+// with a 2xx status, closing the body of the response.
+func expectSuccess(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	return checkStatus(resp)
+}
+
+// checkStatus provides an error including the start of the body of the
+// response if it doesn't have a 2xx status.
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("unexpected response %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+}
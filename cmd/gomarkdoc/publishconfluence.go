@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// confluencePage is the subset of a page of the confluence rest api used for
+// publishing.
+type confluencePage struct {
+	ID        string               `json:"id,omitempty"`
+	Type      string               `json:"type"`
+	Title     string               `json:"title"`
+	Space     confluenceSpace      `json:"space"`
+	Ancestors []confluenceAncestor `json:"ancestors,omitempty"`
+	Version   *confluenceVersion   `json:"version,omitempty"`
+	Body      *confluencePageBody  `json:"body,omitempty"`
+}
+
+type confluenceSpace struct {
+	Key string `json:"key"`
+}
+
+type confluenceAncestor struct {
+	ID string `json:"id"`
+}
+
+type confluenceVersion struct {
+	Number int `json:"number"`
+}
+
+type confluencePageBody struct {
+	Storage confluenceStorage `json:"storage"`
+}
+
+type confluenceStorage struct {
+	Value          string `json:"value"`
+	Representation string `json:"representation"`
+}
+
+type confluencePageMatch struct {
+	ID      string            `json:"id"`
+	Version confluenceVersion `json:"version"`
+}
+
+// publishToConfluence creates or updates a page titled after each of the files
+// in the space of the target, with the file as its body in the confluence
+// storage format. Requests are authorized with CONFLUENCE_USER and
+// CONFLUENCE_TOKEN (an api token) from the environment, or with
+// CONFLUENCE_TOKEN alone as a personal access token.
+func publishToConfluence(ctx context.Context, client *networkClient, t publishTarget, files []*publishFile) error {
+	user, token := os.Getenv("CONFLUENCE_USER"), os.Getenv("CONFLUENCE_TOKEN")
+	if token == "" {
+		return errors.New("gomarkdoc: publishing to confluence requires CONFLUENCE_TOKEN to be set")
+	}
+
+	base := strings.TrimSuffix(t.URL, "/") + "/rest/api/content"
+	send := func(method, u string, body interface{}, result interface{}) error {
+		var r io.Reader
+		if body != nil {
+			b, err := json.Marshal(body)
+			if err != nil {
+				return err
+			}
+
+			r = bytes.NewReader(b)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, u, r)
+		if err != nil {
+			return fmt.Errorf("gomarkdoc: invalid confluence publish target: %w", err)
+		}
+
+		req.Header.Set("Accept", "application/json")
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		if user != "" {
+			req.SetBasicAuth(user, token)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := client.do(req)
+		if err != nil {
+			return err
+		}
+
+		defer resp.Body.Close()
+
+		if err := checkStatus(resp); err != nil || result == nil {
+			return err
+		}
+
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+
+	for _, f := range files {
+		var found struct {
+			Results []*confluencePageMatch `json:"results"`
+		}
+
+		query := url.Values{"spaceKey": {t.Space}, "title": {f.title}, "expand": {"version"}}
+		if err := send(http.MethodGet, base+"?"+query.Encode(), nil, &found); err != nil {
+			return fmt.Errorf("gomarkdoc: failed to find confluence page %s: %w", f.title, err)
+		}
+
+		page := &confluencePage{
+			Type:  "page",
+			Title: f.title,
+			Space: confluenceSpace{Key: t.Space},
+			Body: &confluencePageBody{Storage: confluenceStorage{
+				Value:          string(f.contents),
+				Representation: "storage",
+			}},
+		}
+
+		if t.Parent != "" {
+			page.Ancestors = []confluenceAncestor{{ID: t.Parent}}
+		}
+
+		var err error
+		if len(found.Results) == 0 {
+			err = send(http.MethodPost, base, page, nil)
+		} else {
+			existing := found.Results[0]
+			page.ID = existing.ID
+			page.Version = &confluenceVersion{Number: existing.Version.Number + 1}
+			err = send(http.MethodPut, base+"/"+url.PathEscape(existing.ID), page, nil)
+		}
+
+		if err != nil {
+			return fmt.Errorf("gomarkdoc: failed to publish confluence page %s: %w", f.title, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// gcsEndpoint is the base url of the Google Cloud Storage json api.
+const gcsEndpoint = "https://storage.googleapis.com"
+
+// publishToGCS uploads each of the files as an object in the bucket of the
+// target, authorized with the OAuth access token from the
+// GOOGLE_OAUTH_ACCESS_TOKEN environment variable (e.g. as printed by gcloud
+// auth print-access-token).
+func publishToGCS(ctx context.Context, client *networkClient, t publishTarget, files []*publishFile) error {
+	token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN")
+	if token == "" {
+		return errors.New("gomarkdoc: publishing to gcs requires GOOGLE_OAUTH_ACCESS_TOKEN to be set")
+	}
+
+	base := gcsEndpoint
+	if t.Endpoint != "" {
+		base = strings.TrimSuffix(t.Endpoint, "/")
+	}
+
+	for _, f := range files {
+		name := publishedName(t, f)
+		query := url.Values{"uploadType": {"media"}, "name": {name}}
+		u := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?%s", base, url.PathEscape(t.Bucket), query.Encode())
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(f.contents))
+		if err != nil {
+			return fmt.Errorf("gomarkdoc: invalid gcs publish target: %w", err)
+		}
+
+		req.Header.Set("Content-Type", publishContentType(name))
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		if err := expectSuccess(client.do(req)); err != nil {
+			return fmt.Errorf("gomarkdoc: failed to publish %s to gcs: %w", name, err)
+		}
+	}
+
+	return nil
+}
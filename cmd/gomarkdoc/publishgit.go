@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/anthonyme00/gomarkdoc/logger"
+)
+
+// publishToGit commits the files to the branch of the target and pushes it.
+// The commit is built with a temporary index on top of the branch as fetched
+// from the remote (or as a new root commit if it doesn't exist there yet), so
+// the working tree and the current branch of the repository are left alone.
+// Files already on the branch are kept, and nothing is pushed if the files are
+// unchanged.
+func publishToGit(log logger.Logger, t publishTarget, files []*publishFile) error {
+	remote, branch, message := t.Remote, t.Branch, t.Message
+	if remote == "" {
+		remote = defaultPublishRemote
+	}
+
+	if branch == "" {
+		branch = defaultPublishBranch
+	}
+
+	if message == "" {
+		message = defaultPublishMessage
+	}
+
+	tmp, err := os.MkdirTemp("", "gomarkdoc-publish-")
+	if err != nil {
+		return fmt.Errorf("gomarkdoc: failed to create index for publishing: %w", err)
+	}
+
+	defer os.RemoveAll(tmp)
+
+	// The index starts out empty since the file doesn't exist yet
+	gitEnv := append(os.Environ(), "GIT_INDEX_FILE="+filepath.Join(tmp, "index"))
+	run := func(stdin []byte, args ...string) (string, error) {
+		cmd := exec.Command("git", args...)
+		cmd.Env = gitEnv
+		if stdin != nil {
+			cmd.Stdin = bytes.NewReader(stdin)
+		}
+
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+
+		out, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("gomarkdoc: git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+		}
+
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	var parent string
+	if _, err := run(nil, "fetch", "--quiet", remote, branch); err == nil {
+		if parent, err = run(nil, "rev-parse", "FETCH_HEAD"); err != nil {
+			return err
+		}
+
+		if _, err := run(nil, "read-tree", parent); err != nil {
+			return err
+		}
+	} else {
+		log.Debugf("creating branch %s, since it couldn't be fetched from %s: %s", branch, remote, err)
+	}
+
+	for _, f := range files {
+		blob, err := run(f.contents, "hash-object", "-w", "--stdin")
+		if err != nil {
+			return err
+		}
+
+		if _, err := run(nil, "update-index", "--add", "--cacheinfo", fmt.Sprintf("100644,%s,%s", blob, publishedName(t, f))); err != nil {
+			return err
+		}
+	}
+
+	tree, err := run(nil, "write-tree")
+	if err != nil {
+		return err
+	}
+
+	args := []string{"commit-tree", tree, "-m", message}
+	if parent != "" {
+		parentTree, err := run(nil, "rev-parse", parent+"^{tree}")
+		if err != nil {
+			return err
+		}
+
+		if parentTree == tree {
+			log.Infof("documentation on branch %s is up to date", branch)
+			return nil
+		}
+
+		args = append(args, "-p", parent)
+	}
+
+	commit, err := run(nil, args...)
+	if err != nil {
+		return err
+	}
+
+	_, err = run(nil, "push", "--quiet", remote, fmt.Sprintf("%s:refs/heads/%s", commit, branch))
+	return err
+}
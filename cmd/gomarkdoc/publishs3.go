@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// publishToS3 uploads each of the files as an object in the bucket of the
+// target, signing the requests with the AWS credentials from the environment
+// (AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and optionally AWS_SESSION_TOKEN).
+// The region defaults to AWS_REGION or AWS_DEFAULT_REGION. Buckets are
+// addressed by path on custom endpoints, so that compatible storage services
+// can be used.
+func publishToS3(ctx context.Context, client *networkClient, t publishTarget, files []*publishFile) error {
+	creds := awsCredentials{
+		accessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+		secretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		sessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+	}
+
+	if creds.accessKey == "" || creds.secretKey == "" {
+		return errors.New("gomarkdoc: publishing to s3 requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY to be set")
+	}
+
+	region := t.Region
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+
+	if region == "" {
+		return errors.New("gomarkdoc: publishing to s3 requires a region in the target or AWS_REGION to be set")
+	}
+
+	base := fmt.Sprintf("https://%s.s3.%s.amazonaws.com", t.Bucket, region)
+	if t.Endpoint != "" {
+		base = strings.TrimSuffix(t.Endpoint, "/") + "/" + awsEscape(t.Bucket)
+	}
+
+	for _, f := range files {
+		name := publishedName(t, f)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, base+"/"+awsEscapePath(name), bytes.NewReader(f.contents))
+		if err != nil {
+			return fmt.Errorf("gomarkdoc: invalid s3 publish target: %w", err)
+		}
+
+		req.Header.Set("Content-Type", publishContentType(name))
+		creds.sign(req, f.contents, region, "s3", time.Now())
+
+		if err := expectSuccess(client.do(req)); err != nil {
+			return fmt.Errorf("gomarkdoc: failed to publish %s to s3: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// awsCredentials holds the credentials requests to AWS are signed with.
+type awsCredentials struct {
+	accessKey    string
+	secretKey    string
+	sessionToken string
+}
+
+// sign adds the headers authorizing the request with AWS Signature Version 4.
+// The host, date, session token and payload hash headers are signed.
+func (c awsCredentials) sign(req *http.Request, payload []byte, region, service string, now time.Time) {
+	now = now.UTC()
+	date := now.Format("20060102")
+	stamp := now.Format("20060102T150405Z")
+	sum := sha256.Sum256(payload)
+	payloadHash := hex.EncodeToString(sum[:])
+
+	req.Header.Set("X-Amz-Date", stamp)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if c.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.sessionToken)
+	}
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           stamp,
+	}
+
+	if c.sessionToken != "" {
+		headers["x-amz-security-token"] = c.sessionToken
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, strings.TrimSpace(headers[name]))
+	}
+
+	signedHeaders := strings.Join(names, ";")
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		awsCanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", date, region, service)
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		stamp,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := []byte("AWS4" + c.secretKey)
+	for _, part := range []string{date, region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.accessKey, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// awsCanonicalQuery provides the query parameters sorted by name and then by
+// value, escaped as required for AWS signatures. Parameters without a value
+// keep the "=" separator.
+func awsCanonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var params []string
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			params = append(params, awsEscape(name)+"="+awsEscape(value))
+		}
+	}
+
+	return strings.Join(params, "&")
+}
+
+// awsEscapePath escapes each of the segments of a slash separated path as
+// required for AWS signatures.
+func awsEscapePath(name string) string {
+	segments := strings.Split(name, "/")
+	for i, s := range segments {
+		segments[i] = awsEscape(s)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// awsEscape percent-encodes every byte of the string except for the
+// unreserved characters of RFC 3986, as required for AWS signatures.
+func awsEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"os"
+	"strings"
+
+	"github.com/anthonyme00/gomarkdoc"
+	"github.com/anthonyme00/gomarkdoc/logger"
+)
+
+// splitSymbolQuery splits a go doc style query for a symbol of a package
+// (e.g. net/http.Client.Do) into the path of the package and the name of the
+// symbol. The symbol follows the first dot after the last path separator and
+// is either an exported name or an exported type followed by one of its
+// methods. The last return value is false if the path isn't a query, which is
+// always the case for existing directories.
+func splitSymbolQuery(path string) (string, string, bool) {
+	if fi, err := os.Stat(path); err == nil && fi.IsDir() {
+		return "", "", false
+	}
+
+	i := strings.LastIndex(path, string(os.PathSeparator)) + 1
+	name, symbol, ok := strings.Cut(path[i:], ".")
+	if !ok || name == "" {
+		return "", "", false
+	}
+
+	parts := strings.Split(symbol, ".")
+	if len(parts) > 2 || !token.IsExported(parts[0]) {
+		return "", "", false
+	}
+
+	for _, part := range parts {
+		if !token.IsIdentifier(part) {
+			return "", "", false
+		}
+	}
+
+	return path[:i] + name, symbol, true
+}
+
+// validateSymbolQueries checks that symbol queries aren't mixed with packages
+// to document in full, since only the queried symbols are written.
+func validateSymbolQueries(specs []*PackageSpec) error {
+	var queries int
+	for _, spec := range specs {
+		if spec.symbol != "" {
+			queries++
+		}
+	}
+
+	if queries > 0 && queries < len(specs) {
+		return errors.New("gomarkdoc: symbol queries cannot be combined with packages")
+	}
+
+	return nil
+}
+
+// writeSymbols writes the documentation of the symbols queried by the specs,
+// along with their examples, to their output files or stdout. Symbols written
+// to the same file are separated by a blank line.
+func writeSymbols(log logger.Logger, out *gomarkdoc.Renderer, specs []*PackageSpec, opts commandOptions) error {
+	var files []string
+	texts := make(map[string][]string)
+	for _, spec := range specs {
+		if spec.pkg == nil {
+			continue
+		}
+
+		text, err := renderSymbol(out, spec)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := texts[spec.outputFile]; !ok {
+			files = append(files, spec.outputFile)
+		}
+
+		texts[spec.outputFile] = append(texts[spec.outputFile], strings.TrimSpace(text))
+	}
+
+	var results []*checkResult
+	for _, fileName := range files {
+		res, err := handleFile(log, fileName, strings.Join(texts[fileName], "\n\n")+"\n", opts)
+		if err != nil {
+			return err
+		}
+
+		if res != nil {
+			results = append(results, res)
+		}
+	}
+
+	return reportCheck(results, opts)
+}
+
+// renderSymbol renders the documentation of the function, method, type or
+// value queried by the spec.
+func renderSymbol(out *gomarkdoc.Renderer, spec *PackageSpec) (string, error) {
+	pkg := spec.pkg
+	if fn := pkg.LookupFunc(spec.symbol); fn != nil {
+		return out.Func(fn)
+	}
+
+	if typ := pkg.LookupType(spec.symbol); typ != nil {
+		return out.Type(typ)
+	}
+
+	if v := pkg.LookupValue(spec.symbol); v != nil {
+		return out.Value(v)
+	}
+
+	return "", fmt.Errorf("gomarkdoc: no documented symbol %s in package %s", spec.symbol, pkg.ImportPath())
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/anthonyme00/gomarkdoc/logger"
+)
+
+// redactedText replaces each match of a redaction pattern in the output.
+const redactedText = "[REDACTED]"
+
+type (
+	// redactor removes the text matching the configured patterns from the
+	// rendered documentation before it is written, keeping track of the
+	// redactions it makes so that they can be reported.
+	redactor struct {
+		patterns []redactPattern
+
+		mu         sync.Mutex
+		redactions []*redaction
+	}
+
+	// redactPattern is a single pattern to redact, along with the text it was
+	// configured with.
+	redactPattern struct {
+		text string
+		re   *regexp.Regexp
+	}
+
+	// redaction describes the text redacted for a pattern on a line of an
+	// output file, as included in the redaction report. The redacted text
+	// itself is never reported.
+	redaction struct {
+		// File holds the path of the output file, or "-" for stdout.
+		File string `json:"file"`
+
+		// Line holds the line of the output file the text was redacted from.
+		Line int `json:"line"`
+
+		// Pattern holds the pattern the redacted text matched.
+		Pattern string `json:"pattern"`
+
+		// Count holds the number of matches redacted from the line.
+		Count int `json:"count"`
+	}
+)
+
+// newRedactor creates a redactor for the provided patterns. Patterns enclosed
+// in slashes are regular expressions (e.g. /ghp_[A-Za-z0-9]{36}/), and the
+// rest are redacted wherever they appear.
+func newRedactor(patterns []string) (*redactor, error) {
+	r := &redactor{patterns: make([]redactPattern, 0, len(patterns))}
+	for _, pattern := range patterns {
+		expr := regexp.QuoteMeta(pattern)
+		if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+			expr = pattern[1 : len(pattern)-1]
+		}
+
+		if expr == "" {
+			return nil, errors.New("gomarkdoc: empty redaction pattern")
+		}
+
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: invalid redaction pattern %s: %w", pattern, err)
+		}
+
+		// Patterns matching nothing at all would be redacted everywhere
+		if re.MatchString("") {
+			return nil, fmt.Errorf("gomarkdoc: redaction pattern %s matches empty text", pattern)
+		}
+
+		r.patterns = append(r.patterns, redactPattern{pattern, re})
+	}
+
+	return r, nil
+}
+
+// redact replaces the text matching any of the patterns in the rendered output
+// for the file, logging a warning with the number of redactions made.
+func (r *redactor) redact(log logger.Logger, fileName string, text string) string {
+	if fileName == "" {
+		fileName = "-"
+	}
+
+	var redactions []*redaction
+	for _, p := range r.patterns {
+		matches := p.re.FindAllStringIndex(text, -1)
+		if len(matches) == 0 {
+			continue
+		}
+
+		var last *redaction
+		for _, m := range matches {
+			line := strings.Count(text[:m[0]], "\n") + 1
+			if last != nil && last.Line == line {
+				last.Count++
+				continue
+			}
+
+			last = &redaction{File: fileName, Line: line, Pattern: p.text, Count: 1}
+			redactions = append(redactions, last)
+		}
+
+		text = p.re.ReplaceAllLiteralString(text, redactedText)
+	}
+
+	if len(redactions) == 0 {
+		return text
+	}
+
+	var count int
+	for _, red := range redactions {
+		count += red.Count
+	}
+
+	log.Warnf("redacted %d matches of the redaction patterns from %s", count, fileName)
+
+	r.mu.Lock()
+	r.redactions = append(r.redactions, redactions...)
+	r.mu.Unlock()
+
+	return text
+}
+
+// writeReport writes the redactions made so far to the file as JSON, sorted by
+// file and line, and clears them so that regenerating the documentation in
+// watch mode starts a new report. The report is added to the tar output
+// instead when one is provided.
+func (r *redactor) writeReport(fileName string, t *tarOutput) error {
+	r.mu.Lock()
+	redactions := r.redactions
+	r.redactions = nil
+	r.mu.Unlock()
+
+	if redactions == nil {
+		redactions = []*redaction{}
+	}
+
+	sort.SliceStable(redactions, func(i, j int) bool {
+		if redactions[i].File != redactions[j].File {
+			return redactions[i].File < redactions[j].File
+		}
+
+		return redactions[i].Line < redactions[j].Line
+	})
+
+	b, err := json.MarshalIndent(redactions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := writeOutputFile(fileName, string(b)+"\n", t); err != nil {
+		return fmt.Errorf("gomarkdoc: failed to write redaction report %s: %w", fileName, err)
+	}
+
+	return nil
+}
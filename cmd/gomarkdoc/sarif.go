@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// The rules of the findings of check mode in a SARIF report.
+const (
+	sarifStaleRule   = "stale-documentation"
+	sarifMissingRule = "missing-documentation"
+	sarifErrorRule   = "check-error"
+)
+
+type (
+	// sarifLog is the subset of a SARIF 2.1.0 log written by the --report
+	// option, which code scanning tools use to annotate pull requests.
+	sarifLog struct {
+		Version string     `json:"version"`
+		Schema  string     `json:"$schema"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		Version        string      `json:"version"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+	}
+
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           *sarifRegion          `json:"region,omitempty"`
+	}
+
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+
+	sarifRegion struct {
+		StartLine int `json:"startLine"`
+		EndLine   int `json:"endLine"`
+	}
+)
+
+// newSARIFLog converts the results of check mode to a SARIF log. Each region
+// of a stale file which differs from the expected output is a separate
+// result, so that it can be annotated on the lines it applies to.
+func newSARIFLog(results []*checkResult) sarifLog {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "gomarkdoc",
+			Version:        toolVersion(),
+			InformationURI: "https://github.com/princjef/gomarkdoc",
+			Rules: []sarifRule{
+				{sarifStaleRule, sarifMessage{"Generated documentation is out of date"}},
+				{sarifMissingRule, sarifMessage{"Generated documentation is missing"}},
+				{sarifErrorRule, sarifMessage{"Generated documentation could not be checked"}},
+			},
+		}},
+		Results: []sarifResult{},
+	}
+
+	for _, res := range results {
+		uri := filepath.ToSlash(res.File)
+
+		switch res.Status {
+		case checkStale:
+			for _, change := range res.Changes {
+				text := "Documentation is out of date. Did you forget to run gomarkdoc?"
+				if change.Symbol != "" {
+					text = fmt.Sprintf("Documentation of %s is out of date. Did you forget to run gomarkdoc?", change.Symbol)
+				}
+
+				run.Results = append(run.Results, sarifResult{
+					RuleID:  sarifStaleRule,
+					Level:   "error",
+					Message: sarifMessage{fmt.Sprintf("%s\n\n%s", text, change.Diff)},
+					Locations: []sarifLocation{{sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{uri},
+						Region:           &sarifRegion{change.Line, change.EndLine},
+					}}},
+				})
+			}
+		case checkMissing:
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    sarifMissingRule,
+				Level:     "error",
+				Message:   sarifMessage{"Documentation file does not exist. Did you forget to run gomarkdoc?"},
+				Locations: []sarifLocation{{sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{uri}}}},
+			})
+		case checkError:
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    sarifErrorRule,
+				Level:     "error",
+				Message:   sarifMessage{res.Error},
+				Locations: []sarifLocation{{sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{uri}}}},
+			})
+		}
+	}
+
+	return sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	}
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/anthonyme00/gomarkdoc"
+	"github.com/anthonyme00/gomarkdoc/format"
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/spf13/cobra"
+)
+
+// Paths served by the preview server in addition to the package pages.
+const (
+	serveIndexPath  = "index.html"
+	serveEventsPath = "/_gomarkdoc/events"
+)
+
+// servePreviewHead is added to the head of every page of the preview server to
+// make room for the navigation sidebar and reload the page whenever the
+// documentation is rendered again.
+const servePreviewHead = `<style>
+body { margin-left: 18em; }
+nav.gomarkdoc-nav { position: fixed; top: 0; left: 0; bottom: 0; width: 16em; overflow: auto; padding: 1em; border-right: 1px solid #d0d7de; background-color: #f6f8fa; font-size: 90%; }
+nav.gomarkdoc-nav ul { list-style: none; padding: 0; margin: 0; }
+nav.gomarkdoc-nav li.current { font-weight: bold; }
+</style>
+<script>new EventSource("` + serveEventsPath + `").onmessage = function () { location.reload(); };</script>
+`
+
+// previewServer renders the documentation of packages to html in memory and
+// serves it over http, rendering it again and reloading the browser when the
+// source files of the packages change.
+type previewServer struct {
+	log   logger.Logger
+	opts  commandOptions
+	specs []*PackageSpec
+	out   *gomarkdoc.Renderer
+
+	// mu guards the loaded packages of the specs along with the pages.
+	mu    sync.RWMutex
+	pages map[string]string
+
+	// rendered is closed and replaced each time the pages are rendered so
+	// that every open page is told to reload.
+	rendered chan struct{}
+}
+
+func buildServeCommand() *cobra.Command {
+	var (
+		opts commandOptions
+		addr string
+	)
+
+	command := &cobra.Command{
+		Use:   "serve [package ...]",
+		Short: "preview html documentation with live reload",
+		Long:  "Render the documentation of the packages to html in memory and serve it over http with a navigation sidebar. Pages are reloaded in the browser whenever the Go files of the packages change.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = []string{"./..."}
+			}
+
+			log := logger.New(getLogLevel(opts.verbosity))
+
+			s, err := newPreviewServer(log, args, opts)
+			if err != nil {
+				return err
+			}
+
+			return s.serve(addr)
+		},
+	}
+
+	command.Flags().StringVar(
+		&addr,
+		"addr",
+		"localhost:6060",
+		"Address to serve the documentation on.",
+	)
+	command.Flags().BoolVarP(
+		&opts.includeUnexported,
+		"include-unexported",
+		"u",
+		false,
+		"Output documentation for unexported symbols, methods and fields in addition to exported ones.",
+	)
+	command.Flags().StringSliceVar(
+		&opts.tags,
+		"tags",
+		defaultTags(),
+		"Set of build tags to apply when choosing which files to include for documentation generation.",
+	)
+	command.Flags().CountVarP(
+		&opts.verbosity,
+		"verbose",
+		"v",
+		"Log additional output from the execution of the command. Can be chained for additional verbosity.",
+	)
+
+	return command
+}
+
+// newPreviewServer loads the packages at the provided paths and renders their
+// documentation. Each package is served from a page under pkg/ named by its
+// path, and the index of all of the packages is served from the root.
+func newPreviewServer(log logger.Logger, paths []string, opts commandOptions) (*previewServer, error) {
+	opts, err := subcommandOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.format = "html"
+
+	specs := getSpecs(paths...)
+	for _, spec := range specs {
+		spec.outputFile = filepath.Join("pkg", filepath.Clean(spec.ImportPath), serveIndexPath)
+	}
+
+	if err := loadPackages(specs, opts); err != nil {
+		return nil, err
+	}
+
+	out, err := gomarkdoc.NewRenderer(gomarkdoc.WithFormat(format.HTML), gomarkdoc.WithLogger(log))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &previewServer{
+		log:      log,
+		opts:     opts,
+		specs:    specs,
+		out:      out,
+		rendered: make(chan struct{}),
+	}
+
+	if err := s.render(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// serve serves the documentation on the address until interrupted, watching
+// the packages for changes in the meantime.
+func (s *previewServer) serve(addr string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	srv := &http.Server{Addr: addr, Handler: s}
+
+	go func() {
+		err := watchPackages(ctx, s.log, s.specs, func(affected []*PackageSpec) {
+			if err := s.reload(affected); err != nil {
+				s.log.Errorf("failed to render documentation: %s", err)
+				return
+			}
+
+			s.log.Infof("rendered documentation for %d packages", len(affected))
+		})
+		if err != nil {
+			s.log.Errorf("%s", err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+
+	s.log.Infof("serving documentation at http://%s/", addr)
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("gomarkdoc: failed to serve documentation: %w", err)
+	}
+
+	return nil
+}
+
+// reload loads the affected packages again and renders all of the pages, since
+// the index and links between packages may have changed along with them.
+// The packages are loaded into copies of their specs, which only replace the
+// loaded packages once all of them load, so the previous packages and pages
+// are kept if any of them fail to load.
+func (s *previewServer) reload(affected []*PackageSpec) error {
+	loaded := make([]*PackageSpec, len(affected))
+	for i, spec := range affected {
+		spec := *spec
+		loaded[i] = &spec
+	}
+
+	if err := loadPackages(loaded, s.opts); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	for i, spec := range affected {
+		spec.pkg = loaded[i].pkg
+	}
+	s.mu.Unlock()
+
+	return s.render()
+}
+
+// render renders the pages of all of the packages and tells the open pages to
+// reload.
+func (s *previewServer) render() error {
+	var (
+		pkgs []*lang.Package
+		nav  []*PackageSpec
+	)
+
+	s.mu.RLock()
+	for _, spec := range s.specs {
+		if spec.pkg != nil {
+			spec := *spec
+			pkgs = append(pkgs, spec.pkg)
+			nav = append(nav, &spec)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(nav, func(i, j int) bool {
+		return nav[i].pkg.ImportPath() < nav[j].pkg.ImportPath()
+	})
+
+	pages := make(map[string]string, len(nav)+1)
+
+	text, err := s.out.Packages(lang.NewPackageTree(serveIndexPath, pkgs))
+	if err != nil {
+		return err
+	}
+
+	pages[serveIndexPath] = previewPage(text, nav, "")
+
+	for _, spec := range nav {
+		text, err := s.out.File(lang.NewFile("", "", []*lang.Package{spec.pkg}))
+		if err != nil {
+			return err
+		}
+
+		page := filepath.ToSlash(spec.outputFile)
+		pages[page] = previewPage(text, nav, page)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pages = pages
+	close(s.rendered)
+	s.rendered = make(chan struct{})
+
+	return nil
+}
+
+// ServeHTTP serves the rendered pages along with the stream of events telling
+// the pages to reload.
+func (s *previewServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == serveEventsPath {
+		s.serveEvents(w, r)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+	if name == "" || strings.HasSuffix(r.URL.Path, "/") {
+		name = path.Join(name, serveIndexPath)
+	}
+
+	s.mu.RLock()
+	page, ok := s.pages[name]
+	s.mu.RUnlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = fmt.Fprint(w, page)
+}
+
+// serveEvents streams a server-sent event each time the pages are rendered
+// until the page is closed.
+func (s *previewServer) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		s.mu.RLock()
+		rendered := s.rendered
+		s.mu.RUnlock()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-rendered:
+			_, _ = fmt.Fprint(w, "data: reload\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// previewPage adds the navigation sidebar and live reload script to the html
+// page, marking the entry of the current page.
+func previewPage(text string, nav []*PackageSpec, current string) string {
+	var b strings.Builder
+	b.WriteString("<nav class=\"gomarkdoc-nav\">\n<ul>\n")
+	fmt.Fprintf(&b, "<li><a href=\"/\">Packages</a></li>\n")
+	for _, spec := range nav {
+		page := filepath.ToSlash(spec.outputFile)
+
+		class := ""
+		if page == current {
+			class = " class=\"current\""
+		}
+
+		fmt.Fprintf(&b, "<li%s><a href=\"/%s\">%s</a></li>\n", class, html.EscapeString(page), html.EscapeString(spec.pkg.ImportPath()))
+	}
+	b.WriteString("</ul>\n</nav>\n")
+
+	text = strings.Replace(text, "</head>", servePreviewHead+"</head>", 1)
+	return strings.Replace(text, "<body>\n", "<body>\n"+b.String(), 1)
+}
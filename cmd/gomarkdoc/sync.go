@@ -0,0 +1,293 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/build"
+	"go/doc/comment"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// syncToDoc writes the package comment of doc.go from the README.
+	syncToDoc = "doc"
+
+	// syncToReadme writes the README section from the package comment of
+	// doc.go.
+	syncToReadme = "readme"
+)
+
+var (
+	syncSectionRegex = regexp.MustCompile(`(?s)(<!--\s*gomarkdoc:sync:start\s*-->)(.*?)(<!--\s*gomarkdoc:sync:end\s*-->)`)
+	mdHeadingRegex   = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*$`)
+	mdListRegex      = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdLinkRegex      = regexp.MustCompile(`\[([^\]]+)\]\(<?([^)>\s]+)>?\)`)
+	mdEmphasisRegex  = regexp.MustCompile("(\\*\\*|__|`)")
+)
+
+func buildSyncCommand() *cobra.Command {
+	var (
+		opts   commandOptions
+		readme string
+		to     string
+	)
+
+	command := &cobra.Command{
+		Use:   "sync [directory ...]",
+		Short: "synchronize package comments with a README section",
+		Long:  "Synchronize the package comment in the doc.go file of each package directory with the section of its README between the <!-- gomarkdoc:sync:start --> and <!-- gomarkdoc:sync:end --> markers, so that pkg.go.dev and the README show the same text from a single authored source. By default the package comment is generated from the README, which is the inverse of normal generation.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = []string{"."}
+			}
+
+			if to != syncToDoc && to != syncToReadme {
+				return fmt.Errorf("gomarkdoc: invalid sync target: %s", to)
+			}
+
+			// Out of sync files aren't a usage error
+			cmd.SilenceUsage = true
+
+			log := logger.New(getLogLevel(opts.verbosity))
+
+			return runSync(log, args, readme, to, opts.check)
+		},
+	}
+
+	command.Flags().StringVar(
+		&readme,
+		"readme",
+		"README.md",
+		"Name of the README file holding the synchronized section in each package directory.",
+	)
+	command.Flags().StringVar(
+		&to,
+		"to",
+		syncToDoc,
+		"File to write. Valid options: doc (the package comment of doc.go from the README), readme (the README section from the package comment of doc.go)",
+	)
+	command.Flags().BoolVarP(
+		&opts.check,
+		"check",
+		"c",
+		false,
+		"Check that the files are in sync instead of writing them. The command fails if any are not.",
+	)
+	command.Flags().CountVarP(
+		&opts.verbosity,
+		"verbose",
+		"v",
+		"Log additional output from the execution of the command. Can be chained for additional verbosity.",
+	)
+
+	return command
+}
+
+// runSync synchronizes the package comment and README section of each of the
+// package directories at the provided paths. Directories found through a
+// recursive path are skipped if their README has no synchronized section.
+func runSync(log logger.Logger, paths []string, readme, to string, check bool) error {
+	var stale []string
+	for _, spec := range getSpecs(paths...) {
+		if !spec.isLocal {
+			return fmt.Errorf("gomarkdoc: only local package directories can be synchronized: %s", spec.ImportPath)
+		}
+
+		readmeFile := filepath.Join(spec.Dir, readme)
+		data, err := os.ReadFile(readmeFile)
+		if err == nil && !syncSectionRegex.Match(data) {
+			err = fmt.Errorf("gomarkdoc: no gomarkdoc:sync section found in %s", readmeFile)
+		}
+
+		if err != nil {
+			if spec.isWildcard {
+				log.Debugf("skipping directory %s: %s", spec.Dir, err)
+				continue
+			}
+
+			return err
+		}
+
+		var fileName, text string
+		if to == syncToDoc {
+			fileName = filepath.Join(spec.Dir, "doc.go")
+			text, err = syncDoc(spec.Dir, fileName, string(data))
+		} else {
+			fileName = readmeFile
+			text, err = syncReadme(filepath.Join(spec.Dir, "doc.go"), string(data))
+		}
+
+		if err != nil {
+			return err
+		}
+
+		current, err := os.ReadFile(fileName)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		if string(current) == text {
+			continue
+		}
+
+		if check {
+			stale = append(stale, fileName)
+			continue
+		}
+
+		if err := os.WriteFile(fileName, []byte(text), 0664); err != nil {
+			return fmt.Errorf("gomarkdoc: failed to write synchronized file %s: %w", fileName, err)
+		}
+
+		log.Infof("synchronized %s", fileName)
+	}
+
+	if len(stale) > 0 {
+		return fmt.Errorf("gomarkdoc: files are out of sync: %s. Did you forget to run gomarkdoc sync?", strings.Join(stale, ", "))
+	}
+
+	return nil
+}
+
+// syncDoc provides the contents of the doc.go file with its package comment
+// replaced by the synchronized section of the README. The file is created for
+// the package in the directory if it doesn't exist yet.
+func syncDoc(dir, fileName, readme string) (string, error) {
+	docComment := goComment(markdownToDoc(syncSection(readme)))
+
+	src, err := os.ReadFile(fileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		pkg, err := build.ImportDir(dir, build.ImportComment)
+		if err != nil {
+			return "", fmt.Errorf("gomarkdoc: unable to find package name for %s: %w", fileName, err)
+		}
+
+		src = []byte(fmt.Sprintf("package %s\n", pkg.Name))
+	} else if err != nil {
+		return "", err
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, fileName, src, parser.ParseComments|parser.PackageClauseOnly)
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: failed to parse %s: %w", fileName, err)
+	}
+
+	start := fset.Position(f.Package).Offset
+	end := start
+	if f.Doc != nil {
+		start = fset.Position(f.Doc.Pos()).Offset
+	}
+
+	var b strings.Builder
+	b.Write(src[:start])
+	fmt.Fprintf(&b, "%s\n", docComment)
+	b.Write(src[end:])
+
+	formatted, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: failed to format %s: %w", fileName, err)
+	}
+
+	return string(formatted), nil
+}
+
+// syncReadme provides the contents of the README with its synchronized section
+// replaced by the package comment of the doc.go file, rendered as markdown.
+func syncReadme(fileName, readme string) (string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), fileName, nil, parser.ParseComments|parser.PackageClauseOnly)
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: failed to parse %s: %w", fileName, err)
+	}
+
+	var p comment.Parser
+	text := p.Parse(f.Doc.Text())
+
+	// Heading ids are left out since they aren't supported by all renderers
+	printer := comment.Printer{
+		HeadingLevel: 2,
+		HeadingID:    func(*comment.Heading) string { return "" },
+	}
+	md := strings.TrimSpace(string(printer.Markdown(text)))
+
+	return syncSectionRegex.ReplaceAllStringFunc(readme, func(section string) string {
+		m := syncSectionRegex.FindStringSubmatch(section)
+		return fmt.Sprintf("%s\n\n%s\n\n%s", m[1], md, m[3])
+	}), nil
+}
+
+// syncSection provides the text of the synchronized section of the README.
+func syncSection(readme string) string {
+	m := syncSectionRegex.FindStringSubmatch(readme)
+	if m == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(m[2])
+}
+
+// markdownToDoc converts the markdown to the syntax of Go doc comments.
+// Headings of any level become doc comment headings, fenced code blocks become
+// indented code blocks and links become doc links with definitions at the end
+// of the comment. Emphasis and code spans are left out since doc comments
+// don't support them.
+func markdownToDoc(md string) string {
+	var (
+		lines []string
+		links []string
+		fence bool
+	)
+
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(md, "\n") {
+		if goFenceRegex.MatchString(line) {
+			fence = !fence
+			continue
+		}
+
+		if fence {
+			lines = append(lines, "\t"+line)
+			continue
+		}
+
+		line = mdLinkRegex.ReplaceAllStringFunc(line, func(link string) string {
+			m := mdLinkRegex.FindStringSubmatch(link)
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				links = append(links, fmt.Sprintf("[%s]: %s", m[1], m[2]))
+			}
+
+			return fmt.Sprintf("[%s]", m[1])
+		})
+		line = mdEmphasisRegex.ReplaceAllString(line, "")
+
+		if m := mdHeadingRegex.FindStringSubmatch(line); m != nil {
+			lines = append(lines, "# "+m[1])
+			continue
+		}
+
+		if m := mdListRegex.FindStringSubmatch(line); m != nil {
+			lines = append(lines, fmt.Sprintf("  %s%s %s", m[1], m[2], m[3]))
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	if len(links) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, links...)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// tarStdout is the --output-tar value which writes the archive to stdout.
+const tarStdout = "-"
+
+// tarOutput collects the generated files in a tar archive instead of writing
+// them to the filesystem, so gomarkdoc can run where the source tree is read
+// only. Entries are given a fixed modification time so that the archive is
+// reproducible.
+type tarOutput struct {
+	w      *tar.Writer
+	closer io.Closer
+}
+
+// newTarOutput creates a tarOutput writing to the file at the provided path,
+// or to stdout if the path is tarStdout.
+func newTarOutput(path string) (*tarOutput, error) {
+	if path == tarStdout {
+		return &tarOutput{tar.NewWriter(os.Stdout), nil}, nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to create tar output %s: %w", path, err)
+	}
+
+	return &tarOutput{tar.NewWriter(f), f}, nil
+}
+
+// add adds the file to the archive. The name of its entry is the path of the
+// file relative to the working directory, which is the root of the archive.
+// Files outside of the working directory can't be added.
+func (t *tarOutput) add(fileName string, text string) error {
+	abs, err := filepath.Abs(fileName)
+	if err != nil {
+		return err
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("gomarkdoc: %s is outside of the working directory, so it can't be added to tar output", fileName)
+	}
+
+	name := filepath.ToSlash(rel)
+
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0664,
+		Size:     int64(len(text)),
+		ModTime:  time.Unix(0, 0),
+	}
+
+	if err := t.w.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("gomarkdoc: failed to add %s to tar output: %w", name, err)
+	}
+
+	if _, err := io.WriteString(t.w, text); err != nil {
+		return fmt.Errorf("gomarkdoc: failed to add %s to tar output: %w", name, err)
+	}
+
+	return nil
+}
+
+// close finishes the archive, closing the underlying file if there is one.
+func (t *tarOutput) close() error {
+	if err := t.w.Close(); err != nil {
+		return fmt.Errorf("gomarkdoc: failed to finish tar output: %w", err)
+	}
+
+	if t.closer != nil {
+		return t.closer.Close()
+	}
+
+	return nil
+}
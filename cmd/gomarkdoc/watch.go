@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long to wait after a change for more changes before
+// regenerating, so that saving several files at once (e.g. with goimports or a
+// branch switch) only regenerates each output file once.
+const watchDebounce = 200 * time.Millisecond
+
+// watch monitors the directories of the local packages for changes to their Go
+// files and regenerates the output files of the packages in the changed
+// directories until interrupted. Only the affected output files are rewritten,
+// along with the pages covering all of the packages (e.g. --index-output).
+// Errors while regenerating, such as syntax errors in a file which is still
+// being edited, are logged rather than ending the watch.
+func watch(specs []*PackageSpec, opts commandOptions) error {
+	log := logger.New(getLogLevel(opts.verbosity))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return watchPackages(ctx, log, specs, func(affected []*PackageSpec) {
+		if err := regenerate(specs, affected, opts); err != nil {
+			log.Errorf("failed to regenerate documentation: %s", err)
+			return
+		}
+
+		log.Infof("regenerated documentation for %d packages", len(affected))
+	})
+}
+
+// watchPackages monitors the directories of the local packages for changes to
+// their Go files until the context is done, calling onChange with the
+// packages affected by each batch of changes.
+func watchPackages(ctx context.Context, log logger.Logger, specs []*PackageSpec, onChange func(affected []*PackageSpec)) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("gomarkdoc: failed to start watching for changes: %w", err)
+	}
+	defer w.Close()
+
+	dirs := make(map[string]bool)
+	for _, spec := range specs {
+		if spec.pkg == nil || !spec.isLocal {
+			continue
+		}
+
+		dir := filepath.Clean(spec.Dir)
+		if dirs[dir] {
+			continue
+		}
+
+		if err := w.Add(dir); err != nil {
+			return fmt.Errorf("gomarkdoc: failed to watch directory %s: %w", dir, err)
+		}
+
+		dirs[dir] = true
+	}
+
+	log.Infof("watching %d directories for changes", len(dirs))
+
+	var (
+		changed = make(map[string]bool)
+		timer   <-chan time.Time
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+
+			log.Warnf("error watching for changes: %s", err)
+		case event, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+
+			// Output files are frequently written to the watched directories,
+			// so anything other than Go files is ignored
+			if filepath.Ext(event.Name) != ".go" || event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			changed[filepath.Dir(event.Name)] = true
+			timer = time.After(watchDebounce)
+		case <-timer:
+			timer = nil
+
+			affected := affectedSpecs(specs, changed)
+			changed = make(map[string]bool)
+
+			if len(affected) > 0 {
+				onChange(affected)
+			}
+		}
+	}
+}
+
+// affectedSpecs finds the packages which need to be reloaded after changes to
+// the provided directories. Along with the packages in the directories, this
+// includes the packages written to the same output files as them, since those
+// files are rendered from all of their packages.
+func affectedSpecs(specs []*PackageSpec, changedDirs map[string]bool) []*PackageSpec {
+	outputs := make(map[string]bool)
+	for _, spec := range specs {
+		if spec.isLocal && changedDirs[filepath.Clean(spec.Dir)] {
+			outputs[spec.outputFile] = true
+		}
+	}
+
+	var affected []*PackageSpec
+	for _, spec := range specs {
+		if outputs[spec.outputFile] {
+			affected = append(affected, spec)
+		}
+	}
+
+	return affected
+}
+
+// regenerate reloads the affected packages and rewrites their output files.
+func regenerate(specs []*PackageSpec, affected []*PackageSpec, opts commandOptions) error {
+	if err := loadPackages(affected, opts); err != nil {
+		return err
+	}
+
+	opts.regenerate = make(map[string]bool)
+	for _, spec := range affected {
+		opts.regenerate[spec.outputFile] = true
+		for _, file := range spec.routes {
+			opts.regenerate[file] = true
+		}
+	}
+
+	return writeOutput(specs, opts)
+}
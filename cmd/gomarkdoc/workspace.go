@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// workspaceFile is the name of the file listing the modules of a multi-module
+// workspace.
+const workspaceFile = "go.work"
+
+// workspacePaths provides recursive paths (e.g. ./api/...) for each of the
+// modules listed in the go.work file of the directory, so that all of them are
+// documented when gomarkdoc is run at the root of a workspace. Modules nested
+// within another listed module are covered by its path and left out. The
+// second return value is false if there is no go.work file in the directory or
+// workspaces are disabled with GOWORK=off.
+func workspacePaths(dir string) ([]string, bool, error) {
+	if os.Getenv("GOWORK") == "off" {
+		return nil, false, nil
+	}
+
+	fileName := filepath.Join(dir, workspaceFile)
+	b, err := os.ReadFile(fileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("gomarkdoc: failed to read workspace file %s: %w", fileName, err)
+	}
+
+	work, err := modfile.ParseWork(fileName, b, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("gomarkdoc: failed to parse workspace file %s: %w", fileName, err)
+	}
+
+	dirs := make([]string, 0, len(work.Use))
+	for _, use := range work.Use {
+		d := filepath.FromSlash(use.Path)
+		if !filepath.IsAbs(d) {
+			d = filepath.Join(dir, d)
+		}
+
+		if !isLocalPath(d) {
+			d = cwdPathPrefix + d
+		}
+
+		dirs = append(dirs, d)
+	}
+
+	// Sorting by length places each module before the modules nested within it
+	sort.SliceStable(dirs, func(i, j int) bool {
+		return len(dirs[i]) < len(dirs[j])
+	})
+
+	var kept []string
+	for _, d := range dirs {
+		if !isWithinAny(d, kept) {
+			kept = append(kept, d)
+		}
+	}
+
+	sort.Strings(kept)
+
+	paths := make([]string, len(kept))
+	for i, d := range kept {
+		paths[i] = strings.TrimRight(d, string(os.PathSeparator)) + string(os.PathSeparator) + "..."
+	}
+
+	return paths, true, nil
+}
+
+// isWithinAny checks whether the path is one of the directories or is nested
+// within one of them.
+func isWithinAny(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if isWithinDir(path, dir) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isWithinDir checks whether the path is the directory or is nested within it.
+func isWithinDir(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+
+	return rel == "." || rel != ".." && !strings.HasPrefix(rel, parentPathPrefix)
+}
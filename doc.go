@@ -74,6 +74,20 @@
 //
 //	gomarkdoc --exclude-dirs ./testData/... ./...
 //
+// When run without any packages at the root of a multi-module workspace, the
+// gomarkdoc command documents all of the packages of the modules listed in the
+// go.work file. The import path of each package is resolved from the go.mod
+// file of its own module. Setting GOWORK=off documents the current directory
+// instead.
+//
+// Like go doc, a single symbol of a package can be documented by following the
+// package with the name of the symbol, or of a type and one of its methods.
+// Only the symbol is written, along with its examples, to stdout or the
+// --output file. Queries can't be combined with packages documented in full:
+//
+//	gomarkdoc net/http.Client.Do
+//	gomarkdoc ./mypkg.NewClient ./mypkg.Options -o snippets.md
+//
 // # Output Redirection
 //
 // By default, the documentation generated by the gomarkdoc command is sent to
@@ -86,6 +100,125 @@
 //
 //	gomarkdoc . > doc.md
 //
+// In addition to the markdown formats, --format json exports the documentation
+// for each output file as structured JSON (packages, symbols, signatures,
+// documentation text, locations and examples) for consumption by other tools:
+//
+//	gomarkdoc --format json . > doc.json
+//
+// Similarly, --format asciidoc renders the documentation as AsciiDoc, which can
+// be included in Asciidoctor and Antora-based documentation sites. The --embed
+// option is not supported for AsciiDoc output:
+//
+//	gomarkdoc --format asciidoc -o '{{.Dir}}/README.adoc' ./...
+//
+// For sites which serve raw HTML, --format html renders each output file as a
+// self-contained HTML page with syntax highlighted code blocks. The html format
+// uses HTML variants of the file, doc, list, index and stats templates, which
+// can still be replaced with the --template and --template-file options:
+//
+//	gomarkdoc --format html -o '{{.Dir}}/index.html' ./...
+//
+// Documentation sites built with Docusaurus can use --format docusaurus, which
+// produces MDX-safe markdown (e.g. escaping the { and < characters found in
+// signatures) and starts each file with YAML front matter holding the id, title
+// and slug of the package. Front matter fields can be added, changed or removed
+// with --front-matter, whose values are templates receiving the same data as
+// --output along with the package's Name and Slug:
+//
+//	gomarkdoc --format docusaurus --front-matter sidebar_position=2 -o 'docs/{{.Dir}}.mdx' ./...
+//
+// Similarly, --format hugo produces markdown for the Hugo static site generator,
+// which starts with title and description front matter fields and uses Hugo's
+// heading attributes instead of raw HTML for header anchors. The Summary, Date
+// and Package (e.g. {{.Package.ImportPath}}) are also available to front matter
+// templates, and --front-matter-syntax toml switches from YAML to TOML:
+//
+//	gomarkdoc --format hugo --front-matter-syntax toml --front-matter 'date={{.Date}}' -o 'content/{{.Dir}}/_index.md' ./...
+//
+// The header and footer are templates receiving the same data, so they can
+// describe the package they're written with. For versioned documentation, the
+// ModuleVersion holds the repository tag pointing at the current commit, and
+// the semverCompare, semverValid, semverMajor and semverLatest functions
+// compare versions while relTime describes a time relative to now (e.g. "3 days
+// ago"). These functions are available to front matter and custom templates as
+// well. Headers and footers which aren't valid templates (e.g. ones holding
+// Hugo shortcodes) are included as is:
+//
+//	gomarkdoc --header '{{if eq (semverCompare .ModuleVersion "v2.0.0") 0}}Latest release{{end}}' -o README.md .
+//
+// The package also reports what its documentation holds, so that templates and
+// front matter can include sections, badges or warnings only where they apply:
+// HasGenerics, HasExamples, HasDeprecations and UsesCgo are true when the
+// package declares generic functions or types, has examples, has deprecated
+// symbols or imports "C" respectively:
+//
+//	gomarkdoc --front-matter 'generics={{.Package.HasGenerics}}' --front-matter 'cgo={{.Package.UsesCgo}}' -o README.md .
+//
+// When several packages are documented together, templates can render
+// navigation between them. Packages lists every package of the run in order
+// with its Name, ImportPath, Summary, OutputFile and the Href of its file
+// relative to the current one, Prev and Next hold the neighboring packages and
+// Parents lists the packages in enclosing directories for breadcrumbs:
+//
+//	gomarkdoc --footer '{{with .Prev}}[← {{.Name}}]({{.Href}}){{end}} {{with .Next}}[{{.Name}} →]({{.Href}}){{end}}' -o '{{.Dir}}/README.md' ./...
+//
+// Fragments shared by many files, such as a support matrix table or a
+// contribution blurb, can be maintained outside of the templates and pulled in
+// with the include function of header, footer and front matter templates. The
+// path is relative to the root of the git repository, and files outside of it
+// can't be included:
+//
+//	gomarkdoc --footer '{{include "docs/contributing.md"}}' -o '{{.Dir}}/README.md' ./...
+//
+// To publish documentation to Confluence, --format confluence renders it as
+// Confluence wiki markup, which can be sent to Confluence's REST API using the
+// "wiki" representation. Confluence has no syntax for comments, so the output
+// doesn't include the generated code notice. The --embed option is not
+// supported for Confluence output:
+//
+//	gomarkdoc --format confluence -o '{{.Dir}}/api.confluence' ./...
+//
+// For documentation hosted in GitLab repositories and wikis, --format gitlab
+// generates in-page links using GitLab's heading id rules and source links in
+// GitLab's url format. Code blocks containing backticks get a longer fence so
+// GitLab doesn't end them early:
+//
+//	gomarkdoc --format gitlab -o '{{.Dir}}/README.md' ./...
+//
+// Repositories hosted on a self-hosted Bitbucket Server (Data Center) are
+// detected from their clone URLs under /scm or on ssh port 7999, and their
+// source links use Bitbucket Server's .../browse/<path>?at=<ref>#<line> scheme
+// in place of GitHub's. When the repository URL is set manually, use the web
+// URL of the repository (e.g.
+// https://bitbucket.example.com/projects/PROJ/repos/repo):
+//
+//	gomarkdoc --repository.url https://bitbucket.example.com/projects/PROJ/repos/repo -o README.md .
+//
+// Gitea and Forgejo link to source code with .../src/branch/<branch>/<path>
+// and line ranges like #L10-L20. Repositories on codeberg.org and gitea.com use
+// these links automatically, but self-hosted instances can't be told apart from
+// other hosts by their URLs, so they need the --repository.forge hint:
+//
+//	gomarkdoc --repository.forge gitea -o README.md .
+//
+// To include API documentation in a Sphinx documentation tree, --format rst
+// renders it as reStructuredText. Symbols get hyperlink targets named by their
+// anchors (e.g. Client.Do), which other documents can reference, and signatures
+// are rendered as literal blocks. Headers deeper than three levels are
+// rendered as rubrics and badges as links. The --embed option is not supported
+// for reStructuredText output:
+//
+//	gomarkdoc --format rst -o '{{.Dir}}/api.rst' ./...
+//
+// Libraries which ship command line tools can install API reference pages
+// alongside their binaries by using --format man, which renders a manual page
+// per package in section 3 of the manual. Links are written with their url in
+// angle brackets, while badges and links to source code are left out. The
+// --embed option is not supported for manual pages:
+//
+//	gomarkdoc --format man -o man/man3/mypkg.3 ./mypkg
+//
 // If you want to redirect output for each processed package to a file, you can
 // provide the --output/-o option, which accepts a template specifying how to
 // generate the path of the output file. A common usage of this option is when
@@ -100,6 +233,16 @@
 // PackageSpec struct in the github.com/princjef/gomarkdoc/cmd/gomarkdoc
 // package.
 //
+// Large packages can be split across files by routing kinds of symbols away
+// from the --output file with the --route option, which maps one of consts,
+// vars, funcs, types or examples to a template like the one for --output. The
+// --output file keeps the package's documentation and the rest of its symbols,
+// its index links to the routed files, and links to routed symbols point at the
+// files they are written to. Routed examples are collected in their file along
+// with the package's own examples, titled with the symbols they belong to:
+//
+//	gomarkdoc --output '{{.Dir}}/README.md' --route types='{{.Dir}}/TYPES.md' --route examples='{{.Dir}}/EXAMPLES.md' ./...
+//
 // # Template Overrides
 //
 // The documentation information that is output is formatted using a series of
@@ -140,12 +283,53 @@
 //
 //   - import:  generates the import code used to pull in a package.
 //
+//   - stats:   generates the aggregate documentation statistics page written
+//     by the --stats-output option.
+//
+//   - gettingstarted: generates the Getting Started walkthrough written by the
+//     --getting-started-output option.
+//
+//   - badges:  generates the standard badges for a package when they are
+//     enabled with the --badges flag, and the build target badges for
+//     symbols when they are enabled with the --build-targets flag.
+//
+//   - capi:    generates the C API section for a cgo package when it is
+//     enabled with the --c-api flag.
+//
+//   - buildinfo: generates the build configuration appendix for a main package
+//     when it is enabled with the --build-info flag.
+//
+//   - importers: generates the Imported By section of a package when it is
+//     enabled with the --importers flag.
+//
+//   - benchmarks: generates the Benchmarks section of a package when it is
+//     enabled with the --benchmarks flag.
+//
+//   - benchmark: generates the documentation for a single benchmark function.
+//
+//   - fuzzing: generates the Fuzzing section of a package when it is enabled
+//     with the --fuzz-targets flag.
+//
+//   - fuzztarget: generates the documentation for a single fuzz target.
+//
+//   - classdiagram: generates the Class Diagram section of a package when it
+//     is enabled with the --class-diagram flag.
+//
+//   - constvalues: generates the table of the computed values of the constants
+//     of a const declaration when it is enabled with the --iota-values or
+//     --computed-values flag.
+//
 // Overriding with the --template-file option uses a key-value pair mapping a
 // template name to the file containing the contents of the override template to
 // use. Specified template files must exist:
 //
 //	gomarkdoc --template-file package=custom-package.gotxt --template-file doc=custom-doc.gotxt .
 //
+// Template overrides can be kept working across upgrades of gomarkdoc by
+// testing them against golden files with the helpers of the
+// github.com/anthonyme00/gomarkdoc/formattest package, which render a fixture
+// package with the overrides and report the first line that no longer matches.
+//
 // # Additional Options
 //
 // As with the godoc tool itself, only exported symbols will be shown in
@@ -154,6 +338,21 @@
 //
 //	gomarkdoc -u -o README.md .
 //
+// Symbols declared in the _test.go files of a package are left out as well.
+// For packages which export test doubles or fixtures to the tests of other
+// packages (e.g. through an export_test.go file), the --include-test-helpers
+// flag documents them alongside the rest of the package with a "Test only"
+// badge. Tests, benchmarks, examples and fuzz targets are still left out:
+//
+//	gomarkdoc --include-test-helpers -o README.md .
+//
+// Fixtures declared in the _test.go files of a package's external test package
+// (e.g. a package named store_test) are documented the same way with the
+// --include-external-test-helpers flag, which can be combined with
+// --include-test-helpers to document the symbols of all of the test files:
+//
+//	gomarkdoc --include-test-helpers --include-external-test-helpers -o README.md .
+//
 // If you want to blend the documentation generated by gomarkdoc with your own
 // hand-written markdown, you can use the --embed/-e flag to change the
 // gomarkdoc tool into an append/embed mode. When documentation is generated,
@@ -177,6 +376,17 @@
 //
 //	<!-- gomarkdoc:embed:end -->
 //
+// Documentation can also be embedded into Go files, such as to keep the package
+// comment of a doc.go file in sync with a README. Go files use the
+// /* gomarkdoc:embed */ marker or the /* gomarkdoc:embed:start */ and
+// /* gomarkdoc:embed:end */ markers instead, and the documentation is written
+// between them as line comments wrapped to 80 columns. The end marker is
+// rewritten as a //gomarkdoc:embed:end directive, which can sit right above the
+// package clause without becoming part of the package comment, and the file is
+// formatted with gofmt afterwards:
+//
+//	gomarkdoc --embed --format plain -o doc.go .
+//
 // If you would like to include files that are part of a build tag, you can
 // specify build tags with the --tags flag. Tags are also supported through
 // GOFLAGS, though command line and configuration file definitions override tags
@@ -184,6 +394,532 @@
 //
 //	gomarkdoc --tags sometag .
 //
+// Add the --build-targets flag (described below) to annotate the symbols from
+// tagged files with the constraints under which they exist:
+//
+//	gomarkdoc --tags sometag --build-targets .
+//
+// If you want each package's documentation to start with the standard set of
+// badges (a pkg.go.dev reference, the latest version for GitHub repositories
+// and the documentation coverage of the package), add the --badges flag. Badge
+// urls are derived from the package's import path and detected repository:
+//
+//	gomarkdoc --badges -o README.md .
+//
+// Header and footer templates can render badges as well with the badge
+// function, such as to show the badges of the package above the rest of the
+// header:
+//
+//	gomarkdoc --badges --header '{{range .Package.Badges}}{{badge .Text .Image .URL}} {{end}}' -o README.md .
+//
+// Packages beneath an internal directory are documented just like any other
+// package by default. Use the --internal option to change this: exclude leaves
+// them out entirely, warn documents them with a banner noting that they cannot
+// be imported from outside of their parent tree, and only documents nothing but
+// internal packages (useful for a separate set of internal documentation):
+//
+//	gomarkdoc --internal exclude --output '{{.Dir}}/README.md' ./...
+//
+// For a quick overview of a whole module, the --stats-output option writes a
+// separate page summarizing the number of packages, the symbols of each kind,
+// the documentation coverage of each package and the packages with the most
+// undocumented symbols:
+//
+//	gomarkdoc --stats-output STATS.md --output '{{.Dir}}/README.md' ./...
+//
+// The coverage subcommand reports the percentage of exported symbols with a
+// documentation comment in each package, along with each type and its
+// constructors and methods, and lists the symbols missing one. With the
+// --min-coverage option, it fails if the total coverage across the packages
+// falls below the given percentage, which makes it useful in CI. The --report
+// json option writes the same report as json instead:
+//
+//	gomarkdoc coverage --min-coverage 80 ./...
+//
+// Similarly, the lint subcommand checks the documentation comments that are
+// there: each package should have a package comment starting with "Package
+// <name>", each comment should start with the name of the symbol it documents,
+// doc links like [Symbol] should refer to symbols which exist and deprecation
+// notices should be paragraphs starting with "Deprecated: " so that tools
+// recognize them. Each finding is printed with the file and line of the
+// comment, and the command fails if there are any:
+//
+//	gomarkdoc lint ./...
+//
+// To author the package overview once and show it both on pkg.go.dev and in the
+// README, the sync subcommand generates the package comment of each package's
+// doc.go file from the section of its README between the
+// <!-- gomarkdoc:sync:start --> and <!-- gomarkdoc:sync:end --> markers.
+// Markdown headings, lists, code blocks and links are converted to doc comment
+// syntax. The --to readme option syncs in the other direction instead, and
+// --check fails if the files are out of sync:
+//
+//	gomarkdoc sync --check ./...
+//
+// The --toc option adds a table of contents to the top of each output file,
+// listing the constants, variables, functions and types of its packages with
+// links to their documentation using the anchors of the selected format:
+//
+//	gomarkdoc --toc --output '{{.Dir}}/README.md' ./...
+//
+// Both the index and the table of contents nest the functions and methods of
+// each type under it. For very large APIs, the --index-fields option lists the
+// documented fields of struct types there as well, linking to their types:
+//
+//	gomarkdoc --toc --index-fields --output '{{.Dir}}/README.md' ./...
+//
+// The --recursive option documents the packages nested within each of the
+// provided directories, as if they ended with /.... For monorepos, the
+// --index-output option writes an index page listing every documented package
+// with its summary, nested by directory, with relative links to the generated
+// documentation of each package:
+//
+//	gomarkdoc --recursive --index-output PACKAGES.md --output '{{.Dir}}/README.md' .
+//
+// The --import-graph option adds an Import Graph section to the index page,
+// holding a graph of the imports among the documented packages as either a
+// Mermaid flowchart (mermaid) or a Graphviz digraph (dot). Imports of packages
+// which aren't documented together are left out. To write the source of the
+// graph to a file of its own instead, provide it with --import-graph-output:
+//
+//	gomarkdoc --recursive --index-output PACKAGES.md --import-graph mermaid --output '{{.Dir}}/README.md' .
+//	gomarkdoc --recursive --import-graph dot --import-graph-output imports.dot --output '{{.Dir}}/README.md' .
+//
+// Examples are titled using the suffix of their function name (e.g.
+// ExampleClient_withRetry is titled "Example (With Retry)") and are listed
+// alphabetically. The --example-titles option switches to sentence-cased
+// titles, either in the usual parentheses (humanized) or on their own (short),
+// and the --example-order option lists examples in source order instead:
+//
+//	gomarkdoc --example-titles short --example-order source -o README.md .
+//
+// Functions and types are listed alphabetically as well. The --symbol-order
+// option set to popularity lists the ones referenced most often within the
+// module's own code and tests first, so the main entry points of a package lead
+// its documentation. The references are found with a quick static count rather
+// than full type checking:
+//
+//	gomarkdoc --symbol-order popularity -o README.md .
+//
+// Examples are published as they are written, even if they no longer compile.
+// The --check-examples option type checks the examples of each package and
+// logs a warning for each one referencing undefined symbols or otherwise
+// failing to compile, while --strict-examples fails instead. Imported packages
+// are type checked from source, so checking examples slows generation down:
+//
+//	gomarkdoc --strict-examples -o '{{.Dir}}/README.md' ./...
+//
+// The output shown for examples comes from their // Output: comments, which
+// may be missing or out of date. The --run-examples option runs the examples
+// of each package with go test instead and shows the output they actually
+// print, including for examples without an output comment. Examples which
+// panic or fail to build keep their output comment, as do all of the examples
+// of a package if running them takes more than two minutes. Since running
+// examples executes the documented code, it isn't allowed with
+// --safe-templates:
+//
+//	gomarkdoc --run-examples -o '{{.Dir}}/README.md' ./...
+//
+// Readers can run examples from the documentation when the --playground-links
+// flag is set. The code of each self-contained example (one only using the
+// exported API of its package, as shown in the documentation) is shared
+// through the Go Playground's share api, and a "Run in Go Playground" link is
+// added beneath it. Examples which fail to be shared are left without a link,
+// and no links are added in offline mode:
+//
+//	gomarkdoc --playground-links -o '{{.Dir}}/README.md' ./...
+//
+// Related examples for different symbols can be grouped into themed sections
+// of the package's documentation by tagging the example functions with a
+// scenario using a //gomarkdoc:group directive. Grouped examples are titled
+// with the symbol they belong to and are listed in their group's section
+// instead of with that symbol:
+//
+//	//gomarkdoc:group authentication
+//	func ExampleClient_Login() {
+//		...
+//	}
+//
+// Package-level examples scattered across a module can be turned into a guided
+// walkthrough with the --getting-started-output option, which writes a Getting
+// Started page sequencing the examples of all of the documented packages. Only
+// examples with a //gomarkdoc:order directive are included, in the order of the
+// number in the directive, and each step is titled with the summary of the
+// example's documentation comment:
+//
+//	// Connect to the server.
+//	//
+//	//gomarkdoc:order 1
+//	func Example_connect() {
+//		...
+//	}
+//
+//	gomarkdoc --getting-started-output GETTING_STARTED.md -o '{{.Dir}}/README.md' ./...
+//
+// The heading of a function or type can be replaced with a
+// //gomarkdoc:title directive in its documentation comment, such as to spell
+// out an abbreviation. The anchor of the symbol is still based on its name, so
+// existing links to it keep working:
+//
+//	// NewURL creates a URL.
+//	//
+//	//gomarkdoc:title New Uniform Resource Locator
+//	func NewURL() *URL {
+//		...
+//	}
+//
+// Thin wrappers can reuse the documentation of the function or method they
+// delegate to with a //gomarkdoc:inherit directive naming it, either within the
+// package (e.g. Client.Do) or through one of the file's imports (e.g.
+// http.Client.Do). The inherited documentation follows the wrapper's own, if it
+// has any, and a note names the symbol it was inherited from:
+//
+//	//gomarkdoc:inherit http.Client.Do
+//	func (c *Client) Do(req *http.Request) (*http.Response, error) {
+//		return c.http.Do(req)
+//	}
+//
+// Packages using cgo can also document the API they expose to C. The --c-api
+// flag adds a C API section listing the functions exported with //export
+// directives along with the documented declarations from the C preamble:
+//
+//	gomarkdoc --c-api -o README.md .
+//
+// Commands can describe how they are built with the --build-info flag. It adds a
+// Build Configuration appendix to the documentation of main packages listing
+// the string variables which can be set when linking with -ldflags "-X" (such
+// as a version), along with the packages the command imports and the module
+// versions providing them according to go.mod. The source is only analyzed, so
+// nothing is built:
+//
+//	gomarkdoc --build-info -o README.md ./cmd/...
+//
+// Performance-sensitive libraries can surface their benchmark suites with the
+// --benchmarks flag, which adds a Benchmarks section listing the Benchmark
+// functions declared in the _test.go files of each package along with their
+// documentation comments and code:
+//
+//	gomarkdoc --benchmarks -o README.md .
+//
+// Fuzz targets can be documented in the same way with the --fuzz-targets flag,
+// which adds a Fuzzing section listing the Fuzz functions declared in the
+// _test.go files of each package along with their documentation comments, the
+// number of seed inputs they add and the location of their seed corpus under
+// testdata/fuzz:
+//
+//	gomarkdoc --fuzz-targets -o README.md .
+//
+// The --class-diagram flag adds a Class Diagram section after the index of each
+// package, holding a Mermaid classDiagram of its exported types along with
+// their exported fields and methods, the types of the package they embed and
+// the interfaces of the package they implement. GitHub and many other markdown
+// renderers draw Mermaid diagrams natively. The diagram is built from type
+// information, so it is left out for packages which can't be type checked:
+//
+//	gomarkdoc --class-diagram -o README.md .
+//
+// Constants declared with iota only show the expression of the first constant
+// of their block, leaving the values of the rest implicit. The --iota-values
+// flag follows each const declaration using iota with a table of the value the
+// compiler computes for each of its constants. Like the class diagram, the
+// values come from type information:
+//
+//	gomarkdoc --iota-values -o README.md .
+//
+// Constants can also be declared with expressions whose values take some work
+// to figure out, like 1 << 20 or time.Second * 30. The --computed-values flag
+// adds the same table to every const declaration whose values aren't plain
+// literals, including those using iota:
+//
+//	gomarkdoc --computed-values -o README.md .
+//
+// For small utility packages, the implementation of a function is often the
+// best documentation. The --func-bodies flag adds the full source of each
+// function and method to a collapsible Implementation block after its
+// documentation. To include the source of only some functions, add a
+// //gomarkdoc:body directive to their documentation comments instead:
+//
+//	// Clamp limits v to the range [lo, hi].
+//	//
+//	//gomarkdoc:body
+//	func Clamp(v, lo, hi int) int {
+//		...
+//	}
+//
+// Symbols which are only available for some build targets can be annotated with
+// the --build-targets flag. Each function, type, constant and variable declared
+// in a file with build constraints gets a badge showing the constraint, derived
+// from the file's //go:build line and GOOS/GOARCH file name suffixes. Since
+// only the files matching the current target are documented, this is most
+// useful along with the GOOS, GOARCH and --tags settings for the target:
+//
+//	GOOS=js GOARCH=wasm gomarkdoc --build-targets -o README.md .
+//
+// Packages with platform specific code (such as system call wrappers) can be
+// documented for several platforms at once with the --platforms option, like on
+// pkg.go.dev. The files chosen for each GOOS/GOARCH pair are documented
+// together, and symbols which aren't available on all of the platforms get a
+// badge listing the platforms they are available on:
+//
+//	gomarkdoc --platforms linux/amd64,darwin/arm64,windows/amd64 -o README.md .
+//
+// If gomarkdoc runs over repositories you don't control (e.g. as a service),
+// their configuration and custom templates should be treated as untrusted. The
+// --safe-templates flag disables template functions which can run arbitrary
+// code (such as the call builtin) and only allows template, header and footer
+// files, as well as included files, from within the working directory. Since
+// postprocess commands and running examples execute arbitrary code, they are
+// rejected in safe mode too, as is publishing:
+//
+//	gomarkdoc --safe-templates -o '{{.Dir}}/README.md' ./...
+//
+// To make it possible to audit where generated documentation came from, the
+// --metadata flag adds comments to the end of each file with the version of
+// gomarkdoc used along with the version tag and commit of the documented code.
+// The time of generation is left out by default to keep the output
+// reproducible, but can be included with --metadata-timestamp. Check mode
+// ignores the metadata, so it doesn't cause spurious failures:
+//
+//	gomarkdoc --metadata -o '{{.Dir}}/README.md' ./...
+//
+// Documentation can be translated without changing the source code. Running
+// with --extract-strings writes the prose of the documentation (paragraphs,
+// headers and lists, but not code) to a JSON file keyed by ids derived from the
+// original text. A translated copy of that file can then be provided with
+// --translations, and its text is used in place of the original. Prose whose
+// original text has changed since it was translated falls back to the
+// original:
+//
+//	gomarkdoc --extract-strings strings.json ./...
+//	gomarkdoc --translations de.json -o '{{.Dir}}/README.de.md' ./...
+//
+// The built-in section headings can be renamed without overriding templates
+// using the --heading option, which is useful for following a style guide.
+// Links to the renamed sections are updated to match. The headings which can be
+// renamed are Index, Constants, Variables, Output, C API, Documentation
+// Statistics, Coverage by Package, Largest Undocumented Surfaces, Deprecated,
+// Packages, Contents, Build Configuration, Linker Variables, Dependencies,
+// Imported By, Benchmarks, Fuzzing, Class Diagram, Import Graph, Getting
+// Started and Implementation:
+//
+//	gomarkdoc --heading Index=Contents --heading Constants="Named Values" .
+//
+// Some documentation standards, as well as printed and PDF output, call for
+// numbered sections. The --numbered-headings option prefixes the section and
+// symbol headings with hierarchical numbers (e.g. 2.3.1), leaving the title of
+// each package unnumbered:
+//
+//	gomarkdoc --numbered-headings -o '{{.Dir}}/README.md' ./...
+//
+// Headings nested more deeply than the format supports, such as the examples
+// of a method documented with headings of their own, are rendered at the
+// deepest level (H6 in markdown) by default. The --deep-headings option picks
+// another strategy: "bold" renders them as bold labels, "definition" as the
+// terms of definition lists and "reroot" starts them again at level 2 along
+// with the headings nested within them. The alternatives are supported by the
+// markdown, html and asciidoc formats:
+//
+//	gomarkdoc --deep-headings definition -o README.md .
+//
+// When the published API is meant to differ from the names used in the code
+// (such as during a migration), the --symbol-aliases option accepts a JSON file
+// mapping symbols to the names to present them as. Keys name a top-level symbol
+// or a method or field of a type, and symbols mapped to an empty name are left
+// out. Declarations, references and mentions in doc comments are renamed:
+//
+//	{"clientImpl": "Client", "clientImpl.doRequest": "Do", "legacyHelper": ""}
+//
+// Links to other packages in doc comments (e.g. [net/http.Client]) point at
+// pkg.go.dev by default. The --import-url option maps import path prefixes to
+// url templates instead, such as an internal documentation portal or the
+// documentation generated for sibling packages. The templates can use the
+// package's ImportPath, the matching Prefix and the Path after the prefix, and
+// * replaces the default pkg.go.dev url. Custom templates can resolve urls the
+// same way with the importURL function (e.g. {{importURL "net/http" "Client"}}):
+//
+//	gomarkdoc --import-url 'github.com/org/repo=https://docs.example.com/{{.Path}}' ./...
+//
+// Links to packages documented in the same run (e.g. sibling packages when
+// documenting ./...) take precedence and point at the file and anchor of their
+// generated documentation, relative to the linking file.
+//
+// The type sets of constraint interfaces (e.g. ~int | ~float64) are listed
+// below their declaration with one term per line, linking the named types of
+// the terms to their documentation.
+//
+// Signatures are rendered as code blocks, which can't include links, so the
+// types from other packages used by each signature or type declaration (e.g.
+// context.Context or http.Handler) are linked below it. The links are resolved
+// the same way as the doc comment links above, so --import-url can point them
+// at a private documentation server. The --no-type-links option leaves them
+// out.
+//
+// Custom templates can show a compact version of a declaration with the
+// compactSignature function, which strips its comments and blank lines and
+// formats it with go/format (e.g. {{compactSignature .Decl}} in the type
+// template). The full declaration remains available behind the source link.
+//
+// Packages outside of a Go module have no import path to derive, so their
+// import statement and reference badge are left out and a warning describes
+// what is missing. The --import-path option documents such a package as if it
+// were imported with the provided import path:
+//
+//	gomarkdoc --import-path example.com/legacy/pkg ./legacy/pkg
+//
+// The fields of struct types with documented fields are listed in a table
+// after the type's declaration, along with their types, tags and
+// documentation. The --flatten-embedded option adds the fields promoted to
+// struct types from the structs they embed to the table, including those
+// embedded several levels deep, along with the embedded type each field comes
+// from. This saves readers of configuration structs from chasing every level of
+// embedding.
+//
+// Similarly, the --promoted-methods option lists the methods promoted to each
+// type from the types it embeds after its own methods, along with the embedded
+// type each method comes from, so the documentation covers the type's full
+// method set. For interfaces, the methods of embedded interfaces are listed.
+// The method sets are computed from type information, so nothing is listed for
+// packages which can't be type checked.
+//
+// The --implementers option lists the types implementing each interface after
+// its declaration, linking to their documentation. Implementers are found
+// among the exported types of all of the packages in the interface's module,
+// which is type checked once for all of the packages documented with it. Types
+// whose pointer implements the interface are listed with a "*" prefix, and
+// nothing is listed for empty interfaces:
+//
+//	gomarkdoc --implementers -o '{{.Dir}}/README.md' ./...
+//
+// The --implements option does the inverse, listing the interfaces of the
+// module implemented by each of the other types (or by a pointer to them), so
+// readers can discover the abstractions a type takes part in. Both options can
+// be used together and share the type information of the module:
+//
+//	gomarkdoc --implementers --implements -o '{{.Dir}}/README.md' ./...
+//
+// To help judge the impact of changing a package, the --importers option adds
+// an "Imported By" section to each package listing the other packages which
+// import it, linked to their documentation. Only the packages documented in the
+// same run are considered, so it is meant to be used with ./... to cover a
+// whole module:
+//
+//	gomarkdoc --importers -o '{{.Dir}}/README.md' ./...
+//
+// Packages, types and functions whose documentation contains a paragraph
+// starting with "Deprecated:" are rendered with a deprecated badge and the
+// deprecation notice in bold above the rest of their documentation. The notices
+// of deprecated struct fields remain part of their descriptions.
+//
+// To publish cleaned up documentation for packages carrying a lot of legacy
+// API, the --hide-deprecated option leaves deprecated functions, types and
+// methods out of the generated documentation. The methods of a deprecated type
+// are hidden along with it. They can be moved to an appendix page listing the
+// deprecated symbols of all of the documented packages with the
+// --deprecated-output option:
+//
+//	gomarkdoc --hide-deprecated --deprecated-output DEPRECATED.md -o '{{.Dir}}/README.md' ./...
+//
+// The symbols to document can be selected by name with the --include-symbols
+// and --exclude-symbols options, which can each be provided multiple times.
+// Patterns are globs, or regular expressions when enclosed in slashes, and
+// methods are matched by names like Client.Do. A type is documented when any
+// of its functions or methods are included, and including a type includes all
+// of its functions and methods. Excluded symbols are left out even if they are
+// also included:
+//
+//	gomarkdoc --include-symbols 'Client*' --exclude-symbols '/^Mock/' -o README.md .
+//
+// Types with many boilerplate methods, such as generated gRPC servers, can be
+// documented without their methods with the --hide-methods option, which takes
+// patterns matching the names of the types using the same syntax:
+//
+//	gomarkdoc --hide-methods '*Server' -o README.md .
+//
+// The --manifest option writes a JSON file (conventionally named
+// gomarkdoc-manifest.json) listing every generated file along with the
+// packages documented in it and the SHA-256 hash of its contents, as well as
+// the gomarkdoc version and options the files were generated with. Deploy steps
+// can use it to find the files to publish and to tell which of them changed.
+//
+// When output paths change between runs, such as after changing the --output
+// template or renaming a package, the --redirects option uses the previous
+// manifest to write redirects from the former paths to the current ones.
+// Redirects are kept in the manifest across runs, so older paths keep working
+// too. The redirects are written as a Netlify _redirects file, or as entries
+// for an nginx map block with --redirects-format nginx. Use output paths
+// relative to the root of the published site:
+//
+//	gomarkdoc --manifest gomarkdoc-manifest.json --redirects _redirects -o '{{.Dir}}/README.md' ./...
+//
+// Commands can be chained after gomarkdoc with the --postprocess option (or
+// the postprocess list in the configuration file), such as formatters, link
+// checkers or uploaders. Each command is run by the shell on every written
+// file in order, with the path of the file as its final argument. A failing
+// command stops gomarkdoc unless --postprocess-failure is set to warn or
+// ignore. Postprocess commands are not run in check mode:
+//
+//	gomarkdoc --postprocess "prettier --write" --postprocess-failure warn -o '{{.Dir}}/README.md' ./...
+//
+// Text which must never be published, such as internal hostnames or tokens
+// accidentally left in doc comments, can be removed from the output with the
+// --redact option (or the redact list in the configuration file). Each match
+// is replaced with [REDACTED] in all of the written files, including in check
+// mode. Patterns enclosed in slashes are regular expressions and the rest are
+// matched literally. A warning is logged for each file with redactions, and
+// --redaction-report writes a JSON report of the file, line and pattern of
+// each redaction without the redacted text:
+//
+//	gomarkdoc --redact internal.example.com --redact '/ghp_[A-Za-z0-9]{36}/' --redaction-report redactions.json -o '{{.Dir}}/README.md' ./...
+//
+// Features which use the network share a single http client. Each request is
+// limited by --network-timeout (30s by default), requests failing with a
+// network error, a 429 or a 5xx status are retried --network-retries times
+// with exponential backoff (honoring Retry-After), and no more than
+// --network-rate requests are sent per second. On CI machines without reliable
+// network access, the --offline flag keeps gomarkdoc from accessing the
+// network at all, so features which need it fall back to working without it:
+//
+//	gomarkdoc --offline -o '{{.Dir}}/README.md' ./...
+//
+// The written documentation can be published in the same run with the
+// --publish flag, which pushes the output files to each of the targets listed
+// under publish in the configuration file, in order. A git target commits the
+// files to a branch (gh-pages by default) and pushes it to a remote (origin by
+// default) without touching the working tree. The s3 and gcs targets upload the
+// files to a bucket, using the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY or
+// GOOGLE_OAUTH_ACCESS_TOKEN environment variables for credentials. A confluence
+// target creates or updates a page titled with the import path of the package
+// for each file written with --format confluence, using the CONFLUENCE_USER and
+// CONFLUENCE_TOKEN environment variables. The prefix setting places the files
+// under a directory of the branch or bucket. Publishing uses the credentials
+// of the environment for destinations chosen by the configuration, so it isn't
+// allowed with --safe-templates:
+//
+//	publish:
+//	  - type: git
+//	    branch: gh-pages
+//	    prefix: docs
+//	  - type: s3
+//	    bucket: docs.example.com
+//	    region: us-east-1
+//
+//	gomarkdoc --publish -o '{{.Dir}}/README.md' ./...
+//
+// While writing documentation, the --watch flag keeps gomarkdoc running after
+// the documentation is generated. Whenever a Go file in the directory of one
+// of the packages changes, only the output file of that package is
+// regenerated, along with pages covering all of the packages such as the
+// --index-output page. Press Ctrl+C to stop watching:
+//
+//	gomarkdoc --watch -o '{{.Dir}}/README.md' ./...
+//
+// To preview documentation without writing any files, the serve subcommand
+// renders the packages to html in memory and serves them with a sidebar
+// linking to each package. Open pages reload in the browser whenever the Go
+// files of the packages change:
+//
+//	gomarkdoc serve --addr localhost:6060 ./...
+//
 // You can also run gomarkdoc in a verification mode with the --check/-c flag.
 // This is particularly useful for continuous integration when you want to make
 // sure that a commit correctly updated the generated documentation. This flag
@@ -192,6 +928,40 @@
 //
 //	gomarkdoc -o README.md -c .
 //
+// When a file is out of date, the differences are printed to stderr. Unless
+// stderr is a terminal, they are printed as a unified diff whose hunk headers
+// name the symbol each change is in, so CI logs show exactly what changed. The
+// --check-diff option selects the style explicitly (unified or terminal).
+//
+// For CI dashboards and bots, --report json writes a report of the check to
+// stdout, listing the status of each output file (ok, stale, missing or error)
+// and the packages it documents, along with the lines, symbol and diff of each
+// changed region of stale files:
+//
+//	gomarkdoc -o '{{.Dir}}/README.md' -c --report json ./... > report.json
+//
+// The --report sarif option writes the same findings as a SARIF 2.1.0 log
+// instead, which code scanning tools can use to annotate the stale lines of a
+// pull request:
+//
+//	gomarkdoc -o '{{.Dir}}/README.md' -c --report sarif ./... > gomarkdoc.sarif
+//
+// In read-only containers and hermetic build systems which don't allow writing
+// into the source tree, the --output-tar option writes all of the generated
+// files to a tar archive instead, or to stdout if it is set to -. The entries
+// are named by the paths from --output, relative to the working directory:
+//
+//	gomarkdoc --output-tar - -o '{{.Dir}}/README.md' ./... | tar -x -C /tmp/docs
+//
+// Reviewing a large diff of regenerated documentation is easier with the
+// --changelog option. When overwriting an existing file, it compares the
+// sections of each symbol with the previous contents of the file and adds
+// comments at the bottom listing the symbols which were added, removed or
+// modified. The changelog is replaced on each run and is ignored by check mode:
+//
+//	<!-- gomarkdoc-changes: added NewClient -->
+//	<!-- gomarkdoc-changes: modified Client.Do -->
+//
 // If you're experiencing difficulty with gomarkdoc or just want to get more
 // information about how it's executing underneath, you can add -v to show more
 // logs. This can be chained a second time to show even more verbose logs:
@@ -208,6 +978,14 @@
 //
 //	gomarkdoc --repository.url "https://github.com/princjef/gomarkdoc" --repository.default-branch master --repository.path / -o README.md .
 //
+// Links to the source code point to the default branch, so their line anchors
+// drift as the code on the branch changes. The --repository.pin flag turns them
+// into permalinks to the commit currently checked out, like
+// .../blob/<sha>/file.go#L10-L20. A specific commit can be set with the
+// --repository.commit option instead:
+//
+//	gomarkdoc --repository.pin -o README.md .
+//
 // # Configuring via File
 //
 // If you want to reuse configuration options across multiple invocations, you
@@ -274,6 +1052,37 @@
 //		fmt.Println(out.Package(pkg))
 //	}
 //
+// Tools embedding gomarkdoc can follow its progress (e.g. to show a progress
+// bar) by creating the logger with logger.WithProgress. The callback is told
+// when each package starts being loaded and, for renderers created with
+// WithLogger, when each package has been rendered. The command line tool also
+// reports each file it writes.
+//
+// Packages don't need to be checked out on disk to be documented. The
+// lang.NewPackageFromFS function loads a package from any io/fs file system,
+// such as a module zip opened with lang.ModuleZipFS, an adapter over a git
+// object store or an in-memory fstest.MapFS, and lang.FindPackages discovers
+// the packages within it:
+//
+//	fsys, modPath, err := lang.ModuleZipFS(zipReader)
+//	if err != nil {
+//		// handle error
+//	}
+//
+//	dirs, err := lang.FindPackages(fsys, ".")
+//	if err != nil {
+//		// handle error
+//	}
+//
+//	for _, dir := range dirs {
+//		pkg, err := lang.NewPackageFromFS(log, fsys, dir, path.Join(modPath, dir))
+//		if err != nil {
+//			// handle error
+//		}
+//
+//		fmt.Println(out.Package(pkg))
+//	}
+//
 // # Examples
 //
 // This project uses itself to generate the README files in
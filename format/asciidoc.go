@@ -0,0 +1,248 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+)
+
+// AsciiDocMarkup provides a Format which renders documentation as AsciiDoc
+// instead of markdown, suitable for inclusion in Asciidoctor and Antora-based
+// documentation sites. Links to source code use the same GitHub-style urls as
+// GitHubFlavoredMarkdown.
+type AsciiDocMarkup struct{}
+
+var asciiDocIDRegex = regexp.MustCompile("[^a-z0-9]+")
+
+// TemplateVariant provides the name of the AsciiDoc variants of the default
+// templates, which use AsciiDoc syntax for tables.
+func (f *AsciiDocMarkup) TemplateVariant() string {
+	return "asciidoc"
+}
+
+// Bold converts the provided text to bold
+func (f *AsciiDocMarkup) Bold(text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("**%s**", f.Escape(text)), nil
+}
+
+// CodeBlock wraps the provided code as a listing block and tags it as source
+// code of the provided language (or no language if the empty string is
+// provided).
+func (f *AsciiDocMarkup) CodeBlock(language, code string) (string, error) {
+	code = strings.TrimSpace(code)
+	if language == "" {
+		return fmt.Sprintf("----\n%s\n----", code), nil
+	}
+
+	return fmt.Sprintf("[source,%s]\n----\n%s\n----", language, code), nil
+}
+
+// Anchor produces an anchor for the provided link.
+func (f *AsciiDocMarkup) Anchor(anchor string) string {
+	return fmt.Sprintf("[[%s]]", anchor)
+}
+
+// AnchorHeader converts the provided text and custom anchor link into a header
+// of the provided level. The level is expected to be at least 1.
+func (f *AsciiDocMarkup) AnchorHeader(level int, text, anchor string) (string, error) {
+	return f.RawAnchorHeader(level, f.Escape(text), anchor)
+}
+
+// Header converts the provided text into a header of the provided level. The
+// level is expected to be at least 1.
+func (f *AsciiDocMarkup) Header(level int, text string) (string, error) {
+	return f.RawHeader(level, f.Escape(text))
+}
+
+// RawAnchorHeader converts the provided text and custom anchor link into a
+// header of the provided level without escaping the header text. The level is
+// expected to be at least 1.
+func (f *AsciiDocMarkup) RawAnchorHeader(level int, text, anchor string) (string, error) {
+	header, err := f.RawHeader(level, text)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s\n%s", f.Anchor(anchor), header), nil
+}
+
+// RawHeader converts the provided text into a header of the provided level
+// without escaping the header text. The level is expected to be at least 1. A
+// level 1 header is rendered as the document title.
+func (f *AsciiDocMarkup) RawHeader(level int, text string) (string, error) {
+	if level < 1 {
+		return "", errors.New("format: header level cannot be less than 1")
+	}
+
+	// Only go up to 6 levels. Anything higher is also level 6
+	if level > 6 {
+		level = 6
+	}
+
+	return fmt.Sprintf("%s %s", strings.Repeat("=", level), text), nil
+}
+
+// MaxHeaderLevel provides the deepest header level of the format.
+func (f *AsciiDocMarkup) MaxHeaderLevel() int {
+	return 6
+}
+
+// RawLabel converts the provided text into a bold label standing in place of a
+// header, without escaping the text.
+func (f *AsciiDocMarkup) RawLabel(text string) (string, error) {
+	return fmt.Sprintf("**%s**", text), nil
+}
+
+// RawDefinitionTerm converts the provided text into the term of a description
+// list standing in place of a header, without escaping the text.
+func (f *AsciiDocMarkup) RawDefinitionTerm(text string) (string, error) {
+	return fmt.Sprintf("%s::", text), nil
+}
+
+// LocalHref generates an href for navigating to a header with the given
+// headerText located within the same document as the href itself. The href
+// matches the section ids generated by Asciidoctor by default.
+func (f *AsciiDocMarkup) LocalHref(headerText string) (string, error) {
+	id := asciiDocIDRegex.ReplaceAllString(strings.ToLower(headerText), "_")
+	return fmt.Sprintf("#_%s", strings.Trim(id, "_")), nil
+}
+
+// RawLocalHref generates an href within the same document but with a direct
+// link provided instead of text to slugify.
+func (f *AsciiDocMarkup) RawLocalHref(anchor string) string {
+	return fmt.Sprintf("#%s", anchor)
+}
+
+// Link generates a link with the given text and href values. Hrefs within the
+// same document are rendered as cross references.
+func (f *AsciiDocMarkup) Link(text, href string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	text = f.Escape(text)
+	if href == "" {
+		return text, nil
+	}
+
+	if strings.HasPrefix(href, "#") {
+		return fmt.Sprintf("<<%s,%s>>", strings.TrimPrefix(href, "#"), text), nil
+	}
+
+	return fmt.Sprintf("link:++%s++[%s]", href, text), nil
+}
+
+// Badge generates a badge image with the provided alt text and image url which
+// links to the provided href. If the href is empty, the image is not linked.
+func (f *AsciiDocMarkup) Badge(text, image, href string) (string, error) {
+	if image == "" {
+		return "", nil
+	}
+
+	alt := strings.ReplaceAll(text, "\"", "'")
+	if href == "" {
+		return fmt.Sprintf("image:%s[\"%s\"]", image, alt), nil
+	}
+
+	return fmt.Sprintf("image:%s[\"%s\",link=\"%s\"]", image, alt, href), nil
+}
+
+// CodeHref generates an href to the provided code entry, using the url format
+// of GitHub repositories.
+func (f *AsciiDocMarkup) CodeHref(loc lang.Location) (string, error) {
+	return GitHub.CodeHref(loc)
+}
+
+// Comment generates a single line comment with the provided text.
+func (f *AsciiDocMarkup) Comment(text string) (string, error) {
+	return fmt.Sprintf("// %s", text), nil
+}
+
+// ListEntry generates an unordered list entry with the provided text at the
+// provided zero-indexed depth. A depth of 0 is considered the topmost level of
+// list.
+func (f *AsciiDocMarkup) ListEntry(depth int, text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s %s", strings.Repeat("*", depth+1), text), nil
+}
+
+// Accordion generates a collapsible block. The block's visible title while
+// collapsed is the provided title and the expanded content is the body.
+func (f *AsciiDocMarkup) Accordion(title, body string) (string, error) {
+	header, err := f.AccordionHeader(title)
+	if err != nil {
+		return "", err
+	}
+
+	terminator, err := f.AccordionTerminator()
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s\n%s\n%s", header, body, terminator), nil
+}
+
+// AccordionHeader generates the header visible when an accordion is collapsed.
+//
+// The AccordionHeader is expected to be used in conjunction with
+// AccordionTerminator() when the demands of the body's rendering requires it to
+// be generated independently. The result looks conceptually like the following:
+//
+//	accordion := format.AccordionHeader("Accordion Title") + "Accordion Body" + format.AccordionTerminator()
+func (f *AsciiDocMarkup) AccordionHeader(title string) (string, error) {
+	return fmt.Sprintf(".%s\n[%%collapsible]\n====", title), nil
+}
+
+// AccordionTerminator generates the code necessary to terminate an accordion
+// after the body. It is expected to be used in conjunction with
+// AccordionHeader(). See AccordionHeader for a full description.
+func (f *AsciiDocMarkup) AccordionTerminator() (string, error) {
+	return "====", nil
+}
+
+// Escape replaces the characters with special meaning in AsciiDoc with their
+// character references, but leaves URLs found intact. Note that the URLs
+// included must begin with a scheme to skip the escaping. Escaping text which
+// has already been escaped leaves it unchanged.
+func (f *AsciiDocMarkup) Escape(text string) string {
+	var (
+		cursor  int
+		builder strings.Builder
+	)
+
+	for _, urlLoc := range schemeURLRegex.FindAllStringIndex(text, -1) {
+		builder.WriteString(escapeAsciiDoc(text[cursor:urlLoc[0]]))
+		builder.WriteString(text[urlLoc[0]:urlLoc[1]])
+		cursor = urlLoc[1]
+	}
+
+	builder.WriteString(escapeAsciiDoc(text[cursor:]))
+	return builder.String()
+}
+
+func escapeAsciiDoc(text string) string {
+	var builder strings.Builder
+	for i, r := range text {
+		switch {
+		case r == '#' && i > 0 && text[i-1] == '&':
+			// Leave existing character references alone
+			builder.WriteRune(r)
+		case strings.ContainsRune("*_`^~+[]{}<>#", r):
+			fmt.Fprintf(&builder, "&#%d;", r)
+		default:
+			builder.WriteRune(r)
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,194 @@
+package format_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/format"
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/matryer/is"
+)
+
+func TestAsciiDoc_Bold(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AsciiDocMarkup
+	res, err := f.Bold("sample text")
+	is.NoErr(err)
+	is.Equal(res, "**sample text**")
+}
+
+func TestAsciiDoc_RawDefinitionTerm(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AsciiDocMarkup
+	res, err := f.RawDefinitionTerm("sample *text*")
+	is.NoErr(err)
+	is.Equal(res, "sample *text*::")
+}
+
+func TestAsciiDoc_CodeBlock(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AsciiDocMarkup
+	res, err := f.CodeBlock("go", "Line 1\nLine 2")
+	is.NoErr(err)
+	is.Equal(res, "[source,go]\n----\nLine 1\nLine 2\n----")
+}
+
+func TestAsciiDoc_CodeBlock_noLanguage(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AsciiDocMarkup
+	res, err := f.CodeBlock("", "Line 1\nLine 2")
+	is.NoErr(err)
+	is.Equal(res, "----\nLine 1\nLine 2\n----")
+}
+
+func TestAsciiDoc_Header(t *testing.T) {
+	tests := []struct {
+		text   string
+		level  int
+		result string
+	}{
+		{"header text", 1, "= header text"},
+		{"level 2", 2, "== level 2"},
+		{"level 6", 6, "====== level 6"},
+		{"other level", 12, "====== other level"},
+		{"with * escape", 2, "== with &#42; escape"},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s (level %d)", test.text, test.level), func(t *testing.T) {
+			is := is.New(t)
+
+			var f format.AsciiDocMarkup
+			res, err := f.Header(test.level, test.text)
+			is.NoErr(err)
+			is.Equal(res, test.result)
+		})
+	}
+}
+
+func TestAsciiDoc_Header_invalidLevel(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AsciiDocMarkup
+	_, err := f.Header(-1, "invalid")
+	is.Equal(err.Error(), "format: header level cannot be less than 1")
+}
+
+func TestAsciiDoc_AnchorHeader(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AsciiDocMarkup
+	res, err := f.AnchorHeader(2, "type Receiver", "Receiver")
+	is.NoErr(err)
+	is.Equal(res, "[[Receiver]]\n== type Receiver")
+}
+
+func TestAsciiDoc_LocalHref(t *testing.T) {
+	tests := map[string]string{
+		"Index":                  "#_index",
+		"Normal Header":          "#_normal_header",
+		"Special(#)%^Characters": "#_special_characters",
+	}
+
+	for input, output := range tests {
+		t.Run(input, func(t *testing.T) {
+			is := is.New(t)
+
+			var f format.AsciiDocMarkup
+			res, err := f.LocalHref(input)
+			is.NoErr(err)
+			is.Equal(res, output)
+		})
+	}
+}
+
+func TestAsciiDoc_CodeHref(t *testing.T) {
+	is := is.New(t)
+
+	wd, err := filepath.Abs(".")
+	is.NoErr(err)
+	locPath := filepath.Join(wd, "subdir", "file.go")
+
+	var f format.AsciiDocMarkup
+	res, err := f.CodeHref(lang.Location{
+		Start:    lang.Position{Line: 12, Col: 1},
+		End:      lang.Position{Line: 14, Col: 43},
+		Filepath: locPath,
+		WorkDir:  wd,
+		Repo: &lang.Repo{
+			Remote:        "https://github.com/org/repo",
+			DefaultBranch: "main",
+			PathFromRoot:  "/",
+		},
+	})
+	is.NoErr(err)
+	is.Equal(res, "https://github.com/org/repo/blob/main/subdir/file.go#L12-L14")
+}
+
+func TestAsciiDoc_Link(t *testing.T) {
+	tests := []struct {
+		text   string
+		href   string
+		result string
+	}{
+		{"some text", "https://host.com/path", "link:++https://host.com/path++[some text]"},
+		{"func (r *Receiver) Method()", "#Receiver.Method", "<<Receiver.Method,func (r &#42;Receiver) Method()>>"},
+		{"no link", "", "no link"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			is := is.New(t)
+
+			var f format.AsciiDocMarkup
+			res, err := f.Link(test.text, test.href)
+			is.NoErr(err)
+			is.Equal(res, test.result)
+		})
+	}
+}
+
+func TestAsciiDoc_Badge(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AsciiDocMarkup
+	res, err := f.Badge("Go Reference", "https://pkg.go.dev/badge/pkg.svg", "https://pkg.go.dev/pkg")
+	is.NoErr(err)
+	is.Equal(res, `image:https://pkg.go.dev/badge/pkg.svg["Go Reference",link="https://pkg.go.dev/pkg"]`)
+}
+
+func TestAsciiDoc_ListEntry(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AsciiDocMarkup
+	res, err := f.ListEntry(0, "list entry text")
+	is.NoErr(err)
+	is.Equal(res, "* list entry text")
+
+	res, err = f.ListEntry(2, "nested text")
+	is.NoErr(err)
+	is.Equal(res, "*** nested text")
+}
+
+func TestAsciiDoc_Accordion(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AsciiDocMarkup
+	res, err := f.Accordion("Title", "Body")
+	is.NoErr(err)
+	is.Equal(res, ".Title\n[%collapsible]\n====\nBody\n====")
+}
+
+func TestAsciiDoc_Escape(t *testing.T) {
+	is := is.New(t)
+
+	var f format.AsciiDocMarkup
+	res := f.Escape("a *b* [c] #d see https://host.com/a_b#c")
+	is.Equal(res, "a &#42;b&#42; &#91;c&#93; &#35;d see https://host.com/a_b#c")
+	is.Equal(f.Escape(res), res)
+}
@@ -0,0 +1,45 @@
+package format
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+)
+
+// bitbucketServerRemoteRegex matches the web URLs of repositories hosted on
+// Bitbucket Server (Data Center), as detected from their clone URLs.
+var bitbucketServerRemoteRegex = regexp.MustCompile(`/projects/[^/]+/repos/[^/]+$`)
+
+// bitbucketServerCodeHref generates an href to the provided location in the
+// file at the provided slash-separated path from the root of the repository
+// when the repository is hosted on Bitbucket Server, whose source links take
+// the form .../browse/<path>?at=<ref>#<start>-<end>. The second return value is
+// false if the repository is hosted elsewhere.
+func bitbucketServerCodeHref(loc lang.Location, p string) (string, bool) {
+	if !bitbucketServerRemoteRegex.MatchString(loc.Repo.Remote) {
+		return "", false
+	}
+
+	// Branches are referred to by their full ref
+	at := loc.Repo.Commit
+	if at == "" {
+		at = fmt.Sprintf("refs/heads/%s", loc.Repo.DefaultBranch)
+	}
+
+	var locStr string
+	if loc.Start.Line == loc.End.Line {
+		locStr = fmt.Sprintf("%d", loc.Start.Line)
+	} else {
+		locStr = fmt.Sprintf("%d-%d", loc.Start.Line, loc.End.Line)
+	}
+
+	return fmt.Sprintf(
+		"%s/browse/%s?at=%s#%s",
+		loc.Repo.Remote,
+		p,
+		url.QueryEscape(at),
+		locStr,
+	), true
+}
@@ -0,0 +1,206 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+)
+
+// ConfluenceWiki provides a Format which renders documentation as Confluence
+// wiki markup, which can be published to Confluence pages through its REST API
+// (using the "wiki" representation). Links to source code use the url format of
+// GitHub repositories.
+type ConfluenceWiki struct{}
+
+var (
+	confluenceIDRegex      = regexp.MustCompile(`[^\p{L}\p{N}_.-]+`)
+	confluenceSpecialChars = "\\*_{}[]|!#-+^~?"
+)
+
+// TemplateVariant provides the name of the Confluence variants of the default
+// templates, which use wiki markup for lists.
+func (f *ConfluenceWiki) TemplateVariant() string {
+	return "confluence"
+}
+
+// Bold converts the provided text to bold
+func (f *ConfluenceWiki) Bold(text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("*%s*", f.Escape(text)), nil
+}
+
+// CodeBlock wraps the provided code as a code block and tags it with the
+// provided language (or no language if the empty string is provided).
+func (f *ConfluenceWiki) CodeBlock(language, code string) (string, error) {
+	code = strings.TrimSpace(code)
+	if language == "" {
+		return fmt.Sprintf("{noformat}\n%s\n{noformat}", code), nil
+	}
+
+	return fmt.Sprintf("{code:language=%s}\n%s\n{code}", language, code), nil
+}
+
+// Anchor produces an anchor for the provided link.
+func (f *ConfluenceWiki) Anchor(anchor string) string {
+	return fmt.Sprintf("{anchor:%s}", anchor)
+}
+
+// AnchorHeader converts the provided text and custom anchor link into a header
+// of the provided level. The level is expected to be at least 1.
+func (f *ConfluenceWiki) AnchorHeader(level int, text, anchor string) (string, error) {
+	return f.RawAnchorHeader(level, f.Escape(text), anchor)
+}
+
+// Header converts the provided text into a header of the provided level. The
+// level is expected to be at least 1.
+func (f *ConfluenceWiki) Header(level int, text string) (string, error) {
+	return f.RawHeader(level, f.Escape(text))
+}
+
+// RawAnchorHeader converts the provided text and custom anchor link into a
+// header of the provided level without escaping the header text. The level is
+// expected to be at least 1.
+func (f *ConfluenceWiki) RawAnchorHeader(level int, text, anchor string) (string, error) {
+	return f.RawHeader(level, fmt.Sprintf("%s%s", f.Anchor(anchor), text))
+}
+
+// RawHeader converts the provided text into a header of the provided level
+// without escaping the header text. The level is expected to be at least 1.
+func (f *ConfluenceWiki) RawHeader(level int, text string) (string, error) {
+	if level < 1 {
+		return "", errors.New("format: header level cannot be less than 1")
+	}
+
+	// Only go up to 6 levels. Anything higher is also level 6
+	if level > 6 {
+		level = 6
+	}
+
+	return fmt.Sprintf("h%d. %s", level, text), nil
+}
+
+// LocalHref generates an href for navigating to a header with the given
+// headerText located within the same document as the href itself. Confluence
+// identifies headers by their text without whitespace.
+func (f *ConfluenceWiki) LocalHref(headerText string) (string, error) {
+	return fmt.Sprintf("#%s", confluenceIDRegex.ReplaceAllString(headerText, "")), nil
+}
+
+// RawLocalHref generates an href within the same document but with a direct
+// link provided instead of text to slugify.
+func (f *ConfluenceWiki) RawLocalHref(anchor string) string {
+	return fmt.Sprintf("#%s", anchor)
+}
+
+// Link generates a link with the given text and href values.
+func (f *ConfluenceWiki) Link(text, href string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	if href == "" {
+		return text, nil
+	}
+
+	return fmt.Sprintf("[%s|%s]", f.Escape(text), href), nil
+}
+
+// Badge generates a badge image with the provided alt text and image url which
+// links to the provided href. If the href is empty, the image is not linked.
+func (f *ConfluenceWiki) Badge(text, image, href string) (string, error) {
+	if image == "" {
+		return "", nil
+	}
+
+	img := fmt.Sprintf("!%s|alt=%s!", image, strings.ReplaceAll(f.Escape(text), ",", " "))
+	if href == "" {
+		return img, nil
+	}
+
+	return fmt.Sprintf("[%s|%s]", img, href), nil
+}
+
+// CodeHref generates an href to the provided code entry, using the url format
+// of GitHub repositories.
+func (f *ConfluenceWiki) CodeHref(loc lang.Location) (string, error) {
+	return GitHub.CodeHref(loc)
+}
+
+// Comment generates a comment with the provided text. Confluence wiki markup
+// has no syntax for comments, so nothing is produced.
+func (f *ConfluenceWiki) Comment(text string) (string, error) {
+	return "", nil
+}
+
+// ListEntry generates an unordered list entry with the provided text at the
+// provided zero-indexed depth. A depth of 0 is considered the topmost level of
+// list.
+func (f *ConfluenceWiki) ListEntry(depth int, text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s %s", strings.Repeat("*", depth+1), text), nil
+}
+
+// Accordion generates a collapsible content. The accordion's visible title
+// while collapsed is the provided title and the expanded content is the body.
+func (f *ConfluenceWiki) Accordion(title, body string) (string, error) {
+	return fmt.Sprintf("{expand:%s}\n%s\n{expand}", f.Escape(title), body), nil
+}
+
+// AccordionHeader generates the header visible when an accordion is collapsed.
+//
+// The AccordionHeader is expected to be used in conjunction with
+// AccordionTerminator() when the demands of the body's rendering requires it to
+// be generated independently. The result looks conceptually like the following:
+//
+//	accordion := format.AccordionHeader("Accordion Title") + "Accordion Body" + format.AccordionTerminator()
+func (f *ConfluenceWiki) AccordionHeader(title string) (string, error) {
+	return fmt.Sprintf("{expand:%s}", f.Escape(title)), nil
+}
+
+// AccordionTerminator generates the code necessary to terminate an accordion
+// after the body. It is expected to be used in conjunction with
+// AccordionHeader(). See AccordionHeader for a full description.
+func (f *ConfluenceWiki) AccordionTerminator() (string, error) {
+	return "{expand}\n\n", nil
+}
+
+// Escape escapes the characters with special meaning in Confluence wiki markup
+// from the provided text, but leaves URLs found intact. Note that the URLs
+// included must begin with a scheme to skip the escaping.
+func (f *ConfluenceWiki) Escape(text string) string {
+	var (
+		cursor  int
+		builder strings.Builder
+	)
+
+	for _, urlLoc := range schemeURLRegex.FindAllStringIndex(text, -1) {
+		builder.WriteString(escapeConfluence(text[cursor:urlLoc[0]]))
+		builder.WriteString(text[urlLoc[0]:urlLoc[1]])
+		cursor = urlLoc[1]
+	}
+
+	builder.WriteString(escapeConfluence(text[cursor:]))
+	return builder.String()
+}
+
+func escapeConfluence(text string) string {
+	var builder strings.Builder
+	for _, r := range text {
+		if strings.ContainsRune(confluenceSpecialChars, r) {
+			builder.WriteRune('\\')
+		}
+
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,73 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/format"
+	"github.com/matryer/is"
+)
+
+func TestConfluence_Header(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ConfluenceWiki
+	res, err := f.Header(8, "package [docs]")
+	is.NoErr(err)
+	is.Equal(res, "h6. package \\[docs\\]")
+}
+
+func TestConfluence_AnchorHeader(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ConfluenceWiki
+	res, err := f.AnchorHeader(2, "type Receiver", "Receiver")
+	is.NoErr(err)
+	is.Equal(res, "h2. {anchor:Receiver}type Receiver")
+}
+
+func TestConfluence_CodeBlock(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ConfluenceWiki
+	res, err := f.CodeBlock("go", "func main() {}\n")
+	is.NoErr(err)
+	is.Equal(res, "{code:language=go}\nfunc main() {}\n{code}")
+
+	res, err = f.CodeBlock("", "output")
+	is.NoErr(err)
+	is.Equal(res, "{noformat}\noutput\n{noformat}")
+}
+
+func TestConfluence_Link(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ConfluenceWiki
+	res, err := f.Link("func (r *Receiver) Do()", "#Receiver.Do")
+	is.NoErr(err)
+	is.Equal(res, "[func (r \\*Receiver) Do()|#Receiver.Do]")
+}
+
+func TestConfluence_LocalHref(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ConfluenceWiki
+	res, err := f.LocalHref("Largest Undocumented Surfaces")
+	is.NoErr(err)
+	is.Equal(res, "#LargestUndocumentedSurfaces")
+}
+
+func TestConfluence_ListEntry(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ConfluenceWiki
+	res, err := f.ListEntry(1, "nested")
+	is.NoErr(err)
+	is.Equal(res, "** nested")
+}
+
+func TestConfluence_Escape(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ConfluenceWiki
+	is.Equal(f.Escape("a *bold* {macro} at https://example.com/a_b"), "a \\*bold\\* \\{macro\\} at https://example.com/a_b")
+}
@@ -58,6 +58,23 @@ func (f *AzureDevOpsMarkdown) RawHeader(level int, text string) (string, error)
 	return formatcore.Header(level, text)
 }
 
+// MaxHeaderLevel provides the deepest header level of the format.
+func (f *AzureDevOpsMarkdown) MaxHeaderLevel() int {
+	return formatcore.MaxHeaderLevel
+}
+
+// RawLabel converts the provided text into a bold label standing in place of a
+// header, without escaping the text.
+func (f *AzureDevOpsMarkdown) RawLabel(text string) (string, error) {
+	return formatcore.Label(text), nil
+}
+
+// RawDefinitionTerm converts the provided text into the term of a definition
+// list standing in place of a header, without escaping the text.
+func (f *AzureDevOpsMarkdown) RawDefinitionTerm(text string) (string, error) {
+	return formatcore.DefinitionTerm(text), nil
+}
+
 var devOpsWhitespaceRegex = regexp.MustCompile(`\s`)
 
 // LocalHref generates an href for navigating to a header with the given
@@ -107,11 +124,17 @@ func (f *AzureDevOpsMarkdown) CodeHref(loc lang.Location) (string, error) {
 		return "", err
 	}
 
+	// Versions are prefixed with GB for branches and GC for commits
+	version := fmt.Sprintf("GB%s", loc.Repo.DefaultBranch)
+	if loc.Repo.Commit != "" {
+		version = fmt.Sprintf("GC%s", loc.Repo.Commit)
+	}
+
 	return fmt.Sprintf(
-		"%s?path=%s&version=GB%s&lineStyle=plain&line=%d&lineEnd=%d&lineStartColumn=%d&lineEndColumn=%d",
+		"%s?path=%s&version=%s&lineStyle=plain&line=%d&lineEnd=%d&lineStartColumn=%d&lineEndColumn=%d",
 		loc.Repo.Remote,
 		url.PathEscape(filepath.ToSlash(p)),
-		loc.Repo.DefaultBranch,
+		version,
 		loc.Start.Line,
 		loc.End.Line,
 		loc.Start.Col,
@@ -124,6 +147,17 @@ func (f *AzureDevOpsMarkdown) Link(text, href string) (string, error) {
 	return formatcore.Link(text, href), nil
 }
 
+// Badge generates a badge image with the provided alt text and image url which
+// links to the provided href. If the href is empty, the image is not linked.
+func (f *AzureDevOpsMarkdown) Badge(text, image, href string) (string, error) {
+	return formatcore.Badge(text, image, href), nil
+}
+
+// Comment generates an HTML comment with the provided text.
+func (f *AzureDevOpsMarkdown) Comment(text string) (string, error) {
+	return formatcore.Comment(text), nil
+}
+
 // ListEntry generates an unordered list entry with the provided text at the
 // provided zero-indexed depth. A depth of 0 is considered the topmost level of
 // list.
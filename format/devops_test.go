@@ -139,6 +139,30 @@ func TestCodeHref(t *testing.T) {
 	is.Equal(res, "https://dev.azure.com/org/project/_git/repo?path=subdir%2Ffile.go&version=GBmaster&lineStyle=plain&line=12&lineEnd=14&lineStartColumn=1&lineEndColumn=43")
 }
 
+func TestCodeHref_commit(t *testing.T) {
+	is := is.New(t)
+
+	wd, err := filepath.Abs(".")
+	is.NoErr(err)
+	locPath := filepath.Join(wd, "subdir", "file.go")
+
+	var f format.AzureDevOpsMarkdown
+	res, err := f.CodeHref(lang.Location{
+		Start:    lang.Position{Line: 12, Col: 1},
+		End:      lang.Position{Line: 14, Col: 43},
+		Filepath: locPath,
+		WorkDir:  wd,
+		Repo: &lang.Repo{
+			Remote:        "https://dev.azure.com/org/project/_git/repo",
+			DefaultBranch: "master",
+			PathFromRoot:  "/",
+			Commit:        "0123456789abcdef0123456789abcdef01234567",
+		},
+	})
+	is.NoErr(err)
+	is.Equal(res, "https://dev.azure.com/org/project/_git/repo?path=subdir%2Ffile.go&version=GC0123456789abcdef0123456789abcdef01234567&lineStyle=plain&line=12&lineEnd=14&lineStartColumn=1&lineEndColumn=43")
+}
+
 func TestCodeHref_noRepo(t *testing.T) {
 	is := is.New(t)
 
@@ -0,0 +1,165 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anthonyme00/gomarkdoc/format/formatcore"
+	"github.com/anthonyme00/gomarkdoc/lang"
+)
+
+// DocusaurusMarkdown provides a Format which is compatible with the MDX
+// documents used by Docusaurus. It is similar to GitHubFlavoredMarkdown, but
+// avoids the constructs which are not valid MDX, such as HTML comments and
+// angle bracket link destinations. Links to source code use the url format of
+// GitHub repositories.
+type DocusaurusMarkdown struct{}
+
+var mdxHrefReplacer = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E")
+
+// Bold converts the provided text to bold
+func (f *DocusaurusMarkdown) Bold(text string) (string, error) {
+	return formatcore.Bold(text), nil
+}
+
+// CodeBlock wraps the provided code as a code block and tags it with the
+// provided language (or no language if the empty string is provided).
+func (f *DocusaurusMarkdown) CodeBlock(language, code string) (string, error) {
+	return formatcore.GFMCodeBlock(language, code), nil
+}
+
+// Anchor produces an anchor for the provided link.
+func (f *DocusaurusMarkdown) Anchor(anchor string) string {
+	return formatcore.Anchor(anchor)
+}
+
+// AnchorHeader converts the provided text and custom anchor link into a header
+// of the provided level. The level is expected to be at least 1.
+func (f *DocusaurusMarkdown) AnchorHeader(level int, text, anchor string) (string, error) {
+	return formatcore.AnchorHeader(level, formatcore.Escape(text), anchor)
+}
+
+// Header converts the provided text into a header of the provided level. The
+// level is expected to be at least 1.
+func (f *DocusaurusMarkdown) Header(level int, text string) (string, error) {
+	return formatcore.Header(level, formatcore.Escape(text))
+}
+
+// RawAnchorHeader converts the provided text and custom anchor link into a
+// header of the provided level without escaping the header text. The level is
+// expected to be at least 1.
+func (f *DocusaurusMarkdown) RawAnchorHeader(level int, text, anchor string) (string, error) {
+	return formatcore.AnchorHeader(level, text, anchor)
+}
+
+// RawHeader converts the provided text into a header of the provided level
+// without escaping the header text. The level is expected to be at least 1.
+func (f *DocusaurusMarkdown) RawHeader(level int, text string) (string, error) {
+	return formatcore.Header(level, text)
+}
+
+// MaxHeaderLevel provides the deepest header level of the format.
+func (f *DocusaurusMarkdown) MaxHeaderLevel() int {
+	return formatcore.MaxHeaderLevel
+}
+
+// RawLabel converts the provided text into a bold label standing in place of a
+// header, without escaping the text.
+func (f *DocusaurusMarkdown) RawLabel(text string) (string, error) {
+	return formatcore.Label(text), nil
+}
+
+// RawDefinitionTerm converts the provided text into the term of a definition
+// list standing in place of a header, without escaping the text.
+func (f *DocusaurusMarkdown) RawDefinitionTerm(text string) (string, error) {
+	return formatcore.DefinitionTerm(text), nil
+}
+
+// LocalHref generates an href for navigating to a header with the given
+// headerText located within the same document as the href itself. Docusaurus
+// generates header ids the same way as GitHub.
+func (f *DocusaurusMarkdown) LocalHref(headerText string) (string, error) {
+	return GitHub.LocalHref(headerText)
+}
+
+// RawLocalHref generates an href within the same document but with a direct
+// link provided instead of text to slugify.
+func (f *DocusaurusMarkdown) RawLocalHref(anchor string) string {
+	return fmt.Sprintf("#%s", anchor)
+}
+
+// Link generates a link with the given text and href values.
+func (f *DocusaurusMarkdown) Link(text, href string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	if href == "" {
+		return text, nil
+	}
+
+	return fmt.Sprintf("[%s](%s)", formatcore.Escape(text), mdxHrefReplacer.Replace(href)), nil
+}
+
+// Badge generates a badge image with the provided alt text and image url which
+// links to the provided href. If the href is empty, the image is not linked.
+func (f *DocusaurusMarkdown) Badge(text, image, href string) (string, error) {
+	if image == "" {
+		return "", nil
+	}
+
+	img := fmt.Sprintf("![%s](%s)", formatcore.Escape(text), mdxHrefReplacer.Replace(image))
+	if href == "" {
+		return img, nil
+	}
+
+	return fmt.Sprintf("[%s](%s)", img, mdxHrefReplacer.Replace(href)), nil
+}
+
+// CodeHref generates an href to the provided code entry, using the url format
+// of GitHub repositories.
+func (f *DocusaurusMarkdown) CodeHref(loc lang.Location) (string, error) {
+	return GitHub.CodeHref(loc)
+}
+
+// Comment generates an MDX comment with the provided text.
+func (f *DocusaurusMarkdown) Comment(text string) (string, error) {
+	return fmt.Sprintf("{/* %s */}", strings.ReplaceAll(text, "*/", "* /")), nil
+}
+
+// ListEntry generates an unordered list entry with the provided text at the
+// provided zero-indexed depth. A depth of 0 is considered the topmost level of
+// list.
+func (f *DocusaurusMarkdown) ListEntry(depth int, text string) (string, error) {
+	return formatcore.ListEntry(depth, text), nil
+}
+
+// Accordion generates a collapsible content. The accordion's visible title
+// while collapsed is the provided title and the expanded content is the body.
+func (f *DocusaurusMarkdown) Accordion(title, body string) (string, error) {
+	return fmt.Sprintf("<details>\n<summary>%s</summary>\n\n%s\n\n</details>", formatcore.Escape(title), body), nil
+}
+
+// AccordionHeader generates the header visible when an accordion is collapsed.
+//
+// The AccordionHeader is expected to be used in conjunction with
+// AccordionTerminator() when the demands of the body's rendering requires it to
+// be generated independently. The result looks conceptually like the following:
+//
+//	accordion := format.AccordionHeader("Accordion Title") + "Accordion Body" + format.AccordionTerminator()
+func (f *DocusaurusMarkdown) AccordionHeader(title string) (string, error) {
+	return fmt.Sprintf("<details>\n<summary>%s</summary>\n", formatcore.Escape(title)), nil
+}
+
+// AccordionTerminator generates the code necessary to terminate an accordion
+// after the body. It is expected to be used in conjunction with
+// AccordionHeader(). See AccordionHeader for a full description.
+func (f *DocusaurusMarkdown) AccordionTerminator() (string, error) {
+	return "</details>\n\n", nil
+}
+
+// Escape escapes special markdown characters from the provided text, including
+// the { and < characters which would otherwise start MDX expressions and JSX.
+func (f *DocusaurusMarkdown) Escape(text string) string {
+	return formatcore.Escape(text)
+}
@@ -0,0 +1,51 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/format"
+	"github.com/matryer/is"
+)
+
+func TestDocusaurus_Escape(t *testing.T) {
+	is := is.New(t)
+
+	var f format.DocusaurusMarkdown
+	is.Equal(f.Escape("func Do(m map[string]interface{}) <-chan int"), "func Do\\(m map\\[string\\]interface\\{\\}\\) \\<\\-chan int")
+}
+
+func TestDocusaurus_Link(t *testing.T) {
+	is := is.New(t)
+
+	var f format.DocusaurusMarkdown
+	res, err := f.Link("link {text}", "https://example.com/a b(c)")
+	is.NoErr(err)
+	is.Equal(res, "[link \\{text\\}](https://example.com/a%20b%28c%29)")
+}
+
+func TestDocusaurus_Badge(t *testing.T) {
+	is := is.New(t)
+
+	var f format.DocusaurusMarkdown
+	res, err := f.Badge("Go Reference", "https://pkg.go.dev/badge/example.com/pkg.svg", "https://pkg.go.dev/example.com/pkg")
+	is.NoErr(err)
+	is.Equal(res, "[![Go Reference](https://pkg.go.dev/badge/example.com/pkg.svg)](https://pkg.go.dev/example.com/pkg)")
+}
+
+func TestDocusaurus_Comment(t *testing.T) {
+	is := is.New(t)
+
+	var f format.DocusaurusMarkdown
+	res, err := f.Comment("Code generated by gomarkdoc. DO NOT EDIT")
+	is.NoErr(err)
+	is.Equal(res, "{/* Code generated by gomarkdoc. DO NOT EDIT */}")
+}
+
+func TestDocusaurus_Accordion(t *testing.T) {
+	is := is.New(t)
+
+	var f format.DocusaurusMarkdown
+	res, err := f.Accordion("Example <1>", "body")
+	is.NoErr(err)
+	is.Equal(res, "<details>\n<summary>Example \\<1\\></summary>\n\nbody\n\n</details>")
+}
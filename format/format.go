@@ -1,6 +1,11 @@
 package format
 
-import "github.com/anthonyme00/gomarkdoc/lang"
+import (
+	"fmt"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"mvdan.cc/xurls/v2"
+)
 
 // Format is a generic interface for formatting documentation contents in a
 // particular way.
@@ -75,3 +80,116 @@ type Format interface {
 	// Escape escapes special markdown characters from the provided text.
 	Escape(text string) string
 }
+
+// FileRenderer is implemented by formats which render entire files directly
+// from the documentation structures instead of through the templates (e.g.
+// structured, non-markdown formats). When a format implements FileRenderer,
+// it is used in place of the "file" template.
+type FileRenderer interface {
+	// RenderFile renders the documentation for all of the packages in the
+	// provided file.
+	RenderFile(file *lang.File) (string, error)
+}
+
+// TemplateVariant is implemented by formats which need variants of some of the
+// default templates, such as formats which produce markup other than markdown.
+// The renderer uses the named variants in place of the corresponding default
+// templates, though template overrides still take precedence.
+type TemplateVariant interface {
+	// TemplateVariant provides the name of the set of template variants to
+	// use for the format.
+	TemplateVariant() string
+}
+
+// BadgeRenderer is implemented by formats which can render badge images, such
+// as the status badges of a package. Badges are rendered as their escaped alt
+// text for formats which don't implement it.
+type BadgeRenderer interface {
+	// Badge generates a badge image with the provided alt text and image url
+	// which links to the provided href. If the href is empty, the image is not
+	// linked.
+	Badge(text, image, href string) (string, error)
+}
+
+// CommentRenderer is implemented by formats which have their own syntax for
+// comments which are not visible in the rendered output. HTML comments are
+// used for formats which don't implement it.
+type CommentRenderer interface {
+	// Comment generates a comment with the provided text which is not visible
+	// in the rendered output.
+	Comment(text string) (string, error)
+}
+
+// DeepHeaders is implemented by formats which can render headers nested more
+// deeply than their deepest header level as something other than a header of
+// that level, such as a bold label or the term of a definition list. The
+// renderer only uses these when configured to with a deep heading strategy.
+type DeepHeaders interface {
+	// MaxHeaderLevel provides the deepest header level of the format.
+	MaxHeaderLevel() int
+
+	// RawLabel converts the provided text into a bold label standing in place
+	// of a header, without escaping the text.
+	RawLabel(text string) (string, error)
+
+	// RawDefinitionTerm converts the provided text into the term of a
+	// definition list standing in place of a header, without escaping the
+	// text.
+	RawDefinitionTerm(text string) (string, error)
+}
+
+// Shared instances of each of the built-in formats. Tools which post-process or
+// augment generated documentation can use these to escape text and produce
+// anchors, links and other constructs consistently with gomarkdoc's output
+// (e.g. format.GitHub.Escape("*text*")).
+var (
+	GitHub      = &GitHubFlavoredMarkdown{}
+	AzureDevOps = &AzureDevOpsMarkdown{}
+	GitLab      = &GitLabFlavoredMarkdown{}
+	Plain       = &PlainMarkdown{}
+	JSON        = &JSONDocument{}
+	AsciiDoc    = &AsciiDocMarkup{}
+	HTML        = &HTMLDocument{}
+	Docusaurus  = &DocusaurusMarkdown{}
+	Hugo        = &HugoMarkdown{}
+	Confluence  = &ConfluenceWiki{}
+	RST         = &ReStructuredText{}
+	Man         = &ManPage{}
+)
+
+// schemeURLRegex matches the URLs in text which is escaped by formats that
+// leave URLs intact. URLs are required to have a scheme.
+var schemeURLRegex = xurls.Strict()
+
+// ByName provides the built-in format with the provided name, as accepted by
+// the --format option of the gomarkdoc command.
+func ByName(name string) (Format, error) {
+	switch name {
+	case "github":
+		return GitHub, nil
+	case "azure-devops":
+		return AzureDevOps, nil
+	case "gitlab":
+		return GitLab, nil
+	case "plain":
+		return Plain, nil
+	case "json":
+		return JSON, nil
+	case "asciidoc":
+		return AsciiDoc, nil
+	case "html":
+		return HTML, nil
+	case "docusaurus":
+		return Docusaurus, nil
+	case "hugo":
+		return Hugo, nil
+	case "confluence":
+		return Confluence, nil
+	case "rst":
+		return RST, nil
+	case "man":
+		return Man, nil
+	default:
+		return nil, fmt.Errorf("gomarkdoc: invalid format: %s", name)
+	}
+}
@@ -0,0 +1,55 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/format"
+	"github.com/anthonyme00/gomarkdoc/format/formatcore"
+	"github.com/matryer/is"
+)
+
+func TestByName(t *testing.T) {
+	tests := []struct {
+		name   string
+		result format.Format
+	}{
+		{"github", format.GitHub},
+		{"azure-devops", format.AzureDevOps},
+		{"gitlab", format.GitLab},
+		{"plain", format.Plain},
+		{"json", format.JSON},
+		{"asciidoc", format.AsciiDoc},
+		{"html", format.HTML},
+		{"docusaurus", format.Docusaurus},
+		{"hugo", format.Hugo},
+		{"confluence", format.Confluence},
+		{"rst", format.RST},
+		{"man", format.Man},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			is := is.New(t)
+
+			f, err := format.ByName(test.name)
+			is.NoErr(err)
+			is.Equal(f, test.result)
+		})
+	}
+}
+
+func TestByName_invalid(t *testing.T) {
+	is := is.New(t)
+
+	_, err := format.ByName("html5")
+	is.Equal(err.Error(), "gomarkdoc: invalid format: html5")
+}
+
+func TestSharedFormats_Escape(t *testing.T) {
+	is := is.New(t)
+
+	text := "**bold** [link](https://foo.bar)"
+	for _, f := range []format.Format{format.GitHub, format.AzureDevOps, format.Plain} {
+		is.Equal(f.Escape(text), formatcore.Escape(text))
+	}
+}
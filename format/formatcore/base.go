@@ -88,6 +88,23 @@ func Header(level int, text string) (string, error) {
 	}
 }
 
+// MaxHeaderLevel is the deepest level of markdown headers.
+const MaxHeaderLevel = 6
+
+// Label converts the provided text into a bold label standing in place of a
+// header, without escaping the text.
+func Label(text string) string {
+	return fmt.Sprintf("**%s**", text)
+}
+
+// DefinitionTerm converts the provided text into the term of an HTML
+// definition list standing in place of a header, without escaping the text.
+// The term is surrounded by blank lines so that markdown within it is still
+// rendered.
+func DefinitionTerm(text string) string {
+	return fmt.Sprintf("<dl><dt>\n\n%s\n\n</dt></dl>", text)
+}
+
 // Link generates a link with the given text and href values.
 func Link(text, href string) string {
 	if text == "" {
@@ -101,6 +118,27 @@ func Link(text, href string) string {
 	return fmt.Sprintf("[%s](<%s>)", Escape(text), href)
 }
 
+// Badge generates an image with the provided alt text and image url. If an href
+// is provided, the image links to it.
+func Badge(text, image, href string) string {
+	if image == "" {
+		return ""
+	}
+
+	img := fmt.Sprintf("![%s](<%s>)", Escape(text), image)
+	if href == "" {
+		return img
+	}
+
+	return fmt.Sprintf("[%s](<%s>)", img, href)
+}
+
+// Comment generates an HTML comment with the provided text, which is not
+// visible in rendered markdown.
+func Comment(text string) string {
+	return fmt.Sprintf("<!-- %s -->", text)
+}
+
 // ListEntry generates an unordered list entry with the provided text at the
 // provided zero-indexed depth. A depth of 0 is considered the topmost level of
 // list.
@@ -99,3 +99,33 @@ func TestEscape(t *testing.T) {
 		})
 	}
 }
+
+func TestBadge(t *testing.T) {
+	tests := []struct {
+		text, image, href, out string
+	}{
+		{
+			text:  "Go Reference",
+			image: "https://pkg.go.dev/badge/foo.svg",
+			href:  "https://pkg.go.dev/foo",
+			out:   "[![Go Reference](<https://pkg.go.dev/badge/foo.svg>)](<https://pkg.go.dev/foo>)",
+		},
+		{
+			text:  "Docs Coverage",
+			image: "https://img.shields.io/badge/docs-100%25-brightgreen",
+			out:   "![Docs Coverage](<https://img.shields.io/badge/docs-100%25-brightgreen>)",
+		},
+		{
+			text: "No Image",
+			href: "https://foo.bar",
+			out:  "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(Badge(test.text, test.image, test.href), test.out) // Wrong output for badge()
+		})
+	}
+}
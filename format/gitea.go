@@ -0,0 +1,44 @@
+package format
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+)
+
+// giteaRemoteRegex matches the web URLs of repositories hosted on the public
+// Gitea and Forgejo instances. Self-hosted instances can't be told apart from
+// other hosts by their URLs, so they are identified by the repository's Forge.
+var giteaRemoteRegex = regexp.MustCompile(`^https://(codeberg\.org|gitea\.com)/`)
+
+// giteaCodeHref generates an href to the provided location in the file at the
+// provided slash-separated path from the root of the repository when the
+// repository is hosted on Gitea or Forgejo, whose source links take the form
+// .../src/branch/<branch>/<path>#L<start>-L<end>. The second return value is
+// false if the repository is hosted elsewhere.
+func giteaCodeHref(loc lang.Location, p string) (string, bool) {
+	if loc.Repo.Forge != lang.GiteaForge && !giteaRemoteRegex.MatchString(loc.Repo.Remote) {
+		return "", false
+	}
+
+	ref := fmt.Sprintf("branch/%s", loc.Repo.DefaultBranch)
+	if loc.Repo.Commit != "" {
+		ref = fmt.Sprintf("commit/%s", loc.Repo.Commit)
+	}
+
+	var locStr string
+	if loc.Start.Line == loc.End.Line {
+		locStr = fmt.Sprintf("L%d", loc.Start.Line)
+	} else {
+		locStr = fmt.Sprintf("L%d-L%d", loc.Start.Line, loc.End.Line)
+	}
+
+	return fmt.Sprintf(
+		"%s/src/%s/%s#%s",
+		loc.Repo.Remote,
+		ref,
+		p,
+		locStr,
+	), true
+}
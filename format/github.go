@@ -57,6 +57,23 @@ func (f *GitHubFlavoredMarkdown) RawHeader(level int, text string) (string, erro
 	return formatcore.Header(level, text)
 }
 
+// MaxHeaderLevel provides the deepest header level of the format.
+func (f *GitHubFlavoredMarkdown) MaxHeaderLevel() int {
+	return formatcore.MaxHeaderLevel
+}
+
+// RawLabel converts the provided text into a bold label standing in place of a
+// header, without escaping the text.
+func (f *GitHubFlavoredMarkdown) RawLabel(text string) (string, error) {
+	return formatcore.Label(text), nil
+}
+
+// RawDefinitionTerm converts the provided text into the term of a definition
+// list standing in place of a header, without escaping the text.
+func (f *GitHubFlavoredMarkdown) RawDefinitionTerm(text string) (string, error) {
+	return formatcore.DefinitionTerm(text), nil
+}
+
 var (
 	gfmWhitespaceRegex = regexp.MustCompile(`\s`)
 	gfmRemoveRegex     = regexp.MustCompile(`[^\pL-_\d]+`)
@@ -111,6 +128,15 @@ func (f *GitHubFlavoredMarkdown) CodeHref(loc lang.Location) (string, error) {
 		return "", err
 	}
 
+	// Self-hosted Bitbucket Server and Gitea use different schemes
+	if href, ok := bitbucketServerCodeHref(loc, filepath.ToSlash(p)); ok {
+		return href, nil
+	}
+
+	if href, ok := giteaCodeHref(loc, filepath.ToSlash(p)); ok {
+		return href, nil
+	}
+
 	var locStr string
 	if loc.Start.Line == loc.End.Line {
 		locStr = fmt.Sprintf("L%d", loc.Start.Line)
@@ -121,12 +147,23 @@ func (f *GitHubFlavoredMarkdown) CodeHref(loc lang.Location) (string, error) {
 	return fmt.Sprintf(
 		"%s/blob/%s/%s#%s",
 		loc.Repo.Remote,
-		loc.Repo.DefaultBranch,
+		loc.Repo.Ref(),
 		filepath.ToSlash(p),
 		locStr,
 	), nil
 }
 
+// Badge generates a badge image with the provided alt text and image url which
+// links to the provided href. If the href is empty, the image is not linked.
+func (f *GitHubFlavoredMarkdown) Badge(text, image, href string) (string, error) {
+	return formatcore.Badge(text, image, href), nil
+}
+
+// Comment generates an HTML comment with the provided text.
+func (f *GitHubFlavoredMarkdown) Comment(text string) (string, error) {
+	return formatcore.Comment(text), nil
+}
+
 // ListEntry generates an unordered list entry with the provided text at the
 // provided zero-indexed depth. A depth of 0 is considered the topmost level of
 // list.
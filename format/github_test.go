@@ -139,6 +139,103 @@ func TestGitHubFlavoredMarkdown_CodeHref(t *testing.T) {
 	is.Equal(res, "https://dev.azure.com/org/project/_git/repo/blob/master/subdir/file.go#L12-L14")
 }
 
+func TestGitHubFlavoredMarkdown_CodeHref_commit(t *testing.T) {
+	is := is.New(t)
+
+	wd, err := filepath.Abs(".")
+	is.NoErr(err)
+	locPath := filepath.Join(wd, "subdir", "file.go")
+
+	var f format.GitHubFlavoredMarkdown
+	res, err := f.CodeHref(lang.Location{
+		Start:    lang.Position{Line: 10, Col: 1},
+		End:      lang.Position{Line: 20, Col: 2},
+		Filepath: locPath,
+		WorkDir:  wd,
+		Repo: &lang.Repo{
+			Remote:        "https://github.com/org/repo",
+			DefaultBranch: "master",
+			PathFromRoot:  "/",
+			Commit:        "0123456789abcdef0123456789abcdef01234567",
+		},
+	})
+	is.NoErr(err)
+	is.Equal(res, "https://github.com/org/repo/blob/0123456789abcdef0123456789abcdef01234567/subdir/file.go#L10-L20")
+}
+
+func TestGitHubFlavoredMarkdown_CodeHref_bitbucketServer(t *testing.T) {
+	is := is.New(t)
+
+	wd, err := filepath.Abs(".")
+	is.NoErr(err)
+	locPath := filepath.Join(wd, "subdir", "file.go")
+
+	var f format.GitHubFlavoredMarkdown
+	loc := lang.Location{
+		Start:    lang.Position{Line: 10, Col: 1},
+		End:      lang.Position{Line: 20, Col: 2},
+		Filepath: locPath,
+		WorkDir:  wd,
+		Repo: &lang.Repo{
+			Remote:        "https://bitbucket.example.com/projects/PROJ/repos/repo",
+			DefaultBranch: "main",
+			PathFromRoot:  "/",
+		},
+	}
+
+	res, err := f.CodeHref(loc)
+	is.NoErr(err)
+	is.Equal(res, "https://bitbucket.example.com/projects/PROJ/repos/repo/browse/subdir/file.go?at=refs%2Fheads%2Fmain#10-20")
+
+	loc.End.Line = 10
+	loc.Repo.Commit = "0123456789abcdef0123456789abcdef01234567"
+	res, err = f.CodeHref(loc)
+	is.NoErr(err)
+	is.Equal(res, "https://bitbucket.example.com/projects/PROJ/repos/repo/browse/subdir/file.go?at=0123456789abcdef0123456789abcdef01234567#10")
+}
+
+func TestGitHubFlavoredMarkdown_CodeHref_gitea(t *testing.T) {
+	is := is.New(t)
+
+	wd, err := filepath.Abs(".")
+	is.NoErr(err)
+	locPath := filepath.Join(wd, "subdir", "file.go")
+
+	var f format.GitHubFlavoredMarkdown
+	loc := lang.Location{
+		Start:    lang.Position{Line: 10, Col: 1},
+		End:      lang.Position{Line: 20, Col: 2},
+		Filepath: locPath,
+		WorkDir:  wd,
+		Repo: &lang.Repo{
+			Remote:        "https://git.example.com/org/repo",
+			DefaultBranch: "main",
+			PathFromRoot:  "/",
+			Forge:         lang.GiteaForge,
+		},
+	}
+
+	res, err := f.CodeHref(loc)
+	is.NoErr(err)
+	is.Equal(res, "https://git.example.com/org/repo/src/branch/main/subdir/file.go#L10-L20")
+
+	loc.End.Line = 10
+	loc.Repo.Commit = "0123456789abcdef0123456789abcdef01234567"
+	res, err = f.CodeHref(loc)
+	is.NoErr(err)
+	is.Equal(res, "https://git.example.com/org/repo/src/commit/0123456789abcdef0123456789abcdef01234567/subdir/file.go#L10")
+
+	// Repositories on Codeberg are detected without the forge
+	loc.Repo = &lang.Repo{
+		Remote:        "https://codeberg.org/org/repo",
+		DefaultBranch: "main",
+		PathFromRoot:  "/",
+	}
+	res, err = f.CodeHref(loc)
+	is.NoErr(err)
+	is.Equal(res, "https://codeberg.org/org/repo/src/branch/main/subdir/file.go#L10")
+}
+
 func TestGitHubFlavoredMarkdown_CodeHref_noRepo(t *testing.T) {
 	is := is.New(t)
 
@@ -0,0 +1,209 @@
+package format
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/anthonyme00/gomarkdoc/format/formatcore"
+	"github.com/anthonyme00/gomarkdoc/lang"
+)
+
+// GitLabFlavoredMarkdown provides a Format which is compatible with GitLab
+// Flavored Markdown's syntax and semantics. See GitLab's documentation for
+// more details about their markdown format:
+// https://docs.gitlab.com/ee/user/markdown.html
+type GitLabFlavoredMarkdown struct{}
+
+var (
+	glfmWhitespaceRegex = regexp.MustCompile(`\s+`)
+	glfmRemoveRegex     = regexp.MustCompile(`[^\pL\pN_\s-]+`)
+	glfmHyphenRegex     = regexp.MustCompile(`-{2,}`)
+	glfmBacktickRegex   = regexp.MustCompile("`+")
+)
+
+// Bold converts the provided text to bold
+func (f *GitLabFlavoredMarkdown) Bold(text string) (string, error) {
+	return formatcore.Bold(text), nil
+}
+
+// CodeBlock wraps the provided code as a code block and tags it with the
+// provided language (or no language if the empty string is provided). The
+// fence is made longer than any run of backticks in the code, since GitLab
+// ends a code block at the first fence of at least the same length.
+func (f *GitLabFlavoredMarkdown) CodeBlock(language, code string) (string, error) {
+	code = strings.TrimSpace(code)
+
+	fenceLength := 3
+	for _, run := range glfmBacktickRegex.FindAllString(code, -1) {
+		if len(run) >= fenceLength {
+			fenceLength = len(run) + 1
+		}
+	}
+
+	fence := strings.Repeat("`", fenceLength)
+	return fmt.Sprintf("%s%s\n%s\n%s", fence, language, code, fence), nil
+}
+
+// Anchor produces an anchor for the provided link.
+func (f *GitLabFlavoredMarkdown) Anchor(anchor string) string {
+	return formatcore.Anchor(anchor)
+}
+
+// AnchorHeader converts the provided text and custom anchor link into a header
+// of the provided level. The level is expected to be at least 1.
+func (f *GitLabFlavoredMarkdown) AnchorHeader(level int, text, anchor string) (string, error) {
+	return formatcore.AnchorHeader(level, formatcore.Escape(text), anchor)
+}
+
+// Header converts the provided text into a header of the provided level. The
+// level is expected to be at least 1.
+func (f *GitLabFlavoredMarkdown) Header(level int, text string) (string, error) {
+	return formatcore.Header(level, formatcore.Escape(text))
+}
+
+// RawAnchorHeader converts the provided text and custom anchor link into a
+// header of the provided level without escaping the header text. The level is
+// expected to be at least 1.
+func (f *GitLabFlavoredMarkdown) RawAnchorHeader(level int, text, anchor string) (string, error) {
+	return formatcore.AnchorHeader(level, text, anchor)
+}
+
+// RawHeader converts the provided text into a header of the provided level
+// without escaping the header text. The level is expected to be at least 1.
+func (f *GitLabFlavoredMarkdown) RawHeader(level int, text string) (string, error) {
+	return formatcore.Header(level, text)
+}
+
+// MaxHeaderLevel provides the deepest header level of the format.
+func (f *GitLabFlavoredMarkdown) MaxHeaderLevel() int {
+	return formatcore.MaxHeaderLevel
+}
+
+// RawLabel converts the provided text into a bold label standing in place of a
+// header, without escaping the text.
+func (f *GitLabFlavoredMarkdown) RawLabel(text string) (string, error) {
+	return formatcore.Label(text), nil
+}
+
+// RawDefinitionTerm converts the provided text into the term of a definition
+// list standing in place of a header, without escaping the text.
+func (f *GitLabFlavoredMarkdown) RawDefinitionTerm(text string) (string, error) {
+	return formatcore.DefinitionTerm(text), nil
+}
+
+// LocalHref generates an href for navigating to a header with the given
+// headerText located within the same document as the href itself. Link
+// generation follows the rules described here:
+// https://docs.gitlab.com/ee/user/markdown.html#heading-ids-and-links
+func (f *GitLabFlavoredMarkdown) LocalHref(headerText string) (string, error) {
+	result := formatcore.PlainText(headerText)
+	result = strings.ToLower(result)
+	result = glfmRemoveRegex.ReplaceAllString(result, "")
+	result = strings.TrimSpace(result)
+	result = glfmWhitespaceRegex.ReplaceAllString(result, "-")
+	result = glfmHyphenRegex.ReplaceAllString(result, "-")
+
+	return fmt.Sprintf("#%s", result), nil
+}
+
+// RawLocalHref generates an href within the same document but with a direct
+// link provided instead of text to slugify.
+func (f *GitLabFlavoredMarkdown) RawLocalHref(anchor string) string {
+	return fmt.Sprintf("#%s", anchor)
+}
+
+// Link generates a link with the given text and href values.
+func (f *GitLabFlavoredMarkdown) Link(text, href string) (string, error) {
+	return formatcore.Link(text, href), nil
+}
+
+// CodeHref generates an href to the provided code entry.
+func (f *GitLabFlavoredMarkdown) CodeHref(loc lang.Location) (string, error) {
+	// If there's no repo, we can't compute an href
+	if loc.Repo == nil {
+		return "", nil
+	}
+
+	var (
+		relative string
+		err      error
+	)
+	if filepath.IsAbs(loc.Filepath) {
+		relative, err = filepath.Rel(loc.WorkDir, loc.Filepath)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		relative = loc.Filepath
+	}
+
+	full := filepath.Join(loc.Repo.PathFromRoot, relative)
+	p, err := filepath.Rel(string(filepath.Separator), full)
+	if err != nil {
+		return "", err
+	}
+
+	var locStr string
+	if loc.Start.Line == loc.End.Line {
+		locStr = fmt.Sprintf("L%d", loc.Start.Line)
+	} else {
+		locStr = fmt.Sprintf("L%d-%d", loc.Start.Line, loc.End.Line)
+	}
+
+	return fmt.Sprintf(
+		"%s/-/blob/%s/%s#%s",
+		loc.Repo.Remote,
+		loc.Repo.Ref(),
+		filepath.ToSlash(p),
+		locStr,
+	), nil
+}
+
+// Badge generates a badge image with the provided alt text and image url which
+// links to the provided href. If the href is empty, the image is not linked.
+func (f *GitLabFlavoredMarkdown) Badge(text, image, href string) (string, error) {
+	return formatcore.Badge(text, image, href), nil
+}
+
+// Comment generates an HTML comment with the provided text.
+func (f *GitLabFlavoredMarkdown) Comment(text string) (string, error) {
+	return formatcore.Comment(text), nil
+}
+
+// ListEntry generates an unordered list entry with the provided text at the
+// provided zero-indexed depth. A depth of 0 is considered the topmost level of
+// list.
+func (f *GitLabFlavoredMarkdown) ListEntry(depth int, text string) (string, error) {
+	return formatcore.ListEntry(depth, text), nil
+}
+
+// Accordion generates a collapsible content. The accordion's visible title
+// while collapsed is the provided title and the expanded content is the body.
+func (f *GitLabFlavoredMarkdown) Accordion(title, body string) (string, error) {
+	return formatcore.GFMAccordion(title, body), nil
+}
+
+// AccordionHeader generates the header visible when an accordion is collapsed.
+//
+// The AccordionHeader is expected to be used in conjunction with
+// AccordionTerminator() when the demands of the body's rendering requires it to
+// be generated independently. The result looks conceptually like the following:
+//
+//	accordion := format.AccordionHeader("Accordion Title") + "Accordion Body" + format.AccordionTerminator()
+func (f *GitLabFlavoredMarkdown) AccordionHeader(title string) (string, error) {
+	return formatcore.GFMAccordionHeader(title), nil
+}
+
+// AccordionTerminator generates the code necessary to terminate an accordion
+// after the body. It is expected to be used in conjunction with
+// AccordionHeader(). See AccordionHeader for a full description.
+func (f *GitLabFlavoredMarkdown) AccordionTerminator() (string, error) {
+	return formatcore.GFMAccordionTerminator(), nil
+}
+
+// Escape escapes special markdown characters from the provided text.
+func (f *GitLabFlavoredMarkdown) Escape(text string) string {
+	return formatcore.Escape(text)
+}
@@ -0,0 +1,88 @@
+package format_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/format"
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/matryer/is"
+)
+
+func TestGitLabFlavoredMarkdown_CodeBlock(t *testing.T) {
+	is := is.New(t)
+
+	var f format.GitLabFlavoredMarkdown
+	res, err := f.CodeBlock("go", "Line 1\nLine 2")
+	is.NoErr(err)
+	is.Equal(res, "```go\nLine 1\nLine 2\n```")
+}
+
+func TestGitLabFlavoredMarkdown_CodeBlock_backticks(t *testing.T) {
+	is := is.New(t)
+
+	var f format.GitLabFlavoredMarkdown
+	res, err := f.CodeBlock("go", "s := `\n```\nmarkdown\n```\n`")
+	is.NoErr(err)
+	is.Equal(res, "````go\ns := `\n```\nmarkdown\n```\n`\n````")
+}
+
+func TestGitLabFlavoredMarkdown_LocalHref(t *testing.T) {
+	tests := map[string]string{
+		"Normal Header":          "#normal-header",
+		" Leading whitespace":    "#leading-whitespace",
+		"Multiple	 whitespace":   "#multiple-whitespace",
+		"Special(#)%^Characters": "#specialcharacters",
+		"With:colon":             "#withcolon",
+		"func Foo - deprecated":  "#func-foo-deprecated",
+		"type Under_score":       "#type-under_score",
+	}
+
+	for input, output := range tests {
+		t.Run(input, func(t *testing.T) {
+			is := is.New(t)
+
+			var f format.GitLabFlavoredMarkdown
+			res, err := f.LocalHref(input)
+			is.NoErr(err)
+			is.Equal(res, output)
+		})
+	}
+}
+
+func TestGitLabFlavoredMarkdown_CodeHref(t *testing.T) {
+	is := is.New(t)
+
+	wd, err := filepath.Abs(".")
+	is.NoErr(err)
+	locPath := filepath.Join(wd, "subdir", "file.go")
+
+	var f format.GitLabFlavoredMarkdown
+	res, err := f.CodeHref(lang.Location{
+		Start:    lang.Position{Line: 12, Col: 1},
+		End:      lang.Position{Line: 14, Col: 43},
+		Filepath: locPath,
+		WorkDir:  wd,
+		Repo: &lang.Repo{
+			Remote:        "https://gitlab.com/group/project",
+			DefaultBranch: "main",
+			PathFromRoot:  "/",
+		},
+	})
+	is.NoErr(err)
+	is.Equal(res, "https://gitlab.com/group/project/-/blob/main/subdir/file.go#L12-14")
+}
+
+func TestGitLabFlavoredMarkdown_CodeHref_noRepo(t *testing.T) {
+	is := is.New(t)
+
+	var f format.GitLabFlavoredMarkdown
+	res, err := f.CodeHref(lang.Location{
+		Start:    lang.Position{Line: 12, Col: 1},
+		End:      lang.Position{Line: 14, Col: 43},
+		Filepath: "file.go",
+		Repo:     nil,
+	})
+	is.NoErr(err)
+	is.Equal(res, "")
+}
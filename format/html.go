@@ -0,0 +1,229 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/anthonyme00/gomarkdoc/lang"
+)
+
+// HTMLDocument provides a Format which renders documentation as self-contained
+// HTML pages. Code blocks are syntax highlighted with inline styles so that no
+// external stylesheets are needed. Links to source code use the same
+// GitHub-style urls as GitHubFlavoredMarkdown.
+//
+// Text passed to the functions which produce links and headers without
+// escaping (e.g. Link and RawHeader) is expected to be escaped already.
+type HTMLDocument struct{}
+
+var (
+	htmlIDRegex    = regexp.MustCompile("[^a-z0-9]+")
+	htmlCodeStyle  = styles.Get("github")
+	htmlCodeFormat = chromahtml.New(chromahtml.TabWidth(4))
+)
+
+// TemplateVariant provides the name of the HTML variants of the default
+// templates, which produce markup instead of markdown.
+func (f *HTMLDocument) TemplateVariant() string {
+	return "html"
+}
+
+// Bold converts the provided text to bold
+func (f *HTMLDocument) Bold(text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("<strong>%s</strong>", f.Escape(text)), nil
+}
+
+// CodeBlock wraps the provided code as a preformatted code block. If a
+// language is provided and recognized, the code is syntax highlighted.
+func (f *HTMLDocument) CodeBlock(language, code string) (string, error) {
+	code = strings.TrimSpace(code)
+
+	lexer := lexers.Get(language)
+	if language == "" || lexer == nil {
+		return fmt.Sprintf("<pre><code>%s</code></pre>", f.Escape(code)), nil
+	}
+
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := htmlCodeFormat.Format(&b, htmlCodeStyle, iterator); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// Anchor produces an anchor for the provided link.
+func (f *HTMLDocument) Anchor(anchor string) string {
+	return fmt.Sprintf("<a id=\"%s\"></a>", html.EscapeString(anchor))
+}
+
+// AnchorHeader converts the provided text and custom anchor link into a header
+// of the provided level. The level is expected to be at least 1.
+func (f *HTMLDocument) AnchorHeader(level int, text, anchor string) (string, error) {
+	return f.RawAnchorHeader(level, f.Escape(text), anchor)
+}
+
+// Header converts the provided text into a header of the provided level. The
+// header's id matches the href produced by LocalHref for the same text. The
+// level is expected to be at least 1.
+func (f *HTMLDocument) Header(level int, text string) (string, error) {
+	return f.RawAnchorHeader(level, f.Escape(text), htmlID(text))
+}
+
+// RawAnchorHeader converts the provided text and custom anchor link into a
+// header of the provided level without escaping the header text. The level is
+// expected to be at least 1.
+func (f *HTMLDocument) RawAnchorHeader(level int, text, anchor string) (string, error) {
+	level, err := htmlLevel(level)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("<h%d id=\"%s\">%s</h%d>", level, html.EscapeString(anchor), text, level), nil
+}
+
+// RawHeader converts the provided text into a header of the provided level
+// without escaping the header text. The level is expected to be at least 1.
+func (f *HTMLDocument) RawHeader(level int, text string) (string, error) {
+	level, err := htmlLevel(level)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("<h%d>%s</h%d>", level, text, level), nil
+}
+
+// MaxHeaderLevel provides the deepest header level of the format.
+func (f *HTMLDocument) MaxHeaderLevel() int {
+	return 6
+}
+
+// RawLabel converts the provided text into a bold label standing in place of a
+// header, without escaping the text.
+func (f *HTMLDocument) RawLabel(text string) (string, error) {
+	return fmt.Sprintf("<p><strong>%s</strong></p>", text), nil
+}
+
+// RawDefinitionTerm converts the provided text into the term of a definition
+// list standing in place of a header, without escaping the text.
+func (f *HTMLDocument) RawDefinitionTerm(text string) (string, error) {
+	return fmt.Sprintf("<dl><dt>%s</dt></dl>", text), nil
+}
+
+// LocalHref generates an href for navigating to a header with the given
+// headerText located within the same document as the href itself.
+func (f *HTMLDocument) LocalHref(headerText string) (string, error) {
+	return fmt.Sprintf("#%s", htmlID(headerText)), nil
+}
+
+// RawLocalHref generates an href within the same document but with a direct
+// link provided instead of text to slugify.
+func (f *HTMLDocument) RawLocalHref(anchor string) string {
+	return fmt.Sprintf("#%s", anchor)
+}
+
+// Link generates a link with the given text and href values.
+func (f *HTMLDocument) Link(text, href string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	if href == "" {
+		return text, nil
+	}
+
+	return fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(href), text), nil
+}
+
+// Badge generates a badge image with the provided alt text and image url which
+// links to the provided href. If the href is empty, the image is not linked.
+func (f *HTMLDocument) Badge(text, image, href string) (string, error) {
+	if image == "" {
+		return "", nil
+	}
+
+	img := fmt.Sprintf("<img src=\"%s\" alt=\"%s\">", html.EscapeString(image), html.EscapeString(text))
+	return f.Link(img, href)
+}
+
+// CodeHref generates an href to the provided code entry, using the url format
+// of GitHub repositories.
+func (f *HTMLDocument) CodeHref(loc lang.Location) (string, error) {
+	return GitHub.CodeHref(loc)
+}
+
+// Comment generates an HTML comment with the provided text.
+func (f *HTMLDocument) Comment(text string) (string, error) {
+	return fmt.Sprintf("<!-- %s -->", text), nil
+}
+
+// ListEntry generates a list item with the provided text. The depth is ignored,
+// as nesting is determined by the enclosing list elements.
+func (f *HTMLDocument) ListEntry(depth int, text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("<li>%s</li>", text), nil
+}
+
+// Accordion generates a collapsible content. The accordion's visible title
+// while collapsed is the provided title and the expanded content is the body.
+func (f *HTMLDocument) Accordion(title, body string) (string, error) {
+	return fmt.Sprintf("<details><summary>%s</summary>\n%s\n</details>", f.Escape(title), body), nil
+}
+
+// AccordionHeader generates the header visible when an accordion is collapsed.
+//
+// The AccordionHeader is expected to be used in conjunction with
+// AccordionTerminator() when the demands of the body's rendering requires it to
+// be generated independently. The result looks conceptually like the following:
+//
+//	accordion := format.AccordionHeader("Accordion Title") + "Accordion Body" + format.AccordionTerminator()
+func (f *HTMLDocument) AccordionHeader(title string) (string, error) {
+	return fmt.Sprintf("<details><summary>%s</summary>", f.Escape(title)), nil
+}
+
+// AccordionTerminator generates the code necessary to terminate an accordion
+// after the body. It is expected to be used in conjunction with
+// AccordionHeader(). See AccordionHeader for a full description.
+func (f *HTMLDocument) AccordionTerminator() (string, error) {
+	return "</details>", nil
+}
+
+// Escape escapes the characters with special meaning in HTML.
+func (f *HTMLDocument) Escape(text string) string {
+	return html.EscapeString(text)
+}
+
+func htmlID(text string) string {
+	return strings.Trim(htmlIDRegex.ReplaceAllString(strings.ToLower(text), "-"), "-")
+}
+
+func htmlLevel(level int) (int, error) {
+	if level < 1 {
+		return 0, errors.New("format: header level cannot be less than 1")
+	}
+
+	// Only go up to 6 levels. Anything higher is also level 6
+	if level > 6 {
+		return 6, nil
+	}
+
+	return level, nil
+}
@@ -0,0 +1,133 @@
+package format_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/format"
+	"github.com/matryer/is"
+)
+
+func TestHTML_Bold(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTMLDocument
+	res, err := f.Bold("sample <text>")
+	is.NoErr(err)
+	is.Equal(res, "<strong>sample &lt;text&gt;</strong>")
+}
+
+func TestHTML_CodeBlock(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTMLDocument
+	res, err := f.CodeBlock("go", "func main() {}")
+	is.NoErr(err)
+	is.True(strings.HasPrefix(res, "<pre"))
+	is.True(strings.Contains(res, `<span style="color:#000;font-weight:bold">func</span>`))
+}
+
+func TestHTML_CodeBlock_noLanguage(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTMLDocument
+	res, err := f.CodeBlock("", "a < b\nc")
+	is.NoErr(err)
+	is.Equal(res, "<pre><code>a &lt; b\nc</code></pre>")
+}
+
+func TestHTML_Header(t *testing.T) {
+	tests := []struct {
+		text   string
+		level  int
+		result string
+	}{
+		{"header text", 1, `<h1 id="header-text">header text</h1>`},
+		{"level 2", 2, `<h2 id="level-2">level 2</h2>`},
+		{"other level", 12, `<h6 id="other-level">other level</h6>`},
+		{"with <escape>", 2, `<h2 id="with-escape">with &lt;escape&gt;</h2>`},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s (level %d)", test.text, test.level), func(t *testing.T) {
+			is := is.New(t)
+
+			var f format.HTMLDocument
+			res, err := f.Header(test.level, test.text)
+			is.NoErr(err)
+			is.Equal(res, test.result)
+		})
+	}
+}
+
+func TestHTML_Header_invalidLevel(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTMLDocument
+	_, err := f.Header(-1, "invalid")
+	is.Equal(err.Error(), "format: header level cannot be less than 1")
+}
+
+func TestHTML_RawAnchorHeader(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTMLDocument
+	res, err := f.RawAnchorHeader(2, "type <em>Receiver</em>", "Receiver")
+	is.NoErr(err)
+	is.Equal(res, `<h2 id="Receiver">type <em>Receiver</em></h2>`)
+}
+
+func TestHTML_RawDefinitionTerm(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTMLDocument
+	res, err := f.RawDefinitionTerm("sample <em>text</em>")
+	is.NoErr(err)
+	is.Equal(res, "<dl><dt>sample <em>text</em></dt></dl>")
+}
+
+func TestHTML_LocalHref(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTMLDocument
+	res, err := f.LocalHref("Largest Undocumented Surfaces")
+	is.NoErr(err)
+	is.Equal(res, "#largest-undocumented-surfaces")
+}
+
+func TestHTML_Link(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTMLDocument
+	res, err := f.Link("text", "https://host.com/?a=1&b=2")
+	is.NoErr(err)
+	is.Equal(res, `<a href="https://host.com/?a=1&amp;b=2">text</a>`)
+}
+
+func TestHTML_Badge(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTMLDocument
+	res, err := f.Badge("Go Reference", "https://pkg.go.dev/badge/pkg.svg", "https://pkg.go.dev/pkg")
+	is.NoErr(err)
+	is.Equal(res, `<a href="https://pkg.go.dev/pkg"><img src="https://pkg.go.dev/badge/pkg.svg" alt="Go Reference"></a>`)
+}
+
+func TestHTML_ListEntry(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTMLDocument
+	res, err := f.ListEntry(1, "entry")
+	is.NoErr(err)
+	is.Equal(res, "<li>entry</li>")
+}
+
+func TestHTML_Accordion(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HTMLDocument
+	res, err := f.Accordion("Title", "<p>Body</p>")
+	is.NoErr(err)
+	is.Equal(res, "<details><summary>Title</summary>\n<p>Body</p>\n</details>")
+}
@@ -0,0 +1,151 @@
+package format
+
+import (
+	"fmt"
+
+	"github.com/anthonyme00/gomarkdoc/format/formatcore"
+	"github.com/anthonyme00/gomarkdoc/lang"
+)
+
+// HugoMarkdown provides a Format which is compatible with the markdown rendered
+// by the Hugo static site generator. Since Hugo leaves out raw HTML by default,
+// header anchors use Hugo's heading attribute syntax
+// (e.g. "## Header {#anchor}") and accordions are rendered as plain headers.
+// Links to source code use the url format of GitHub repositories.
+type HugoMarkdown struct{}
+
+// Bold converts the provided text to bold
+func (f *HugoMarkdown) Bold(text string) (string, error) {
+	return formatcore.Bold(text), nil
+}
+
+// CodeBlock wraps the provided code as a code block and tags it with the
+// provided language (or no language if the empty string is provided).
+func (f *HugoMarkdown) CodeBlock(language, code string) (string, error) {
+	return formatcore.GFMCodeBlock(language, code), nil
+}
+
+// Anchor produces an anchor for the provided link.
+func (f *HugoMarkdown) Anchor(anchor string) string {
+	return formatcore.Anchor(anchor)
+}
+
+// AnchorHeader converts the provided text and custom anchor link into a header
+// of the provided level. The level is expected to be at least 1.
+func (f *HugoMarkdown) AnchorHeader(level int, text, anchor string) (string, error) {
+	return f.RawAnchorHeader(level, formatcore.Escape(text), anchor)
+}
+
+// Header converts the provided text into a header of the provided level. The
+// level is expected to be at least 1.
+func (f *HugoMarkdown) Header(level int, text string) (string, error) {
+	return formatcore.Header(level, formatcore.Escape(text))
+}
+
+// RawAnchorHeader converts the provided text and custom anchor link into a
+// header of the provided level without escaping the header text. The level is
+// expected to be at least 1.
+func (f *HugoMarkdown) RawAnchorHeader(level int, text, anchor string) (string, error) {
+	return formatcore.Header(level, fmt.Sprintf("%s {#%s}", text, anchor))
+}
+
+// RawHeader converts the provided text into a header of the provided level
+// without escaping the header text. The level is expected to be at least 1.
+func (f *HugoMarkdown) RawHeader(level int, text string) (string, error) {
+	return formatcore.Header(level, text)
+}
+
+// MaxHeaderLevel provides the deepest header level of the format.
+func (f *HugoMarkdown) MaxHeaderLevel() int {
+	return formatcore.MaxHeaderLevel
+}
+
+// RawLabel converts the provided text into a bold label standing in place of a
+// header, without escaping the text.
+func (f *HugoMarkdown) RawLabel(text string) (string, error) {
+	return formatcore.Label(text), nil
+}
+
+// RawDefinitionTerm converts the provided text into the term of a definition
+// list standing in place of a header, without escaping the text.
+func (f *HugoMarkdown) RawDefinitionTerm(text string) (string, error) {
+	return formatcore.DefinitionTerm(text), nil
+}
+
+// LocalHref generates an href for navigating to a header with the given
+// headerText located within the same document as the href itself. Hugo
+// generates header ids the same way as GitHub by default.
+func (f *HugoMarkdown) LocalHref(headerText string) (string, error) {
+	return GitHub.LocalHref(headerText)
+}
+
+// RawLocalHref generates an href within the same document but with a direct
+// link provided instead of text to slugify.
+func (f *HugoMarkdown) RawLocalHref(anchor string) string {
+	return fmt.Sprintf("#%s", anchor)
+}
+
+// Link generates a link with the given text and href values.
+func (f *HugoMarkdown) Link(text, href string) (string, error) {
+	return formatcore.Link(text, href), nil
+}
+
+// Badge generates a badge image with the provided alt text and image url which
+// links to the provided href. If the href is empty, the image is not linked.
+func (f *HugoMarkdown) Badge(text, image, href string) (string, error) {
+	return formatcore.Badge(text, image, href), nil
+}
+
+// CodeHref generates an href to the provided code entry, using the url format
+// of GitHub repositories.
+func (f *HugoMarkdown) CodeHref(loc lang.Location) (string, error) {
+	return GitHub.CodeHref(loc)
+}
+
+// Comment generates a comment with the provided text, which Hugo leaves out of
+// the rendered page.
+func (f *HugoMarkdown) Comment(text string) (string, error) {
+	return formatcore.Comment(text), nil
+}
+
+// ListEntry generates an unordered list entry with the provided text at the
+// provided zero-indexed depth. A depth of 0 is considered the topmost level of
+// list.
+func (f *HugoMarkdown) ListEntry(depth int, text string) (string, error) {
+	return formatcore.ListEntry(depth, text), nil
+}
+
+// Accordion generates a collapsible content. Since Hugo leaves out the raw HTML
+// needed to collapse content by default, the title is rendered as a header
+// followed by the body.
+func (f *HugoMarkdown) Accordion(title, body string) (string, error) {
+	h, err := f.Header(6, title)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s\n\n%s\n\n", h, body), nil
+}
+
+// AccordionHeader generates the header visible when an accordion is collapsed.
+//
+// The AccordionHeader is expected to be used in conjunction with
+// AccordionTerminator() when the demands of the body's rendering requires it to
+// be generated independently. The result looks conceptually like the following:
+//
+//	accordion := format.AccordionHeader("Accordion Title") + "Accordion Body" + format.AccordionTerminator()
+func (f *HugoMarkdown) AccordionHeader(title string) (string, error) {
+	return f.Header(6, title)
+}
+
+// AccordionTerminator generates the code necessary to terminate an accordion
+// after the body. It is expected to be used in conjunction with
+// AccordionHeader(). See AccordionHeader for a full description.
+func (f *HugoMarkdown) AccordionTerminator() (string, error) {
+	return "\n\n", nil
+}
+
+// Escape escapes special markdown characters from the provided text.
+func (f *HugoMarkdown) Escape(text string) string {
+	return formatcore.Escape(text)
+}
@@ -0,0 +1,35 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/format"
+	"github.com/matryer/is"
+)
+
+func TestHugo_AnchorHeader(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HugoMarkdown
+	res, err := f.AnchorHeader(2, "type Receiver", "Receiver")
+	is.NoErr(err)
+	is.Equal(res, "## type Receiver {#Receiver}")
+}
+
+func TestHugo_Accordion(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HugoMarkdown
+	res, err := f.Accordion("Example (Zero)", "body")
+	is.NoErr(err)
+	is.Equal(res, "###### Example \\(Zero\\)\n\nbody\n\n")
+}
+
+func TestHugo_LocalHref(t *testing.T) {
+	is := is.New(t)
+
+	var f format.HugoMarkdown
+	res, err := f.LocalHref("Index")
+	is.NoErr(err)
+	is.Equal(res, "#index")
+}
@@ -0,0 +1,476 @@
+package format
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+)
+
+// JSONDocument provides a Format which exports documentation as structured JSON
+// rather than markdown. Entire files are rendered directly from the
+// documentation structures (see FileRenderer), so the templates are not used.
+// The remaining methods produce plain text for the cases where individual
+// symbols are still rendered through templates.
+type JSONDocument struct{}
+
+type (
+	jsonFile struct {
+		Header   string         `json:"header,omitempty"`
+		Footer   string         `json:"footer,omitempty"`
+		Packages []*jsonPackage `json:"packages"`
+	}
+
+	jsonPackage struct {
+		Name       string         `json:"name"`
+		ImportPath string         `json:"importPath"`
+		Summary    string         `json:"summary,omitempty"`
+		Doc        string         `json:"doc,omitempty"`
+		Deprecated string         `json:"deprecated,omitempty"`
+		Consts     []*jsonValue   `json:"consts,omitempty"`
+		Vars       []*jsonValue   `json:"vars,omitempty"`
+		Funcs      []*jsonFunc    `json:"funcs,omitempty"`
+		Types      []*jsonType    `json:"types,omitempty"`
+		Examples   []*jsonExample `json:"examples,omitempty"`
+		Groups     []*jsonGroup   `json:"exampleGroups,omitempty"`
+	}
+
+	jsonGroup struct {
+		Name     string         `json:"name"`
+		Title    string         `json:"title"`
+		Examples []*jsonExample `json:"examples"`
+	}
+
+	jsonValue struct {
+		Anchor   string        `json:"anchor"`
+		Decl     string        `json:"decl"`
+		Summary  string        `json:"summary,omitempty"`
+		Doc      string        `json:"doc,omitempty"`
+		Location *jsonLocation `json:"location"`
+		Build    string        `json:"build,omitempty"`
+	}
+
+	jsonFunc struct {
+		Name      string         `json:"name"`
+		Receiver  string         `json:"receiver,omitempty"`
+		Anchor    string         `json:"anchor"`
+		Signature string         `json:"signature"`
+		Summary   string         `json:"summary,omitempty"`
+		Doc       string         `json:"doc,omitempty"`
+		Location  *jsonLocation  `json:"location"`
+		Build     string         `json:"build,omitempty"`
+		Examples  []*jsonExample `json:"examples,omitempty"`
+
+		Deprecated string `json:"deprecated,omitempty"`
+	}
+
+	jsonType struct {
+		Name     string         `json:"name"`
+		Anchor   string         `json:"anchor"`
+		Decl     string         `json:"decl"`
+		Summary  string         `json:"summary,omitempty"`
+		Doc      string         `json:"doc,omitempty"`
+		Location *jsonLocation  `json:"location"`
+		Build    string         `json:"build,omitempty"`
+		Consts   []*jsonValue   `json:"consts,omitempty"`
+		Vars     []*jsonValue   `json:"vars,omitempty"`
+		Funcs    []*jsonFunc    `json:"funcs,omitempty"`
+		Methods  []*jsonFunc    `json:"methods,omitempty"`
+		Examples []*jsonExample `json:"examples,omitempty"`
+
+		InterfaceMethods []*jsonFunc  `json:"interfaceMethods,omitempty"`
+		Fields           []*jsonField `json:"fields,omitempty"`
+		Deprecated       string       `json:"deprecated,omitempty"`
+	}
+
+	jsonField struct {
+		Name         string `json:"name"`
+		Type         string `json:"type"`
+		Tag          string `json:"tag,omitempty"`
+		Summary      string `json:"summary,omitempty"`
+		Doc          string `json:"doc,omitempty"`
+		EmbeddedFrom string `json:"embeddedFrom,omitempty"`
+		Deprecated   string `json:"deprecated,omitempty"`
+	}
+
+	jsonExample struct {
+		Name   string `json:"name,omitempty"`
+		Title  string `json:"title"`
+		Doc    string `json:"doc,omitempty"`
+		Code   string `json:"code"`
+		Output string `json:"output,omitempty"`
+	}
+
+	jsonLocation struct {
+		File      string `json:"file"`
+		StartLine int    `json:"startLine"`
+		EndLine   int    `json:"endLine"`
+	}
+)
+
+// RenderFile renders the documentation for all of the packages in the file as
+// an indented JSON document.
+func (f *JSONDocument) RenderFile(file *lang.File) (string, error) {
+	out := jsonFile{
+		Header:   file.Header,
+		Footer:   file.Footer,
+		Packages: make([]*jsonPackage, len(file.Packages)),
+	}
+
+	for i, pkg := range file.Packages {
+		p, err := jsonFromPackage(pkg)
+		if err != nil {
+			return "", err
+		}
+
+		out.Packages[i] = p
+	}
+
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(b) + "\n", nil
+}
+
+func jsonFromPackage(pkg *lang.Package) (*jsonPackage, error) {
+	var err error
+	p := &jsonPackage{
+		Name:       pkg.Name(),
+		ImportPath: pkg.ImportPath(),
+		Summary:    pkg.Summary(),
+		Doc:        docText(pkg.Doc()),
+		Deprecated: pkg.DeprecationNotice(),
+		Examples:   jsonFromExamples(pkg.Examples()),
+	}
+
+	for _, g := range pkg.ExampleGroups() {
+		p.Groups = append(p.Groups, &jsonGroup{
+			Name:     g.Name(),
+			Title:    g.Title(),
+			Examples: jsonFromExamples(g.Examples()),
+		})
+	}
+
+	if p.Consts, err = jsonFromValues(pkg.Consts()); err != nil {
+		return nil, err
+	}
+
+	if p.Vars, err = jsonFromValues(pkg.Vars()); err != nil {
+		return nil, err
+	}
+
+	if p.Funcs, err = jsonFromFuncs(pkg.Funcs()); err != nil {
+		return nil, err
+	}
+
+	for _, typ := range pkg.Types() {
+		t, err := jsonFromType(typ)
+		if err != nil {
+			return nil, err
+		}
+
+		p.Types = append(p.Types, t)
+	}
+
+	return p, nil
+}
+
+func jsonFromType(typ *lang.Type) (*jsonType, error) {
+	decl, err := typ.Decl()
+	if err != nil {
+		return nil, err
+	}
+
+	t := &jsonType{
+		Name:     typ.Name(),
+		Anchor:   typ.Anchor(),
+		Decl:     decl,
+		Summary:  typ.Summary(),
+		Doc:      docText(typ.Doc()),
+		Location: jsonFromLocation(typ.Location()),
+		Build:    typ.BuildConstraint(),
+		Examples: jsonFromExamples(typ.Examples()),
+
+		Deprecated: typ.DeprecationNotice(),
+	}
+
+	if t.Consts, err = jsonFromValues(typ.Consts()); err != nil {
+		return nil, err
+	}
+
+	if t.Vars, err = jsonFromValues(typ.Vars()); err != nil {
+		return nil, err
+	}
+
+	if t.Funcs, err = jsonFromFuncs(typ.Funcs()); err != nil {
+		return nil, err
+	}
+
+	if t.Methods, err = jsonFromFuncs(typ.Methods()); err != nil {
+		return nil, err
+	}
+
+	for _, m := range typ.InterfaceMethods() {
+		sig, err := m.Signature()
+		if err != nil {
+			return nil, err
+		}
+
+		t.InterfaceMethods = append(t.InterfaceMethods, &jsonFunc{
+			Name:      m.Name(),
+			Receiver:  m.Receiver(),
+			Anchor:    m.Anchor(),
+			Signature: sig,
+			Summary:   m.Summary(),
+			Doc:       docText(m.Doc()),
+			Location:  jsonFromLocation(m.Location()),
+		})
+	}
+
+	for _, f := range append(typ.Fields(), typ.PromotedFields()...) {
+		fieldType, err := f.Type()
+		if err != nil {
+			return nil, err
+		}
+
+		t.Fields = append(t.Fields, &jsonField{
+			Name:         f.Name(),
+			Type:         fieldType,
+			Tag:          f.Tag(),
+			Summary:      f.Summary(),
+			Doc:          docText(f.Doc()),
+			EmbeddedFrom: f.EmbeddedFrom(),
+			Deprecated:   f.DeprecationNotice(),
+		})
+	}
+
+	return t, nil
+}
+
+func jsonFromValues(values []*lang.Value) ([]*jsonValue, error) {
+	var res []*jsonValue
+	for _, v := range values {
+		decl, err := v.Decl()
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, &jsonValue{
+			Anchor:   v.Anchor(),
+			Decl:     decl,
+			Summary:  v.Summary(),
+			Doc:      docText(v.Doc()),
+			Location: jsonFromLocation(v.Location()),
+			Build:    v.BuildConstraint(),
+		})
+	}
+
+	return res, nil
+}
+
+func jsonFromFuncs(funcs []*lang.Func) ([]*jsonFunc, error) {
+	var res []*jsonFunc
+	for _, fn := range funcs {
+		sig, err := fn.Signature()
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, &jsonFunc{
+			Name:      fn.Name(),
+			Receiver:  fn.Receiver(),
+			Anchor:    fn.Anchor(),
+			Signature: sig,
+			Summary:   fn.Summary(),
+			Doc:       docText(fn.Doc()),
+			Location:  jsonFromLocation(fn.Location()),
+			Build:     fn.BuildConstraint(),
+			Examples:  jsonFromExamples(fn.Examples()),
+
+			Deprecated: fn.DeprecationNotice(),
+		})
+	}
+
+	return res, nil
+}
+
+func jsonFromExamples(examples []*lang.Example) []*jsonExample {
+	var res []*jsonExample
+	for _, ex := range examples {
+		// Examples whose code can't be printed are still worth listing
+		code, _ := ex.Code()
+
+		res = append(res, &jsonExample{
+			Name:   ex.Name(),
+			Title:  ex.Title(),
+			Doc:    docText(ex.Doc()),
+			Code:   strings.TrimSpace(code),
+			Output: ex.Output(),
+		})
+	}
+
+	return res
+}
+
+func jsonFromLocation(loc lang.Location) *jsonLocation {
+	file := loc.Filepath
+	if rel, err := filepath.Rel(loc.WorkDir, loc.Filepath); err == nil && filepath.IsAbs(loc.Filepath) {
+		file = rel
+	}
+
+	return &jsonLocation{
+		File:      filepath.ToSlash(file),
+		StartLine: loc.Start.Line,
+		EndLine:   loc.End.Line,
+	}
+}
+
+// docText converts structured documentation into plain text, separating
+// blocks with blank lines.
+func docText(doc *lang.Doc) string {
+	var b strings.Builder
+	writeBlocks(&b, doc.Blocks(), "")
+	return strings.TrimSpace(b.String())
+}
+
+func writeBlocks(b *strings.Builder, blocks []*lang.Block, indent string) {
+	for i, block := range blocks {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+
+		switch block.Kind() {
+		case lang.ListBlock:
+			for j, item := range block.List().Items() {
+				if j > 0 {
+					b.WriteRune('\n')
+				}
+
+				if item.Kind() == lang.OrderedItem {
+					fmt.Fprintf(b, "%s%d. ", indent, item.Number())
+				} else {
+					fmt.Fprintf(b, "%s- ", indent)
+				}
+
+				writeBlocks(b, item.Blocks(), indent+"  ")
+			}
+		default:
+			for _, span := range block.Spans() {
+				b.WriteString(span.Text())
+			}
+		}
+	}
+}
+
+// Bold returns the text as-is, as JSON output has no styling.
+func (f *JSONDocument) Bold(text string) (string, error) {
+	return text, nil
+}
+
+// CodeBlock returns the code as-is, as JSON output has no code blocks.
+func (f *JSONDocument) CodeBlock(language, code string) (string, error) {
+	return strings.TrimSpace(code), nil
+}
+
+// Anchor always returns the empty string, as JSON output has no anchors.
+func (f *JSONDocument) Anchor(anchor string) string {
+	return ""
+}
+
+// AnchorHeader returns the text as-is, as JSON output has no headers.
+func (f *JSONDocument) AnchorHeader(level int, text, anchor string) (string, error) {
+	return f.Header(level, text)
+}
+
+// Header returns the text as-is, as JSON output has no headers. The level is
+// expected to be at least 1.
+func (f *JSONDocument) Header(level int, text string) (string, error) {
+	if level < 1 {
+		return "", errors.New("format: header level cannot be less than 1")
+	}
+
+	return text, nil
+}
+
+// RawAnchorHeader returns the text as-is, as JSON output has no headers.
+func (f *JSONDocument) RawAnchorHeader(level int, text, anchor string) (string, error) {
+	return f.Header(level, text)
+}
+
+// RawHeader returns the text as-is, as JSON output has no headers.
+func (f *JSONDocument) RawHeader(level int, text string) (string, error) {
+	return f.Header(level, text)
+}
+
+// LocalHref always returns the empty string, as JSON output has no headers to
+// link to.
+func (f *JSONDocument) LocalHref(headerText string) (string, error) {
+	return "", nil
+}
+
+// RawLocalHref generates an href within the same document but with a direct
+// link provided instead of text to slugify.
+func (f *JSONDocument) RawLocalHref(anchor string) string {
+	return fmt.Sprintf("#%s", anchor)
+}
+
+// Link returns the text as-is, as JSON output has no links.
+func (f *JSONDocument) Link(text, href string) (string, error) {
+	return text, nil
+}
+
+// Badge returns the alt text of the badge, as JSON output has no images.
+func (f *JSONDocument) Badge(text, image, href string) (string, error) {
+	return text, nil
+}
+
+// CodeHref always returns the empty string, as locations are exported as
+// structured data instead.
+func (f *JSONDocument) CodeHref(loc lang.Location) (string, error) {
+	return "", nil
+}
+
+// Comment always returns the empty string, as JSON output has no comments.
+func (f *JSONDocument) Comment(text string) (string, error) {
+	return "", nil
+}
+
+// ListEntry generates an unordered list entry with the provided text at the
+// provided zero-indexed depth. A depth of 0 is considered the topmost level of
+// list.
+func (f *JSONDocument) ListEntry(depth int, text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s- %s", strings.Repeat("  ", depth), text), nil
+}
+
+// Accordion returns the title followed by the body, as JSON output has no
+// collapsible content.
+func (f *JSONDocument) Accordion(title, body string) (string, error) {
+	return fmt.Sprintf("%s\n\n%s", title, body), nil
+}
+
+// AccordionHeader returns the title as-is, as JSON output has no collapsible
+// content.
+func (f *JSONDocument) AccordionHeader(title string) (string, error) {
+	return title, nil
+}
+
+// AccordionTerminator always returns the empty string, as JSON output has no
+// collapsible content.
+func (f *JSONDocument) AccordionTerminator() (string, error) {
+	return "", nil
+}
+
+// Escape returns the text as-is, as escaping is handled when the JSON output
+// is encoded.
+func (f *JSONDocument) Escape(text string) string {
+	return text
+}
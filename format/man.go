@@ -0,0 +1,204 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+)
+
+// ManPage provides a Format which renders documentation as a manual page using
+// the man macros for roff (e.g. mypkg.3), which can be installed and viewed
+// with man alongside a library's binaries. The package's name is used as the
+// title of the page, which is placed in section 3 of the manual. Since manual
+// pages are viewed in a terminal, links are rendered with their url in angle
+// brackets, in-page links and links to source code are left out and badges are
+// not rendered.
+type ManPage struct{}
+
+// ManSection is the section of the manual in which pages rendered with the Man
+// format are placed.
+const ManSection = 3
+
+var roffEscaper = strings.NewReplacer(`\`, `\e`, "-", `\-`)
+
+// TemplateVariant provides the name of the man variants of the default
+// templates, which use roff requests for paragraphs and lists.
+func (f *ManPage) TemplateVariant() string {
+	return "man"
+}
+
+// Bold converts the provided text to bold
+func (f *ManPage) Bold(text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf(`\fB%s\fR`, f.Escape(text)), nil
+}
+
+// CodeBlock wraps the provided code as an indented block without filling. The
+// language is not used.
+func (f *ManPage) CodeBlock(language, code string) (string, error) {
+	return fmt.Sprintf(".PP\n.RS 4\n.nf\n%s\n.fi\n.RE", f.Escape(strings.TrimSpace(code))), nil
+}
+
+// Anchor produces an anchor for the provided link. Manual pages have no
+// anchors, so nothing is produced.
+func (f *ManPage) Anchor(anchor string) string {
+	return ""
+}
+
+// AnchorHeader converts the provided text and custom anchor link into a header
+// of the provided level. The level is expected to be at least 1.
+func (f *ManPage) AnchorHeader(level int, text, anchor string) (string, error) {
+	return f.Header(level, text)
+}
+
+// Header converts the provided text into a header of the provided level. The
+// level is expected to be at least 1.
+func (f *ManPage) Header(level int, text string) (string, error) {
+	return f.RawHeader(level, f.Escape(text))
+}
+
+// RawAnchorHeader converts the provided text and custom anchor link into a
+// header of the provided level without escaping the header text. The level is
+// expected to be at least 1.
+func (f *ManPage) RawAnchorHeader(level int, text, anchor string) (string, error) {
+	return f.RawHeader(level, text)
+}
+
+// RawHeader converts the provided text into a header of the provided level
+// without escaping the header text. The level is expected to be at least 1.
+// The first level produces the title of the page, the second level a section
+// and deeper levels a subsection.
+func (f *ManPage) RawHeader(level int, text string) (string, error) {
+	switch {
+	case level < 1:
+		return "", errors.New("format: header level cannot be less than 1")
+	case level == 1:
+		return fmt.Sprintf(`.TH "%s" %d`, strings.ReplaceAll(text, `"`, `\(dq`), ManSection), nil
+	case level == 2:
+		return fmt.Sprintf(".SH %s", text), nil
+	default:
+		return fmt.Sprintf(".SS %s", text), nil
+	}
+}
+
+// LocalHref generates an href for navigating to a header with the given
+// headerText located within the same document as the href itself. Manual pages
+// can't link within the page, so the href is empty.
+func (f *ManPage) LocalHref(headerText string) (string, error) {
+	return "", nil
+}
+
+// RawLocalHref generates an href within the same document but with a direct
+// link provided instead of text to slugify. Manual pages can't link within
+// the page, so the href is empty.
+func (f *ManPage) RawLocalHref(anchor string) string {
+	return ""
+}
+
+// Link generates a link with the given text and href values. The href is
+// written after the text, since manual pages can't include links. Hrefs within
+// the same document are left out.
+func (f *ManPage) Link(text, href string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	if href == "" || strings.HasPrefix(href, "#") {
+		return text, nil
+	}
+
+	return fmt.Sprintf("%s <%s>", text, f.Escape(href)), nil
+}
+
+// Badge generates a badge image with the provided alt text and image url which
+// links to the provided href. Manual pages can't include images, so nothing is
+// produced.
+func (f *ManPage) Badge(text, image, href string) (string, error) {
+	return "", nil
+}
+
+// CodeHref generates an href to the provided code entry. Links to source code
+// are left out of manual pages, so the href is empty.
+func (f *ManPage) CodeHref(loc lang.Location) (string, error) {
+	return "", nil
+}
+
+// Comment generates a roff comment with the provided text.
+func (f *ManPage) Comment(text string) (string, error) {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = fmt.Sprintf(`.\" %s`, line)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// ListEntry generates an unordered list entry with the provided text at the
+// provided zero-indexed depth. A depth of 0 is considered the topmost level of
+// list.
+func (f *ManPage) ListEntry(depth int, text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf(
+		"%s.IP \\(bu 2\n%s%s",
+		strings.Repeat(".RS 2\n", depth),
+		text,
+		strings.Repeat("\n.RE", depth),
+	), nil
+}
+
+// Accordion generates a collapsible content. Since manual pages have no
+// collapsible content, the title is rendered as a bold paragraph followed by
+// the body.
+func (f *ManPage) Accordion(title, body string) (string, error) {
+	header, err := f.AccordionHeader(title)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s\n%s", header, body), nil
+}
+
+// AccordionHeader generates the header visible when an accordion is collapsed.
+//
+// The AccordionHeader is expected to be used in conjunction with
+// AccordionTerminator() when the demands of the body's rendering requires it to
+// be generated independently. The result looks conceptually like the following:
+//
+//	accordion := format.AccordionHeader("Accordion Title") + "Accordion Body" + format.AccordionTerminator()
+func (f *ManPage) AccordionHeader(title string) (string, error) {
+	bold, err := f.Bold(title)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(".PP\n%s", bold), nil
+}
+
+// AccordionTerminator generates the code necessary to terminate an accordion
+// after the body. It is expected to be used in conjunction with
+// AccordionHeader(). See AccordionHeader for a full description.
+func (f *ManPage) AccordionTerminator() (string, error) {
+	return "", nil
+}
+
+// Escape escapes the characters with special meaning in roff from the provided
+// text, including periods and apostrophes at the beginning of lines, which
+// would otherwise be treated as requests.
+func (f *ManPage) Escape(text string) string {
+	lines := strings.Split(roffEscaper.Replace(text), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = fmt.Sprintf(`\&%s`, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,88 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/format"
+	"github.com/matryer/is"
+)
+
+func TestMan_Header(t *testing.T) {
+	tests := []struct {
+		text   string
+		level  int
+		result string
+	}{
+		{"mypkg", 1, `.TH "mypkg" 3`},
+		{"type Client", 2, ".SH type Client"},
+		{"func (*Client) Do", 3, ".SS func (*Client) Do"},
+		{"deeper", 5, ".SS deeper"},
+		{"non-standard", 2, `.SH non\-standard`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			is := is.New(t)
+
+			var f format.ManPage
+			res, err := f.Header(test.level, test.text)
+			is.NoErr(err)
+			is.Equal(res, test.result)
+		})
+	}
+}
+
+func TestMan_CodeBlock(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ManPage
+	res, err := f.CodeBlock("go", "x := `a\\b`\n.start")
+	is.NoErr(err)
+	is.Equal(res, ".PP\n.RS 4\n.nf\nx := `a\\eb`\n\\&.start\n.fi\n.RE")
+}
+
+func TestMan_Link(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ManPage
+	res, err := f.Link("link text", "https://test.com/a-b")
+	is.NoErr(err)
+	is.Equal(res, `link text <https://test.com/a\-b>`)
+
+	res, err = f.Link("type Client", f.RawLocalHref("Client"))
+	is.NoErr(err)
+	is.Equal(res, "type Client")
+
+	res, err = f.Link("type Client", "#Client")
+	is.NoErr(err)
+	is.Equal(res, "type Client")
+}
+
+func TestMan_ListEntry(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ManPage
+	res, err := f.ListEntry(0, "entry")
+	is.NoErr(err)
+	is.Equal(res, ".IP \\(bu 2\nentry")
+
+	res, err = f.ListEntry(1, "nested entry")
+	is.NoErr(err)
+	is.Equal(res, ".RS 2\n.IP \\(bu 2\nnested entry\n.RE")
+}
+
+func TestMan_Comment(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ManPage
+	res, err := f.Comment("line 1\nline 2")
+	is.NoErr(err)
+	is.Equal(res, ".\\\" line 1\n.\\\" line 2")
+}
+
+func TestMan_Escape(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ManPage
+	is.Equal(f.Escape("'quoted' and \\ with -flag\n.dot"), "\\&'quoted' and \\e with \\-flag\n\\&.dot")
+}
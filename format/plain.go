@@ -52,6 +52,23 @@ func (f *PlainMarkdown) RawHeader(level int, text string) (string, error) {
 	return formatcore.Header(level, text)
 }
 
+// MaxHeaderLevel provides the deepest header level of the format.
+func (f *PlainMarkdown) MaxHeaderLevel() int {
+	return formatcore.MaxHeaderLevel
+}
+
+// RawLabel converts the provided text into a bold label standing in place of a
+// header, without escaping the text.
+func (f *PlainMarkdown) RawLabel(text string) (string, error) {
+	return formatcore.Label(text), nil
+}
+
+// RawDefinitionTerm converts the provided text into the term of a definition
+// list standing in place of a header, without escaping the text.
+func (f *PlainMarkdown) RawDefinitionTerm(text string) (string, error) {
+	return formatcore.DefinitionTerm(text), nil
+}
+
 // LocalHref always returns the empty string, as header links are not supported
 // in plain markdown.
 func (f *PlainMarkdown) LocalHref(headerText string) (string, error) {
@@ -75,6 +92,17 @@ func (f *PlainMarkdown) Link(text, href string) (string, error) {
 	return formatcore.Link(text, href), nil
 }
 
+// Badge generates a badge image with the provided alt text and image url which
+// links to the provided href. If the href is empty, the image is not linked.
+func (f *PlainMarkdown) Badge(text, image, href string) (string, error) {
+	return formatcore.Badge(text, image, href), nil
+}
+
+// Comment generates an HTML comment with the provided text.
+func (f *PlainMarkdown) Comment(text string) (string, error) {
+	return formatcore.Comment(text), nil
+}
+
 // ListEntry generates an unordered list entry with the provided text at the
 // provided zero-indexed depth. A depth of 0 is considered the topmost level of
 // list.
@@ -0,0 +1,233 @@
+package format
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+)
+
+// ReStructuredText provides a Format which renders documentation as
+// reStructuredText, suitable for inclusion in Sphinx documentation trees.
+// Anchors are rendered as hyperlink targets which can be referenced from other
+// documents, and signatures and declarations are rendered as literal blocks.
+// Links to source code use the url format of GitHub repositories.
+type ReStructuredText struct{}
+
+var (
+	rstHeaderChars  = []string{"=", "-", "~"}
+	rstSpecialChars = "\\`*_|<>"
+)
+
+// TemplateVariant provides the name of the reStructuredText variants of the
+// default templates, which use reStructuredText syntax for lists.
+func (f *ReStructuredText) TemplateVariant() string {
+	return "rst"
+}
+
+// Bold converts the provided text to bold
+func (f *ReStructuredText) Bold(text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("**%s**", f.Escape(text)), nil
+}
+
+// CodeBlock wraps the provided code as a literal block and tags it as source
+// code of the provided language (or no language if the empty string is
+// provided).
+func (f *ReStructuredText) CodeBlock(language, code string) (string, error) {
+	code = rstIndent(strings.TrimSpace(code), 3)
+	if language == "" {
+		return fmt.Sprintf("::\n\n%s", code), nil
+	}
+
+	return fmt.Sprintf(".. code-block:: %s\n\n%s", language, code), nil
+}
+
+// Anchor produces an anchor for the provided link. The anchor is a hyperlink
+// target, which needs to be on its own line.
+func (f *ReStructuredText) Anchor(anchor string) string {
+	return fmt.Sprintf(".. _%s:\n\n", anchor)
+}
+
+// AnchorHeader converts the provided text and custom anchor link into a header
+// of the provided level. The level is expected to be at least 1.
+func (f *ReStructuredText) AnchorHeader(level int, text, anchor string) (string, error) {
+	return f.RawAnchorHeader(level, f.Escape(text), anchor)
+}
+
+// Header converts the provided text into a header of the provided level. The
+// level is expected to be at least 1.
+func (f *ReStructuredText) Header(level int, text string) (string, error) {
+	return f.RawHeader(level, f.Escape(text))
+}
+
+// RawAnchorHeader converts the provided text and custom anchor link into a
+// header of the provided level without escaping the header text. The level is
+// expected to be at least 1.
+func (f *ReStructuredText) RawAnchorHeader(level int, text, anchor string) (string, error) {
+	header, err := f.RawHeader(level, text)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%s", f.Anchor(anchor), header), nil
+}
+
+// RawHeader converts the provided text into a header of the provided level
+// without escaping the header text. The level is expected to be at least 1.
+// Each of the first three levels is underlined with a different character.
+// Since reStructuredText infers section levels from the order in which
+// underline styles are first seen, deeper headers are rendered as rubrics,
+// which don't start a new section.
+func (f *ReStructuredText) RawHeader(level int, text string) (string, error) {
+	if level < 1 {
+		return "", errors.New("format: header level cannot be less than 1")
+	}
+
+	if level > len(rstHeaderChars) {
+		return fmt.Sprintf(".. rubric:: %s", text), nil
+	}
+
+	underline := strings.Repeat(rstHeaderChars[level-1], utf8.RuneCountInString(text))
+	return fmt.Sprintf("%s\n%s", text, underline), nil
+}
+
+// LocalHref generates an href for navigating to a header with the given
+// headerText located within the same document as the href itself. Headers are
+// implicit hyperlink targets named by their text.
+func (f *ReStructuredText) LocalHref(headerText string) (string, error) {
+	return fmt.Sprintf("#%s", headerText), nil
+}
+
+// RawLocalHref generates an href within the same document but with a direct
+// link provided instead of text to slugify.
+func (f *ReStructuredText) RawLocalHref(anchor string) string {
+	return fmt.Sprintf("#%s", anchor)
+}
+
+// Link generates a link with the given text and href values. Hrefs within the
+// same document are rendered as references to the hyperlink target.
+func (f *ReStructuredText) Link(text, href string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	if href == "" {
+		return text, nil
+	}
+
+	if strings.HasPrefix(href, "#") {
+		return fmt.Sprintf("`%s <%s_>`_", f.Escape(text), href[1:]), nil
+	}
+
+	return fmt.Sprintf("`%s <%s>`__", f.Escape(text), href), nil
+}
+
+// Badge generates a badge with the provided alt text and image url which links
+// to the provided href. Since reStructuredText only supports inline images
+// through substitution definitions, the badge is rendered as its alt text,
+// linked to the href if provided.
+func (f *ReStructuredText) Badge(text, image, href string) (string, error) {
+	if image == "" {
+		return "", nil
+	}
+
+	return f.Link(text, href)
+}
+
+// CodeHref generates an href to the provided code entry, using the url format
+// of GitHub repositories.
+func (f *ReStructuredText) CodeHref(loc lang.Location) (string, error) {
+	return GitHub.CodeHref(loc)
+}
+
+// Comment generates a comment with the provided text.
+func (f *ReStructuredText) Comment(text string) (string, error) {
+	return fmt.Sprintf(".. %s", strings.TrimLeft(rstIndent(text, 3), " ")), nil
+}
+
+// ListEntry generates an unordered list entry with the provided text at the
+// provided zero-indexed depth. A depth of 0 is considered the topmost level of
+// list. Entries are followed by a blank line, which reStructuredText requires
+// around nested lists.
+func (f *ReStructuredText) ListEntry(depth int, text string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s- %s\n", strings.Repeat("  ", depth), text), nil
+}
+
+// Accordion generates a collapsible content. Since reStructuredText has no
+// collapsible content, the title is rendered as a rubric followed by the body.
+func (f *ReStructuredText) Accordion(title, body string) (string, error) {
+	return fmt.Sprintf(".. rubric:: %s\n\n%s\n\n", f.Escape(title), body), nil
+}
+
+// AccordionHeader generates the header visible when an accordion is collapsed.
+//
+// The AccordionHeader is expected to be used in conjunction with
+// AccordionTerminator() when the demands of the body's rendering requires it to
+// be generated independently. The result looks conceptually like the following:
+//
+//	accordion := format.AccordionHeader("Accordion Title") + "Accordion Body" + format.AccordionTerminator()
+func (f *ReStructuredText) AccordionHeader(title string) (string, error) {
+	return fmt.Sprintf(".. rubric:: %s\n\n", f.Escape(title)), nil
+}
+
+// AccordionTerminator generates the code necessary to terminate an accordion
+// after the body. It is expected to be used in conjunction with
+// AccordionHeader(). See AccordionHeader for a full description.
+func (f *ReStructuredText) AccordionTerminator() (string, error) {
+	return "\n\n", nil
+}
+
+// Escape escapes the characters with special meaning in reStructuredText
+// inline markup from the provided text, but leaves URLs found intact. Note
+// that the URLs included must begin with a scheme to skip the escaping.
+func (f *ReStructuredText) Escape(text string) string {
+	var (
+		cursor  int
+		builder strings.Builder
+	)
+
+	for _, urlLoc := range schemeURLRegex.FindAllStringIndex(text, -1) {
+		builder.WriteString(escapeRST(text[cursor:urlLoc[0]]))
+		builder.WriteString(text[urlLoc[0]:urlLoc[1]])
+		cursor = urlLoc[1]
+	}
+
+	builder.WriteString(escapeRST(text[cursor:]))
+	return builder.String()
+}
+
+func escapeRST(text string) string {
+	var builder strings.Builder
+	for _, r := range text {
+		if strings.ContainsRune(rstSpecialChars, r) {
+			builder.WriteRune('\\')
+		}
+
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
+
+// rstIndent indents each non-empty line of the text by the provided number of
+// spaces.
+func rstIndent(text string, spaces int) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = fmt.Sprintf("%s%s", strings.Repeat(" ", spaces), line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,82 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/format"
+	"github.com/matryer/is"
+)
+
+func TestReStructuredText_Header(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ReStructuredText
+	res, err := f.Header(2, "Header *text*")
+	is.NoErr(err)
+	is.Equal(res, "Header \\*text\\*\n---------------")
+
+	res, err = f.Header(4, "Deep header")
+	is.NoErr(err)
+	is.Equal(res, ".. rubric:: Deep header")
+
+	_, err = f.Header(0, "invalid")
+	is.Equal(err.Error(), "format: header level cannot be less than 1")
+}
+
+func TestReStructuredText_AnchorHeader(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ReStructuredText
+	res, err := f.AnchorHeader(1, "type Client", "Client")
+	is.NoErr(err)
+	is.Equal(res, ".. _Client:\n\ntype Client\n===========")
+}
+
+func TestReStructuredText_CodeBlock(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ReStructuredText
+	res, err := f.CodeBlock("go", "func main() {\n\tx := 1\n\n\t_ = x\n}")
+	is.NoErr(err)
+	is.Equal(res, ".. code-block:: go\n\n   func main() {\n   \tx := 1\n\n   \t_ = x\n   }")
+
+	res, err = f.CodeBlock("", "Line 1\nLine 2")
+	is.NoErr(err)
+	is.Equal(res, "::\n\n   Line 1\n   Line 2")
+}
+
+func TestReStructuredText_Link(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ReStructuredText
+	res, err := f.Link("link text", "https://test.com/a/b/c")
+	is.NoErr(err)
+	is.Equal(res, "`link text <https://test.com/a/b/c>`__")
+
+	res, err = f.Link("func (*Client) Do()", f.RawLocalHref("Client.Do"))
+	is.NoErr(err)
+	is.Equal(res, "`func (\\*Client) Do() <Client.Do_>`_")
+}
+
+func TestReStructuredText_ListEntry(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ReStructuredText
+	res, err := f.ListEntry(1, "nested entry")
+	is.NoErr(err)
+	is.Equal(res, "  - nested entry\n")
+
+	res, err = f.ListEntry(0, "")
+	is.NoErr(err)
+	is.Equal(res, "")
+}
+
+func TestReStructuredText_Escape(t *testing.T) {
+	is := is.New(t)
+
+	var f format.ReStructuredText
+	is.Equal(
+		f.Escape("*bold* and `code` at https://test.com/a_b_c"),
+		"\\*bold\\* and \\`code\\` at https://test.com/a_b_c",
+	)
+}
@@ -0,0 +1,187 @@
+// Package formattest provides helpers for testing custom templates against
+// golden files, so that teams maintaining template overrides can catch changes
+// to the documentation they render when upgrading gomarkdoc.
+//
+// A test renders a fixture package with the overrides and compares the result
+// with a golden file checked in next to it:
+//
+//	func TestTemplates(t *testing.T) {
+//		formattest.Golden(t, "./testdata/fixture", "testdata/fixture.md",
+//			formattest.WithTemplateFiles(map[string]string{
+//				"type": "templates/type.gotxt",
+//			}),
+//		)
+//	}
+//
+// Golden files are written instead of compared when the GOMARKDOC_UPDATE_GOLDEN
+// environment variable is set to a non-empty value, which is how they are
+// created and updated after intentional changes:
+//
+//	GOMARKDOC_UPDATE_GOLDEN=1 go test ./...
+package formattest
+
+import (
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc"
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+)
+
+// UpdateEnv is the environment variable which causes golden files to be
+// written with the rendered documentation instead of compared with it.
+const UpdateEnv = "GOMARKDOC_UPDATE_GOLDEN"
+
+type (
+	// Option configures how a fixture package is rendered.
+	Option func(opts *options) error
+
+	options struct {
+		rendererOpts []gomarkdoc.RendererOption
+		packageOpts  []lang.PackageOption
+	}
+)
+
+// WithTemplates overrides the templates with the provided names with the
+// provided template strings.
+func WithTemplates(templates map[string]string) Option {
+	return func(opts *options) error {
+		for name, tmpl := range templates {
+			opts.rendererOpts = append(opts.rendererOpts, gomarkdoc.WithTemplateOverride(name, tmpl))
+		}
+
+		return nil
+	}
+}
+
+// WithTemplateFiles overrides the templates with the provided names with the
+// contents of the files at the provided paths.
+func WithTemplateFiles(files map[string]string) Option {
+	return func(opts *options) error {
+		for name, file := range files {
+			b, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("gomarkdoc: failed to read template file %s: %w", file, err)
+			}
+
+			opts.rendererOpts = append(opts.rendererOpts, gomarkdoc.WithTemplateOverride(name, string(b)))
+		}
+
+		return nil
+	}
+}
+
+// WithRendererOptions configures the renderer with the provided options, such
+// as its format or headings.
+func WithRendererOptions(rendererOpts ...gomarkdoc.RendererOption) Option {
+	return func(opts *options) error {
+		opts.rendererOpts = append(opts.rendererOpts, rendererOpts...)
+		return nil
+	}
+}
+
+// WithPackageOptions loads the fixture package with the provided options.
+func WithPackageOptions(packageOpts ...lang.PackageOption) Option {
+	return func(opts *options) error {
+		opts.packageOpts = append(opts.packageOpts, packageOpts...)
+		return nil
+	}
+}
+
+// Render renders the documentation file for the package in the provided
+// directory with the provided options.
+func Render(dir string, opts ...Option) (string, error) {
+	var options options
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return "", err
+		}
+	}
+
+	// The import path is found from the go.mod file of the package
+	buildPkg, err := build.ImportDir(dir, build.ImportComment)
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: failed to import fixture package %s: %w", dir, err)
+	}
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, options.packageOpts...)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := gomarkdoc.NewRenderer(append([]gomarkdoc.RendererOption{gomarkdoc.WithLogger(log)}, options.rendererOpts...)...)
+	if err != nil {
+		return "", err
+	}
+
+	return out.File(lang.NewFile("", "", []*lang.Package{pkg}))
+}
+
+// Golden renders the documentation file for the package in the provided
+// directory and fails the test if it doesn't match the contents of the golden
+// file, reporting the first line which differs. If the UpdateEnv environment
+// variable is set, the golden file is written with the rendered documentation
+// instead.
+func Golden(t testing.TB, dir, goldenFile string, opts ...Option) {
+	t.Helper()
+
+	actual, err := Render(dir, opts...)
+	if err != nil {
+		t.Fatalf("formattest: failed to render %s: %s", dir, err)
+		return
+	}
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(goldenFile), 0755); err != nil {
+			t.Fatalf("formattest: failed to create directory for golden file %s: %s", goldenFile, err)
+			return
+		}
+
+		if err := os.WriteFile(goldenFile, []byte(actual), 0664); err != nil {
+			t.Fatalf("formattest: failed to write golden file %s: %s", goldenFile, err)
+		}
+
+		return
+	}
+
+	b, err := os.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("formattest: failed to read golden file %s (set %s=1 to create it): %s", goldenFile, UpdateEnv, err)
+		return
+	}
+
+	if diff := firstDiff(string(b), actual); diff != "" {
+		t.Errorf("formattest: rendered documentation of %s does not match %s (set %s=1 to update it)\n%s", dir, goldenFile, UpdateEnv, diff)
+	}
+}
+
+// firstDiff describes the first line which differs between the expected and
+// actual text, or provides an empty string if they are equal.
+func firstDiff(expected, actual string) string {
+	if expected == actual {
+		return ""
+	}
+
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+	for i := 0; ; i++ {
+		var e, a string
+		if i < len(expectedLines) {
+			e = expectedLines[i]
+		}
+
+		if i < len(actualLines) {
+			a = actualLines[i]
+		}
+
+		if e != a || i >= len(expectedLines) || i >= len(actualLines) {
+			return fmt.Sprintf("line %d:\n  expected: %q\n  actual:   %q", i+1, e, a)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package formattest_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/formattest"
+	"github.com/matryer/is"
+)
+
+// recorder captures the failures reported by the helpers in place of a test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+var typeTemplate = map[string]string{
+	"type": `{{- header .Level .Title -}}{{- spacer -}}{{- template "doc" .Doc -}}`,
+}
+
+func TestGolden(t *testing.T) {
+	formattest.Golden(t, "../testData/simple", "../testData/formattest/simple.md", formattest.WithTemplates(typeTemplate))
+}
+
+func TestGolden_mismatch(t *testing.T) {
+	is := is.New(t)
+
+	r := &recorder{TB: t}
+	formattest.Golden(r, "../testData/simple", "../testData/formattest/simple.md")
+	is.Equal(len(r.errors), 1)
+	is.True(strings.Contains(r.errors[0], "does not match ../testData/formattest/simple.md"))
+	is.True(strings.Contains(r.errors[0], "line ")) // names the first differing line
+
+	r = &recorder{TB: t}
+	formattest.Golden(r, "../testData/simple", "../testData/formattest/missing.md")
+	is.Equal(len(r.errors), 1)
+	is.True(strings.Contains(r.errors[0], formattest.UpdateEnv))
+}
+
+func TestWithTemplateFiles(t *testing.T) {
+	is := is.New(t)
+
+	_, err := formattest.Render("../testData/simple", formattest.WithTemplateFiles(map[string]string{
+		"type": "../testData/formattest/missing.gotxt",
+	}))
+	is.True(err != nil)
+}
@@ -0,0 +1,135 @@
+package gomarkdoc
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+// HelperFuncs provides general purpose template functions for comparing
+// versions and describing times, such as marking the documentation of the
+// latest version in versioned documentation. They are available to all of the
+// templates of a Renderer and can be added to other templates rendered along
+// with the documentation (e.g. headers, footers and front matter) so that
+// they behave the same way. The functions are:
+//
+//	semverCompare v w     -1, 0 or +1 as v is less than, equal to or greater than w
+//	semverValid v         whether v is a valid semantic version
+//	semverMajor v         the major version prefix of v (e.g. v2)
+//	semverLatest v...     the greatest of the valid versions, or "" if none are
+//	now                   the current time
+//	relTime t             the time relative to now (e.g. "3 days ago")
+//
+// Versions may be written with or without the leading "v". Times may be
+// provided as a time.Time or as text in RFC 3339 format. Note that output
+// using now or relTime changes between runs.
+func HelperFuncs() template.FuncMap {
+	return template.FuncMap{
+		"semverCompare": func(v, w string) int {
+			return semver.Compare(canonicalVersion(v), canonicalVersion(w))
+		},
+		"semverValid": func(v string) bool {
+			return semver.IsValid(canonicalVersion(v))
+		},
+		"semverMajor": func(v string) string {
+			return semver.Major(canonicalVersion(v))
+		},
+		"semverLatest": func(versions ...string) string {
+			var latest string
+			for _, v := range versions {
+				if !semver.IsValid(canonicalVersion(v)) {
+					continue
+				}
+
+				if latest == "" || semver.Compare(canonicalVersion(v), canonicalVersion(latest)) > 0 {
+					latest = v
+				}
+			}
+
+			return latest
+		},
+		"now": time.Now,
+		"relTime": func(t any) (string, error) {
+			switch v := t.(type) {
+			case time.Time:
+				return relativeTime(v, time.Now()), nil
+			case string:
+				parsed, err := time.Parse(time.RFC3339, v)
+				if err != nil {
+					return "", fmt.Errorf("gomarkdoc: relTime requires a time in RFC 3339 format: %w", err)
+				}
+
+				return relativeTime(parsed, time.Now()), nil
+			default:
+				return "", fmt.Errorf("gomarkdoc: relTime requires a time, got %T", t)
+			}
+		},
+	}
+}
+
+// SafeHelperFuncs provides the HelperFuncs along with replacements for the
+// template functions disabled by WithSafeTemplates, which fail when used. It is
+// meant for templates rendered outside of the Renderer, such as headers and
+// footers, so that they are restricted in the same way.
+func SafeHelperFuncs() template.FuncMap {
+	funcs := HelperFuncs()
+	for _, n := range unsafeTemplateFuncs {
+		funcs[n] = disallowedTemplateFunc(n)
+	}
+
+	return funcs
+}
+
+// canonicalVersion adds the "v" prefix required by the semver package to
+// versions written without it.
+func canonicalVersion(v string) string {
+	if v == "" || strings.HasPrefix(v, "v") {
+		return v
+	}
+
+	return "v" + v
+}
+
+// relativeTime describes the time t relative to now in the largest whole unit
+// (e.g. "5 minutes ago" or "in 2 days"). Differences under a minute are
+// described as "just now".
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var (
+		n    int64
+		unit string
+	)
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		n, unit = int64(d/time.Minute), "minute"
+	case d < 24*time.Hour:
+		n, unit = int64(d/time.Hour), "hour"
+	case d < 30*24*time.Hour:
+		n, unit = int64(d/(24*time.Hour)), "day"
+	case d < 365*24*time.Hour:
+		n, unit = int64(d/(30*24*time.Hour)), "month"
+	default:
+		n, unit = int64(d/(365*24*time.Hour)), "year"
+	}
+
+	if n != 1 {
+		unit += "s"
+	}
+
+	if future {
+		return fmt.Sprintf("in %d %s", n, unit)
+	}
+
+	return fmt.Sprintf("%d %s ago", n, unit)
+}
@@ -0,0 +1,330 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// validateSymbolAliases checks that each of the keys of the provided aliases
+// identifies a top-level symbol ("Name") or a member of a type ("Type.Member")
+// and that each alias is either an identifier or empty.
+func validateSymbolAliases(aliases map[string]string) error {
+	for key, alias := range aliases {
+		valid := token.IsIdentifier(key)
+		if typeName, member, ok := splitMemberAlias(key); ok {
+			valid = token.IsIdentifier(typeName) && token.IsIdentifier(member)
+		}
+
+		if !valid || (alias != "" && !token.IsIdentifier(alias)) {
+			return fmt.Errorf("gomarkdoc: invalid symbol alias %s: %s", key, alias)
+		}
+	}
+
+	return nil
+}
+
+// aliasPackage renames and hides symbols in the files of the package according
+// to the provided aliases before its documentation is computed, so the rest of
+// the documentation treats the aliased names as if they were declared in the
+// code. Members of types are handled before top-level symbols so their keys can
+// refer to the original type names.
+func aliasPackage(pkg *ast.Package, aliases map[string]string) {
+	if len(aliases) == 0 {
+		return
+	}
+
+	renames := make(map[string]string)
+	for key, alias := range aliases {
+		if typeName, member, ok := splitMemberAlias(key); ok {
+			for _, f := range pkg.Files {
+				aliasMember(f, typeName, member, alias)
+			}
+		} else if alias != "" {
+			renames[key] = alias
+		}
+	}
+
+	for _, f := range pkg.Files {
+		hideTopLevel(f, aliases)
+		renameTopLevel(f, renames)
+	}
+}
+
+// aliasExamples renames the examples for aliased symbols to match the names of
+// the symbols in the documentation.
+func aliasExamples(examples []*doc.Example, aliases map[string]string) {
+	if len(aliases) == 0 {
+		return
+	}
+
+	for _, example := range examples {
+		parts := strings.Split(example.Name, "_")
+		if len(parts) > 1 {
+			if alias := aliases[fmt.Sprintf("%s.%s", parts[0], parts[1])]; alias != "" {
+				parts[1] = alias
+			}
+		}
+
+		if alias := aliases[parts[0]]; alias != "" {
+			parts[0] = alias
+		}
+
+		example.Name = strings.Join(parts, "_")
+	}
+}
+
+// aliasMember renames or hides the method, struct field or interface method
+// with the provided name of the named type.
+func aliasMember(f *ast.File, typeName, member, alias string) {
+	decls := f.Decls[:0]
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv != nil && d.Name.Name == member && recvBaseName(d.Recv) == typeName {
+				if alias == "" {
+					continue
+				}
+
+				d.Name.Name = alias
+				renameInComment(d.Doc, map[string]string{member: alias})
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == typeName {
+					switch t := ts.Type.(type) {
+					case *ast.StructType:
+						aliasFields(t.Fields, member, alias)
+					case *ast.InterfaceType:
+						aliasFields(t.Methods, member, alias)
+					}
+				}
+			}
+		}
+
+		decls = append(decls, decl)
+	}
+
+	f.Decls = decls
+
+	if alias != "" {
+		qualified := fmt.Sprintf("%s.%s", typeName, member)
+		for _, c := range f.Comments {
+			renameInComment(c, map[string]string{qualified: fmt.Sprintf("%s.%s", typeName, alias)})
+		}
+	}
+}
+
+// aliasFields renames or hides the field with the provided name in the list.
+func aliasFields(fields *ast.FieldList, member, alias string) {
+	if fields == nil {
+		return
+	}
+
+	list := fields.List[:0]
+	for _, field := range fields.List {
+		named := len(field.Names) > 0
+		names := field.Names[:0]
+		for _, name := range field.Names {
+			if name.Name == member {
+				if alias == "" {
+					continue
+				}
+
+				name.Name = alias
+				renameInComment(field.Doc, map[string]string{member: alias})
+			}
+
+			names = append(names, name)
+		}
+
+		if named && len(names) == 0 {
+			continue
+		}
+
+		field.Names = names
+		list = append(list, field)
+	}
+
+	fields.List = list
+}
+
+// hideTopLevel removes the declarations of the top-level symbols with an empty
+// alias, along with the methods of hidden types.
+func hideTopLevel(f *ast.File, aliases map[string]string) {
+	hidden := func(name string) bool {
+		alias, ok := aliases[name]
+		return ok && alias == ""
+	}
+
+	decls := f.Decls[:0]
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil && hidden(d.Name.Name) || d.Recv != nil && hidden(recvBaseName(d.Recv)) {
+				continue
+			}
+		case *ast.GenDecl:
+			specs := d.Specs[:0]
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					if hidden(s.Name.Name) {
+						continue
+					}
+				case *ast.ValueSpec:
+					if !hideValues(s, hidden) {
+						continue
+					}
+				}
+
+				specs = append(specs, spec)
+			}
+
+			if len(specs) == 0 {
+				continue
+			}
+
+			d.Specs = specs
+		}
+
+		decls = append(decls, decl)
+	}
+
+	f.Decls = decls
+}
+
+// hideValues removes the hidden names from the value spec, returning false if
+// none of its names are left. Names are only removed individually if each has
+// its own value.
+func hideValues(spec *ast.ValueSpec, hidden func(string) bool) bool {
+	aligned := len(spec.Values) == len(spec.Names)
+
+	var (
+		names  []*ast.Ident
+		values []ast.Expr
+	)
+	for i, name := range spec.Names {
+		if hidden(name.Name) {
+			continue
+		}
+
+		names = append(names, name)
+		if aligned {
+			values = append(values, spec.Values[i])
+		}
+	}
+
+	if len(names) == 0 {
+		return false
+	}
+
+	if aligned {
+		spec.Names = names
+		spec.Values = values
+	}
+
+	return true
+}
+
+// renameTopLevel renames the top-level symbols in the file, along with the
+// references to them and their mentions in comments. Identifiers which refer to
+// something other than a top-level symbol, such as fields, methods and local
+// variables, are left as is.
+func renameTopLevel(f *ast.File, renames map[string]string) {
+	if len(renames) == 0 {
+		return
+	}
+
+	skip := make(map[*ast.Ident]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.SelectorExpr:
+			skip[node.Sel] = true
+		case *ast.KeyValueExpr:
+			if key, ok := node.Key.(*ast.Ident); ok {
+				skip[key] = true
+			}
+		case *ast.FuncDecl:
+			if node.Recv != nil {
+				skip[node.Name] = true
+			}
+		}
+
+		return true
+	})
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok || skip[ident] {
+			return true
+		}
+
+		alias, ok := renames[ident.Name]
+		if !ok {
+			return true
+		}
+
+		if ident.Obj == nil || ident.Obj == f.Scope.Lookup(ident.Name) {
+			ident.Name = alias
+		}
+
+		return true
+	})
+
+	for _, c := range f.Comments {
+		renameInComment(c, renames)
+	}
+}
+
+// renameInComment replaces the whole word mentions of each of the keys of the
+// provided renames in the comment's text with the corresponding value.
+func renameInComment(c *ast.CommentGroup, renames map[string]string) {
+	if c == nil || len(renames) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(renames))
+	for name := range renames {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+
+	// Longer names come first so they take precedence over names they contain
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+
+		return names[i] < names[j]
+	})
+
+	re := regexp.MustCompile(fmt.Sprintf(`\b(?:%s)\b`, strings.Join(names, "|")))
+	for _, comment := range c.List {
+		comment.Text = re.ReplaceAllStringFunc(comment.Text, func(name string) string {
+			return renames[name]
+		})
+	}
+}
+
+// recvBaseName provides the name of the type of the receiver, without any
+// pointer or type parameters.
+func recvBaseName(recv *ast.FieldList) string {
+	if len(recv.List) == 0 {
+		return ""
+	}
+
+	return baseTypeName(recv.List[0].Type)
+}
+
+func splitMemberAlias(key string) (typeName, member string, ok bool) {
+	i := strings.Index(key, ".")
+	if i < 0 {
+		return "", "", false
+	}
+
+	return key[:i], key[i+1:], true
+}
@@ -0,0 +1,74 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestPackageWithSymbolAliases(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/aliases")
+	is.NoErr(err)
+
+	pkg, err := lang.NewPackageFromBuild(
+		logger.New(logger.ErrorLevel),
+		buildPkg,
+		lang.PackageWithSymbolAliases(map[string]string{
+			"clientImpl":           "Client",
+			"newClientImpl":        "NewClient",
+			"clientImpl.doRequest": "Do",
+			"clientImpl.Secret":    "",
+			"clientImpl.Debug":     "",
+			"Legacy":               "",
+			"Hidden":               "",
+		}),
+	)
+	is.NoErr(err)
+
+	// Unexported symbols renamed to exported names are documented
+	is.Equal(len(pkg.Funcs()), 0)
+	is.Equal(len(pkg.Types()), 1)
+
+	typ := pkg.Types()[0]
+	is.Equal(typ.Name(), "Client")
+	is.Equal(typ.Title(), "type Client")
+	is.Equal(typ.Summary(), "Client sends requests.")
+
+	decl, err := typ.Decl()
+	is.NoErr(err)
+	is.Equal(decl, "type Client struct {\n    // Endpoint holds the address requests are sent to.\n    Endpoint string\n}")
+
+	is.Equal(len(typ.Funcs()), 1)
+	sig, err := typ.Funcs()[0].Signature()
+	is.NoErr(err)
+	is.Equal(sig, "func NewClient(endpoint string) *Client")
+
+	is.Equal(len(typ.Methods()), 1)
+	method := typ.Methods()[0]
+	is.Equal(method.Title(), "func (*Client) Do")
+	is.Equal(method.Summary(), "Do sends a request.")
+
+	consts := pkg.Consts()
+	is.Equal(len(consts), 1)
+	decl, err = consts[0].Decl()
+	is.NoErr(err)
+	is.Equal(decl, "const (\n    Visible = 1\n)")
+}
+
+func TestPackageWithSymbolAliases_invalid(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/aliases")
+	is.NoErr(err)
+
+	_, err = lang.NewPackageFromBuild(
+		logger.New(logger.ErrorLevel),
+		buildPkg,
+		lang.PackageWithSymbolAliases(map[string]string{"clientImpl": "not valid"}),
+	)
+	is.Equal(err.Error(), "gomarkdoc: invalid symbol alias clientImpl: not valid")
+}
@@ -0,0 +1,162 @@
+package lang
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type (
+	// Badge holds the information needed to render a single status badge
+	// image, typically in the header section of a package's documentation.
+	Badge struct {
+		kind  BadgeKind
+		text  string
+		image string
+		url   string
+	}
+
+	// BadgeKind identifies the type of information conveyed by a badge.
+	BadgeKind string
+)
+
+const (
+	// ReferenceBadge identifies a badge linking to the package's reference
+	// documentation on pkg.go.dev.
+	ReferenceBadge BadgeKind = "reference"
+
+	// VersionBadge identifies a badge showing the latest released version of
+	// the package's repository.
+	VersionBadge BadgeKind = "version"
+
+	// CoverageBadge identifies a badge showing the percentage of exported
+	// symbols in the package which have documentation comments.
+	CoverageBadge BadgeKind = "coverage"
+
+	// TargetBadge identifies a badge showing the build constraint which must
+	// be satisfied for a symbol to be available (e.g. js && wasm).
+	TargetBadge BadgeKind = "target"
+
+	// PlatformBadge identifies a badge listing the GOOS/GOARCH platforms a
+	// symbol is available on when it isn't available on all of the platforms
+	// its package is documented for.
+	PlatformBadge BadgeKind = "platform"
+
+	// DeprecatedBadge identifies a badge marking a symbol or package as
+	// deprecated by a "Deprecated:" paragraph in its documentation.
+	DeprecatedBadge BadgeKind = "deprecated"
+
+	// TestOnlyBadge identifies a badge marking a symbol as declared in a
+	// _test.go file, so that it is only available to tests.
+	TestOnlyBadge BadgeKind = "test"
+)
+
+// NewBadge creates a new badge of the provided kind with the given alt text,
+// image url and link target. The link target may be empty if the badge should
+// not link anywhere.
+func NewBadge(kind BadgeKind, text, image, url string) *Badge {
+	return &Badge{kind, text, image, url}
+}
+
+// Kind provides the kind of information conveyed by the badge.
+func (b *Badge) Kind() BadgeKind {
+	return b.kind
+}
+
+// Text provides the alternate text for the badge's image.
+func (b *Badge) Text() string {
+	return b.text
+}
+
+// Image provides the url of the badge's image.
+func (b *Badge) Image() string {
+	return b.image
+}
+
+// URL provides the url the badge links to, if any.
+func (b *Badge) URL() string {
+	return b.url
+}
+
+// Badges lists the standard badges for the package. Badges are only produced
+// when they have been enabled for the package, and only the badges whose urls
+// can be derived from the package's import path and repository are included.
+// A deprecated package always has a deprecated badge, even if the standard
+// badges are not enabled.
+func (pkg *Package) Badges() []*Badge {
+	var badges []*Badge
+	if pkg.IsDeprecated() {
+		badges = append(badges, deprecatedBadge())
+	}
+
+	if !pkg.cfg.Badges {
+		return badges
+	}
+
+	importPath := pkg.ImportPath()
+	if importPath != "" && importPath != "." {
+		badges = append(badges, NewBadge(
+			ReferenceBadge,
+			"Go Reference",
+			fmt.Sprintf("https://pkg.go.dev/badge/%s.svg", importPath),
+			fmt.Sprintf("https://pkg.go.dev/%s", importPath),
+		))
+	}
+
+	if repo := pkg.cfg.Repo; repo != nil {
+		if ownerRepo, ok := gitHubOwnerRepo(repo.Remote); ok {
+			badges = append(badges, NewBadge(
+				VersionBadge,
+				"Version",
+				fmt.Sprintf("https://img.shields.io/github/v/tag/%s?sort=semver", ownerRepo),
+				fmt.Sprintf("%s/tags", repo.Remote),
+			))
+		}
+	}
+
+	if stats := pkg.Stats(); stats.Total() > 0 {
+		percent := stats.Coverage()
+		badges = append(badges, NewBadge(
+			CoverageBadge,
+			"Docs Coverage",
+			fmt.Sprintf(
+				"https://img.shields.io/badge/%s-%s-%s",
+				url.PathEscape("docs coverage"),
+				url.PathEscape(fmt.Sprintf("%d%%", percent)),
+				coverageColor(percent),
+			),
+			"",
+		))
+	}
+
+	return badges
+}
+
+func coverageColor(percent int) string {
+	switch {
+	case percent >= 90:
+		return "brightgreen"
+	case percent >= 75:
+		return "green"
+	case percent >= 50:
+		return "yellow"
+	default:
+		return "red"
+	}
+}
+
+// gitHubOwnerRepo extracts the "owner/repo" portion of a normalized GitHub
+// remote url.
+func gitHubOwnerRepo(remote string) (string, bool) {
+	const prefix = "https://github.com/"
+	if !strings.HasPrefix(remote, prefix) {
+		return "", false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(remote, prefix), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", false
+	}
+
+	return strings.Join(parts, "/"), true
+}
@@ -0,0 +1,53 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestPackage_Badges(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/function")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(
+		log,
+		buildPkg,
+		lang.PackageWithBadges(),
+		lang.PackageWithOverrideImport("github.com/princjef/gomarkdoc/testData/lang/function"),
+		lang.PackageWithRepositoryOverrides(&lang.Repo{
+			Remote:        "https://github.com/princjef/gomarkdoc",
+			DefaultBranch: "master",
+			PathFromRoot:  "/",
+		}),
+	)
+	is.NoErr(err)
+
+	badges := pkg.Badges()
+	is.Equal(len(badges), 3)
+
+	is.Equal(badges[0].Kind(), lang.ReferenceBadge)
+	is.Equal(badges[0].Image(), "https://pkg.go.dev/badge/github.com/princjef/gomarkdoc/testData/lang/function.svg")
+	is.Equal(badges[0].URL(), "https://pkg.go.dev/github.com/princjef/gomarkdoc/testData/lang/function")
+
+	is.Equal(badges[1].Kind(), lang.VersionBadge)
+	is.Equal(badges[1].Image(), "https://img.shields.io/github/v/tag/princjef/gomarkdoc?sort=semver")
+	is.Equal(badges[1].URL(), "https://github.com/princjef/gomarkdoc/tags")
+
+	is.Equal(badges[2].Kind(), lang.CoverageBadge)
+	is.Equal(badges[2].URL(), "")
+}
+
+func TestPackage_Badges_disabled(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/lang/function")
+	is.NoErr(err)
+
+	is.Equal(len(pkg.Badges()), 0)
+}
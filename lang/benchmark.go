@@ -0,0 +1,110 @@
+package lang
+
+import (
+	"go/ast"
+	"go/printer"
+	"sort"
+	"strings"
+)
+
+// Benchmark holds a benchmark function declared in one of the _test.go files
+// of a package.
+type Benchmark struct {
+	cfg  *Config
+	file *ast.File
+	fn   *ast.FuncDecl
+}
+
+// Level provides the default level that headers for the benchmark should be
+// rendered.
+func (b *Benchmark) Level() int {
+	return b.cfg.Level
+}
+
+// Name provides the name of the benchmark function (e.g. BenchmarkLookup).
+func (b *Benchmark) Name() string {
+	return b.fn.Name.Name
+}
+
+// Location returns a representation of the node's location in a file within a
+// repository.
+func (b *Benchmark) Location() Location {
+	return NewLocation(b.cfg, b.fn)
+}
+
+// Summary provides the one-sentence summary of the benchmark's documentation
+// comment.
+func (b *Benchmark) Summary() string {
+	return extractSummary(b.fn.Doc.Text())
+}
+
+// Doc provides the structured contents of the documentation comment for the
+// benchmark.
+func (b *Benchmark) Doc() *Doc {
+	return NewDoc(b.cfg.Inc(1), b.fn.Doc.Text())
+}
+
+// Code provides the raw text code representation of the benchmark's body,
+// including its comments.
+func (b *Benchmark) Code() (string, error) {
+	var comments []*ast.CommentGroup
+	for _, c := range b.file.Comments {
+		if c.Pos() >= b.fn.Body.Pos() && c.End() <= b.fn.Body.End() {
+			comments = append(comments, c)
+		}
+	}
+
+	return printCode(b.cfg, &printer.CommentedNode{Node: b.fn.Body, Comments: comments})
+}
+
+// Benchmarks lists the benchmark functions declared in the _test.go files of
+// the package, sorted by name. The list is empty unless benchmarks have been
+// enabled for the package.
+func (pkg *Package) Benchmarks() (benchmarks []*Benchmark) {
+	if !pkg.cfg.Benchmarks {
+		return nil
+	}
+
+	for _, f := range pkg.cfg.Files {
+		if !strings.HasSuffix(pkg.cfg.FileSet.Position(f.Pos()).Filename, "_test.go") {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Body == nil || !isTestingFunc(fn, "Benchmark", "B") {
+				continue
+			}
+
+			benchmarks = append(benchmarks, &Benchmark{pkg.cfg.Inc(2), f, fn})
+		}
+	}
+
+	sort.Slice(benchmarks, func(i, j int) bool {
+		return benchmarks[i].Name() < benchmarks[j].Name()
+	})
+
+	return
+}
+
+// isTestingFunc checks whether the function is one run by go test with the
+// provided prefix (e.g. Benchmark), which takes a single parameter of the
+// provided type from the testing package (e.g. B).
+func isTestingFunc(fn *ast.FuncDecl, prefix, param string) bool {
+	if !strings.HasPrefix(fn.Name.Name, prefix) || !isTestFunc(fn.Name.Name) {
+		return false
+	}
+
+	params := fn.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) > 1 {
+		return false
+	}
+
+	star, ok := params[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+
+	sel, ok := star.X.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == param
+}
@@ -0,0 +1,45 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestPackage_Benchmarks(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/benchmarks")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithBenchmarks())
+	is.NoErr(err)
+
+	benchmarks := pkg.Benchmarks()
+	is.Equal(len(benchmarks), 2)
+
+	is.Equal(benchmarks[0].Name(), "BenchmarkGet")
+	is.Equal(benchmarks[0].Summary(), "BenchmarkGet measures lookups of a key which is present.")
+	is.Equal(benchmarks[0].Level(), pkg.Level()+2)
+
+	code, err := benchmarks[0].Code()
+	is.NoErr(err)
+	is.Equal(code, `
+c := Cache{"key": 1}
+
+for i := 0; i < b.N; i++ {
+	// Lookups don't allocate
+	c.Get("key")
+}
+`)
+
+	is.Equal(benchmarks[1].Name(), "BenchmarkGetMissing")
+	is.Equal(len(benchmarks[1].Doc().Blocks()), 0)
+
+	pkg, err = loadPackage("../testData/lang/benchmarks")
+	is.NoErr(err)
+	is.Equal(len(pkg.Benchmarks()), 0) // left out by default
+}
@@ -0,0 +1,277 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+type (
+	// BuildVar holds a package-level string variable of a main package which
+	// can be set when the command is linked with -ldflags "-X".
+	BuildVar struct {
+		cfg   *Config
+		name  string
+		spec  *ast.ValueSpec
+		value string
+		doc   string
+	}
+
+	// BuildDependency holds a package imported by a main package, along with
+	// the module which provides it.
+	BuildDependency struct {
+		importPath string
+		module     string
+		version    string
+		standard   bool
+	}
+)
+
+// Name provides the name of the variable.
+func (v *BuildVar) Name() string {
+	return v.name
+}
+
+// Target provides the symbol name passed to the -X linker flag to set the
+// variable (e.g. main.version).
+func (v *BuildVar) Target() string {
+	return fmt.Sprintf("main.%s", v.name)
+}
+
+// Flag provides the linker flag setting the variable to the provided value,
+// for use as part of -ldflags.
+func (v *BuildVar) Flag(value string) string {
+	return fmt.Sprintf("-X %s=%s", v.Target(), value)
+}
+
+// Default provides the value of the variable when it isn't set by the linker,
+// which is an empty string if it has no initial value.
+func (v *BuildVar) Default() string {
+	return v.value
+}
+
+// Location returns a representation of the node's location in a file within a
+// repository.
+func (v *BuildVar) Location() Location {
+	return NewLocation(v.cfg, v.spec)
+}
+
+// Summary provides the one-sentence summary of the variable's documentation
+// comment.
+func (v *BuildVar) Summary() string {
+	return extractSummary(v.doc)
+}
+
+// Doc provides the structured contents of the documentation comment for the
+// variable.
+func (v *BuildVar) Doc() *Doc {
+	return NewDoc(v.cfg.Inc(1), v.doc)
+}
+
+// ImportPath provides the import path of the package.
+func (d *BuildDependency) ImportPath() string {
+	return d.importPath
+}
+
+// Module provides the path of the module providing the package, which is empty
+// for packages of the standard library or of modules which aren't required by
+// the go.mod file.
+func (d *BuildDependency) Module() string {
+	return d.module
+}
+
+// Version provides the version of the module providing the package required by
+// the go.mod file. It is empty for packages of the main module.
+func (d *BuildDependency) Version() string {
+	return d.version
+}
+
+// IsStandard indicates whether the package is part of the standard library.
+func (d *BuildDependency) IsStandard() bool {
+	return d.standard
+}
+
+// BuildVars lists the package-level string variables of a main package which
+// can be set at link time with -ldflags "-X", in the order they are declared.
+// Only variables without an initial value or initialized to a string literal
+// are listed, since the linker can't replace any other value. The list is
+// empty unless build information has been enabled for the package.
+func (pkg *Package) BuildVars() (vars []*BuildVar) {
+	if !pkg.cfg.BuildInfo || pkg.doc.Name != "main" {
+		return nil
+	}
+
+	for _, f := range pkg.cfg.Files {
+		if !packageFile(pkg.doc, filepath.Base(pkg.cfg.FileSet.Position(f.Pos()).Filename)) {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+
+			for _, s := range gen.Specs {
+				spec := s.(*ast.ValueSpec)
+				values, ok := linkerValues(spec)
+				if !ok {
+					continue
+				}
+
+				doc := spec.Doc.Text()
+				if doc == "" && len(gen.Specs) == 1 {
+					doc = gen.Doc.Text()
+				}
+
+				for i, name := range spec.Names {
+					if name.Name == "_" {
+						continue
+					}
+
+					vars = append(vars, &BuildVar{pkg.cfg.Inc(2), name.Name, spec, values[i], doc})
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// BuildDependencies lists the packages imported by a main package, sorted by
+// import path, along with the modules providing them according to the go.mod
+// file of the main module. The list is empty unless build information has been
+// enabled for the package.
+func (pkg *Package) BuildDependencies() (deps []*BuildDependency) {
+	if !pkg.cfg.BuildInfo || pkg.doc.Name != "main" {
+		return nil
+	}
+
+	mod := loadModFile(pkg.cfg.PkgDir)
+
+	for _, importPath := range pkg.imports() {
+		dep := &BuildDependency{importPath: importPath}
+		if mod != nil && mod.Module != nil && pathInModule(importPath, mod.Module.Mod.Path) {
+			dep.module = mod.Module.Mod.Path
+		} else if mod != nil {
+			for _, req := range mod.Require {
+				if pathInModule(importPath, req.Mod.Path) && len(req.Mod.Path) > len(dep.module) {
+					dep.module = req.Mod.Path
+					dep.version = req.Mod.Version
+				}
+			}
+		}
+
+		// Packages of the standard library have no dot in their first element
+		first := strings.SplitN(importPath, "/", 2)[0]
+		dep.standard = dep.module == "" && !strings.Contains(first, ".")
+
+		deps = append(deps, dep)
+	}
+
+	return
+}
+
+// imports lists the packages imported by the files of the package other than
+// "C", sorted by import path. The imports are found in the files themselves
+// since they may be filtered out of the documentation along with unexported
+// symbols.
+func (pkg *Package) imports() []string {
+	seen := make(map[string]bool)
+	for _, f := range pkg.cfg.Files {
+		if !packageFile(pkg.doc, filepath.Base(pkg.cfg.FileSet.Position(f.Pos()).Filename)) {
+			continue
+		}
+
+		for _, spec := range f.Imports {
+			p, err := strconv.Unquote(spec.Path.Value)
+			if err != nil || p == "C" {
+				continue
+			}
+
+			seen[p] = true
+		}
+	}
+
+	imports := make([]string, 0, len(seen))
+	for p := range seen {
+		imports = append(imports, p)
+	}
+
+	sort.Strings(imports)
+	return imports
+}
+
+// linkerValues provides the initial values of the variables of the spec if
+// they can be set by the linker: their type must be string and they must be
+// uninitialized or initialized to string literals.
+func linkerValues(spec *ast.ValueSpec) ([]string, bool) {
+	if spec.Type != nil {
+		if ident, ok := spec.Type.(*ast.Ident); !ok || ident.Name != "string" {
+			return nil, false
+		}
+	}
+
+	values := make([]string, len(spec.Names))
+	if len(spec.Values) == 0 {
+		return values, spec.Type != nil
+	}
+
+	if len(spec.Values) != len(spec.Names) {
+		return nil, false
+	}
+
+	for i, v := range spec.Values {
+		lit, ok := v.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return nil, false
+		}
+
+		s, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return nil, false
+		}
+
+		values[i] = s
+	}
+
+	return values, true
+}
+
+// loadModFile parses the go.mod file of the module containing the directory,
+// or returns nil if there isn't one.
+func loadModFile(dir string) *modfile.File {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil
+	}
+
+	f, ok := findFileInParent(absDir, "go.mod", false)
+	if !ok {
+		return nil
+	}
+	defer f.Close()
+
+	b, err := ioutil.ReadAll(f)
+	if err != nil {
+		return nil
+	}
+
+	mod, err := modfile.ParseLax(f.Name(), b, nil)
+	if err != nil {
+		return nil
+	}
+
+	return mod
+}
+
+func pathInModule(importPath, modPath string) bool {
+	return importPath == modPath || strings.HasPrefix(importPath, modPath+"/")
+}
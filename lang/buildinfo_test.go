@@ -0,0 +1,68 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestPackage_BuildVars(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadBuildInfoPackage(lang.PackageWithBuildInfo())
+	is.NoErr(err)
+
+	vars := pkg.BuildVars()
+	is.Equal(len(vars), 4) // count and started can't be set by the linker
+
+	is.Equal(vars[0].Name(), "version")
+	is.Equal(vars[0].Target(), "main.version")
+	is.Equal(vars[0].Flag("v1.0.0"), "-X main.version=v1.0.0")
+	is.Equal(vars[0].Default(), "dev")
+	is.Equal(vars[0].Summary(), "version is the released version of the command.")
+
+	is.Equal(vars[1].Name(), "commit")
+	is.Equal(vars[1].Default(), "")
+	is.Equal(vars[1].Summary(), "commit is the commit the command was built from.")
+
+	is.Equal(vars[3].Name(), "builtBy")
+	is.Equal(vars[3].Default(), "unknown")
+
+	pkg, err = loadBuildInfoPackage()
+	is.NoErr(err)
+	is.Equal(len(pkg.BuildVars()), 0)
+}
+
+func TestPackage_BuildDependencies(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadBuildInfoPackage(lang.PackageWithBuildInfo())
+	is.NoErr(err)
+
+	deps := pkg.BuildDependencies()
+	is.Equal(len(deps), 3)
+
+	is.Equal(deps[0].ImportPath(), "fmt")
+	is.True(deps[0].IsStandard())
+
+	is.Equal(deps[1].ImportPath(), "github.com/anthonyme00/gomarkdoc/testData/simple")
+	is.Equal(deps[1].Module(), "github.com/anthonyme00/gomarkdoc")
+	is.Equal(deps[1].Version(), "")
+	is.True(!deps[1].IsStandard())
+
+	is.Equal(deps[2].ImportPath(), "github.com/spf13/cobra")
+	is.Equal(deps[2].Module(), "github.com/spf13/cobra")
+	is.Equal(deps[2].Version(), "v1.7.0")
+}
+
+func loadBuildInfoPackage(opts ...lang.PackageOption) (*lang.Package, error) {
+	buildPkg, err := getBuildPackage("../testData/lang/buildinfo")
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.New(logger.ErrorLevel)
+	return lang.NewPackageFromBuild(log, buildPkg, opts...)
+}
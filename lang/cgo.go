@@ -0,0 +1,304 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+type (
+	// CExport holds documentation for a Go function which is exported to C
+	// consumers with a cgo //export directive.
+	CExport struct {
+		cfg  *Config
+		name string
+		decl *ast.FuncDecl
+		doc  string
+	}
+
+	// CDecl holds a documented C declaration from the preamble of a cgo file.
+	CDecl struct {
+		cfg  *Config
+		decl string
+		doc  string
+	}
+)
+
+// NewCExport creates a new CExport from the name it is exported to C with, the
+// Go declaration of the function and its documentation comment. Directives such
+// as //export are expected to have been removed from the documentation.
+func NewCExport(cfg *Config, name string, decl *ast.FuncDecl, doc string) *CExport {
+	return &CExport{cfg, name, decl, doc}
+}
+
+// Level provides the default level that headers for the export should be
+// rendered.
+func (e *CExport) Level() int {
+	return e.cfg.Level
+}
+
+// Name provides the name of the function as it is visible from C.
+func (e *CExport) Name() string {
+	return e.name
+}
+
+// Location returns a representation of the node's location in a file within a
+// repository.
+func (e *CExport) Location() Location {
+	return NewLocation(e.cfg, e.decl)
+}
+
+// Summary provides the one-sentence summary of the export's documentation
+// comment.
+func (e *CExport) Summary() string {
+	return extractSummary(e.doc)
+}
+
+// Doc provides the structured contents of the documentation comment for the
+// export.
+func (e *CExport) Doc() *Doc {
+	return NewDoc(e.cfg.Inc(1), e.doc)
+}
+
+// Signature provides the raw text representation of the Go signature of the
+// exported function.
+func (e *CExport) Signature() (string, error) {
+	decl := *e.decl
+	decl.Doc = nil
+	decl.Body = nil
+
+	return printNode(&decl, token.NewFileSet())
+}
+
+// Anchor produces anchor text for the export.
+func (e *CExport) Anchor() string {
+	return fmt.Sprintf("C.%s", e.name)
+}
+
+// NewCDecl creates a new CDecl from the text of a C declaration and its
+// documentation comment.
+func NewCDecl(cfg *Config, decl, doc string) *CDecl {
+	return &CDecl{cfg, decl, doc}
+}
+
+// Level provides the default level that headers for the declaration should be
+// rendered.
+func (d *CDecl) Level() int {
+	return d.cfg.Level
+}
+
+// Decl provides the raw text of the C declaration.
+func (d *CDecl) Decl() string {
+	return d.decl
+}
+
+// Summary provides the one-sentence summary of the declaration's
+// documentation comment.
+func (d *CDecl) Summary() string {
+	return extractSummary(d.doc)
+}
+
+// Doc provides the structured contents of the documentation comment for the
+// declaration.
+func (d *CDecl) Doc() *Doc {
+	return NewDoc(d.cfg.Inc(1), d.doc)
+}
+
+// CExports lists the functions of the package which are exported to C with a
+// cgo //export directive. Exports are only listed if the C API section has been
+// enabled for the package.
+func (pkg *Package) CExports() (exports []*CExport) {
+	if !pkg.cfg.CAPI {
+		return nil
+	}
+
+	for _, f := range pkg.cgoFiles() {
+		for _, d := range f.Decls {
+			fn, ok := d.(*ast.FuncDecl)
+			if !ok || fn.Doc == nil {
+				continue
+			}
+
+			var name string
+			for _, c := range fn.Doc.List {
+				if n, ok := exportDirective(c.Text); ok {
+					name = n
+					break
+				}
+			}
+
+			if name == "" {
+				continue
+			}
+
+			exports = append(exports, NewCExport(pkg.cfg.Inc(2), name, fn, fn.Doc.Text()))
+		}
+	}
+
+	return
+}
+
+// CDecls lists the documented declarations from the C preambles of the
+// package's cgo files. Declarations are only listed if the C API section has
+// been enabled for the package.
+func (pkg *Package) CDecls() (decls []*CDecl) {
+	if !pkg.cfg.CAPI {
+		return nil
+	}
+
+	for _, f := range pkg.cgoFiles() {
+		for _, d := range f.Decls {
+			gen, ok := d.(*ast.GenDecl)
+			if !ok || gen.Tok != token.IMPORT {
+				continue
+			}
+
+			for _, s := range gen.Specs {
+				spec := s.(*ast.ImportSpec)
+				if p, _ := strconv.Unquote(spec.Path.Value); p != "C" {
+					continue
+				}
+
+				preamble := spec.Doc
+				if preamble == nil {
+					preamble = gen.Doc
+				}
+
+				if preamble == nil {
+					continue
+				}
+
+				for _, cd := range parsePreamble(preamble.Text()) {
+					decls = append(decls, NewCDecl(pkg.cfg.Inc(2), cd[0], cd[1]))
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// cgoFiles provides the non-test files of the package which import "C".
+func (pkg *Package) cgoFiles() (files []*ast.File) {
+	for _, f := range pkg.cfg.Files {
+		if f.Name.Name != pkg.doc.Name {
+			continue
+		}
+
+		if strings.HasSuffix(pkg.cfg.FileSet.Position(f.Pos()).Filename, "_test.go") {
+			continue
+		}
+
+		for _, imp := range f.Imports {
+			if p, _ := strconv.Unquote(imp.Path.Value); p == "C" {
+				files = append(files, f)
+				break
+			}
+		}
+	}
+
+	return
+}
+
+// exportDirective identifies whether the comment is a cgo //export directive
+// and returns the exported name if so.
+func exportDirective(comment string) (string, bool) {
+	if !strings.HasPrefix(comment, "//export ") {
+		return "", false
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(comment, "//export "))
+	if len(fields) == 0 {
+		return "", false
+	}
+
+	return fields[0], true
+}
+
+// parsePreamble finds the documented declarations in a cgo preamble. Each
+// result holds the declaration text followed by its documentation. Only
+// declarations immediately preceded by a comment are included, and the bodies
+// of function definitions are left out.
+func parsePreamble(preamble string) (res [][2]string) {
+	var (
+		doc      []string
+		decl     []string
+		depth    int
+		inBlock  bool
+		skipping bool
+	)
+
+	for _, line := range strings.Split(preamble, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case skipping:
+			// Skip over the remainder of a function body
+			depth += strings.Count(line, "{") - strings.Count(line, "}")
+			skipping = depth > 0
+			continue
+		case inBlock:
+			if idx := strings.Index(trimmed, "*/"); idx != -1 {
+				trimmed = trimmed[:idx]
+				inBlock = false
+			}
+
+			doc = append(doc, strings.TrimSpace(strings.TrimPrefix(trimmed, "*")))
+			continue
+		case len(decl) == 0 && strings.HasPrefix(trimmed, "//"):
+			doc = append(doc, strings.TrimSpace(strings.TrimPrefix(trimmed, "//")))
+			continue
+		case len(decl) == 0 && strings.HasPrefix(trimmed, "/*"):
+			trimmed = strings.TrimPrefix(trimmed, "/*")
+			if idx := strings.Index(trimmed, "*/"); idx != -1 {
+				trimmed = trimmed[:idx]
+			} else {
+				inBlock = true
+			}
+
+			doc = append(doc, strings.TrimSpace(strings.TrimPrefix(trimmed, "*")))
+			continue
+		case len(decl) == 0 && (trimmed == "" || strings.HasPrefix(trimmed, "#")):
+			// Blank lines and preprocessor directives separate comments from
+			// the declarations that follow them
+			doc = nil
+			continue
+		}
+
+		// Function definitions end the declaration at the opening brace of the
+		// body, which is then skipped
+		if idx := strings.Index(line, "{"); idx != -1 && depth == 0 && isFuncDecl(strings.Join(decl, "\n")+line[:idx]) {
+			decl = append(decl, strings.TrimRight(line[:idx], " \t")+";")
+			depth = strings.Count(line[idx:], "{") - strings.Count(line[idx:], "}")
+			skipping = depth > 0
+		} else {
+			decl = append(decl, line)
+			depth += strings.Count(line, "{") - strings.Count(line, "}")
+			if depth > 0 || !strings.HasSuffix(trimmed, ";") {
+				continue
+			}
+		}
+
+		if len(doc) > 0 {
+			res = append(res, [2]string{
+				strings.TrimSpace(strings.Join(decl, "\n")),
+				strings.TrimSpace(strings.Join(doc, "\n")),
+			})
+		}
+
+		doc = nil
+		decl = nil
+	}
+
+	return
+}
+
+// isFuncDecl identifies whether the C code leading up to an opening brace is
+// the signature of a function definition rather than an aggregate type or
+// initializer.
+func isFuncDecl(code string) bool {
+	code = strings.TrimSpace(code)
+	return strings.HasSuffix(code, ")") && !strings.Contains(code, "=")
+}
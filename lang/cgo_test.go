@@ -0,0 +1,68 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestPackage_CExports(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadCgoPackage()
+	is.NoErr(err)
+
+	exports := pkg.CExports()
+	is.Equal(len(exports), 2)
+
+	is.Equal(exports[0].Name(), "Add")
+	is.Equal(exports[0].Anchor(), "C.Add")
+	is.Equal(exports[0].Level(), 3)
+	is.Equal(exports[0].Summary(), "Add sums two integers.")
+	is.Equal(len(exports[0].Doc().Blocks()), 2)
+
+	sig, err := exports[0].Signature()
+	is.NoErr(err)
+	is.Equal(sig, "func Add(a, b C.int) C.int")
+
+	is.Equal(exports[1].Name(), "Origin")
+	is.Equal(len(exports[1].Doc().Blocks()), 0)
+}
+
+func TestPackage_CDecls(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadCgoPackage()
+	is.NoErr(err)
+
+	decls := pkg.CDecls()
+	is.Equal(len(decls), 2)
+
+	is.Equal(decls[0].Decl(), "typedef struct {\n\tint32_t x;\n\tint32_t y;\n} Point;")
+	is.Equal(decls[0].Summary(), "Point is a location on a two-dimensional grid.")
+
+	is.Equal(decls[1].Decl(), "static inline int64_t distance(Point a, Point b);")
+	is.Equal(decls[1].Summary(), "distance computes the squared distance between two points.")
+}
+
+func TestPackage_CAPI_disabled(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/lang/cgo")
+	is.NoErr(err)
+
+	is.Equal(len(pkg.CExports()), 0)
+	is.Equal(len(pkg.CDecls()), 0)
+}
+
+func loadCgoPackage() (*lang.Package, error) {
+	buildPkg, err := getBuildPackage("../testData/lang/cgo")
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.New(logger.ErrorLevel)
+	return lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithCAPI())
+}
@@ -0,0 +1,186 @@
+package lang
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// mermaidEscaper replaces the characters with special meaning in the members
+// of a Mermaid class diagram with their entity codes.
+var mermaidEscaper = strings.NewReplacer(
+	"{", "#123;",
+	"}", "#125;",
+	";", "#59;",
+	"~", "#126;",
+)
+
+// ClassDiagram provides the source of a Mermaid class diagram of the exported
+// types documented for the package, listing their exported fields and methods,
+// the types they embed and the interfaces of the package they implement.
+// Interfaces are implemented by a type when either the type or a pointer to it
+// implements them. An empty string is returned unless class diagrams are
+// enabled for the package, type information is available for it and it has
+// exported types.
+func (pkg *Package) ClassDiagram() string {
+	cfg := pkg.cfg
+	if !cfg.ClassDiagram || cfg.Types == nil {
+		return ""
+	}
+
+	var named []*types.Named
+	for _, typ := range pkg.doc.Types {
+		if !token.IsExported(typ.Name) {
+			continue
+		}
+
+		obj, ok := cfg.Types.Scope().Lookup(typ.Name).(*types.TypeName)
+		if !ok || obj.IsAlias() {
+			continue
+		}
+
+		if t, ok := obj.Type().(*types.Named); ok {
+			named = append(named, t)
+		}
+	}
+
+	if len(named) == 0 {
+		return ""
+	}
+
+	documented := make(map[*types.TypeName]bool)
+	for _, t := range named {
+		documented[t.Obj()] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("classDiagram\n")
+
+	var relations []string
+	for _, t := range named {
+		writeMermaidClass(cfg, &b, t)
+
+		for _, embedded := range embeddedTypes(t) {
+			if documented[embedded.Obj()] {
+				relations = append(relations, fmt.Sprintf("%s <|-- %s", embedded.Obj().Name(), t.Obj().Name()))
+			}
+		}
+	}
+
+	for _, iface := range named {
+		it, ok := iface.Underlying().(*types.Interface)
+		if !ok || it.Empty() || !it.IsMethodSet() || iface.TypeParams().Len() > 0 {
+			continue
+		}
+
+		for _, t := range named {
+			if types.IsInterface(t) || t.TypeParams().Len() > 0 {
+				continue
+			}
+
+			if types.Implements(t, it) || types.Implements(types.NewPointer(t), it) {
+				relations = append(relations, fmt.Sprintf("%s <|.. %s", iface.Obj().Name(), t.Obj().Name()))
+			}
+		}
+	}
+
+	for _, rel := range relations {
+		fmt.Fprintf(&b, "    %s\n", rel)
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// writeMermaidClass writes the declaration of the type as a class of a Mermaid
+// class diagram, with its exported fields and the exported methods declared for
+// it. Interfaces are annotated as such and list their own methods.
+func writeMermaidClass(cfg *Config, b *strings.Builder, t *types.Named) {
+	qualifier := packageQualifier(cfg)
+
+	name := t.Obj().Name()
+	if params := t.TypeParams(); params.Len() > 0 {
+		names := make([]string, params.Len())
+		for i := range names {
+			names[i] = params.At(i).Obj().Name()
+		}
+
+		// Mermaid writes generic types with tildes (e.g. Set~T~)
+		name = fmt.Sprintf("%s~%s~", name, strings.Join(names, ","))
+	}
+
+	var members []string
+	switch u := t.Underlying().(type) {
+	case *types.Interface:
+		members = append(members, "<<interface>>")
+		for i := 0; i < u.NumExplicitMethods(); i++ {
+			if m := u.ExplicitMethod(i); m.Exported() {
+				members = append(members, mermaidMethod(m, qualifier))
+			}
+		}
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			f := u.Field(i)
+			if f.Exported() && !f.Embedded() {
+				members = append(members, fmt.Sprintf("+%s %s", f.Name(), mermaidEscaper.Replace(types.TypeString(f.Type(), qualifier))))
+			}
+		}
+	}
+
+	if !types.IsInterface(t) {
+		for i := 0; i < t.NumMethods(); i++ {
+			if m := t.Method(i); m.Exported() {
+				members = append(members, mermaidMethod(m, qualifier))
+			}
+		}
+	}
+
+	if len(members) == 0 {
+		fmt.Fprintf(b, "    class %s\n", name)
+		return
+	}
+
+	fmt.Fprintf(b, "    class %s {\n", name)
+	for _, m := range members {
+		fmt.Fprintf(b, "        %s\n", m)
+	}
+
+	b.WriteString("    }\n")
+}
+
+// mermaidMethod formats the method as a member of a Mermaid class, with its
+// results following its parameters (e.g. "+Read(p []byte) (n int, err error)").
+func mermaidMethod(m *types.Func, qualifier types.Qualifier) string {
+	sig := strings.TrimPrefix(types.TypeString(m.Type(), qualifier), "func")
+	return fmt.Sprintf("+%s%s", m.Name(), mermaidEscaper.Replace(sig))
+}
+
+// embeddedTypes provides the named types embedded in the struct or interface
+// type, without any pointer indirection.
+func embeddedTypes(t *types.Named) []*types.Named {
+	var embedded []*types.Named
+	add := func(e types.Type) {
+		if ptr, ok := e.(*types.Pointer); ok {
+			e = ptr.Elem()
+		}
+
+		if n, ok := e.(*types.Named); ok {
+			embedded = append(embedded, n.Origin())
+		}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			if f := u.Field(i); f.Embedded() {
+				add(f.Type())
+			}
+		}
+	case *types.Interface:
+		for i := 0; i < u.NumEmbeddeds(); i++ {
+			add(u.EmbeddedType(i))
+		}
+	}
+
+	return embedded
+}
@@ -7,7 +7,9 @@ import (
 	"go/doc"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"io"
+	"io/fs"
 	"io/ioutil"
 	"os"
 	"path"
@@ -23,25 +25,71 @@ type (
 	// Config defines contextual information used to resolve documentation for
 	// a construct.
 	Config struct {
-		FileSet        *token.FileSet
-		Files          []*ast.File
-		Level          int
-		Repo           *Repo
-		PkgDir         string
-		WorkDir        string
-		Symbols        map[string]Symbol
-		Pkg            *doc.Package
-		Log            logger.Logger
-		FileFilter     *string
-		OverrideImport *string
+		FileSet         *token.FileSet
+		Files           []*ast.File
+		Imports         []string
+		FS              fs.FS
+		Level           int
+		Repo            *Repo
+		PkgDir          string
+		WorkDir         string
+		Symbols         map[string]Symbol
+		Pkg             *doc.Package
+		Log             logger.Logger
+		FileFilter      *string
+		OverrideImport  *string
+		Badges          bool
+		WarnInternal    bool
+		ExampleTitles   ExampleTitleStyle
+		ExampleOrder    ExampleOrder
+		CAPI            bool
+		BuildTargets    bool
+		Translations    map[string]string
+		StringCatalog   *StringCatalog
+		ImportURLs      *ImportURLResolver
+		Playground      PlaygroundSharer
+		FlattenEmbedded bool
+		PromotedMethods bool
+		SymbolOrder     SymbolOrder
+		Usage           map[string]int
+		ExampleOutputs  map[*doc.Example]string
+		Inherited       map[*doc.Func]string
+		SymbolIndex     *SymbolIndex
+		Implementers    *ImplementerIndex
+		Implemented     *ImplementerIndex
+		Importers       *ImporterIndex
+		OutputFile      string
+		Routes          map[Section]string
+		Section         Section
+		SymbolSections  map[string]Section
+		HideDeprecated  bool
+		TypeLinks       bool
+		IndexFields     bool
+		BuildInfo       bool
+		Benchmarks      bool
+		FuzzTargets     bool
+		ClassDiagram    bool
+		IotaValues      bool
+		ComputedValues  bool
+		FuncBodies      bool
+		PinCommit       bool
+		Platforms       []string
+		SymbolPlatforms map[string][]string
+		SourceImporter  *SourceImporter
+		Types           *types.Package
+		TypesInfo       *types.Info
 	}
 
 	// Repo represents information about a repository relevant to documentation
-	// generation.
+	// generation. The Forge identifies the software hosting the repository
+	// when it can't be told from the remote, such as for self-hosted Gitea
+	// instances.
 	Repo struct {
 		Remote        string
 		DefaultBranch string
 		PathFromRoot  string
+		Commit        string
+		Forge         string
 	}
 
 	// Location holds information for identifying a position within a file and
@@ -64,15 +112,21 @@ type (
 	ConfigOption func(c *Config) error
 )
 
+// GiteaForge is the Forge of repositories hosted on Gitea or Forgejo, whose
+// source links differ from GitHub's. Repositories on Codeberg and gitea.com are
+// detected as such without it.
+const GiteaForge = "gitea"
+
 // NewConfig generates a Config for the provided package directory. It will
 // resolve the filepath and attempt to determine the repository containing the
 // directory. If no repository is found, the Repo field will be set to nil. An
 // error is returned if the provided directory is invalid.
 func NewConfig(log logger.Logger, workDir string, pkgDir string, opts ...ConfigOption) (*Config, error) {
 	cfg := &Config{
-		FileSet: token.NewFileSet(),
-		Level:   1,
-		Log:     log,
+		FileSet:   token.NewFileSet(),
+		Level:     1,
+		Log:       log,
+		TypeLinks: true,
 	}
 
 	for _, opt := range opts {
@@ -93,13 +147,18 @@ func NewConfig(log logger.Logger, workDir string, pkgDir string, opts ...ConfigO
 		return nil, err
 	}
 
-	files, err := parsePkgFiles(pkgDir, cfg.FileSet)
+	files, err := parsePkgFiles(cfg.FS, pkgDir, cfg.FileSet)
 	if err != nil {
 		return nil, err
 	}
 
 	cfg.Files = files
 
+	// Packages from other file systems have no local repository
+	if cfg.FS != nil {
+		return cfg, nil
+	}
+
 	if cfg.Repo == nil || cfg.Repo.Remote == "" || cfg.Repo.DefaultBranch == "" || cfg.Repo.PathFromRoot == "" {
 		repo, err := getRepoForDir(log, cfg.WorkDir, cfg.PkgDir, cfg.Repo)
 		if err != nil {
@@ -119,21 +178,60 @@ func NewConfig(log logger.Logger, workDir string, pkgDir string, opts ...ConfigO
 		log.Debugf("skipping repository resolution because all values have manual overrides")
 	}
 
+	if cfg.PinCommit && cfg.Repo != nil && cfg.Repo.Commit == "" {
+		commit, err := getHeadCommit(cfg.PkgDir)
+		if err != nil {
+			log.Infof("unable to pin source links to a commit due to error: %s", err)
+			return cfg, nil
+		}
+
+		log.Debugf("pinned source links to commit %s", commit)
+
+		// The repository may be shared with other packages through the
+		// overrides, so it is copied
+		repo := *cfg.Repo
+		repo.Commit = commit
+		cfg.Repo = &repo
+	}
+
 	return cfg, nil
 }
 
+// Ref provides the git reference that links to the repository's source code
+// point to, which is the commit they are pinned to if there is one, or the
+// default branch otherwise.
+func (r *Repo) Ref() string {
+	if r.Commit != "" {
+		return r.Commit
+	}
+
+	return r.DefaultBranch
+}
+
 // Inc copies the Config and increments the level by the provided step.
 func (c *Config) Inc(step int) *Config {
-	return &Config{
-		FileSet: c.FileSet,
-		Files:   c.Files,
-		Level:   c.Level + step,
-		PkgDir:  c.PkgDir,
-		WorkDir: c.WorkDir,
-		Repo:    c.Repo,
-		Symbols: c.Symbols,
-		Pkg:     c.Pkg,
-		Log:     c.Log,
+	cfg := *c
+	cfg.Level += step
+	return &cfg
+}
+
+// ConfigWithFS defines the file system the files of the package are read from
+// in place of the local file system.
+func ConfigWithFS(fsys fs.FS) ConfigOption {
+	return func(c *Config) error {
+		c.FS = fsys
+		return nil
+	}
+}
+
+// ConfigWithPinnedCommit defines whether links to the source code of the
+// package should point to the commit checked out in its repository rather than
+// to the repository's default branch, so that they keep pointing to the same
+// lines as the branch moves on.
+func ConfigWithPinnedCommit(enabled bool) ConfigOption {
+	return func(c *Config) error {
+		c.PinCommit = enabled
+		return nil
 	}
 }
 
@@ -156,6 +254,10 @@ func ConfigWithRepoOverrides(overrides *Repo) ConfigOption {
 			overrides.PathFromRoot = unslashed
 		}
 
+		if overrides.Forge != "" && overrides.Forge != GiteaForge {
+			return fmt.Errorf("provided repository forge %s is not supported, expected %s", overrides.Forge, GiteaForge)
+		}
+
 		c.Repo = overrides
 		return nil
 	}
@@ -175,6 +277,282 @@ func ConfigWithOverrideImport(importPath *string) ConfigOption {
 	}
 }
 
+// ConfigWithBadges defines whether the standard badges should be generated for
+// the package's documentation.
+func ConfigWithBadges(enabled bool) ConfigOption {
+	return func(c *Config) error {
+		c.Badges = enabled
+		return nil
+	}
+}
+
+// ConfigWithInternalWarning defines whether internal packages should be
+// documented with a warning that they cannot be imported from outside of their
+// parent tree.
+func ConfigWithInternalWarning(enabled bool) ConfigOption {
+	return func(c *Config) error {
+		c.WarnInternal = enabled
+		return nil
+	}
+}
+
+// ConfigWithExampleTitles defines the style used for the titles of examples.
+func ConfigWithExampleTitles(style ExampleTitleStyle) ConfigOption {
+	return func(c *Config) error {
+		c.ExampleTitles = style
+		return nil
+	}
+}
+
+// ConfigWithExampleOrder defines the order in which examples are listed.
+func ConfigWithExampleOrder(order ExampleOrder) ConfigOption {
+	return func(c *Config) error {
+		c.ExampleOrder = order
+		return nil
+	}
+}
+
+// ConfigWithCAPI defines whether the symbols a cgo package exposes to C should
+// be documented in a separate C API section.
+func ConfigWithCAPI(enabled bool) ConfigOption {
+	return func(c *Config) error {
+		c.CAPI = enabled
+		return nil
+	}
+}
+
+// ConfigWithBuildTargets defines whether symbols declared in files with build
+// constraints should be annotated with the targets they are available for.
+func ConfigWithBuildTargets(enabled bool) ConfigOption {
+	return func(c *Config) error {
+		c.BuildTargets = enabled
+		return nil
+	}
+}
+
+// ConfigWithPlatforms defines the GOOS/GOARCH platforms (e.g. linux/amd64) the
+// package is documented for, so that symbols which are only available on some
+// of them can be marked with their platforms.
+func ConfigWithPlatforms(platforms []string) ConfigOption {
+	return func(c *Config) error {
+		c.Platforms = platforms
+		return nil
+	}
+}
+
+// ConfigWithPromotedMethods defines whether the methods promoted to types from
+// the types they embed should be documented.
+func ConfigWithPromotedMethods(enabled bool) ConfigOption {
+	return func(c *Config) error {
+		c.PromotedMethods = enabled
+		return nil
+	}
+}
+
+// ConfigWithFlattenedEmbedding defines whether the fields promoted to struct
+// types from the structs they embed should be listed along with the type.
+func ConfigWithFlattenedEmbedding(enabled bool) ConfigOption {
+	return func(c *Config) error {
+		c.FlattenEmbedded = enabled
+		return nil
+	}
+}
+
+// ConfigWithSymbolOrder defines the order in which the functions and types of
+// the package are listed.
+func ConfigWithSymbolOrder(order SymbolOrder) ConfigOption {
+	return func(c *Config) error {
+		c.SymbolOrder = order
+		return nil
+	}
+}
+
+// ConfigWithSymbolIndex defines the index of the packages documented together
+// and the file the package's documentation is written to, which are used to
+// link to the documentation of the other packages in the index.
+func ConfigWithSymbolIndex(idx *SymbolIndex, outputFile string) ConfigOption {
+	return func(c *Config) error {
+		c.SymbolIndex = idx
+		c.OutputFile = outputFile
+		return nil
+	}
+}
+
+// ConfigWithImplementerIndex defines the index used to find the types
+// implementing the package's interfaces within its module.
+func ConfigWithImplementerIndex(idx *ImplementerIndex) ConfigOption {
+	return func(c *Config) error {
+		c.Implementers = idx
+		return nil
+	}
+}
+
+// ConfigWithSourceImporter defines the importer used to type check the
+// packages imported by the package.
+func ConfigWithSourceImporter(imp *SourceImporter) ConfigOption {
+	return func(c *Config) error {
+		c.SourceImporter = imp
+		return nil
+	}
+}
+
+// ConfigWithImporterIndex defines the index used to find the packages
+// documented along with the package which import it.
+func ConfigWithImporterIndex(idx *ImporterIndex) ConfigOption {
+	return func(c *Config) error {
+		c.Importers = idx
+		return nil
+	}
+}
+
+// ConfigWithImplementedInterfaces defines the index used to find the
+// interfaces within the package's module implemented by its types.
+func ConfigWithImplementedInterfaces(idx *ImplementerIndex) ConfigOption {
+	return func(c *Config) error {
+		c.Implemented = idx
+		return nil
+	}
+}
+
+// ConfigWithRoutes defines the files the sections of the package's
+// documentation are written to instead of the package's own documentation
+// file.
+func ConfigWithRoutes(routes map[Section]string) ConfigOption {
+	return func(c *Config) error {
+		c.Routes = routes
+		return nil
+	}
+}
+
+// ConfigWithDeprecatedHidden defines whether the deprecated functions and
+// types of the package are left out of its documentation.
+func ConfigWithDeprecatedHidden(hidden bool) ConfigOption {
+	return func(c *Config) error {
+		c.HideDeprecated = hidden
+		return nil
+	}
+}
+
+// ConfigWithTypeLinks defines whether the types from other packages referenced
+// by the signatures and declarations of the package are linked to their
+// documentation. They are linked by default.
+func ConfigWithTypeLinks(enabled bool) ConfigOption {
+	return func(c *Config) error {
+		c.TypeLinks = enabled
+		return nil
+	}
+}
+
+// ConfigWithIndexedFields defines whether the documented fields of struct
+// types are listed under their types in the index and table of contents.
+func ConfigWithIndexedFields(indexed bool) ConfigOption {
+	return func(c *Config) error {
+		c.IndexFields = indexed
+		return nil
+	}
+}
+
+// ConfigWithBenchmarks defines whether the benchmark functions of the package
+// should be documented.
+func ConfigWithBenchmarks(enabled bool) ConfigOption {
+	return func(c *Config) error {
+		c.Benchmarks = enabled
+		return nil
+	}
+}
+
+// ConfigWithFuzzTargets defines whether the fuzz targets of the package should
+// be documented.
+func ConfigWithFuzzTargets(enabled bool) ConfigOption {
+	return func(c *Config) error {
+		c.FuzzTargets = enabled
+		return nil
+	}
+}
+
+// ConfigWithClassDiagram defines whether a class diagram of the package's types
+// should be included in its documentation.
+func ConfigWithClassDiagram(enabled bool) ConfigOption {
+	return func(c *Config) error {
+		c.ClassDiagram = enabled
+		return nil
+	}
+}
+
+// ConfigWithIotaValues defines whether the computed values of constants
+// declared with iota should be included in the package's documentation.
+func ConfigWithIotaValues(enabled bool) ConfigOption {
+	return func(c *Config) error {
+		c.IotaValues = enabled
+		return nil
+	}
+}
+
+// ConfigWithComputedValues defines whether the computed values of constants
+// declared with expressions other than literals should be included in the
+// package's documentation.
+func ConfigWithComputedValues(enabled bool) ConfigOption {
+	return func(c *Config) error {
+		c.ComputedValues = enabled
+		return nil
+	}
+}
+
+// ConfigWithFuncBodies defines whether the full declarations of functions,
+// including their bodies, should be included in the package's documentation.
+func ConfigWithFuncBodies(enabled bool) ConfigOption {
+	return func(c *Config) error {
+		c.FuncBodies = enabled
+		return nil
+	}
+}
+
+// ConfigWithBuildInfo defines whether main packages should be documented with
+// an appendix listing their dependencies and the variables which can be set
+// when they are linked.
+func ConfigWithBuildInfo(enabled bool) ConfigOption {
+	return func(c *Config) error {
+		c.BuildInfo = enabled
+		return nil
+	}
+}
+
+// ConfigWithTranslations defines the translated text to use in place of the
+// prose blocks of documentation, keyed by the ProseID of the original text.
+func ConfigWithTranslations(translations map[string]string) ConfigOption {
+	return func(c *Config) error {
+		c.Translations = translations
+		return nil
+	}
+}
+
+// ConfigWithStringCatalog defines a catalog in which the prose blocks of
+// documentation are recorded as they are rendered.
+func ConfigWithStringCatalog(catalog *StringCatalog) ConfigOption {
+	return func(c *Config) error {
+		c.StringCatalog = catalog
+		return nil
+	}
+}
+
+// ConfigWithImportURLResolver defines the resolver used to find the urls of the
+// documentation of other packages when linking to them.
+func ConfigWithImportURLResolver(resolver *ImportURLResolver) ConfigOption {
+	return func(c *Config) error {
+		c.ImportURLs = resolver
+		return nil
+	}
+}
+
+// ConfigWithPlaygroundSharer defines the function used to share self-contained
+// examples on the Go Playground, which enables links for running them.
+func ConfigWithPlaygroundSharer(share PlaygroundSharer) ConfigOption {
+	return func(c *Config) error {
+		c.Playground = share
+		return nil
+	}
+}
+
 func getRepoForDir(log logger.Logger, wd string, dir string, ri *Repo) (*Repo, error) {
 	if ri == nil {
 		ri = &Repo{}
@@ -238,6 +616,24 @@ func getRepoForDir(log logger.Logger, wd string, dir string, ri *Repo) (*Repo, e
 	return ri, nil
 }
 
+// getHeadCommit provides the hash of the commit checked out in the repository
+// holding the directory.
+func getHeadCommit(dir string) (string, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+
+	return head.Hash().String(), nil
+}
+
 func processRemote(log logger.Logger, repository *git.Repository, remote *git.Remote, ri Repo) (*Repo, bool) {
 	repo := &ri
 
@@ -309,9 +705,27 @@ var (
 	httpsRemoteRegex     = regexp.MustCompile(`^(https?://)(?:[^@/]+@)?([\w-.]+)(/.+?)?(?:\.git)?$`)
 	devOpsSSHV3PathRegex = regexp.MustCompile(`^v3/([^/]+)/([^/]+)/([^/]+)$`)
 	devOpsHTTPSPathRegex = regexp.MustCompile(`^/([^/]+)/([^/]+)/_git/([^/]+)$`)
+
+	// Bitbucket Server (Data Center) serves clones over ssh on port 7999 by
+	// default and over https under /scm, optionally below a context path.
+	bitbucketSSHRemoteRegex = regexp.MustCompile(`^ssh://[\w-]+@([^:/]+):7999/([^/]+)/([^/]+?)(?:\.git)?$`)
+	bitbucketHTTPSPathRegex = regexp.MustCompile(`^(/.+)?/scm/([^/]+)/([^/]+)$`)
+
+	// Self-hosted instances, such as Gitea and Forgejo, often serve clones
+	// over ssh URLs on a port other than 22.
+	sshURLRemoteRegex = regexp.MustCompile(`^ssh://[\w-]+@([^:/]+)(?::\d+)?/(.+?)(?:\.git)?$`)
 )
 
 func normalizeRemote(remote string) (string, bool) {
+	if match := bitbucketSSHRemoteRegex.FindStringSubmatch(remote); match != nil {
+		// Bitbucket Server
+		return fmt.Sprintf("https://%s/projects/%s/repos/%s", match[1], match[2], match[3]), true
+	}
+
+	if match := sshURLRemoteRegex.FindStringSubmatch(remote); match != nil {
+		return fmt.Sprintf("https://%s/%s", match[1], match[2]), true
+	}
+
 	if match := sshRemoteRegex.FindStringSubmatch(remote); match != nil {
 		switch match[1] {
 		case "ssh.dev.azure.com", "vs-ssh.visualstudio.com":
@@ -361,6 +775,17 @@ func normalizeRemote(remote string) (string, bool) {
 			}
 
 			return "", false
+		case bitbucketHTTPSPathRegex.MatchString(match[3]):
+			// Bitbucket Server
+			pathMatch := bitbucketHTTPSPathRegex.FindStringSubmatch(match[3])
+			return fmt.Sprintf(
+				"%s%s%s/projects/%s/repos/%s",
+				match[1],
+				match[2],
+				pathMatch[1],
+				pathMatch[2],
+				pathMatch[3],
+			), true
 		default:
 			// GitHub and friends
 			return fmt.Sprintf("%s%s%s", match[1], match[2], match[3]), true
@@ -387,7 +812,11 @@ func NewLocation(cfg *Config, node ast.Node) Location {
 	}
 }
 
-func parsePkgFiles(pkgDir string, fs *token.FileSet) ([]*ast.File, error) {
+func parsePkgFiles(fsys fs.FS, pkgDir string, fset *token.FileSet) ([]*ast.File, error) {
+	if fsys != nil {
+		return parseFSPkgFiles(fsys, pkgDir, fset)
+	}
+
 	rawFiles, err := ioutil.ReadDir(pkgDir)
 	if err != nil {
 		return nil, fmt.Errorf("gomarkdoc: error reading package dir: %w", err)
@@ -406,7 +835,7 @@ func parsePkgFiles(pkgDir string, fs *token.FileSet) ([]*ast.File, error) {
 			continue
 		}
 
-		parsed, err := parser.ParseFile(fs, p, nil, parser.ParseComments)
+		parsed, err := parser.ParseFile(fset, p, nil, parser.ParseComments)
 		if err != nil {
 			return nil, fmt.Errorf("gomarkdoc: failed to parse package file %s", f.Name())
 		}
@@ -416,3 +845,34 @@ func parsePkgFiles(pkgDir string, fs *token.FileSet) ([]*ast.File, error) {
 
 	return files, nil
 }
+
+// parseFSPkgFiles parses the files of the package in the directory of the file
+// system like parsePkgFiles.
+func parseFSPkgFiles(fsys fs.FS, pkgDir string, fset *token.FileSet) ([]*ast.File, error) {
+	entries, err := fs.ReadDir(fsys, pkgDir)
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: error reading package dir: %w", err)
+	}
+
+	var files []*ast.File
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".go") && !strings.HasSuffix(e.Name(), ".cgo") || !e.Type().IsRegular() {
+			continue
+		}
+
+		p := path.Join(pkgDir, e.Name())
+		src, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to read package file %s: %w", e.Name(), err)
+		}
+
+		parsed, err := parser.ParseFile(fset, p, src, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to parse package file %s", e.Name())
+		}
+
+		files = append(files, parsed)
+	}
+
+	return files, nil
+}
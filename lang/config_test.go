@@ -43,6 +43,22 @@ func TestNormalizeRemote(t *testing.T) {
 			raw:        "git@gitlab.com:org/repo.git",
 			normalized: "https://gitlab.com/org/repo",
 		},
+		"Bitbucket Server https": {
+			raw:        "https://user@bitbucket.example.com/scm/proj/repo.git",
+			normalized: "https://bitbucket.example.com/projects/proj/repos/repo",
+		},
+		"Bitbucket Server https (context path)": {
+			raw:        "https://git.example.com/bitbucket/scm/proj/repo.git",
+			normalized: "https://git.example.com/bitbucket/projects/proj/repos/repo",
+		},
+		"Bitbucket Server ssh": {
+			raw:        "ssh://git@bitbucket.example.com:7999/proj/repo.git",
+			normalized: "https://bitbucket.example.com/projects/proj/repos/repo",
+		},
+		"Gitea ssh (port)": {
+			raw:        "ssh://git@gitea.example.com:2222/org/repo.git",
+			normalized: "https://gitea.example.com/org/repo",
+		},
 	}
 
 	for name, test := range tests {
@@ -55,3 +71,11 @@ func TestNormalizeRemote(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHeadCommit(t *testing.T) {
+	is := is.New(t)
+
+	commit, err := getHeadCommit(".")
+	is.NoErr(err)
+	is.Equal(len(commit), 40)
+}
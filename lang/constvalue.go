@@ -0,0 +1,115 @@
+package lang
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+)
+
+// ConstValue holds the value of a constant as computed by the compiler.
+type ConstValue struct {
+	// Name holds the name of the constant.
+	Name string
+
+	// Value holds the value of the constant (e.g. 3, "text" or 0.5).
+	Value string
+}
+
+// ConstValues provides the computed value of each of the constants declared by
+// a const declaration which uses iota, where the values of the constants are
+// implicit in their declaration, or whose values are other expressions which
+// the reader would have to evaluate. Values are only provided when enabled for
+// the package with PackageWithIotaValues or PackageWithComputedValues
+// respectively and type information is available for it.
+func (v *Value) ConstValues() []*ConstValue {
+	cfg := v.cfg
+	if cfg.Types == nil || v.doc.Decl.Tok != token.CONST {
+		return nil
+	}
+
+	if !(cfg.IotaValues && usesIota(v.doc.Decl)) && !(cfg.ComputedValues && usesExpressions(v.doc.Decl)) {
+		return nil
+	}
+
+	var values []*ConstValue
+	for _, name := range v.doc.Names {
+		c, ok := cfg.Types.Scope().Lookup(name).(*types.Const)
+		if !ok {
+			continue
+		}
+
+		values = append(values, &ConstValue{Name: name, Value: constValueString(c.Val())})
+	}
+
+	return values
+}
+
+// usesIota checks whether the values of any of the specs of the declaration
+// refer to iota.
+func usesIota(decl *ast.GenDecl) bool {
+	var found bool
+	for _, spec := range decl.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+
+		for _, val := range vs.Values {
+			ast.Inspect(val, func(n ast.Node) bool {
+				if ident, ok := n.(*ast.Ident); ok && ident.Name == "iota" {
+					found = true
+				}
+
+				return !found
+			})
+		}
+	}
+
+	return found
+}
+
+// usesExpressions checks whether the values of any of the specs of the
+// declaration are expressions rather than literals. Specs without values repeat
+// the expression of the spec before them, so they don't need to be checked.
+func usesExpressions(decl *ast.GenDecl) bool {
+	for _, spec := range decl.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+
+		for _, val := range vs.Values {
+			if !isLiteral(val) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isLiteral checks whether the expression is a basic literal, optionally
+// negated and in parentheses, whose value is already shown by the declaration.
+func isLiteral(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return true
+	case *ast.ParenExpr:
+		return isLiteral(e.X)
+	case *ast.UnaryExpr:
+		return (e.Op == token.SUB || e.Op == token.ADD) && isLiteral(e.X)
+	default:
+		return false
+	}
+}
+
+// constValueString formats the value like it would be written in code, with
+// floating point values in decimal notation rather than as exact fractions.
+func constValueString(val constant.Value) string {
+	if val.Kind() == constant.Float {
+		return val.String()
+	}
+
+	return val.ExactString()
+}
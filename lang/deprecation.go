@@ -0,0 +1,151 @@
+package lang
+
+import (
+	"strings"
+)
+
+// deprecatedPrefix begins the paragraph of a doc comment which marks the
+// documented symbol as deprecated, following the Go convention.
+const deprecatedPrefix = "Deprecated: "
+
+// IsDeprecated indicates whether the package's documentation contains a
+// "Deprecated:" paragraph.
+func (pkg *Package) IsDeprecated() bool {
+	_, ok := deprecation(pkg.doc.Doc)
+	return ok
+}
+
+// DeprecationNotice provides the text of the "Deprecated:" paragraph of the
+// package's documentation without the prefix, or an empty string if the
+// package is not deprecated.
+func (pkg *Package) DeprecationNotice() string {
+	notice, _ := deprecation(pkg.doc.Doc)
+	return notice
+}
+
+// IsDeprecated indicates whether the type's documentation contains a
+// "Deprecated:" paragraph.
+func (typ *Type) IsDeprecated() bool {
+	_, ok := deprecation(typ.doc.Doc)
+	return ok
+}
+
+// DeprecationNotice provides the text of the "Deprecated:" paragraph of the
+// type's documentation without the prefix, or an empty string if the type is
+// not deprecated.
+func (typ *Type) DeprecationNotice() string {
+	notice, _ := deprecation(typ.doc.Doc)
+	return notice
+}
+
+// IsDeprecated indicates whether the function's documentation contains a
+// "Deprecated:" paragraph.
+func (fn *Func) IsDeprecated() bool {
+	_, ok := deprecation(fn.doc.Doc)
+	return ok
+}
+
+// DeprecationNotice provides the text of the "Deprecated:" paragraph of the
+// function's documentation without the prefix, or an empty string if the
+// function is not deprecated.
+func (fn *Func) DeprecationNotice() string {
+	notice, _ := deprecation(fn.doc.Doc)
+	return notice
+}
+
+// IsDeprecated indicates whether the field's documentation contains a
+// "Deprecated:" paragraph.
+func (f *Field) IsDeprecated() bool {
+	_, ok := deprecation(f.docText())
+	return ok
+}
+
+// DeprecationNotice provides the text of the "Deprecated:" paragraph of the
+// field's documentation without the prefix, or an empty string if the field
+// is not deprecated.
+func (f *Field) DeprecationNotice() string {
+	notice, _ := deprecation(f.docText())
+	return notice
+}
+
+func deprecatedBadge() *Badge {
+	return NewBadge(
+		DeprecatedBadge,
+		"Deprecated",
+		"https://img.shields.io/badge/status-deprecated-red",
+		"",
+	)
+}
+
+// deprecation finds the first paragraph of the doc text which starts with the
+// "Deprecated: " prefix, providing its text without the prefix and with its
+// lines joined. The second return value is false if there is no such
+// paragraph.
+func deprecation(text string) (string, bool) {
+	for _, para := range paragraphs(text) {
+		if !strings.HasPrefix(para[0], deprecatedPrefix) {
+			continue
+		}
+
+		lines := make([]string, len(para))
+		for i, line := range para {
+			lines[i] = strings.TrimSpace(line)
+		}
+
+		return strings.TrimPrefix(strings.Join(lines, " "), deprecatedPrefix), true
+	}
+
+	return "", false
+}
+
+// withoutDeprecation removes the "Deprecated:" paragraph from the doc text, so
+// the notice can be rendered separately from the rest of the documentation.
+func withoutDeprecation(text string) string {
+	var kept []string
+	found := false
+	for _, para := range paragraphs(text) {
+		if !found && strings.HasPrefix(para[0], deprecatedPrefix) {
+			found = true
+			continue
+		}
+
+		kept = append(kept, strings.Join(para, "\n"))
+	}
+
+	if !found {
+		return text
+	}
+
+	if len(kept) == 0 {
+		return ""
+	}
+
+	return strings.Join(kept, "\n\n") + "\n"
+}
+
+// paragraphs splits the doc text into groups of consecutive non-blank lines.
+func paragraphs(text string) [][]string {
+	var (
+		paras [][]string
+		para  []string
+	)
+
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(para) > 0 {
+				paras = append(paras, para)
+				para = nil
+			}
+
+			continue
+		}
+
+		para = append(para, line)
+	}
+
+	if len(para) > 0 {
+		paras = append(paras, para)
+	}
+
+	return paras
+}
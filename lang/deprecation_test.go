@@ -0,0 +1,90 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestPackage_deprecated(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/lang/deprecated")
+	is.NoErr(err)
+
+	is.True(pkg.IsDeprecated())
+	is.Equal(pkg.DeprecationNotice(), "Use the replacement package instead.")
+	is.Equal(len(pkg.Doc().Blocks()), 1) // the notice is left out of the doc
+
+	badges := pkg.Badges()
+	is.Equal(len(badges), 1) // standard badges are disabled
+	is.Equal(badges[0].Kind(), lang.DeprecatedBadge)
+	is.Equal(badges[0].Text(), "Deprecated")
+
+	is.Equal(len(pkg.Types()), 1)
+	typ := pkg.Types()[0]
+	is.True(typ.IsDeprecated())
+	is.Equal(typ.DeprecationNotice(), "Use NewClient instead.")
+	is.Equal(len(typ.Badges()), 1)
+
+	fields := typ.Fields()
+	is.Equal(len(fields), 2)
+	is.True(!fields[0].IsDeprecated())
+	is.Equal(fields[0].DeprecationNotice(), "")
+	is.True(fields[1].IsDeprecated())
+	is.Equal(fields[1].DeprecationNotice(), "Use Deadline instead.")
+
+	funcs := make(map[string]*lang.Func)
+	for _, fn := range typ.Funcs() {
+		funcs[fn.Name()] = fn
+	}
+
+	is.True(funcs["Dial"].IsDeprecated())
+	is.Equal(funcs["Dial"].DeprecationNotice(), "Use Connect instead.")
+	is.Equal(len(funcs["Dial"].Doc().Blocks()), 2) // paragraphs around the notice are kept
+
+	is.True(!funcs["Connect"].IsDeprecated())
+	is.Equal(len(funcs["Connect"].Badges()), 0)
+}
+
+func TestPackage_hideDeprecated(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/hidedeprecated")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithDeprecatedHidden())
+	is.NoErr(err)
+
+	types := pkg.Types()
+	is.Equal(len(types), 1)
+	is.Equal(types[0].Name(), "Server")
+	is.Equal(len(types[0].Methods()), 1)
+	is.Equal(types[0].Methods()[0].Name(), "Start")
+	is.Equal(len(types[0].Funcs()), 1)
+	is.Equal(types[0].Funcs()[0].Name(), "Connect")
+
+	is.Equal(len(pkg.Funcs()), 0) // Dial is grouped with Server
+
+	deprecatedTypes := pkg.DeprecatedTypes()
+	is.Equal(len(deprecatedTypes), 1)
+	is.Equal(deprecatedTypes[0].Name(), "Client")
+	is.Equal(deprecatedTypes[0].Level(), 3)
+	is.Equal(len(deprecatedTypes[0].Methods()), 1) // kept with the type
+
+	var names []string
+	for _, fn := range pkg.DeprecatedFuncs() {
+		names = append(names, fn.Name())
+	}
+
+	is.Equal(names, []string{"Dial", "Run"})
+
+	pkg, err = loadPackage("../testData/lang/hidedeprecated")
+	is.NoErr(err)
+
+	is.Equal(len(pkg.Types()), 2) // shown by default
+	is.Equal(len(pkg.Types()[1].Methods()), 2)
+}
@@ -17,7 +17,7 @@ func NewDoc(cfg *Config, text string) *Doc {
 
 	parsed := cfg.Pkg.Parser().Parse(rawText)
 
-	blocks := ParseBlocks(cfg, parsed.Content, false)
+	blocks := ParseBlocks(cfg, translateBlocks(cfg, parsed.Content), false)
 
 	return &Doc{cfg, blocks}
 }
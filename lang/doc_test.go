@@ -0,0 +1,38 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/matryer/is"
+)
+
+func TestDoc_structure(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/lang/structure")
+	is.NoErr(err)
+
+	blocks := pkg.Doc().Blocks()
+	is.Equal(len(blocks), 9)
+
+	is.Equal(blocks[1].Kind(), lang.HeaderBlock)
+	is.Equal(blocks[1].Spans()[0].Text(), "Usage")
+
+	is.Equal(blocks[3].Kind(), lang.ListBlock)
+	items := blocks[3].List().Items()
+	is.Equal(len(items), 2)
+	is.Equal(items[0].Kind(), lang.OrderedItem)
+	is.Equal(items[1].Number(), 2)
+
+	is.Equal(blocks[5].Kind(), lang.ListBlock)
+	is.Equal(blocks[5].List().Items()[0].Kind(), lang.UnorderedItem)
+
+	spans := blocks[6].Spans()
+	is.Equal(len(spans), 3)
+	is.Equal(spans[1].Kind(), lang.AutolinkSpan)
+	is.Equal(spans[1].URL(), "https://example.com/docs")
+
+	is.Equal(blocks[7].Kind(), lang.HeaderBlock) // implicit heading
+	is.Equal(blocks[7].Spans()[0].Text(), "Old Style Heading")
+}
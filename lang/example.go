@@ -2,22 +2,67 @@ package lang
 
 import (
 	"fmt"
+	"go/ast"
 	"go/doc"
 	"go/printer"
+	"regexp"
+	"sort"
 	"strings"
+	"unicode"
 )
 
-// Example holds a single documentation example for a package or symbol.
-type Example struct {
-	cfg  *Config
-	name string
-	doc  *doc.Example
-}
+type (
+	// Example holds a single documentation example for a package or symbol.
+	Example struct {
+		cfg     *Config
+		name    string
+		doc     *doc.Example
+		subject string
+	}
+
+	// ExampleGroup holds the examples of a package which share a scenario
+	// tag, set with a //gomarkdoc:group directive on the example function.
+	ExampleGroup struct {
+		cfg      *Config
+		name     string
+		examples []*Example
+	}
+
+	// ExampleTitleStyle identifies how the titles of examples are generated.
+	ExampleTitleStyle string
+
+	// ExampleOrder identifies the order in which examples are listed.
+	ExampleOrder string
+)
+
+const (
+	// DefaultExampleTitles titles examples with the capitalized words of their
+	// suffix in parentheses, e.g. "Example (With Retry)".
+	DefaultExampleTitles ExampleTitleStyle = "default"
+
+	// HumanizedExampleTitles titles examples with the suffix converted to
+	// sentence case in parentheses, e.g. "Example (With retry)".
+	HumanizedExampleTitles ExampleTitleStyle = "humanized"
+
+	// ShortExampleTitles titles examples with only their suffix converted to
+	// sentence case, e.g. "With retry". Examples without a suffix are titled
+	// "Example".
+	ShortExampleTitles ExampleTitleStyle = "short"
+)
+
+const (
+	// AlphabeticalExampleOrder lists examples sorted by name.
+	AlphabeticalExampleOrder ExampleOrder = "alphabetical"
+
+	// SourceExampleOrder lists examples in the order they appear in the
+	// source code.
+	SourceExampleOrder ExampleOrder = "source"
+)
 
 // NewExample creates a new example from the example function's name, its
 // documentation example and the files holding code related to the example.
 func NewExample(cfg *Config, name string, doc *doc.Example) *Example {
-	return &Example{cfg, name, doc}
+	return &Example{cfg, name, doc, ""}
 }
 
 // Level provides the default level that headers for the example should be
@@ -33,14 +78,32 @@ func (ex *Example) Name() string {
 }
 
 // Title provides a formatted string to print as the title of the example. It
-// incorporates the example's name, if present.
+// incorporates the example's name, if present, using the configured title
+// style. Examples in an ExampleGroup are prefixed with the symbol they belong
+// to (e.g. "Client.Login: Example (With Token)").
 func (ex *Example) Title() string {
+	title := ex.title()
+	if ex.subject != "" {
+		return fmt.Sprintf("%s: %s", ex.subject, title)
+	}
+
+	return title
+}
+
+func (ex *Example) title() string {
 	name := ex.Name()
 	if name == "" {
 		return "Example"
 	}
 
-	return fmt.Sprintf("Example (%s)", name)
+	switch ex.cfg.ExampleTitles {
+	case HumanizedExampleTitles:
+		return fmt.Sprintf("Example (%s)", humanize(name))
+	case ShortExampleTitles:
+		return humanize(name)
+	default:
+		return fmt.Sprintf("Example (%s)", name)
+	}
 }
 
 // Location returns a representation of the node's location in a file within a
@@ -63,16 +126,56 @@ func (ex *Example) Doc() *Doc {
 
 // Code provides the raw text code representation of the example's contents.
 func (ex *Example) Code() (string, error) {
-	var codeNode interface{}
 	if ex.doc.Play != nil {
-		codeNode = ex.doc.Play
-	} else {
-		codeNode = &printer.CommentedNode{Node: ex.doc.Code, Comments: ex.doc.Comments}
+		return printCode(ex.cfg, ex.doc.Play)
+	}
+
+	comments, stripped := ex.codeComments()
+	code, err := printCode(ex.cfg, &printer.CommentedNode{Node: ex.doc.Code, Comments: comments})
+	if err != nil || !stripped {
+		return code, err
+	}
+
+	// The line of the output comment is left blank
+	return strings.TrimRight(code, "\n") + "\n", nil
+}
+
+// outputPrefix matches the comment holding the expected output of an example,
+// as recognized by go test.
+var outputPrefix = regexp.MustCompile(`(?i)^[[:space:]]*(unordered )?output:`)
+
+// codeComments provides the comments of the example's code without the
+// comment holding its expected output, which is rendered separately. The
+// playable version of the example already leaves it out. The second return
+// value reports whether the output comment was removed.
+func (ex *Example) codeComments() ([]*ast.CommentGroup, bool) {
+	if ex.doc.Output == "" && !ex.doc.EmptyOutput {
+		return ex.doc.Comments, false
+	}
+
+	// The output comment is the last one in the body of the example
+	output := -1
+	for i, c := range ex.doc.Comments {
+		if c.Pos() >= ex.doc.Code.Pos() && c.End() <= ex.doc.Code.End() {
+			output = i
+		}
+	}
+
+	if output < 0 || !outputPrefix.MatchString(ex.doc.Comments[output].Text()) {
+		return ex.doc.Comments, false
 	}
 
+	comments := make([]*ast.CommentGroup, 0, len(ex.doc.Comments)-1)
+	comments = append(comments, ex.doc.Comments[:output]...)
+	return append(comments, ex.doc.Comments[output+1:]...), true
+}
+
+// printCode prints the code of the node, removing the surrounding braces and
+// indentation of function bodies.
+func printCode(cfg *Config, node interface{}) (string, error) {
 	var code strings.Builder
 	p := &printer.Config{Mode: printer.TabIndent | printer.UseSpaces, Tabwidth: 8}
-	err := p.Fprint(&code, ex.cfg.FileSet, codeNode)
+	err := p.Fprint(&code, cfg.FileSet, node)
 	if err != nil {
 		return "", err
 	}
@@ -90,12 +193,111 @@ func (ex *Example) Code() (string, error) {
 	return str, nil
 }
 
-// Output provides the code's example output.
+// Output provides the code's example output. When examples are run for the
+// package, this is the output the example actually printed.
 func (ex *Example) Output() string {
+	if out, ok := ex.cfg.ExampleOutputs[ex.doc]; ok {
+		return out
+	}
+
 	return ex.doc.Output
 }
 
 // HasOutput indicates whether the example contains any example output.
 func (ex *Example) HasOutput() bool {
+	if out, ok := ex.cfg.ExampleOutputs[ex.doc]; ok {
+		return out != ""
+	}
+
 	return ex.doc.Output != "" || ex.doc.EmptyOutput
 }
+
+// OutputCaptured indicates whether the output of the example was captured by
+// running it, rather than taken from its output comment.
+func (ex *Example) OutputCaptured() bool {
+	_, ok := ex.cfg.ExampleOutputs[ex.doc]
+	return ok
+}
+
+// Level provides the default level that the header for the group should be
+// rendered.
+func (g *ExampleGroup) Level() int {
+	return g.cfg.Level
+}
+
+// Name provides the scenario tag shared by the examples in the group, as
+// written in the //gomarkdoc:group directive.
+func (g *ExampleGroup) Name() string {
+	return g.name
+}
+
+// Title provides the scenario tag formatted as a title (e.g. "error-handling"
+// becomes "Error Handling").
+func (g *ExampleGroup) Title() string {
+	words := strings.FieldsFunc(g.name, func(r rune) bool {
+		return r == '-' || r == '_' || unicode.IsSpace(r)
+	})
+
+	for i, w := range words {
+		r := []rune(w)
+		r[0] = runeToUpper(r[0])
+		words[i] = string(r)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// Examples provides the examples in the group.
+func (g *ExampleGroup) Examples() []*Example {
+	return g.examples
+}
+
+// newGroupedExample creates an example for an ExampleGroup, which is titled
+// with the symbol it belongs to since it is listed away from that symbol.
+func newGroupedExample(cfg *Config, example *doc.Example) *Example {
+	// Example names are made up of the symbol (e.g. "Type_Method") and an
+	// optional suffix starting with a lowercase letter.
+	parts := strings.Split(example.Name, "_")
+	var suffix string
+	if last := parts[len(parts)-1]; last != "" && unicode.IsLower([]rune(last)[0]) {
+		suffix = last
+		parts = parts[:len(parts)-1]
+	}
+
+	ex := NewExample(cfg, suffix, example)
+	ex.subject = strings.Trim(strings.Join(parts, "."), ".")
+
+	return ex
+}
+
+const exampleGroupDirective = "//gomarkdoc:group "
+
+// exampleGroup finds the scenario tag set with a //gomarkdoc:group directive on
+// the function declaring the provided example, if any.
+func exampleGroup(cfg *Config, example *doc.Example) string {
+	name := fmt.Sprintf("Example%s", example.Name)
+	for _, file := range cfg.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Name.Name != name {
+				continue
+			}
+
+			return directiveValue(exampleGroupDirective, fn.Doc)
+		}
+	}
+
+	return ""
+}
+
+// sortExamples sorts the provided examples in place according to the provided
+// order. Examples from the standard library are already sorted alphabetically.
+func sortExamples(examples []*doc.Example, order ExampleOrder) {
+	if order != SourceExampleOrder {
+		return
+	}
+
+	sort.SliceStable(examples, func(i, j int) bool {
+		return examples[i].Order < examples[j].Order
+	})
+}
@@ -0,0 +1,234 @@
+package lang_test
+
+import (
+	"fmt"
+	"go/build"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestExample_Title(t *testing.T) {
+	tests := []struct {
+		style  lang.ExampleTitleStyle
+		titles []string
+	}{
+		{lang.DefaultExampleTitles, []string{"Example", "Example (Using HTTP Proxy)", "Example (With Retry)"}},
+		{lang.HumanizedExampleTitles, []string{"Example", "Example (Using HTTP proxy)", "Example (With retry)"}},
+		{lang.ShortExampleTitles, []string{"Example", "Using HTTP proxy", "With retry"}},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.style), func(t *testing.T) {
+			is := is.New(t)
+
+			examples, err := loadExamples("../testData/lang/examples", "Client", lang.PackageWithExampleTitles(test.style))
+			is.NoErr(err)
+
+			is.Equal(len(examples), len(test.titles))
+			for i, ex := range examples {
+				is.Equal(ex.Title(), test.titles[i])
+			}
+		})
+	}
+}
+
+func TestExample_sourceOrder(t *testing.T) {
+	is := is.New(t)
+
+	examples, err := loadExamples(
+		"../testData/lang/examples",
+		"Client",
+		lang.PackageWithExampleOrder(lang.SourceExampleOrder),
+	)
+	is.NoErr(err)
+
+	is.Equal(len(examples), 3)
+	is.Equal(examples[0].Name(), "")
+	is.Equal(examples[1].Name(), "With Retry")
+	is.Equal(examples[2].Name(), "Using HTTP Proxy")
+}
+
+func TestExample_invalidOptions(t *testing.T) {
+	is := is.New(t)
+
+	_, err := loadExamples("../testData/lang/examples", "Client", lang.PackageWithExampleOrder("random"))
+	is.Equal(err.Error(), "gomarkdoc: invalid example order: random")
+
+	_, err = loadExamples("../testData/lang/examples", "Client", lang.PackageWithExampleTitles("fancy"))
+	is.Equal(err.Error(), "gomarkdoc: invalid example title style: fancy")
+}
+
+func loadExamples(dir, typeName string, opts ...lang.PackageOption) ([]*lang.Example, error) {
+	buildPkg, err := getBuildPackage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range pkg.Types() {
+		if t.Name() == typeName {
+			return t.Examples(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func TestExample_check(t *testing.T) {
+	is := is.New(t)
+
+	wd, err := os.Getwd()
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+
+	buildPkg, err := build.Import("../testData/lang/brokenexample", wd, build.ImportComment)
+	is.NoErr(err)
+
+	_, err = lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithStrictExamples())
+	is.NoErr(err) // all examples compile
+
+	ctx := build.Default
+	ctx.BuildTags = []string{"brokenexample"}
+
+	buildPkg, err = ctx.Import("../testData/lang/brokenexample", wd, build.ImportComment)
+	is.NoErr(err)
+
+	_, err = lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithExampleCheck())
+	is.NoErr(err) // failures are only logged
+
+	_, err = lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithStrictExamples())
+	is.True(err != nil)
+	is.Equal(err.Error(), "gomarkdoc: examples of package brokenexample do not compile")
+}
+
+func TestPackage_ExampleGroups(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/examplegroups")
+	is.NoErr(err)
+
+	pkg, err := lang.NewPackageFromBuild(logger.New(logger.ErrorLevel), buildPkg)
+	is.NoErr(err)
+
+	// Groups are listed in the order they're first seen in the sorted examples
+	groups := pkg.ExampleGroups()
+	is.Equal(len(groups), 2)
+
+	is.Equal(groups[0].Name(), "pagination")
+	is.Equal(groups[0].Title(), "Pagination")
+	is.Equal(groups[0].Level(), 2)
+	is.Equal(len(groups[0].Examples()), 1)
+	is.Equal(groups[0].Examples()[0].Title(), "Client.List: Example (Next Page)")
+
+	is.Equal(groups[1].Title(), "Authentication")
+	is.Equal(len(groups[1].Examples()), 2)
+	is.Equal(groups[1].Examples()[0].Title(), "Client.Login: Example")
+	is.Equal(groups[1].Examples()[0].Summary(), "Logging in with a token.")
+	is.Equal(groups[1].Examples()[1].Title(), "Example (Session Reuse)")
+
+	// Grouped examples are not listed with their symbols
+	is.Equal(len(pkg.Examples()), 0)
+	for _, typ := range pkg.Types() {
+		is.Equal(len(typ.Examples()), 1)
+		for _, m := range typ.Methods() {
+			is.Equal(len(m.Examples()), 0)
+		}
+	}
+}
+
+func TestExample_output(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/exampleoutput")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg)
+	is.NoErr(err)
+
+	examples := pkg.Funcs()[0].Examples()
+	is.Equal(len(examples), 2)
+
+	// The expected output is left out of the code and rendered separately
+	code, err := examples[0].Code()
+	is.NoErr(err)
+	is.Equal(code, `
+// Greet a single person
+Greet("Gopher")
+`)
+	is.True(examples[0].HasOutput())
+	is.Equal(examples[0].Output(), "Hello, Gopher!\n")
+
+	code, err = examples[1].Code()
+	is.NoErr(err)
+	is.Equal(code, `
+Greet("Alice", "Bob")
+`)
+	is.Equal(examples[1].Output(), "Hello, Bob!\nHello, Alice!\n")
+}
+
+func TestExample_PlaygroundURL(t *testing.T) {
+	is := is.New(t)
+
+	var shared []string
+	share := func(code string) (string, error) {
+		shared = append(shared, code)
+		return fmt.Sprintf("https://go.dev/play/p/%d", len(shared)), nil
+	}
+
+	examples, err := loadExamples("../testData/lang/examples", "Client", lang.PackageWithPlaygroundLinks(share))
+	is.NoErr(err)
+
+	is.Equal(examples[0].PlaygroundURL(), "https://go.dev/play/p/1")
+	is.True(strings.HasPrefix(shared[0], "package main\n")) // the complete program is shared
+
+	// Examples which aren't self-contained can't be run on the playground
+	buildPkg, err := getBuildPackage("../testData/lang/exampleoutput")
+	is.NoErr(err)
+
+	pkg, err := lang.NewPackageFromBuild(logger.New(logger.ErrorLevel), buildPkg, lang.PackageWithPlaygroundLinks(share))
+	is.NoErr(err)
+	is.Equal(pkg.Funcs()[0].Examples()[0].PlaygroundURL(), "")
+
+	// Links are left out by default
+	examples, err = loadExamples("../testData/lang/examples", "Client")
+	is.NoErr(err)
+	is.Equal(examples[0].PlaygroundURL(), "")
+	is.Equal(len(shared), 1)
+}
+
+func TestExample_runOutput(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/examplerun")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithExampleExecution())
+	is.NoErr(err)
+
+	examples := make(map[string]*lang.Example)
+	for _, ex := range pkg.Funcs()[0].Examples() {
+		examples[ex.Name()] = ex
+	}
+
+	is.Equal(examples[""].Output(), "1\n2\n")
+	is.True(!examples[""].OutputCaptured()) // passing examples print their output comment
+
+	is.True(examples["Missing"].HasOutput())
+	is.True(examples["Missing"].OutputCaptured())
+	is.Equal(examples["Missing"].Output(), "1\n2\n3\n")
+
+	is.True(!examples["Silent"].HasOutput())
+}
@@ -0,0 +1,120 @@
+package lang
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"github.com/anthonyme00/gomarkdoc/logger"
+)
+
+// checkExamples type checks the tests of the package and logs a warning for
+// each example function which doesn't compile, such as one referencing a
+// symbol which was renamed or removed. If strict is set, an error is returned
+// when any example doesn't compile. Errors outside of example functions are
+// left to the compiler. Imported packages are type checked from source, so
+// checking examples is slower than generating documentation alone.
+func checkExamples(log logger.Logger, pkg *build.Package, strict bool) error {
+	if len(pkg.CgoFiles) > 0 {
+		log.Debugf("skipping example check for package %s since it uses cgo", pkg.ImportPath)
+		return nil
+	}
+
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+
+	internal, err := exampleErrors(fset, imp, pkg.ImportPath, pkg.Dir, append(append([]string(nil), pkg.GoFiles...), pkg.TestGoFiles...))
+	if err != nil {
+		return err
+	}
+
+	external, err := exampleErrors(fset, imp, pkg.ImportPath+"_test", pkg.Dir, pkg.XTestGoFiles)
+	if err != nil {
+		return err
+	}
+
+	failures := append(internal, external...)
+	for _, failure := range failures {
+		log.Warnf("%s", failure)
+	}
+
+	if strict && len(failures) > 0 {
+		return fmt.Errorf("gomarkdoc: examples of package %s do not compile", pkg.Name)
+	}
+
+	return nil
+}
+
+// exampleErrors type checks the provided files of the package as a single
+// package and describes the errors found within example functions.
+func exampleErrors(fset *token.FileSet, imp types.Importer, path, dir string, names []string) ([]string, error) {
+	var (
+		files    []*ast.File
+		examples []*ast.FuncDecl
+	)
+
+	for _, name := range names {
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to parse package file %s: %w", name, err)
+		}
+
+		files = append(files, f)
+
+		if !strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && strings.HasPrefix(fn.Name.Name, "Example") {
+				examples = append(examples, fn)
+			}
+		}
+	}
+
+	if len(examples) == 0 {
+		return nil, nil
+	}
+
+	var typeErrs []types.Error
+	conf := types.Config{
+		Importer: imp,
+		Error: func(err error) {
+			var typeErr types.Error
+			if errors.As(err, &typeErr) {
+				typeErrs = append(typeErrs, typeErr)
+			}
+		},
+	}
+
+	// The errors are collected above, so the returned error is redundant
+	_, _ = conf.Check(path, fset, files, nil)
+
+	var failures []string
+	for _, typeErr := range typeErrs {
+		for _, fn := range examples {
+			if typeErr.Pos < fn.Pos() || typeErr.Pos >= fn.End() {
+				continue
+			}
+
+			pos := fset.Position(typeErr.Pos)
+			failures = append(failures, fmt.Sprintf(
+				"example %s does not compile: %s:%d:%d: %s",
+				fn.Name.Name,
+				filepath.Base(pos.Filename),
+				pos.Line,
+				pos.Column,
+				typeErr.Msg,
+			))
+		}
+	}
+
+	return failures, nil
+}
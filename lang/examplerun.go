@@ -0,0 +1,187 @@
+package lang
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/build"
+	"go/doc"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anthonyme00/gomarkdoc/logger"
+)
+
+// exampleTimeout bounds the time spent building and running the examples of a
+// package, so that an example which blocks doesn't stall documentation
+// generation.
+const exampleTimeout = 2 * time.Minute
+
+// runExamples runs the testable examples of the package with go test and
+// captures their output, keyed by the name of the example function. Examples
+// without an output comment are normally compiled but not run, so they are
+// given an empty one through an overlay, which makes go test report the output
+// they actually print. Examples which pass print exactly their output comment,
+// so their output isn't captured. Examples which panic or fail to build are
+// left out, as are all of the outputs if the run exceeds exampleTimeout.
+func runExamples(log logger.Logger, pkg *build.Package, tags []string) (map[string]string, error) {
+	dir, err := filepath.Abs(pkg.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.MkdirTemp("", "gomarkdoc-examples-")
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to create directory for running examples: %w", err)
+	}
+
+	defer os.RemoveAll(tmp)
+
+	overlay := make(map[string]string)
+	var names []string
+	for _, name := range append(append([]string(nil), pkg.TestGoFiles...), pkg.XTestGoFiles...) {
+		fileName := filepath.Join(dir, name)
+		src, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to read package file %s: %w", name, err)
+		}
+
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, fileName, src, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to parse package file %s: %w", name, err)
+		}
+
+		// Output comments are added from the end of the file so that the
+		// offsets of the other examples stay the same
+		var offsets []int
+		for _, ex := range doc.Examples(f) {
+			names = append(names, exampleFuncName(ex))
+			if ex.Output == "" && !ex.EmptyOutput {
+				offsets = append(offsets, fset.Position(ex.Code.End()).Offset-1)
+			}
+		}
+
+		if len(offsets) == 0 {
+			continue
+		}
+
+		sort.Sort(sort.Reverse(sort.IntSlice(offsets)))
+		for _, offset := range offsets {
+			src = append(src[:offset:offset], append([]byte("\n// Output:\n"), src[offset:]...)...)
+		}
+
+		replacement := filepath.Join(tmp, name)
+		if err := os.WriteFile(replacement, src, 0644); err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to write overlay for package file %s: %w", name, err)
+		}
+
+		overlay[fileName] = replacement
+	}
+
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	overlayFile := filepath.Join(tmp, "overlay.json")
+	b, err := json.Marshal(map[string]interface{}{"Replace": overlay})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(overlayFile, b, 0644); err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to write overlay for running examples: %w", err)
+	}
+
+	args := []string{"test", "-count=1", "-vet=off", "-v", "-timeout", exampleTimeout.String(), "-overlay", overlayFile}
+	if len(tags) > 0 {
+		args = append(args, "-tags", strings.Join(tags, ","))
+	}
+
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+
+	args = append(args, "-run", fmt.Sprintf("^(%s)$", strings.Join(quoted, "|")), ".")
+
+	ctx, cancel := context.WithTimeout(context.Background(), exampleTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+
+	// Failing examples make go test fail, so its output is checked instead
+	out, _ := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		log.Warnf("running examples of package %s timed out after %s", pkg.ImportPath, exampleTimeout)
+		return nil, nil
+	}
+
+	outputs, ran := parseExampleOutputs(out)
+	if !ran {
+		log.Warnf("unable to run examples of package %s: %s", pkg.ImportPath, strings.TrimSpace(string(out)))
+	}
+
+	return outputs, nil
+}
+
+// parseExampleOutputs reads the output printed by the failing examples from
+// the verbose output of go test. The second return value reports whether any
+// examples were run at all.
+func parseExampleOutputs(out []byte) (map[string]string, bool) {
+	outputs := make(map[string]string)
+
+	var (
+		ran     bool
+		current string
+		got     []string
+		reading bool
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "--- PASS: ") || strings.HasPrefix(line, "--- FAIL: "):
+			ran = true
+			current, reading = "", false
+			if strings.HasPrefix(line, "--- FAIL: ") {
+				current = strings.Fields(strings.TrimPrefix(line, "--- FAIL: "))[0]
+			}
+		case current != "" && !reading && line == "got:":
+			reading, got = true, nil
+		case reading && line == "want:":
+			if len(got) > 0 {
+				outputs[current] = strings.Join(got, "\n") + "\n"
+			} else {
+				outputs[current] = ""
+			}
+
+			current, reading = "", false
+		case reading:
+			got = append(got, line)
+		}
+	}
+
+	return outputs, ran
+}
+
+// exampleFuncName provides the name of the function declaring the example
+// (e.g. ExampleClient_Do).
+func exampleFuncName(ex *doc.Example) string {
+	if ex.Name == "" {
+		return "Example"
+	}
+
+	return "Example" + ex.Name
+}
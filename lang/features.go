@@ -0,0 +1,74 @@
+package lang
+
+import (
+	"go/doc"
+)
+
+// HasGenerics indicates whether the package declares any generic functions or
+// types, including methods of generic types.
+func (pkg *Package) HasGenerics() bool {
+	isGeneric := func(fn *doc.Func) bool {
+		return fn.Decl != nil && fn.Decl.Type.TypeParams != nil && len(fn.Decl.Type.TypeParams.List) > 0
+	}
+
+	for _, fn := range pkg.doc.Funcs {
+		if isGeneric(fn) {
+			return true
+		}
+	}
+
+	for _, typ := range pkg.doc.Types {
+		if spec := NewType(pkg.cfg, typ, nil).typeSpec(); spec != nil && spec.TypeParams != nil && len(spec.TypeParams.List) > 0 {
+			return true
+		}
+
+		for _, fn := range typ.Funcs {
+			if isGeneric(fn) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// HasExamples indicates whether the package has any examples, either for the
+// package itself or for its symbols.
+func (pkg *Package) HasExamples() bool {
+	return len(pkg.examples) > 0
+}
+
+// HasDeprecations indicates whether the package or any of its functions,
+// types or methods have a "Deprecated:" paragraph in their documentation.
+func (pkg *Package) HasDeprecations() bool {
+	if pkg.IsDeprecated() {
+		return true
+	}
+
+	isDeprecated := func(fns []*doc.Func) bool {
+		for _, fn := range fns {
+			if NewFunc(pkg.cfg, fn, nil).IsDeprecated() {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if isDeprecated(pkg.doc.Funcs) {
+		return true
+	}
+
+	for _, typ := range pkg.doc.Types {
+		if NewType(pkg.cfg, typ, nil).IsDeprecated() || isDeprecated(typ.Funcs) || isDeprecated(typ.Methods) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UsesCgo indicates whether any of the package's files import "C".
+func (pkg *Package) UsesCgo() bool {
+	return len(pkg.cgoFiles()) > 0
+}
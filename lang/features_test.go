@@ -0,0 +1,32 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestPackage_features(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/generics")
+	is.NoErr(err)
+	is.True(pkg.HasGenerics())
+	is.True(!pkg.HasExamples())
+	is.True(!pkg.HasDeprecations())
+	is.True(!pkg.UsesCgo())
+
+	pkg, err = loadPackage("../testData/lang/examples")
+	is.NoErr(err)
+	is.True(!pkg.HasGenerics())
+	is.True(pkg.HasExamples())
+
+	pkg, err = loadPackage("../testData/lang/deprecated")
+	is.NoErr(err)
+	is.True(pkg.HasDeprecations())
+
+	pkg, err = loadPackage("../testData/lang/cgo")
+	is.NoErr(err)
+	is.True(pkg.UsesCgo())
+	is.True(!pkg.HasDeprecations())
+}
@@ -0,0 +1,286 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// Field holds documentation information for a single field of a struct type.
+type Field struct {
+	cfg      *Config
+	typeName string
+	name     string
+	field    *ast.Field
+	from     []string
+}
+
+// NewField creates a new Field from the name of the struct type it belongs to,
+// the name of the field and the node declaring it. For fields promoted from
+// embedded structs, from holds the names of the embedded types the field is
+// promoted through, starting with the one embedded in the struct type itself.
+func NewField(cfg *Config, typeName, name string, field *ast.Field, from []string) *Field {
+	return &Field{cfg, typeName, name, field, from}
+}
+
+// Name provides the name of the field.
+func (f *Field) Name() string {
+	return f.name
+}
+
+// Title provides a formatted name suitable for identifying the field in the
+// index, qualified by the struct type it belongs to (e.g. "field
+// Server.Addr").
+func (f *Field) Title() string {
+	return fmt.Sprintf("field %s.%s", f.typeName, f.name)
+}
+
+// Type provides the raw text representation of the field's type.
+func (f *Field) Type() (string, error) {
+	// We use a custom FileSet so that we don't inherit multiline formatting
+	return printNode(f.field.Type, token.NewFileSet())
+}
+
+// Tag provides the raw contents of the field's struct tag without the
+// surrounding quotes (e.g. `json:"name,omitempty"`), or an empty string if the
+// field has no tag.
+func (f *Field) Tag() string {
+	if f.field.Tag == nil {
+		return ""
+	}
+
+	tag, err := strconv.Unquote(f.field.Tag.Value)
+	if err != nil {
+		return f.field.Tag.Value
+	}
+
+	return tag
+}
+
+// Location returns a representation of the node's location in a file within a
+// repository.
+func (f *Field) Location() Location {
+	return NewLocation(f.cfg, f.field)
+}
+
+// Summary provides the one-sentence summary of the field's documentation
+// comment.
+func (f *Field) Summary() string {
+	return extractSummary(f.docText())
+}
+
+// Doc provides the structured contents of the documentation comment for the
+// field. The line comment following the field is used if it has no doc
+// comment.
+func (f *Field) Doc() *Doc {
+	return NewDoc(f.cfg.Inc(1), f.docText())
+}
+
+// Promoted indicates whether the field is promoted from an embedded struct
+// rather than declared by the struct type itself.
+func (f *Field) Promoted() bool {
+	return len(f.from) > 0
+}
+
+// EmbeddedFrom provides the path of embedded types the field is promoted
+// through (e.g. "BaseConfig.TLSConfig"), or an empty string if the field is
+// declared by the struct type itself.
+func (f *Field) EmbeddedFrom() string {
+	return strings.Join(f.from, ".")
+}
+
+// Anchor produces anchor text for the field. Fields have no headers of their
+// own, so the anchor is the one of the struct type.
+func (f *Field) Anchor() string {
+	return Symbol{
+		Kind: TypeSymbolKind,
+		Name: f.typeName,
+	}.Anchor()
+}
+
+func (f *Field) docText() string {
+	if text := f.field.Doc.Text(); text != "" {
+		return text
+	}
+
+	return f.field.Comment.Text()
+}
+
+// promotedFields finds the exported fields promoted to the struct type with the
+// provided name from the structs it embeds, following Go's selector rules: a
+// field is shadowed by fields of the same name at a shallower depth and fields
+// of the same name at the same depth are ambiguous, so neither is promoted.
+// Only embedded types declared in the same package can be followed.
+func promotedFields(cfg *Config, typeName string) []*Field {
+	specs := packageTypeSpecs(cfg)
+
+	root, ok := structType(specs[typeName])
+	if !ok {
+		return nil
+	}
+
+	type embedded struct {
+		st   *ast.StructType
+		from []string
+	}
+
+	// Fields declared by the type itself shadow all promoted fields
+	seen := make(map[string]bool)
+	visited := map[string]bool{typeName: true}
+	var current []embedded
+	for _, field := range root.Fields.List {
+		for _, name := range fieldNames(field) {
+			seen[name] = true
+		}
+
+		if name, ok := embeddedTypeName(field); ok && !visited[name] {
+			if st, ok := structType(specs[name]); ok {
+				visited[name] = true
+				current = append(current, embedded{st, []string{name}})
+			}
+		}
+	}
+
+	var fields []*Field
+	for len(current) > 0 {
+		var (
+			next   []embedded
+			found  []*Field
+			counts = make(map[string]int)
+		)
+
+		for _, e := range current {
+			for _, field := range e.st.Fields.List {
+				for _, name := range fieldNames(field) {
+					counts[name]++
+					found = append(found, NewField(cfg, typeName, name, field, e.from))
+				}
+
+				name, ok := embeddedTypeName(field)
+				if !ok || visited[name] {
+					continue
+				}
+
+				if st, ok := structType(specs[name]); ok {
+					visited[name] = true
+					from := append(append([]string(nil), e.from...), name)
+					next = append(next, embedded{st, from})
+				}
+			}
+		}
+
+		for _, f := range found {
+			if !seen[f.name] && counts[f.name] == 1 && token.IsExported(f.name) {
+				fields = append(fields, f)
+			}
+		}
+
+		for name := range counts {
+			seen[name] = true
+		}
+
+		current = next
+	}
+
+	return fields
+}
+
+// packageTypeSpecs indexes the type declarations of the package's source files
+// by name. The unfiltered files of the package are used so that unexported
+// embedded types can be followed.
+func packageTypeSpecs(cfg *Config) map[string]*ast.TypeSpec {
+	specs := make(map[string]*ast.TypeSpec)
+	for _, f := range cfg.Files {
+		if f.Name.Name != cfg.Pkg.Name || strings.HasSuffix(cfg.FileSet.Position(f.Pos()).Filename, "_test.go") {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok {
+					specs[ts.Name.Name] = ts
+				}
+			}
+		}
+	}
+
+	return specs
+}
+
+func structType(spec *ast.TypeSpec) (*ast.StructType, bool) {
+	if spec == nil {
+		return nil, false
+	}
+
+	st, ok := spec.Type.(*ast.StructType)
+	return st, ok
+}
+
+// fieldNames provides the names the field can be selected by. An embedded field
+// is selected by the name of its type.
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		expr := field.Type
+		if star, ok := expr.(*ast.StarExpr); ok {
+			expr = star.X
+		}
+
+		// Types from other packages are selected by their unqualified name
+		if sel, ok := expr.(*ast.SelectorExpr); ok {
+			return []string{sel.Sel.Name}
+		}
+
+		if name := baseTypeName(expr); name != "" {
+			return []string{name}
+		}
+
+		return nil
+	}
+
+	names := make([]string, len(field.Names))
+	for i, name := range field.Names {
+		names[i] = name.Name
+	}
+
+	return names
+}
+
+// embeddedTypeName provides the name of the type of an embedded field declared
+// in the same package, without any pointer or type parameters. The second
+// return value is false if the field is not embedded or its type is declared
+// in another package.
+func embeddedTypeName(field *ast.Field) (string, bool) {
+	if len(field.Names) > 0 {
+		return "", false
+	}
+
+	name := baseTypeName(field.Type)
+	return name, name != ""
+}
+
+// baseTypeName provides the name of the named type the expression refers to,
+// without any pointer or type parameters, or an empty string if it isn't a
+// named type declared in the same package.
+func baseTypeName(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
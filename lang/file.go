@@ -1,11 +1,18 @@
 package lang
 
 // File holds information for rendering a single file that contains one or more
-// packages.
+// packages. The FrontMatter is optional and is rendered at the very start of
+// the file when present. Similarly, the optional Metadata is rendered as
+// comments at the very end of the file. If TableOfContents is set, a list of
+// the symbols of all of the packages linking to their documentation is
+// rendered at the top of the file.
 type File struct {
-	Header   string
-	Footer   string
-	Packages []*Package
+	Header          string
+	Footer          string
+	Packages        []*Package
+	FrontMatter     *FrontMatter
+	Metadata        *Metadata
+	TableOfContents bool
 }
 
 // NewFile creates a new instance of File with the provided information.
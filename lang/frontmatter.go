@@ -0,0 +1,139 @@
+package lang
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var plainFrontMatterKeyRegex = regexp.MustCompile("^[A-Za-z0-9_-]+$")
+
+type (
+	// FrontMatter holds an ordered set of metadata fields which are rendered
+	// as a front matter block at the start of a file, as used by static site
+	// generators. The Syntax determines how the block is written, defaulting to
+	// YAML.
+	FrontMatter struct {
+		Syntax FrontMatterSyntax
+		fields []*FrontMatterField
+	}
+
+	// FrontMatterSyntax identifies the language a front matter block is
+	// written in.
+	FrontMatterSyntax string
+
+	// FrontMatterField holds a single front matter key and its value.
+	FrontMatterField struct {
+		Key   string
+		Value any
+	}
+)
+
+const (
+	// YAMLFrontMatter writes front matter as YAML delimited by "---" lines.
+	YAMLFrontMatter FrontMatterSyntax = "yaml"
+
+	// TOMLFrontMatter writes front matter as TOML delimited by "+++" lines.
+	TOMLFrontMatter FrontMatterSyntax = "toml"
+)
+
+// NewFrontMatter creates an empty set of front matter fields.
+func NewFrontMatter() *FrontMatter {
+	return &FrontMatter{}
+}
+
+// Set sets the value of the field with the provided key. Fields which are
+// already present keep their position, while new fields are added to the end.
+func (fm *FrontMatter) Set(key string, value any) {
+	for _, f := range fm.fields {
+		if f.Key == key {
+			f.Value = value
+			return
+		}
+	}
+
+	fm.fields = append(fm.fields, &FrontMatterField{key, value})
+}
+
+// Delete removes the field with the provided key, if present.
+func (fm *FrontMatter) Delete(key string) {
+	for i, f := range fm.fields {
+		if f.Key == key {
+			fm.fields = append(fm.fields[:i], fm.fields[i+1:]...)
+			return
+		}
+	}
+}
+
+// Fields lists the front matter fields in order.
+func (fm *FrontMatter) Fields() []*FrontMatterField {
+	return fm.fields
+}
+
+// Render renders the front matter as a block in the language determined by its
+// Syntax. An empty string is returned if there are no fields.
+func (fm *FrontMatter) Render() (string, error) {
+	switch fm.Syntax {
+	case YAMLFrontMatter, "":
+		return fm.YAML()
+	case TOMLFrontMatter:
+		return fm.TOML()
+	default:
+		return "", fmt.Errorf("gomarkdoc: invalid front matter syntax: %s", fm.Syntax)
+	}
+}
+
+// YAML renders the front matter as a YAML block delimited by "---" lines. The
+// values are encoded in the JSON-compatible subset of YAML so that strings
+// never need to be interpreted by the reader. An empty string is returned if
+// there are no fields.
+func (fm *FrontMatter) YAML() (string, error) {
+	return fm.render("---", ": ")
+}
+
+// TOML renders the front matter as a TOML block delimited by "+++" lines. An
+// empty string is returned if there are no fields.
+func (fm *FrontMatter) TOML() (string, error) {
+	return fm.render("+++", " = ")
+}
+
+// render writes each of the fields as a key and a JSON encoded value, which is
+// valid for both YAML and TOML, between the provided delimiters.
+func (fm *FrontMatter) render(delim, sep string) (string, error) {
+	if len(fm.fields) == 0 {
+		return "", nil
+	}
+
+	var b strings.Builder
+	b.WriteString(delim)
+	b.WriteByte('\n')
+	for _, f := range fm.fields {
+		v, err := frontMatterJSON(f.Value)
+		if err != nil {
+			return "", fmt.Errorf("gomarkdoc: invalid front matter value for %s: %w", f.Key, err)
+		}
+
+		key := f.Key
+		if !plainFrontMatterKeyRegex.MatchString(key) {
+			key, _ = frontMatterJSON(key)
+		}
+
+		fmt.Fprintf(&b, "%s%s%s\n", key, sep, v)
+	}
+	b.WriteString(delim)
+
+	return b.String(), nil
+}
+
+func frontMatterJSON(v any) (string, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
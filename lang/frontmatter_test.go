@@ -0,0 +1,67 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/matryer/is"
+)
+
+func TestFrontMatter_YAML(t *testing.T) {
+	is := is.New(t)
+
+	fm := lang.NewFrontMatter()
+	fm.Set("id", "lang")
+	fm.Set("title", "lang: \"docs\"")
+	fm.Set("sidebar_position", 3)
+	fm.Set("hide table", true)
+	fm.Set("id", "lang-pkg")
+
+	res, err := fm.YAML()
+	is.NoErr(err)
+	is.Equal(res, `---
+id: "lang-pkg"
+title: "lang: \"docs\""
+sidebar_position: 3
+"hide table": true
+---`)
+}
+
+func TestFrontMatter_TOML(t *testing.T) {
+	is := is.New(t)
+
+	fm := lang.NewFrontMatter()
+	fm.Syntax = lang.TOMLFrontMatter
+	fm.Set("title", "lang <docs>")
+	fm.Set("weight", 10)
+	fm.Set("draft", false)
+
+	res, err := fm.Render()
+	is.NoErr(err)
+	is.Equal(res, `+++
+title = "lang <docs>"
+weight = 10
+draft = false
++++`)
+}
+
+func TestFrontMatter_Delete(t *testing.T) {
+	is := is.New(t)
+
+	fm := lang.NewFrontMatter()
+	fm.Set("id", "lang")
+	fm.Set("title", "lang")
+	fm.Delete("id")
+	fm.Delete("missing")
+
+	is.Equal(len(fm.Fields()), 1)
+	is.Equal(fm.Fields()[0].Key, "title")
+}
+
+func TestFrontMatter_empty(t *testing.T) {
+	is := is.New(t)
+
+	res, err := lang.NewFrontMatter().YAML()
+	is.NoErr(err)
+	is.Equal(res, "")
+}
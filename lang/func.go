@@ -2,7 +2,9 @@ package lang
 
 import (
 	"fmt"
+	"go/ast"
 	"go/doc"
+	"go/printer"
 	"go/token"
 	"strings"
 )
@@ -33,14 +35,47 @@ func (fn *Func) Name() string {
 	return fn.doc.Name
 }
 
-// Title provides the formatted name of the func. It is primarily designed for
-// generating headers.
+// Title provides the formatted name of the func, along with its type
+// parameters if it is generic. It is primarily designed for generating headers.
+// The title set with a //gomarkdoc:title directive is used instead if there is
+// one.
 func (fn *Func) Title() string {
+	if title := fn.CustomTitle(); title != "" {
+		return title
+	}
+
 	if fn.doc.Recv != "" {
-		return fmt.Sprintf("func (%s) %s", fn.doc.Recv, fn.doc.Name)
+		return fmt.Sprintf("func (%s) %s%s", fn.doc.Recv, fn.doc.Name, fn.TypeParams())
+	}
+
+	return fmt.Sprintf("func %s%s", fn.doc.Name, fn.TypeParams())
+}
+
+// CustomTitle provides the title set with a //gomarkdoc:title directive in the
+// function's documentation comment, or an empty string if there is none.
+func (fn *Func) CustomTitle() string {
+	if fn.doc.Decl == nil {
+		return ""
 	}
 
-	return fmt.Sprintf("func %s", fn.doc.Name)
+	return directiveValue(titleDirective, sourceDoc(fn.cfg, fn.doc.Decl))
+}
+
+// TypeParams provides the type parameter list of a generic function, including
+// the surrounding brackets and constraints (e.g. "[T any, U any]"). The type
+// parameters of a method are part of its receiver instead. An empty string is
+// returned for functions which are not generic.
+func (fn *Func) TypeParams() string {
+	if fn.doc.Decl == nil {
+		return ""
+	}
+
+	params, err := printTypeParams(fn.doc.Decl.Type.TypeParams)
+	if err != nil {
+		return ""
+	}
+
+	return params
 }
 
 // Receiver provides the type of the receiver for the function, or empty string
@@ -62,9 +97,10 @@ func (fn *Func) Summary() string {
 }
 
 // Doc provides the structured contents of the documentation comment for the
-// function.
+// function. The "Deprecated:" paragraph is left out, since it is provided by
+// DeprecationNotice.
 func (fn *Func) Doc() *Doc {
-	return NewDoc(fn.cfg.Inc(1), fn.doc.Doc)
+	return NewDoc(fn.cfg.Inc(1), withoutDeprecation(fn.doc.Doc))
 }
 
 // Signature provides the raw text representation of the code for the
@@ -74,9 +110,73 @@ func (fn *Func) Signature() (string, error) {
 	return printNode(fn.doc.Decl, token.NewFileSet())
 }
 
+// Body provides the raw text representation of the function's full
+// declaration, including its body and the comments within it. The body is
+// provided for all functions when enabled for the package with
+// PackageWithFuncBodies, or for a function with a //gomarkdoc:body directive in
+// its documentation comment. An empty string is returned otherwise, or if the
+// function has no body, such as when it is implemented in assembly.
+func (fn *Func) Body() (string, error) {
+	if fn.doc.Decl == nil {
+		return "", nil
+	}
+
+	if !fn.cfg.FuncBodies && !hasDirective(bodyDirective, sourceDoc(fn.cfg, fn.doc.Decl)) {
+		return "", nil
+	}
+
+	// The body is removed from the declaration provided by go/doc, so the
+	// declaration is found in the files of the package instead
+	f := declFile(fn.cfg, fn.doc.Decl)
+	if f == nil {
+		return "", nil
+	}
+
+	offset := fn.cfg.FileSet.Position(fn.doc.Decl.Pos()).Offset
+	for _, d := range f.Decls {
+		decl, ok := d.(*ast.FuncDecl)
+		if !ok || decl.Body == nil || fn.cfg.FileSet.Position(decl.Pos()).Offset != offset {
+			continue
+		}
+
+		var comments []*ast.CommentGroup
+		for _, c := range f.Comments {
+			if c.Pos() >= decl.Body.Pos() && c.End() <= decl.Body.End() {
+				comments = append(comments, c)
+			}
+		}
+
+		// The documentation comment is already rendered above the body
+		withoutDoc := *decl
+		withoutDoc.Doc = nil
+
+		var out strings.Builder
+		p := printer.Config{Mode: printer.UseSpaces, Tabwidth: 4}
+		if err := p.Fprint(&out, fn.cfg.FileSet, &printer.CommentedNode{Node: &withoutDoc, Comments: comments}); err != nil {
+			return "", err
+		}
+
+		return out.String(), nil
+	}
+
+	return "", nil
+}
+
+// TypeLinks provides links to the documentation of the types from other
+// packages used in the function's signature (e.g. context.Context), in the
+// order they first appear.
+func (fn *Func) TypeLinks() []*Span {
+	return typeLinks(fn.cfg, fn.doc.Decl)
+}
+
 // Examples provides the list of examples from the list given on initialization
 // that pertain to the function.
 func (fn *Func) Examples() (examples []*Example) {
+	// Examples routed to a file of their own are documented there instead
+	if _, ok := fn.cfg.Routes[ExampleSection]; ok {
+		return nil
+	}
+
 	var fullName string
 	if fn.doc.Recv != "" {
 		fullName = fmt.Sprintf("%s_%s", fn.rawRecv(), fn.doc.Name)
@@ -120,12 +220,5 @@ func (fn *Func) Anchor() string {
 }
 
 func (fn *Func) rawRecv() string {
-	// remove type parameters
-	recv := strings.Split(fn.doc.Recv, "[")[0]
-
-	if strings.HasPrefix(recv, "*") {
-		return recv[1:]
-	}
-
-	return recv
+	return receiverTypeName(fn.doc.Recv)
 }
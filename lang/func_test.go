@@ -99,6 +99,40 @@ func TestFunc_Examples_generic(t *testing.T) {
 	is.Equal(ex.Name(), "")
 }
 
+func TestFunc_Anchor_generic(t *testing.T) {
+	is := is.New(t)
+	fn, err := loadFunc("../testData/lang/function", "WithGenericReceiver")
+	is.NoErr(err)
+
+	is.Equal(fn.Receiver(), "Generic[T]")
+	is.Equal(fn.Title(), "func (Generic[T]) WithGenericReceiver")
+	is.Equal(fn.Anchor(), "Generic.WithGenericReceiver")
+}
+
+func TestFunc_TypeParams(t *testing.T) {
+	tests := map[string]struct {
+		typeParams string
+		title      string
+	}{
+		"Func":       {"[S int | float64]", "func Func[S int | float64]"},
+		"Map":        {"[T, U any]", "func Map[T, U any]"},
+		"NewGeneric": {"[T any]", "func NewGeneric[T any]"},
+		"Set":        {"", "func (*Generic[T]) Set"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+
+			fn, err := loadFunc("../testData/generics", name)
+			is.NoErr(err)
+
+			is.Equal(fn.TypeParams(), test.typeParams)
+			is.Equal(fn.Title(), test.title)
+		})
+	}
+}
+
 func TestFunc_stringsCompare(t *testing.T) {
 	is := is.New(t)
 
@@ -201,14 +235,50 @@ func TestFunc_ioIoutilTempFile(t *testing.T) {
 	is.Equal(len(fn.Examples()), 2)
 }
 
-func loadFunc(dir, name string) (*lang.Func, error) {
+func TestFunc_Body(t *testing.T) {
+	is := is.New(t)
+
+	fn, err := loadFunc("../testData/lang/funcbody", "Clamp")
+	is.NoErr(err)
+
+	body, err := fn.Body()
+	is.NoErr(err)
+	is.Equal(body, `func Clamp(v, lo, hi int) int {
+    // Values below the range are raised first
+    if v < lo {
+        return lo
+    }
+
+    if v > hi {
+        return hi
+    }
+
+    return v
+}`)
+
+	fn, err = loadFunc("../testData/lang/funcbody", "Abs")
+	is.NoErr(err)
+
+	body, err = fn.Body()
+	is.NoErr(err)
+	is.Equal(body, "") // no directive
+
+	fn, err = loadFunc("../testData/lang/funcbody", "Abs", lang.PackageWithFuncBodies())
+	is.NoErr(err)
+
+	body, err = fn.Body()
+	is.NoErr(err)
+	is.True(strings.HasPrefix(body, "func Abs(v int) int {\n"))
+}
+
+func loadFunc(dir, name string, opts ...lang.PackageOption) (*lang.Func, error) {
 	buildPkg, err := getBuildPackage(dir)
 	if err != nil {
 		return nil, err
 	}
 
 	log := logger.New(logger.ErrorLevel)
-	pkg, err := lang.NewPackageFromBuild(log, buildPkg)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -235,3 +305,88 @@ func loadFunc(dir, name string) (*lang.Func, error) {
 
 	return nil, errors.New("func not found")
 }
+
+func TestFunc_typeLinks(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/lang/typelinks")
+	is.NoErr(err)
+
+	funcs := make(map[string]*lang.Func)
+	for _, fn := range pkg.Funcs() {
+		funcs[fn.Name()] = fn
+	}
+
+	links := funcs["Copy"].TypeLinks()
+	is.Equal(len(links), 2)
+	is.Equal(links[0].Text(), "stdio.Writer")
+	is.Equal(links[0].URL(), "https://pkg.go.dev/io#Writer")
+	is.Equal(links[1].Text(), "stdio.Reader")
+
+	is.Equal(len(funcs["Add"].TypeLinks()), 0)
+
+	typ := pkg.Types()[0]
+	is.Equal(len(typ.TypeLinks()), 2)
+	is.Equal(typ.TypeLinks()[0].URL(), "https://pkg.go.dev/net/http#Handler")
+
+	links = typ.Methods()[0].TypeLinks()
+	is.Equal(len(links), 2)
+	is.Equal(links[0].Text(), "context.Context")
+	is.Equal(links[0].URL(), "https://pkg.go.dev/context#Context")
+
+	buildPkg, err := getBuildPackage("../testData/lang/typelinks")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err = lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithoutTypeLinks())
+	is.NoErr(err)
+
+	is.Equal(len(pkg.Types()[0].TypeLinks()), 0)
+}
+
+func TestFunc_customTitle(t *testing.T) {
+	is := is.New(t)
+
+	fn, err := loadFunc("../testData/lang/title", "NewURL")
+	is.NoErr(err)
+
+	is.Equal(fn.CustomTitle(), "New Uniform Resource Locator")
+	is.Equal(fn.Title(), "New Uniform Resource Locator")
+	is.Equal(fn.Anchor(), "NewURL")
+	is.Equal(fn.Summary(), "NewURL creates a URL.")
+
+	fn, err = loadFunc("../testData/lang/title", "String")
+	is.NoErr(err)
+
+	is.Equal(fn.CustomTitle(), "")
+	is.Equal(fn.Title(), "func (*URL) String")
+}
+
+func TestFunc_inherited(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/inherit")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg)
+	is.NoErr(err)
+
+	methods := make(map[string]*lang.Func)
+	for _, fn := range pkg.Types()[0].Methods() {
+		methods[fn.Name()] = fn
+	}
+
+	is.Equal(methods["Append"].InheritedFrom(), "Builder.Write")
+	is.Equal(methods["Append"].Summary(), "Write appends the contents of p to the builder.")
+
+	is.Equal(methods["Len"].InheritedFrom(), "strings.Builder.Len")
+	is.Equal(methods["Len"].Summary(), "Len is a wrapper.")
+	is.Equal(len(methods["Len"].Doc().Blocks()), 2) // own documentation followed by the inherited one
+
+	is.Equal(methods["Write"].InheritedFrom(), "")
+
+	missing := pkg.Funcs()[0]
+	is.Equal(missing.InheritedFrom(), "") // the symbol doesn't exist
+	is.Equal(missing.Summary(), "")
+}
@@ -0,0 +1,136 @@
+package lang
+
+import (
+	"go/ast"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FuzzTarget holds a fuzz target declared in one of the _test.go files of a
+// package.
+type FuzzTarget struct {
+	cfg *Config
+	fn  *ast.FuncDecl
+}
+
+// Level provides the default level that headers for the fuzz target should be
+// rendered.
+func (t *FuzzTarget) Level() int {
+	return t.cfg.Level
+}
+
+// Name provides the name of the fuzz target function (e.g. FuzzParse).
+func (t *FuzzTarget) Name() string {
+	return t.fn.Name.Name
+}
+
+// Location returns a representation of the node's location in a file within a
+// repository.
+func (t *FuzzTarget) Location() Location {
+	return NewLocation(t.cfg, t.fn)
+}
+
+// Summary provides the one-sentence summary of the fuzz target's documentation
+// comment.
+func (t *FuzzTarget) Summary() string {
+	return extractSummary(t.fn.Doc.Text())
+}
+
+// Doc provides the structured contents of the documentation comment for the
+// fuzz target.
+func (t *FuzzTarget) Doc() *Doc {
+	return NewDoc(t.cfg.Inc(1), t.fn.Doc.Text())
+}
+
+// Seeds provides the number of calls adding seed inputs to the corpus in the
+// body of the fuzz target (e.g. f.Add("input")).
+func (t *FuzzTarget) Seeds() int {
+	if len(t.fn.Type.Params.List[0].Names) == 0 {
+		return 0
+	}
+
+	param := t.fn.Type.Params.List[0].Names[0].Name
+
+	var seeds int
+	ast.Inspect(t.fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Add" {
+			return true
+		}
+
+		if id, ok := sel.X.(*ast.Ident); ok && id.Name == param {
+			seeds++
+		}
+
+		return true
+	})
+
+	return seeds
+}
+
+// Corpus provides the path of the seed corpus directory of the fuzz target
+// relative to the package (e.g. testdata/fuzz/FuzzParse), or an empty string
+// if it doesn't have one.
+func (t *FuzzTarget) Corpus() string {
+	if t.CorpusSize() == 0 {
+		return ""
+	}
+
+	return path.Join("testdata", "fuzz", t.Name())
+}
+
+// CorpusSize provides the number of files in the seed corpus directory of the
+// fuzz target.
+func (t *FuzzTarget) CorpusSize() int {
+	entries, err := os.ReadDir(filepath.Join(t.cfg.PkgDir, "testdata", "fuzz", t.Name()))
+	if err != nil {
+		return 0
+	}
+
+	var size int
+	for _, e := range entries {
+		if e.Type().IsRegular() {
+			size++
+		}
+	}
+
+	return size
+}
+
+// FuzzTargets lists the fuzz targets declared in the _test.go files of the
+// package, sorted by name. The list is empty unless fuzz targets have been
+// enabled for the package.
+func (pkg *Package) FuzzTargets() (targets []*FuzzTarget) {
+	if !pkg.cfg.FuzzTargets {
+		return nil
+	}
+
+	for _, f := range pkg.cfg.Files {
+		if !strings.HasSuffix(pkg.cfg.FileSet.Position(f.Pos()).Filename, "_test.go") {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Body == nil || !isTestingFunc(fn, "Fuzz", "F") {
+				continue
+			}
+
+			targets = append(targets, &FuzzTarget{pkg.cfg.Inc(2), fn})
+		}
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Name() < targets[j].Name()
+	})
+
+	return
+}
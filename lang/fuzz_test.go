@@ -0,0 +1,38 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestPackage_FuzzTargets(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/fuzz")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithFuzzTargets())
+	is.NoErr(err)
+
+	targets := pkg.FuzzTargets()
+	is.Equal(len(targets), 2)
+
+	is.Equal(targets[0].Name(), "FuzzParse")
+	is.Equal(targets[0].Summary(), "FuzzParse checks that parsed numbers are positive.")
+	is.Equal(targets[0].Level(), pkg.Level()+2)
+	is.Equal(targets[0].Seeds(), 2)
+	is.Equal(targets[0].Corpus(), "testdata/fuzz/FuzzParse")
+	is.Equal(targets[0].CorpusSize(), 1)
+
+	is.Equal(targets[1].Name(), "FuzzParseEmpty")
+	is.Equal(targets[1].Seeds(), 0)
+	is.Equal(targets[1].Corpus(), "")
+
+	pkg, err = loadPackage("../testData/lang/fuzz")
+	is.NoErr(err)
+	is.Equal(len(pkg.FuzzTargets()), 0) // left out by default
+}
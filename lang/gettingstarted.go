@@ -0,0 +1,126 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// GettingStarted holds a guided walkthrough of the package-level examples
+	// of a set of packages, such as the packages of a module. Only examples
+	// with a //gomarkdoc:order directive are included, sequenced by the
+	// number in the directive.
+	GettingStarted struct {
+		steps []*GettingStartedStep
+	}
+
+	// GettingStartedStep is a single example of a GettingStarted walkthrough.
+	GettingStartedStep struct {
+		number  int
+		pkg     *Package
+		example *Example
+	}
+)
+
+const exampleOrderDirective = "//gomarkdoc:order "
+
+// NewGettingStarted creates a walkthrough of the package-level examples of the
+// provided packages which have a //gomarkdoc:order directive, such as
+// "//gomarkdoc:order 2". Examples are sequenced by the number in their
+// directive, with examples sharing a number kept in the order of the packages
+// and of the examples within each package. An error is returned if a directive
+// doesn't hold a number.
+func NewGettingStarted(packages []*Package) (*GettingStarted, error) {
+	type ordered struct {
+		order int
+		step  *GettingStartedStep
+	}
+
+	var steps []ordered
+	for _, pkg := range packages {
+		for _, example := range pkg.examples {
+			if example.Name != "" && !strings.HasPrefix(example.Name, "_") {
+				continue
+			}
+
+			value := exampleOrder(pkg.cfg, example)
+			if value == "" {
+				continue
+			}
+
+			order, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("gomarkdoc: invalid order %s for example Example%s of package %s", value, example.Name, pkg.ImportPath())
+			}
+
+			steps = append(steps, ordered{order, &GettingStartedStep{
+				pkg:     pkg,
+				example: NewExample(pkg.cfg.Inc(1), strings.TrimPrefix(example.Name, "_"), example),
+			}})
+		}
+	}
+
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].order < steps[j].order
+	})
+
+	g := &GettingStarted{}
+	for i, s := range steps {
+		s.step.number = i + 1
+		g.steps = append(g.steps, s.step)
+	}
+
+	return g, nil
+}
+
+// Steps provides the examples of the walkthrough in order.
+func (g *GettingStarted) Steps() []*GettingStartedStep {
+	return g.steps
+}
+
+// Number provides the position of the step in the walkthrough, starting at 1.
+func (s *GettingStartedStep) Number() int {
+	return s.number
+}
+
+// Package provides the package the example of the step belongs to.
+func (s *GettingStartedStep) Package() *Package {
+	return s.pkg
+}
+
+// Example provides the example of the step.
+func (s *GettingStartedStep) Example() *Example {
+	return s.example
+}
+
+// Title provides the title of the step, which is the summary of the example's
+// documentation comment if it has one, or the title of the example otherwise.
+func (s *GettingStartedStep) Title() string {
+	if summary := s.example.Summary(); summary != "" {
+		return strings.TrimSuffix(summary, ".")
+	}
+
+	return s.example.Title()
+}
+
+// exampleOrder finds the position set with a //gomarkdoc:order directive on the
+// function declaring the provided example, if any.
+func exampleOrder(cfg *Config, example *doc.Example) string {
+	name := fmt.Sprintf("Example%s", example.Name)
+	for _, file := range cfg.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Name.Name != name {
+				continue
+			}
+
+			return directiveValue(exampleOrderDirective, fn.Doc)
+		}
+	}
+
+	return ""
+}
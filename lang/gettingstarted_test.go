@@ -0,0 +1,39 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/matryer/is"
+)
+
+func TestNewGettingStarted(t *testing.T) {
+	is := is.New(t)
+
+	var pkgs []*lang.Package
+	for _, dir := range []string{"../testData/lang/gettingstarted/usage", "../testData/lang/gettingstarted/setup"} {
+		pkg, err := loadPackage(dir)
+		is.NoErr(err)
+
+		pkgs = append(pkgs, pkg)
+	}
+
+	g, err := lang.NewGettingStarted(pkgs)
+	is.NoErr(err)
+
+	// Unordered and symbol examples are left out
+	steps := g.Steps()
+	is.Equal(len(steps), 3)
+
+	is.Equal(steps[0].Number(), 1)
+	is.Equal(steps[0].Title(), "Create a client")
+	is.Equal(steps[0].Package().Name(), "setup")
+	is.True(steps[0].Example().HasOutput())
+
+	is.Equal(steps[1].Number(), 2)
+	is.Equal(steps[1].Title(), "Send a query")
+	is.Equal(steps[1].Package().Name(), "usage")
+
+	is.Equal(steps[2].Number(), 3)
+	is.Equal(steps[2].Title(), "Example (Reuse)")
+}
@@ -0,0 +1,270 @@
+package lang
+
+import (
+	"fmt"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type (
+	// ImplementerIndex holds the type information of the modules containing
+	// the documented packages, which is used to find the types implementing
+	// their interfaces and the interfaces implemented by their types. Each
+	// module is loaded once, when the first package
+	// within it asks for implementers, so the index should be shared by all of
+	// the packages documented together.
+	ImplementerIndex struct {
+		imp     *SourceImporter
+		mu      sync.Mutex
+		modules map[string]map[string]*types.Package
+	}
+
+	// implementer identifies a type implementing an interface, or an
+	// interface implemented by a type. Pointer is set when only a pointer to
+	// the type implements the interface and local when it is declared in the
+	// documented package.
+	implementer struct {
+		pkg     *types.Package
+		name    string
+		pointer bool
+		local   bool
+	}
+)
+
+// NewImplementerIndex creates an empty ImplementerIndex which type checks
+// modules with the provided importer, which can be shared with the packages
+// documented along with the index.
+func NewImplementerIndex(imp *SourceImporter) *ImplementerIndex {
+	return &ImplementerIndex{
+		imp:     imp,
+		modules: make(map[string]map[string]*types.Package),
+	}
+}
+
+// packages provides the type information of the packages of the module
+// rooted at the provided directory, keyed by the directory of each package,
+// loading them on first use. Like the go command, directories named testdata
+// or vendor, those starting with "." or "_" and nested modules are skipped.
+func (idx *ImplementerIndex) packages(cfg *Config, root string) map[string]*types.Package {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if pkgs, ok := idx.modules[root]; ok {
+		return pkgs
+	}
+
+	pkgs := make(map[string]*types.Package)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+
+		if p != root {
+			name := d.Name()
+			if name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+
+			if _, err := os.Stat(filepath.Join(p, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+
+		if pkg, ok := idx.imp.importDir(p); ok {
+			pkgs[p] = pkg
+		}
+
+		return nil
+	})
+	if err != nil {
+		cfg.Log.Warnf("unable to load module %s to find implementers: %s", root, err)
+	}
+
+	idx.modules[root] = pkgs
+	return pkgs
+}
+
+// implementers finds the exported types of the module which implement the
+// interface with the provided name from the documented package, sorted with
+// those of the package itself first and then by import path and name. Empty
+// interfaces are implemented by every type, so they have no implementers
+// listed.
+func (idx *ImplementerIndex) implementers(cfg *Config, name string) []implementer {
+	pkgs := idx.packages(cfg, moduleRoot(cfg.PkgDir))
+	pkg, ok := pkgs[cfg.PkgDir]
+	if !ok {
+		return nil
+	}
+
+	obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+
+	iface, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok || iface.Empty() || !iface.IsMethodSet() {
+		return nil
+	}
+
+	var found []implementer
+	for _, p := range pkgs {
+		for _, named := range exportedTypes(p, false) {
+			if types.Implements(named, iface) {
+				found = append(found, implementer{p, named.Obj().Name(), false, p == pkg})
+			} else if types.Implements(types.NewPointer(named), iface) {
+				found = append(found, implementer{p, named.Obj().Name(), true, p == pkg})
+			}
+		}
+	}
+
+	sortImplementers(found)
+	return found
+}
+
+// implemented finds the exported interfaces of the module which are
+// implemented by the type with the provided name from the documented package,
+// or by a pointer to it, sorted like the implementers of an interface. Empty
+// interfaces are implemented by every type, so they are left out.
+func (idx *ImplementerIndex) implemented(cfg *Config, name string) []implementer {
+	pkgs := idx.packages(cfg, moduleRoot(cfg.PkgDir))
+	pkg, ok := pkgs[cfg.PkgDir]
+	if !ok {
+		return nil
+	}
+
+	obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+
+	named, ok := obj.Type().(*types.Named)
+	if !ok || named.TypeParams().Len() > 0 || types.IsInterface(named) {
+		return nil
+	}
+
+	var found []implementer
+	for _, p := range pkgs {
+		for _, ifaceType := range exportedTypes(p, true) {
+			iface := ifaceType.Underlying().(*types.Interface)
+			if iface.Empty() || !iface.IsMethodSet() {
+				continue
+			}
+
+			if types.Implements(named, iface) {
+				found = append(found, implementer{p, ifaceType.Obj().Name(), false, p == pkg})
+			} else if types.Implements(types.NewPointer(named), iface) {
+				found = append(found, implementer{p, ifaceType.Obj().Name(), true, p == pkg})
+			}
+		}
+	}
+
+	sortImplementers(found)
+	return found
+}
+
+// exportedTypes provides the exported, non-generic named types declared by the
+// package, which are either its interfaces or the rest of its types.
+func exportedTypes(pkg *types.Package, interfaces bool) []*types.Named {
+	var named []*types.Named
+	scope := pkg.Scope()
+	for _, n := range scope.Names() {
+		tn, ok := scope.Lookup(n).(*types.TypeName)
+		if !ok || !tn.Exported() || tn.IsAlias() {
+			continue
+		}
+
+		t, ok := tn.Type().(*types.Named)
+		if !ok || t.TypeParams().Len() > 0 || types.IsInterface(t) != interfaces {
+			continue
+		}
+
+		named = append(named, t)
+	}
+
+	return named
+}
+
+// sortImplementers sorts the types declared in the documented package first,
+// followed by the rest by import path and name.
+func sortImplementers(found []implementer) {
+	sort.Slice(found, func(i, j int) bool {
+		a, b := found[i], found[j]
+		if a.local != b.local {
+			return a.local
+		}
+
+		if a.pkg.Path() != b.pkg.Path() {
+			return a.pkg.Path() < b.pkg.Path()
+		}
+
+		return a.name < b.name
+	})
+}
+
+// Implementers provides links to the documentation of the types within the
+// module that implement the interface, with the types of the package itself
+// listed first. Types from other packages are qualified by the name of their
+// package and types which only implement the interface through a pointer are
+// prefixed with "*". Implementers are only listed for interfaces when an
+// ImplementerIndex is provided for the package with PackageWithImplementers.
+func (typ *Type) Implementers() []*Span {
+	if typ.cfg.Implementers == nil {
+		return nil
+	}
+
+	return implementerLinks(typ.cfg, typ.cfg.Implementers.implementers(typ.cfg, typ.doc.Name), true)
+}
+
+// Implements provides links to the documentation of the interfaces within the
+// module that the type implements, with the interfaces of the package itself
+// listed first. Interfaces from other packages are qualified by the name of
+// their package, and interfaces which are only implemented by a pointer to the
+// type are included. Interfaces are only listed for types other than
+// interfaces when an ImplementerIndex is provided for the package with
+// PackageWithImplementedInterfaces.
+func (typ *Type) Implements() []*Span {
+	if typ.cfg.Implemented == nil {
+		return nil
+	}
+
+	return implementerLinks(typ.cfg, typ.cfg.Implemented.implemented(typ.cfg, typ.doc.Name), false)
+}
+
+// implementerLinks provides links to the documentation of the types, which
+// are prefixed with "*" when only their pointer implements the interface if
+// pointers is set.
+func implementerLinks(cfg *Config, impls []implementer, pointers bool) []*Span {
+	var links []*Span
+	for _, impl := range impls {
+		text := impl.name
+		if !impl.local {
+			text = fmt.Sprintf("%s.%s", impl.pkg.Name(), impl.name)
+		}
+
+		if pointers && impl.pointer {
+			text = "*" + text
+		}
+
+		var url string
+		if impl.local {
+			if sym, ok := cfg.Symbols[impl.name]; ok {
+				url = symbolHref(cfg, impl.name, sym)
+			}
+		} else {
+			url, _ = importedSymbolURL(cfg, impl.pkg.Path(), impl.name)
+		}
+
+		if url == "" {
+			links = append(links, NewSpan(cfg.Inc(0), TextSpan, text, ""))
+		} else {
+			links = append(links, NewSpan(cfg.Inc(0), LinkSpan, text, url))
+		}
+	}
+
+	return links
+}
@@ -0,0 +1,75 @@
+package lang
+
+import (
+	"sort"
+	"sync"
+)
+
+// ImporterIndex holds the imports of the packages documented together, which
+// are used to list the packages importing each of them. Since importers are
+// found when the documentation is rendered, packages can be added to the index
+// after the packages they import are created.
+type ImporterIndex struct {
+	mu      sync.Mutex
+	imports map[string][]string
+}
+
+// NewImporterIndex creates an empty ImporterIndex.
+func NewImporterIndex() *ImporterIndex {
+	return &ImporterIndex{imports: make(map[string][]string)}
+}
+
+// Add adds the imports of the package to the index. Packages without a known
+// import path are ignored.
+func (idx *ImporterIndex) Add(pkg *Package) {
+	if pkg.ImportPath() == unknownImportPath {
+		return
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.imports[pkg.ImportPath()] = pkg.cfg.Imports
+}
+
+// importers provides the import paths of the packages in the index which
+// import the package with the provided import path, sorted.
+func (idx *ImporterIndex) importers(importPath string) []string {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	var found []string
+	for path, imports := range idx.imports {
+		for _, imp := range imports {
+			if imp == importPath && path != importPath {
+				found = append(found, path)
+				break
+			}
+		}
+	}
+
+	sort.Strings(found)
+	return found
+}
+
+// Importers provides links to the documentation of the other packages which
+// import the package, sorted by import path. Importers are only listed when an
+// ImporterIndex is provided for the package with PackageWithImporters, and
+// only packages added to the index are included, so imports from outside of
+// the packages documented together aren't known.
+func (pkg *Package) Importers() []*Span {
+	if pkg.cfg.Importers == nil {
+		return nil
+	}
+
+	var links []*Span
+	for _, path := range pkg.cfg.Importers.importers(pkg.ImportPath()) {
+		if url, ok := importedSymbolURL(pkg.cfg, path, ""); ok && url != "" {
+			links = append(links, NewSpan(pkg.cfg.Inc(0), LinkSpan, path, url))
+		} else {
+			links = append(links, NewSpan(pkg.cfg.Inc(0), TextSpan, path, ""))
+		}
+	}
+
+	return links
+}
@@ -0,0 +1,51 @@
+package lang_test
+
+import (
+	"go/build"
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestPackage_Importers(t *testing.T) {
+	is := is.New(t)
+
+	importers := lang.NewImporterIndex()
+	symbols := lang.NewSymbolIndex()
+	log := logger.New(logger.ErrorLevel)
+
+	pkgs := make(map[string]*lang.Package)
+	for _, name := range []string{"base", "client", "server"} {
+		buildPkg, err := build.ImportDir("../testData/lang/importers/"+name, build.ImportComment)
+		is.NoErr(err)
+
+		file := "docs/" + name + "/README.md"
+		pkg, err := lang.NewPackageFromBuild(
+			log,
+			buildPkg,
+			lang.PackageWithImporters(importers),
+			lang.PackageWithSymbolIndex(symbols, file),
+		)
+		is.NoErr(err)
+
+		importers.Add(pkg)
+		symbols.Add(pkg, file)
+		pkgs[name] = pkg
+	}
+
+	spans := pkgs["base"].Importers()
+	is.Equal(len(spans), 2)
+	is.Equal(spans[0].Text(), "github.com/anthonyme00/gomarkdoc/testData/lang/importers/client")
+	is.Equal(spans[0].Kind(), lang.LinkSpan)
+	is.Equal(spans[0].URL(), "../client/README.md")
+	is.Equal(spans[1].Text(), "github.com/anthonyme00/gomarkdoc/testData/lang/importers/server")
+	is.Equal(spans[1].URL(), "../server/README.md")
+
+	spans = pkgs["client"].Importers()
+	is.Equal(len(spans), 1)
+	is.Equal(spans[0].Text(), "github.com/anthonyme00/gomarkdoc/testData/lang/importers/server")
+
+	is.Equal(len(pkgs["server"].Importers()), 0)
+}
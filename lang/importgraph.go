@@ -0,0 +1,111 @@
+package lang
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// ImportGraphFormat defines the language an ImportGraph is written in.
+	ImportGraphFormat string
+
+	// ImportGraph holds the import relationships among a set of packages
+	// documented together. Only imports of the other packages in the set are
+	// included, so imports of the standard library and of other modules are
+	// left out.
+	ImportGraph struct {
+		format ImportGraphFormat
+		paths  []string
+		edges  [][2]string
+	}
+)
+
+const (
+	// MermaidImportGraph writes the graph as a Mermaid flowchart.
+	MermaidImportGraph ImportGraphFormat = "mermaid"
+
+	// DOTImportGraph writes the graph as a Graphviz DOT digraph.
+	DOTImportGraph ImportGraphFormat = "dot"
+)
+
+// NewImportGraph creates an ImportGraph of the imports among the provided
+// packages in the provided format. Packages and their imports are sorted by
+// import path.
+func NewImportGraph(format ImportGraphFormat, packages []*Package) (*ImportGraph, error) {
+	switch format {
+	case MermaidImportGraph, DOTImportGraph:
+	default:
+		return nil, fmt.Errorf("gomarkdoc: invalid import graph format %s, expected mermaid or dot", format)
+	}
+
+	documented := make(map[string]bool, len(packages))
+	for _, pkg := range packages {
+		documented[pkg.ImportPath()] = true
+	}
+
+	g := &ImportGraph{format: format}
+	for path := range documented {
+		g.paths = append(g.paths, path)
+	}
+
+	sort.Strings(g.paths)
+
+	for _, pkg := range packages {
+		for _, imp := range pkg.cfg.Imports {
+			if documented[imp] && imp != pkg.ImportPath() {
+				g.edges = append(g.edges, [2]string{pkg.ImportPath(), imp})
+			}
+		}
+	}
+
+	sort.Slice(g.edges, func(i, j int) bool {
+		if g.edges[i][0] != g.edges[j][0] {
+			return g.edges[i][0] < g.edges[j][0]
+		}
+
+		return g.edges[i][1] < g.edges[j][1]
+	})
+
+	return g, nil
+}
+
+// Format provides the language the graph is written in, which is also the
+// language of the code block it is embedded in.
+func (g *ImportGraph) Format() ImportGraphFormat {
+	return g.format
+}
+
+// Source provides the source of the graph, with an arrow from each package to
+// each of the packages it imports.
+func (g *ImportGraph) Source() string {
+	var b strings.Builder
+	switch g.format {
+	case DOTImportGraph:
+		b.WriteString("digraph imports {\n")
+		for _, path := range g.paths {
+			fmt.Fprintf(&b, "    %s;\n", strconv.Quote(path))
+		}
+
+		for _, edge := range g.edges {
+			fmt.Fprintf(&b, "    %s -> %s;\n", strconv.Quote(edge[0]), strconv.Quote(edge[1]))
+		}
+
+		b.WriteString("}")
+	default:
+		// Import paths aren't valid node IDs, so they are used as labels
+		ids := make(map[string]string, len(g.paths))
+		b.WriteString("flowchart LR")
+		for i, path := range g.paths {
+			ids[path] = fmt.Sprintf("p%d", i)
+			fmt.Fprintf(&b, "\n    %s[\"%s\"]", ids[path], strings.ReplaceAll(path, `"`, "#quot;"))
+		}
+
+		for _, edge := range g.edges {
+			fmt.Fprintf(&b, "\n    %s --> %s", ids[edge[0]], ids[edge[1]])
+		}
+	}
+
+	return b.String()
+}
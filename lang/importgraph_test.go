@@ -0,0 +1,41 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/matryer/is"
+)
+
+func TestNewImportGraph(t *testing.T) {
+	is := is.New(t)
+
+	var pkgs []*lang.Package
+	for _, dir := range []string{"github.com/anthonyme00/gomarkdoc/testData/simple", "github.com/anthonyme00/gomarkdoc/testData/lang/buildinfo", "github.com/anthonyme00/gomarkdoc/testData/lang/function"} {
+		pkg, err := loadPackage(dir)
+		is.NoErr(err)
+
+		pkgs = append(pkgs, pkg)
+	}
+
+	g, err := lang.NewImportGraph(lang.MermaidImportGraph, pkgs)
+	is.NoErr(err)
+	is.Equal(g.Format(), lang.MermaidImportGraph)
+	is.Equal(g.Source(), `flowchart LR
+    p0["github.com/anthonyme00/gomarkdoc/testData/lang/buildinfo"]
+    p1["github.com/anthonyme00/gomarkdoc/testData/lang/function"]
+    p2["github.com/anthonyme00/gomarkdoc/testData/simple"]
+    p0 --> p2`)
+
+	g, err = lang.NewImportGraph(lang.DOTImportGraph, pkgs)
+	is.NoErr(err)
+	is.Equal(g.Source(), `digraph imports {
+    "github.com/anthonyme00/gomarkdoc/testData/lang/buildinfo";
+    "github.com/anthonyme00/gomarkdoc/testData/lang/function";
+    "github.com/anthonyme00/gomarkdoc/testData/simple";
+    "github.com/anthonyme00/gomarkdoc/testData/lang/buildinfo" -> "github.com/anthonyme00/gomarkdoc/testData/simple";
+}`)
+
+	_, err = lang.NewImportGraph("svg", pkgs)
+	is.True(err != nil)
+}
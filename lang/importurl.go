@@ -0,0 +1,133 @@
+package lang
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// DefaultImportURL is the template used to resolve the documentation url of
+// import paths which don't match any of the rules of an ImportURLResolver.
+const DefaultImportURL = "https://pkg.go.dev/{{.ImportPath}}"
+
+// ImportURLFallback is the prefix which can be used in the rules provided to
+// NewImportURLResolver to replace DefaultImportURL.
+const ImportURLFallback = "*"
+
+type (
+	// ImportURLResolver maps import paths to the urls of their documentation,
+	// such as an internal documentation portal, pkg.go.dev or the documentation
+	// files generated for sibling packages. Features linking to the
+	// documentation of other packages resolve their urls through it so links
+	// are consistent. A nil resolver resolves all import paths using
+	// DefaultImportURL.
+	ImportURLResolver struct {
+		rules    []importURLRule
+		fallback *template.Template
+	}
+
+	// ImportURLData holds the data available to the url templates of an
+	// ImportURLResolver.
+	ImportURLData struct {
+		// ImportPath holds the full import path being resolved.
+		ImportPath string
+
+		// Prefix holds the import path prefix of the matching rule. It is empty
+		// for the fallback url.
+		Prefix string
+
+		// Path holds the rest of the import path after the prefix, without a
+		// leading slash. It is empty if the import path is the prefix itself.
+		Path string
+	}
+
+	importURLRule struct {
+		prefix string
+		tmpl   *template.Template
+	}
+)
+
+var defaultImportURLTemplate = template.Must(template.New("importURL").Parse(DefaultImportURL))
+
+// NewImportURLResolver creates an ImportURLResolver from the provided rules,
+// which map import path prefixes to templates for the url of the matching
+// import paths (e.g. "github.com/org/repo" to
+// "https://docs.example.com/{{.Path}}"). The templates are given an
+// ImportURLData. A prefix matches import paths which are equal to it or are
+// nested within it, and the longest matching prefix is used. Prefixes are
+// matched case insensitively, since configuration files may not preserve their
+// case. The ImportURLFallback prefix replaces DefaultImportURL.
+func NewImportURLResolver(rules map[string]string) (*ImportURLResolver, error) {
+	r := &ImportURLResolver{fallback: defaultImportURLTemplate}
+	for prefix, urlTmpl := range rules {
+		tmpl, err := template.New("importURL").Parse(urlTmpl)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: invalid import url template for %s: %w", prefix, err)
+		}
+
+		if prefix == ImportURLFallback {
+			r.fallback = tmpl
+			continue
+		}
+
+		r.rules = append(r.rules, importURLRule{strings.TrimSuffix(prefix, "/"), tmpl})
+	}
+
+	// Longer prefixes take precedence over the prefixes they're nested in
+	sort.Slice(r.rules, func(i, j int) bool {
+		return len(r.rules[i].prefix) > len(r.rules[j].prefix)
+	})
+
+	return r, nil
+}
+
+// Resolve provides the url of the documentation for the provided import path.
+// If a symbol is provided (e.g. "Client" or "Client.Do"), the url points at
+// the symbol within the package's documentation.
+func (r *ImportURLResolver) Resolve(importPath, symbol string) (string, error) {
+	data := ImportURLData{ImportPath: importPath}
+	tmpl := defaultImportURLTemplate
+
+	if r != nil {
+		tmpl = r.fallback
+		for _, rule := range r.rules {
+			if rest, ok := matchImportPrefix(importPath, rule.prefix); ok {
+				data.Prefix = importPath[:len(rule.prefix)]
+				data.Path = rest
+				tmpl = rule.tmpl
+				break
+			}
+		}
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("gomarkdoc: failed to resolve url for import path %s: %w", importPath, err)
+	}
+
+	if symbol != "" {
+		return fmt.Sprintf("%s#%s", b.String(), symbol), nil
+	}
+
+	return b.String(), nil
+}
+
+// matchImportPrefix checks whether the import path is the prefix or is nested
+// within it, providing the rest of the import path after the prefix.
+func matchImportPrefix(importPath, prefix string) (string, bool) {
+	if len(importPath) < len(prefix) || !strings.EqualFold(importPath[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	rest := importPath[len(prefix):]
+	if rest == "" {
+		return "", true
+	}
+
+	if !strings.HasPrefix(rest, "/") {
+		return "", false
+	}
+
+	return rest[1:], true
+}
@@ -0,0 +1,69 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/matryer/is"
+)
+
+func TestImportURLResolver_Resolve(t *testing.T) {
+	r, err := lang.NewImportURLResolver(map[string]string{
+		"github.com/org/repo":          "https://docs.example.com/{{.Path}}",
+		"github.com/org/repo/internal": "https://internal.example.com/{{.ImportPath}}",
+		"github.com/org/site/":         "/api/{{.Path}}/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		importPath string
+		symbol     string
+		result     string
+	}{
+		{"github.com/org/repo/pkg/client", "", "https://docs.example.com/pkg/client"},
+		{"github.com/org/repo/pkg/client", "Client.Do", "https://docs.example.com/pkg/client#Client.Do"},
+		{"github.com/org/repo", "", "https://docs.example.com/"},
+		{"github.com/org/repo/internal/auth", "", "https://internal.example.com/github.com/org/repo/internal/auth"},
+		{"github.com/org/repository", "", "https://pkg.go.dev/github.com/org/repository"},
+		{"github.com/org/site/util", "", "/api/util/"},
+		{"net/http", "Client", "https://pkg.go.dev/net/http#Client"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.importPath, func(t *testing.T) {
+			is := is.New(t)
+
+			res, err := r.Resolve(test.importPath, test.symbol)
+			is.NoErr(err)
+			is.Equal(res, test.result)
+		})
+	}
+}
+
+func TestImportURLResolver_fallback(t *testing.T) {
+	is := is.New(t)
+
+	r, err := lang.NewImportURLResolver(map[string]string{
+		lang.ImportURLFallback: "https://mirror.example.com/{{.ImportPath}}",
+	})
+	is.NoErr(err)
+
+	res, err := r.Resolve("net/http", "")
+	is.NoErr(err)
+	is.Equal(res, "https://mirror.example.com/net/http")
+
+	// A nil resolver uses the default url
+	var nilResolver *lang.ImportURLResolver
+	res, err = nilResolver.Resolve("net/http", "")
+	is.NoErr(err)
+	is.Equal(res, "https://pkg.go.dev/net/http")
+}
+
+func TestNewImportURLResolver_invalid(t *testing.T) {
+	is := is.New(t)
+
+	_, err := lang.NewImportURLResolver(map[string]string{"github.com/org/repo": "{{.Path"})
+	is.True(err != nil)
+}
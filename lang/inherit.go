@@ -0,0 +1,174 @@
+package lang
+
+import (
+	"go/ast"
+	"go/build"
+	"go/doc"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+)
+
+// inheritDirective copies the documentation of another symbol to a function or
+// method, for thin wrappers which would otherwise be left undocumented or
+// repeat the documentation of the symbol they delegate to. The symbol is named
+// relative to the package (e.g. "Client.Do") or through one of the file's
+// imports (e.g. "http.Client.Do").
+const inheritDirective = "//gomarkdoc:inherit "
+
+// inheritDocs adds the documentation of the symbols named by the
+// //gomarkdoc:inherit directives of the package's functions and methods to
+// their own, recording the symbol each one inherits from in the config. A
+// warning is logged for symbols whose documentation can't be found.
+func inheritDocs(cfg *Config) {
+	imported := make(map[string]*doc.Package)
+
+	inherit := func(fn *doc.Func) {
+		if fn.Decl == nil {
+			return
+		}
+
+		target := directiveValue(inheritDirective, sourceDoc(cfg, fn.Decl))
+		if target == "" {
+			return
+		}
+
+		text, ok := inheritedDoc(cfg, fn.Decl, target, imported)
+		if !ok {
+			cfg.Log.Warnf("unable to find documentation of %s to inherit for %s", target, symbolName(fn.Recv, fn.Name))
+			return
+		}
+
+		if strings.TrimSpace(fn.Doc) != "" {
+			text = fn.Doc + "\n" + text
+		}
+
+		fn.Doc = text
+
+		if cfg.Inherited == nil {
+			cfg.Inherited = make(map[*doc.Func]string)
+		}
+
+		cfg.Inherited[fn] = target
+	}
+
+	for _, fn := range cfg.Pkg.Funcs {
+		inherit(fn)
+	}
+
+	for _, typ := range cfg.Pkg.Types {
+		for _, fn := range typ.Funcs {
+			inherit(fn)
+		}
+
+		for _, fn := range typ.Methods {
+			inherit(fn)
+		}
+	}
+}
+
+// inheritedDoc finds the documentation of the named symbol, looking in the
+// package itself first and then in the package imported by the file of the
+// declaration under the first part of the name. Imported packages are parsed
+// once and kept in the provided map by import path.
+func inheritedDoc(cfg *Config, decl ast.Node, target string, imported map[string]*doc.Package) (string, bool) {
+	if text, ok := symbolDoc(cfg.Pkg, target); ok {
+		return text, true
+	}
+
+	name, rest, ok := strings.Cut(target, ".")
+	if !ok {
+		return "", false
+	}
+
+	f := declFile(cfg, decl)
+	if f == nil {
+		return "", false
+	}
+
+	importPath, ok := fileImports(f)[name]
+	if !ok {
+		return "", false
+	}
+
+	pkg, ok := imported[importPath]
+	if !ok {
+		pkg = importedDocPkg(cfg, importPath)
+		imported[importPath] = pkg
+	}
+
+	if pkg == nil {
+		return "", false
+	}
+
+	return symbolDoc(pkg, rest)
+}
+
+// importedDocPkg computes the documentation of the package with the provided
+// import path, as imported from the directory of the documented package. Nil
+// is returned if the package can't be found or parsed.
+func importedDocPkg(cfg *Config, importPath string) *doc.Package {
+	pkg, err := build.Import(importPath, cfg.PkgDir, 0)
+	if err != nil {
+		cfg.Log.Debugf("unable to find package %s to inherit documentation from: %s", importPath, err)
+		return nil
+	}
+
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(pkg.GoFiles))
+	for _, name := range pkg.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(pkg.Dir, name), nil, parser.ParseComments)
+		if err != nil {
+			cfg.Log.Debugf("unable to parse package %s to inherit documentation from: %s", importPath, err)
+			return nil
+		}
+
+		files = append(files, f)
+	}
+
+	docPkg, err := doc.NewFromFiles(fset, files, importPath)
+	if err != nil {
+		cfg.Log.Debugf("unable to compute documentation of package %s to inherit from: %s", importPath, err)
+		return nil
+	}
+
+	return docPkg
+}
+
+// symbolDoc finds the documentation of the function, type or method with the
+// provided name (e.g. "Type.Method") in the package.
+func symbolDoc(pkg *doc.Package, name string) (string, bool) {
+	for _, fn := range pkg.Funcs {
+		if fn.Name == name {
+			return fn.Doc, true
+		}
+	}
+
+	for _, typ := range pkg.Types {
+		if typ.Name == name {
+			return typ.Doc, true
+		}
+
+		for _, fn := range typ.Funcs {
+			if fn.Name == name {
+				return fn.Doc, true
+			}
+		}
+
+		for _, fn := range typ.Methods {
+			if symbolName(typ.Name, fn.Name) == name {
+				return fn.Doc, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// InheritedFrom provides the symbol the function's documentation was
+// inherited from with a //gomarkdoc:inherit directive (e.g. "http.Client.Do"),
+// or an empty string if it wasn't inherited.
+func (fn *Func) InheritedFrom() string {
+	return fn.cfg.Inherited[fn.doc]
+}
@@ -0,0 +1,119 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// InterfaceMethod holds documentation information for a single method declared
+// within an interface type.
+type InterfaceMethod struct {
+	cfg      *Config
+	typeName string
+	field    *ast.Field
+}
+
+// NewInterfaceMethod creates a new InterfaceMethod from the name of the
+// interface type declaring it and the field representing the method within the
+// interface's method list.
+func NewInterfaceMethod(cfg *Config, typeName string, field *ast.Field) *InterfaceMethod {
+	return &InterfaceMethod{cfg, typeName, field}
+}
+
+// Level provides the default level at which headers for the method should be
+// rendered in the final documentation.
+func (m *InterfaceMethod) Level() int {
+	return m.cfg.Level
+}
+
+// Name provides the name of the method.
+func (m *InterfaceMethod) Name() string {
+	return m.field.Names[0].Name
+}
+
+// Receiver provides the name of the interface type declaring the method.
+func (m *InterfaceMethod) Receiver() string {
+	return m.typeName
+}
+
+// Signature provides the raw text representation of the method's signature as
+// it appears in the interface (e.g. "Read(p []byte) (n int, err error)").
+func (m *InterfaceMethod) Signature() (string, error) {
+	// We use a custom FileSet so that we don't inherit multiline formatting
+	sig, err := printNode(m.field.Type, token.NewFileSet())
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%s", m.Name(), strings.TrimPrefix(sig, "func")), nil
+}
+
+// Location returns a representation of the node's location in a file within a
+// repository.
+func (m *InterfaceMethod) Location() Location {
+	return NewLocation(m.cfg, m.field)
+}
+
+// Summary provides the one-sentence summary of the method's documentation
+// comment.
+func (m *InterfaceMethod) Summary() string {
+	return extractSummary(m.field.Doc.Text())
+}
+
+// Doc provides the structured contents of the documentation comment for the
+// method.
+func (m *InterfaceMethod) Doc() *Doc {
+	return NewDoc(m.cfg.Inc(1), m.field.Doc.Text())
+}
+
+// Anchor produces anchor text for the method. Interface methods have no
+// headers of their own, so the anchor is the one of the interface type.
+func (m *InterfaceMethod) Anchor() string {
+	return Symbol{
+		Kind: TypeSymbolKind,
+		Name: m.typeName,
+	}.Anchor()
+}
+
+// stripInterfaceMethodDocs provides a copy of the declaration of an interface
+// type without the doc comments of its methods. The original declaration is
+// left untouched.
+func stripInterfaceMethodDocs(decl *ast.GenDecl) *ast.GenDecl {
+	stripped := *decl
+	stripped.Specs = make([]ast.Spec, len(decl.Specs))
+	for i, spec := range decl.Specs {
+		stripped.Specs[i] = spec
+
+		ts, ok := spec.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+
+		iface, ok := ts.Type.(*ast.InterfaceType)
+		if !ok || iface.Methods == nil {
+			continue
+		}
+
+		methods := *iface.Methods
+		methods.List = make([]*ast.Field, len(iface.Methods.List))
+		for j, field := range iface.Methods.List {
+			f := *field
+			if _, ok := f.Type.(*ast.FuncType); ok {
+				f.Doc = nil
+			}
+
+			methods.List[j] = &f
+		}
+
+		strippedIface := *iface
+		strippedIface.Methods = &methods
+
+		strippedSpec := *ts
+		strippedSpec.Type = &strippedIface
+		stripped.Specs[i] = &strippedSpec
+	}
+
+	return &stripped
+}
@@ -0,0 +1,337 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/doc/comment"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type (
+	// LintFinding describes a problem found in a documentation comment of a
+	// package, along with the location of the comment in its source.
+	LintFinding struct {
+		rule     LintRule
+		symbol   string
+		message  string
+		location Location
+	}
+
+	// LintRule identifies the convention a documentation comment breaks.
+	LintRule string
+)
+
+const (
+	// PackageCommentRule identifies findings of packages which have no
+	// package comment, or whose package comment does not start with
+	// "Package <name>".
+	PackageCommentRule LintRule = "package-comment"
+
+	// SymbolNameRule identifies findings of documentation comments whose first
+	// sentence does not start with the name of the symbol they document.
+	SymbolNameRule LintRule = "symbol-name"
+
+	// BrokenLinkRule identifies findings of doc links (e.g. [Symbol]) which
+	// don't refer to a symbol of the package.
+	BrokenLinkRule LintRule = "broken-link"
+
+	// DeprecatedRule identifies findings of deprecation notices which are not
+	// a paragraph starting with "Deprecated: ", so tools don't recognize them.
+	DeprecatedRule LintRule = "deprecated"
+)
+
+// docArticles are the words which may come before the name of a type or value
+// at the start of its documentation comment.
+var docArticles = []string{"A ", "An ", "The "}
+
+// Rule provides the convention broken by the documentation comment.
+func (f *LintFinding) Rule() LintRule {
+	return f.rule
+}
+
+// Symbol provides the name of the symbol whose documentation comment the
+// finding is for, or an empty string for the package comment.
+func (f *LintFinding) Symbol() string {
+	return f.symbol
+}
+
+// Message describes the problem found.
+func (f *LintFinding) Message() string {
+	return f.message
+}
+
+// Location provides the location of the documentation comment, or of the
+// declaration of the symbol if it has no comment.
+func (f *LintFinding) Location() Location {
+	return f.location
+}
+
+// Lint checks the documentation comments of the package and its symbols for
+// common mistakes: a missing package comment, comments which don't start with
+// the name of the symbol, doc links to symbols which don't exist and
+// deprecation notices which aren't recognized. Symbols without a documentation
+// comment are left to the documentation coverage instead. Findings are listed
+// in the order of the symbols in the documentation.
+func (pkg *Package) Lint() []*LintFinding {
+	l := &linter{cfg: pkg.cfg}
+	l.lintPackage(pkg.doc)
+
+	for _, v := range pkg.doc.Consts {
+		l.lintValue(v)
+	}
+
+	for _, v := range pkg.doc.Vars {
+		l.lintValue(v)
+	}
+
+	for _, fn := range pkg.doc.Funcs {
+		l.lintFunc(fn)
+	}
+
+	for _, typ := range pkg.doc.Types {
+		l.lintDoc(typ.Name, typ.Name, typ.Doc, typ.Decl, true)
+
+		for _, v := range typ.Consts {
+			l.lintValue(v)
+		}
+
+		for _, v := range typ.Vars {
+			l.lintValue(v)
+		}
+
+		for _, fn := range typ.Funcs {
+			l.lintFunc(fn)
+		}
+
+		for _, fn := range typ.Methods {
+			l.lintFunc(fn)
+		}
+	}
+
+	return l.findings
+}
+
+type linter struct {
+	cfg      *Config
+	findings []*LintFinding
+}
+
+func (l *linter) lintPackage(pkg *doc.Package) {
+	var (
+		node ast.Node
+		docs *ast.CommentGroup
+	)
+	for _, f := range l.cfg.Files {
+		name := filepath.Base(l.cfg.FileSet.Position(f.Pos()).Filename)
+		if !packageFile(pkg, name) {
+			continue
+		}
+
+		if node == nil {
+			node = f.Name
+		}
+
+		if f.Doc != nil {
+			docs = f.Doc
+			break
+		}
+	}
+
+	if node == nil {
+		return
+	}
+
+	if strings.TrimSpace(pkg.Doc) == "" {
+		l.add(PackageCommentRule, "", fmt.Sprintf("package %s has no package comment", pkg.Name), NewLocation(l.cfg, node))
+		return
+	}
+
+	if docs != nil {
+		node = docs
+	}
+
+	// Commands are described by what they do rather than their package name
+	prefix := fmt.Sprintf("Package %s", pkg.Name)
+	if pkg.Name != "main" && !startsWithName(pkg.Doc, prefix) {
+		l.add(PackageCommentRule, "", fmt.Sprintf("package comment should start with %q", prefix+" "), NewLocation(l.cfg, node))
+	}
+
+	l.lintText("", pkg.Doc, node)
+}
+
+func (l *linter) lintValue(v *doc.Value) {
+	// Comments of groups of values describe the group rather than naming one
+	// of the values
+	if len(v.Names) == 1 {
+		l.lintDoc(v.Names[0], v.Names[0], v.Doc, v.Decl, true)
+		return
+	}
+
+	l.lintDoc(v.Names[0], "", v.Doc, v.Decl, false)
+}
+
+func (l *linter) lintFunc(fn *doc.Func) {
+	symbol := fn.Name
+	if fn.Recv != "" {
+		symbol = symbolName(strings.TrimPrefix(fn.Recv, "*"), fn.Name)
+	}
+
+	l.lintDoc(symbol, fn.Name, fn.Doc, fn.Decl, false)
+}
+
+// lintDoc checks the documentation comment of a symbol, which should start
+// with the provided name unless it is empty.
+func (l *linter) lintDoc(symbol, name, text string, decl ast.Node, articles bool) {
+	if strings.TrimSpace(text) == "" || decl == nil {
+		return
+	}
+
+	var node ast.Node = decl
+	if docs := sourceDoc(l.cfg, decl); docs != nil {
+		node = docs
+	}
+
+	// A comment which only holds a deprecation notice doesn't need to repeat
+	// the name
+	if name != "" && !strings.HasPrefix(strings.TrimSpace(text), deprecatedPrefix) && !startsWithName(text, name) && !(articles && startsWithArticle(text, name)) {
+		l.add(SymbolNameRule, symbol, fmt.Sprintf("comment on %s should start with %q", symbol, name+" "), NewLocation(l.cfg, node))
+	}
+
+	l.lintText(symbol, text, node)
+}
+
+// lintText checks the doc links and deprecation notices of the text of a
+// documentation comment.
+func (l *linter) lintText(symbol, text string, node ast.Node) {
+	loc := NewLocation(l.cfg, node)
+
+	for _, para := range paragraphs(text) {
+		if strings.HasPrefix(para[0], deprecatedPrefix) {
+			continue
+		}
+
+		if isMisformattedDeprecation(para[0]) {
+			l.add(DeprecatedRule, symbol, fmt.Sprintf("deprecation notice should be a paragraph starting with %q", deprecatedPrefix), loc)
+			continue
+		}
+
+		for _, line := range para[1:] {
+			if strings.HasPrefix(line, deprecatedPrefix) {
+				l.add(DeprecatedRule, symbol, "deprecation notice should be separated from the paragraph before it by a blank line", loc)
+				break
+			}
+		}
+	}
+
+	// Every link is resolved by the parser so that links to symbols which
+	// don't exist are kept as links instead of text
+	parser := &comment.Parser{
+		LookupPackage: l.cfg.Pkg.Parser().LookupPackage,
+		LookupSym:     func(recv, name string) bool { return true },
+	}
+
+	for _, link := range docLinks(parser.Parse(text).Content) {
+		if link.ImportPath != "" || !isExportedName(link.Name) {
+			continue
+		}
+
+		name := symbolName(link.Recv, link.Name)
+		if _, ok := l.cfg.Symbols[name]; !ok {
+			l.add(BrokenLinkRule, symbol, fmt.Sprintf("doc link [%s] does not refer to a symbol of the package", name), loc)
+		}
+	}
+}
+
+func (l *linter) add(rule LintRule, symbol, message string, loc Location) {
+	l.findings = append(l.findings, &LintFinding{rule, symbol, message, loc})
+}
+
+// docLinks finds the doc links within the blocks of a parsed comment.
+func docLinks(blocks []comment.Block) []*comment.DocLink {
+	var links []*comment.DocLink
+	for _, b := range blocks {
+		switch b := b.(type) {
+		case *comment.Paragraph:
+			links = append(links, textDocLinks(b.Text)...)
+		case *comment.Heading:
+			links = append(links, textDocLinks(b.Text)...)
+		case *comment.List:
+			for _, item := range b.Items {
+				links = append(links, docLinks(item.Content)...)
+			}
+		}
+	}
+
+	return links
+}
+
+func textDocLinks(text []comment.Text) []*comment.DocLink {
+	var links []*comment.DocLink
+	for _, t := range text {
+		switch t := t.(type) {
+		case *comment.DocLink:
+			links = append(links, t)
+		case *comment.Link:
+			links = append(links, textDocLinks(t.Text)...)
+		}
+	}
+
+	return links
+}
+
+// startsWithName checks whether the text starts with the name as a separate
+// word.
+func startsWithName(text, name string) bool {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, name) {
+		return false
+	}
+
+	r, _ := utf8.DecodeRuneInString(text[len(name):])
+	return r == utf8.RuneError || !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+}
+
+// isMisformattedDeprecation checks whether the first line of a paragraph is
+// meant to be a deprecation notice (e.g. "DEPRECATED: ..." or "Deprecated -
+// ...") without using the exact "Deprecated: " prefix.
+func isMisformattedDeprecation(line string) bool {
+	const word = "deprecated"
+
+	line = strings.TrimSpace(line)
+	if len(line) < len(word) || !strings.EqualFold(line[:len(word)], word) {
+		return false
+	}
+
+	rest := strings.TrimSpace(line[len(word):])
+	return rest == "" || strings.ContainsAny(rest[:1], ":.-!")
+}
+
+func startsWithArticle(text, name string) bool {
+	text = strings.TrimSpace(text)
+	for _, article := range docArticles {
+		if strings.HasPrefix(text, article) && startsWithName(text[len(article):], name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isExportedName(name string) bool {
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
+}
+
+func packageFile(pkg *doc.Package, name string) bool {
+	for _, f := range pkg.Filenames {
+		if filepath.Base(f) == name {
+			return true
+		}
+	}
+
+	return false
+}
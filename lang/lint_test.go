@@ -0,0 +1,46 @@
+package lang_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/matryer/is"
+)
+
+func TestPackage_Lint(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/lang/lint")
+	is.NoErr(err)
+
+	type finding struct {
+		rule   lang.LintRule
+		symbol string
+		line   int
+	}
+
+	var findings []finding
+	for _, f := range pkg.Lint() {
+		is.Equal(filepath.Base(f.Location().Filepath), "lint.go")
+		findings = append(findings, finding{f.Rule(), f.Symbol(), f.Location().Start.Line})
+	}
+
+	is.Equal(findings, []finding{
+		{lang.PackageCommentRule, "", 1},
+		{lang.BrokenLinkRule, "Client", 5},
+		{lang.DeprecatedRule, "Connect", 19},
+		{lang.BrokenLinkRule, "Connect", 19},
+		{lang.DeprecatedRule, "Client.Close", 15},
+		{lang.SymbolNameRule, "Client.Dial", 12},
+	})
+}
+
+func TestPackage_Lint_clean(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/simple")
+	is.NoErr(err)
+
+	is.Equal(len(pkg.Lint()), 0)
+}
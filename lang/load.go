@@ -0,0 +1,356 @@
+package lang
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SourceImporter type checks packages with the build tags it was created
+// with, along with the packages they import. Imported packages are loaded
+// from the export data compiled by the go command, which caches it between
+// runs, and only type checked from source when it has none for them (e.g.
+// outside of a module). Each imported package is only loaded once, so the
+// importer should be shared by all of the packages documented together. It is
+// safe for concurrent use.
+type SourceImporter struct {
+	ctx  build.Context
+	fset *token.FileSet
+	gc   types.Importer
+	mu   sync.Mutex
+	pkgs map[string]*types.Package
+
+	// exports holds the export data file of each package by import path,
+	// and listed the directories the go command has listed them for.
+	exports map[string]string
+	listed  map[string]bool
+}
+
+// NewSourceImporter creates a SourceImporter which selects the files of
+// packages with the provided build tags.
+func NewSourceImporter(tags ...string) *SourceImporter {
+	ctx := build.Default
+	ctx.BuildTags = tags
+
+	// Imported packages are checked without cgo, which picks the pure Go
+	// implementation of the packages which provide one
+	ctx.CgoEnabled = false
+
+	imp := &SourceImporter{
+		ctx:     ctx,
+		fset:    token.NewFileSet(),
+		pkgs:    make(map[string]*types.Package),
+		exports: make(map[string]string),
+		listed:  make(map[string]bool),
+	}
+
+	imp.gc = importer.ForCompiler(imp.fset, "gc", func(path string) (io.ReadCloser, error) {
+		file, ok := imp.exports[path]
+		if !ok {
+			return nil, fmt.Errorf("gomarkdoc: no export data for package %s", path)
+		}
+
+		return os.Open(file)
+	})
+
+	return imp
+}
+
+// sourceImports implements types.ImporterFrom for the packages type checked
+// while the lock of the SourceImporter is held.
+type sourceImports SourceImporter
+
+func (imp *sourceImports) Import(path string) (*types.Package, error) {
+	return imp.ImportFrom(path, "", 0)
+}
+
+func (imp *sourceImports) ImportFrom(path, dir string, _ types.ImportMode) (*types.Package, error) {
+	if path == "unsafe" {
+		return types.Unsafe, nil
+	}
+
+	pkg, err := imp.ctx.Import(path, dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := imp.exports[pkg.ImportPath]; !ok && !imp.listed[dir] {
+		imp.listExports(dir, ".")
+	}
+
+	if p, ok := imp.importExport(pkg.ImportPath); ok {
+		return p, nil
+	}
+
+	return imp.importPackage(pkg.ImportPath, pkg)
+}
+
+// listExports lists the export data of the packages matching the pattern in
+// the directory and of all of their dependencies with the go command, which
+// compiles the packages it doesn't have cached. Cgo is disabled to match the
+// build context. Failures leave the packages to be type checked from source.
+func (imp *sourceImports) listExports(dir, pattern string) {
+	imp.listed[dir] = true
+
+	args := []string{"list", "-e", "-export", "-deps", "-f", "{{.ImportPath}} {{.Export}}"}
+	if len(imp.ctx.BuildTags) > 0 {
+		args = append(args, "-tags="+strings.Join(imp.ctx.BuildTags, ","))
+	}
+
+	args = append(args, "--", pattern)
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "CGO_ENABLED=0")
+
+	out, err := cmd.Output()
+	if err != nil {
+		return
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		importPath, file, ok := strings.Cut(line, " ")
+		if ok && file != "" {
+			imp.exports[importPath] = file
+		}
+	}
+}
+
+// importExport imports the package with the provided import path from its
+// export data. The last return value is false if there is none for it, or if
+// the package is being type checked from source.
+func (imp *sourceImports) importExport(importPath string) (*types.Package, bool) {
+	if p, ok := imp.pkgs[importPath]; ok {
+		return p, p != nil
+	}
+
+	if _, ok := imp.exports[importPath]; !ok {
+		return nil, false
+	}
+
+	p, err := imp.gc.Import(importPath)
+	if err != nil {
+		return nil, false
+	}
+
+	imp.pkgs[importPath] = p
+	return p, true
+}
+
+// importPackage type checks the declarations of the build package as the
+// package with the provided import path, ignoring the bodies of its functions.
+// Errors in the package are tolerated, since the types it does declare are
+// still useful to its importers.
+func (imp *sourceImports) importPackage(importPath string, pkg *build.Package) (*types.Package, error) {
+	if p, ok := imp.pkgs[importPath]; ok {
+		if p == nil {
+			return nil, fmt.Errorf("gomarkdoc: import cycle through package %s", importPath)
+		}
+
+		return p, nil
+	}
+
+	imp.pkgs[importPath] = nil
+
+	var files []*ast.File
+	for _, name := range pkg.GoFiles {
+		f, err := parser.ParseFile(imp.fset, filepath.Join(pkg.Dir, name), nil, 0)
+		if err != nil {
+			delete(imp.pkgs, importPath)
+			return nil, fmt.Errorf("gomarkdoc: failed to parse package %s: %w", importPath, err)
+		}
+
+		files = append(files, f)
+	}
+
+	conf := types.Config{
+		Importer:         imp,
+		IgnoreFuncBodies: true,
+		Error:            func(error) {},
+	}
+
+	// The errors are ignored above, so the returned error is redundant
+	p, _ := conf.Check(importPath, imp.fset, files, nil)
+	imp.pkgs[importPath] = p
+
+	return p, nil
+}
+
+// importDir loads the package in the directory, which is found with the build
+// tags of the importer. The import path of the package is found from the
+// go.mod file of its module, and the export data of all of the packages of the
+// module is listed at once. The last return value is false if the directory
+// doesn't contain a package.
+func (imp *SourceImporter) importDir(dir string) (*types.Package, bool) {
+	imp.mu.Lock()
+	defer imp.mu.Unlock()
+
+	pkg, err := imp.ctx.ImportDir(dir, 0)
+	if err != nil {
+		return nil, false
+	}
+
+	importPath, ok := findImportPath(dir)
+	if !ok {
+		importPath = pkg.ImportPath
+	}
+
+	imports := (*sourceImports)(imp)
+	if root := moduleRoot(dir); !imp.listed[root] {
+		imports.listExports(root, "./...")
+	}
+
+	if p, ok := imports.importExport(importPath); ok {
+		return p, true
+	}
+
+	p, err := imports.importPackage(importPath, pkg)
+	if err != nil {
+		return nil, false
+	}
+
+	return p, true
+}
+
+// check type checks the files of the documented package, recording the type
+// information in the info and reporting each error to the error function.
+// Imports are type checked from the directory of the package.
+func (imp *SourceImporter) check(importPath string, fset *token.FileSet, files []*ast.File, cgo bool, info *types.Info, errFn func(error)) *types.Package {
+	imp.mu.Lock()
+	defer imp.mu.Unlock()
+
+	conf := types.Config{
+		Importer:    (*sourceImports)(imp),
+		FakeImportC: cgo,
+		Error:       errFn,
+	}
+
+	// The errors are reported above, so the returned error is redundant
+	p, _ := conf.Check(importPath, fset, files, info)
+	return p
+}
+
+// loadFiles parses the files of the build package to document, keyed by file
+// name. When the features enabled for the package need type information, the
+// files of the package itself are type checked as well, storing its type
+// information in the config. Imports are type checked from source by the
+// SourceImporter of the config, or a new one for the provided build tags if
+// the config doesn't have one. Type errors are logged and leave the type
+// information incomplete. The files of the external test package (e.g.
+// pkg_test) are not type checked, since their types belong to another
+// package, and neither are packages merged from several platforms or read
+// from a file system other than the local one.
+//
+// Packages are found with go/build rather than golang.org/x/tools/go/packages,
+// since the releases of go/packages which build with current Go versions
+// require a newer go directive than the go 1.18 this module supports.
+func loadFiles(cfg *Config, pkg *build.Package, tags []string, includeTestHelpers, includeExternalTestHelpers bool) (map[string]*ast.File, error) {
+	names := pkg.GoFiles
+	names = append(names[:len(names):len(names)], pkg.CgoFiles...)
+	if includeTestHelpers {
+		names = append(names, pkg.TestGoFiles...)
+	}
+
+	files := make(map[string]*ast.File)
+	checked, err := parseFiles(cfg, pkg.Dir, names, files)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.needsTypes() && cfg.FS == nil && len(cfg.Platforms) == 0 && len(checked) > 0 {
+		checkTypes(cfg, pkg, tags, checked)
+	}
+
+	if includeExternalTestHelpers {
+		if _, err := parseFiles(cfg, pkg.Dir, pkg.XTestGoFiles, files); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// needsTypes reports whether any of the features enabled for the package use
+// its type information. Type checking imports every dependency of the package
+// from source, so it is skipped otherwise.
+func (c *Config) needsTypes() bool {
+	return c.PromotedMethods || c.ClassDiagram || c.IotaValues || c.ComputedValues
+}
+
+// parseFiles parses the named files in the directory, adding their syntax to
+// the files keyed by file name. The syntax is also returned in the order of
+// the names.
+func parseFiles(cfg *Config, dir string, names []string, files map[string]*ast.File) ([]*ast.File, error) {
+	parsed := make([]*ast.File, 0, len(names))
+	for _, name := range names {
+		fileName := filepath.Join(dir, name)
+
+		// The source is read by the parser unless the file system is provided
+		var src interface{}
+		if cfg.FS != nil {
+			fileName = path.Join(dir, name)
+			b, err := fs.ReadFile(cfg.FS, fileName)
+			if err != nil {
+				return nil, fmt.Errorf("gomarkdoc: failed to read package: %w", err)
+			}
+
+			src = b
+		}
+
+		f, err := parser.ParseFile(cfg.FileSet, fileName, src, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("gomarkdoc: failed to parse package: %w", err)
+		}
+
+		files[fileName] = f
+		parsed = append(parsed, f)
+	}
+
+	return parsed, nil
+}
+
+// checkTypes type checks the syntax of the build package, storing its type
+// information in the config.
+func checkTypes(cfg *Config, pkg *build.Package, tags []string, files []*ast.File) {
+	imp := cfg.SourceImporter
+	if imp == nil {
+		imp = NewSourceImporter(tags...)
+	}
+
+	importPath := pkg.ImportPath
+	if modPath, ok := findImportPath(pkg.Dir); ok && importPath == unknownImportPath {
+		importPath = modPath
+	}
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Scopes:     make(map[ast.Node]*types.Scope),
+	}
+
+	cfg.Types = imp.check(importPath, cfg.FileSet, files, len(pkg.CgoFiles) > 0, info, func(err error) {
+		var typeErr types.Error
+		if errors.As(err, &typeErr) && typeErr.Soft {
+			return
+		}
+
+		cfg.Log.Debugf("type checking package: %s", err)
+	})
+	cfg.TypesInfo = info
+}
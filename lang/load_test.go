@@ -0,0 +1,90 @@
+package lang
+
+import (
+	"go/build"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestLoadFiles(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := build.ImportDir("../testData/lang/function", build.ImportComment)
+	is.NoErr(err)
+
+	cfg := &Config{FileSet: token.NewFileSet(), Log: logger.New(logger.ErrorLevel), PromotedMethods: true}
+	files, err := loadFiles(cfg, pkg, nil, false, false)
+	is.NoErr(err)
+
+	is.Equal(len(files), len(pkg.GoFiles))
+	for _, name := range pkg.GoFiles {
+		_, ok := files[filepath.Join(pkg.Dir, name)]
+		is.True(ok) // File not loaded
+	}
+
+	is.True(cfg.Types != nil) // No type information
+	is.Equal(cfg.Types.Name(), "function")
+	is.True(cfg.Types.Scope().Lookup("Standalone") != nil)
+}
+
+func TestLoadFiles_withoutTypes(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := build.ImportDir("../testData/lang/function", build.ImportComment)
+	is.NoErr(err)
+
+	cfg := &Config{FileSet: token.NewFileSet(), Log: logger.New(logger.ErrorLevel)}
+	files, err := loadFiles(cfg, pkg, nil, false, false)
+	is.NoErr(err)
+
+	is.Equal(len(files), len(pkg.GoFiles))
+	is.True(cfg.Types == nil)     // Type checked without a feature needing it
+	is.True(cfg.TypesInfo == nil) // Type checked without a feature needing it
+}
+
+func TestLoadFiles_buildTags(t *testing.T) {
+	is := is.New(t)
+
+	ctx := build.Default
+	ctx.BuildTags = []string{"tagged"}
+	pkg, err := ctx.ImportDir("../testData/tags", build.ImportComment)
+	is.NoErr(err)
+
+	cfg := &Config{FileSet: token.NewFileSet(), Log: logger.New(logger.ErrorLevel), PromotedMethods: true}
+	files, err := loadFiles(cfg, pkg, []string{"tagged"}, false, false)
+	is.NoErr(err)
+
+	is.Equal(len(files), 2)
+	is.True(cfg.Types != nil) // No type information
+}
+
+func TestLoadFiles_sharedImporter(t *testing.T) {
+	is := is.New(t)
+
+	imp := NewSourceImporter()
+
+	var imported []*types.Package
+	for _, dir := range []string{"../testData/lang/importers/client", "../testData/lang/importers/server"} {
+		pkg, err := build.ImportDir(dir, build.ImportComment)
+		is.NoErr(err)
+
+		cfg := &Config{FileSet: token.NewFileSet(), Log: logger.New(logger.ErrorLevel), SourceImporter: imp, ClassDiagram: true}
+		_, err = loadFiles(cfg, pkg, nil, false, false)
+		is.NoErr(err)
+
+		is.True(cfg.Types != nil) // No type information
+		for _, p := range cfg.Types.Imports() {
+			if p.Path() == "github.com/anthonyme00/gomarkdoc/testData/lang/importers/base" {
+				imported = append(imported, p)
+			}
+		}
+	}
+
+	is.Equal(len(imported), 2)          // Import not type checked
+	is.True(imported[0] == imported[1]) // Import type checked twice
+}
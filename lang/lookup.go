@@ -0,0 +1,73 @@
+package lang
+
+import "strings"
+
+// LookupFunc finds the documented function of the package with the provided
+// name, which may be a method of one of its types (e.g. "Client.Do"). Nil is
+// returned if there is no such function.
+func (pkg *Package) LookupFunc(name string) *Func {
+	if typeName, method, ok := strings.Cut(name, "."); ok {
+		typ := pkg.LookupType(typeName)
+		if typ == nil {
+			return nil
+		}
+
+		for _, fn := range typ.Methods() {
+			if fn.Name() == method {
+				return fn
+			}
+		}
+
+		return nil
+	}
+
+	for _, fn := range pkg.Funcs() {
+		if fn.Name() == name {
+			return fn
+		}
+	}
+
+	// Functions returning a type are documented along with it
+	for _, typ := range pkg.Types() {
+		for _, fn := range typ.Funcs() {
+			if fn.Name() == name {
+				return fn
+			}
+		}
+	}
+
+	return nil
+}
+
+// LookupType finds the documented type of the package with the provided name.
+// Nil is returned if there is no such type.
+func (pkg *Package) LookupType(name string) *Type {
+	for _, typ := range pkg.Types() {
+		if typ.Name() == name {
+			return typ
+		}
+	}
+
+	return nil
+}
+
+// LookupValue finds the documented const or var declaration of the package
+// which declares the provided name. Nil is returned if there is no such
+// declaration.
+func (pkg *Package) LookupValue(name string) *Value {
+	values := append(pkg.Consts(), pkg.Vars()...)
+	for _, typ := range pkg.Types() {
+		values = append(values, typ.Consts()...)
+		values = append(values, typ.Vars()...)
+	}
+
+	for _, v := range values {
+		for _, n := range v.doc.Names {
+			if n == name {
+				return v
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package lang
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// MetadataPrefix is included in each of the entries of the generation metadata
+// written to a file. Tools comparing generated files (such as gomarkdoc's check
+// mode) can use it to find and ignore the metadata.
+const MetadataPrefix = "gomarkdoc-metadata:"
+
+// Metadata holds information about how the documentation in a file was
+// generated, which allows the provenance of the documentation to be audited.
+type Metadata struct {
+	// ToolVersion holds the version of gomarkdoc used to generate the
+	// documentation.
+	ToolVersion string
+
+	// ModuleVersion holds the tag of the documented code's repository which
+	// points at the current commit. It is empty if there is no such tag.
+	ModuleVersion string
+
+	// Commit holds the hash of the documented code's repository's current
+	// commit. It is empty if the code is not in a git repository.
+	Commit string
+
+	// Timestamp holds the time at which the documentation was generated. It is
+	// the zero time if the timestamp was left out to keep the output
+	// reproducible.
+	Timestamp time.Time
+}
+
+// NewMetadata creates the generation metadata for documentation of the provided
+// package. The repository information is detected on a best effort basis and
+// left empty if it can't be found. The timestamp is only included if it is not
+// the zero time.
+func NewMetadata(toolVersion string, pkg *Package, timestamp time.Time) *Metadata {
+	m := &Metadata{
+		ToolVersion: toolVersion,
+		Timestamp:   timestamp,
+	}
+
+	repo, err := git.PlainOpenWithOptions(pkg.cfg.PkgDir, &git.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+	if err != nil {
+		pkg.cfg.Log.Debugf("unable to find repository for metadata: %s", err)
+		return m
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		pkg.cfg.Log.Debugf("unable to resolve repository head for metadata: %s", err)
+		return m
+	}
+
+	m.Commit = head.Hash().String()
+	m.ModuleVersion = headTag(repo, head.Hash())
+
+	return m
+}
+
+// Entries lists the metadata as a series of single line entries, each starting
+// with MetadataPrefix. Empty fields are left out.
+func (m *Metadata) Entries() []string {
+	var entries []string
+	add := func(key, value string) {
+		if value != "" {
+			entries = append(entries, fmt.Sprintf("%s %s=%s", MetadataPrefix, key, value))
+		}
+	}
+
+	add("tool", m.ToolVersion)
+	add("module", m.ModuleVersion)
+	add("commit", m.Commit)
+	if !m.Timestamp.IsZero() {
+		add("generated", m.Timestamp.UTC().Format(time.RFC3339))
+	}
+
+	return entries
+}
+
+// headTag finds the name of a tag pointing at the provided commit, resolving
+// annotated tags to the commit they point to.
+func headTag(repo *git.Repository, hash plumbing.Hash) string {
+	tags, err := repo.Tags()
+	if err != nil {
+		return ""
+	}
+
+	var name string
+	_ = tags.ForEach(func(ref *plumbing.Reference) error {
+		target := ref.Hash()
+		if tag, err := repo.TagObject(target); err == nil {
+			target = tag.Target
+		}
+
+		if target == hash {
+			name = ref.Name().Short()
+			return storer.ErrStop
+		}
+
+		return nil
+	})
+
+	return name
+}
@@ -0,0 +1,47 @@
+package lang_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestMetadata_Entries(t *testing.T) {
+	is := is.New(t)
+
+	m := &lang.Metadata{
+		ToolVersion: "v1.0.1",
+		Commit:      "0123456789abcdef0123456789abcdef01234567",
+	}
+	is.Equal(m.Entries(), []string{
+		"gomarkdoc-metadata: tool=v1.0.1",
+		"gomarkdoc-metadata: commit=0123456789abcdef0123456789abcdef01234567",
+	})
+
+	m.ModuleVersion = "v1.2.0"
+	m.Timestamp = time.Date(2021, 3, 4, 5, 6, 7, 0, time.FixedZone("PST", -8*60*60))
+	is.Equal(m.Entries(), []string{
+		"gomarkdoc-metadata: tool=v1.0.1",
+		"gomarkdoc-metadata: module=v1.2.0",
+		"gomarkdoc-metadata: commit=0123456789abcdef0123456789abcdef01234567",
+		"gomarkdoc-metadata: generated=2021-03-04T13:06:07Z",
+	})
+}
+
+func TestNewMetadata(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/function")
+	is.NoErr(err)
+
+	pkg, err := lang.NewPackageFromBuild(logger.New(logger.ErrorLevel), buildPkg)
+	is.NoErr(err)
+
+	m := lang.NewMetadata("v1.0.1", pkg, time.Time{})
+	is.Equal(m.ToolVersion, "v1.0.1")
+	is.Equal(len(m.Commit), 40) // commit of the gomarkdoc repository
+	is.True(m.Timestamp.IsZero())
+}
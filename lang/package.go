@@ -5,8 +5,7 @@ import (
 	"go/ast"
 	"go/build"
 	"go/doc"
-	"go/parser"
-	"go/token"
+	"io/fs"
 	"io/ioutil"
 	"os"
 	"path"
@@ -33,6 +32,48 @@ type (
 		filterOutFile       *string
 		overrideImportPath  *string
 		repositoryOverrides *Repo
+		badges              bool
+		warnInternal        bool
+		exampleTitles       ExampleTitleStyle
+		exampleOrder        ExampleOrder
+		cAPI                bool
+		buildTargets        bool
+		translations        map[string]string
+		stringCatalog       *StringCatalog
+		symbolAliases       map[string]string
+		importURLs          *ImportURLResolver
+		playground          PlaygroundSharer
+		flattenEmbedded     bool
+		promotedMethods     bool
+		symbolOrder         SymbolOrder
+		checkExamples       bool
+		strictExamples      bool
+		runExamples         bool
+		symbolIndex         *SymbolIndex
+		implementers        *ImplementerIndex
+		implemented         *ImplementerIndex
+		sourceImporter      *SourceImporter
+		importers           *ImporterIndex
+		outputFile          string
+		routes              map[Section]string
+		hideDeprecated      bool
+		noTypeLinks         bool
+		indexFields         bool
+		testHelpers         bool
+		xtestHelpers        bool
+		buildInfo           bool
+		benchmarks          bool
+		fuzzTargets         bool
+		classDiagram        bool
+		iotaValues          bool
+		computedValues      bool
+		funcBodies          bool
+		pinCommit           bool
+		fsys                fs.FS
+		symbolFilter        *SymbolFilter
+		hiddenMethods       []symbolPattern
+		buildTags           []string
+		platforms           []string
 	}
 
 	// PackageOption configures one or more options for the package.
@@ -58,30 +99,136 @@ func NewPackageFromBuild(log logger.Logger, pkg *build.Package, opts ...PackageO
 		}
 	}
 
+	logger.ReportProgress(log, logger.Progress{
+		Event:   logger.PackageStartedEvent,
+		Package: pkg.ImportPath,
+	})
+
+	var filePlatforms map[string][]string
+	if options.platforms != nil {
+		var err error
+		if pkg, filePlatforms, err = platformPackage(options.fsys, pkg, options.buildTags, options.platforms); err != nil {
+			return nil, err
+		}
+	} else if options.buildTags != nil {
+		var err error
+		if pkg, err = taggedPackage(options.fsys, pkg, options.buildTags); err != nil {
+			return nil, err
+		}
+	}
+
 	wd, err := os.Getwd()
 	if err != nil {
 		return nil, err
 	}
 
 	cfg, err := NewConfig(log, wd, pkg.Dir,
+		ConfigWithFS(options.fsys),
 		ConfigWithRepoOverrides(options.repositoryOverrides),
+		ConfigWithPinnedCommit(options.pinCommit),
 		ConfigWithFileFilter(options.filterOutFile),
 		ConfigWithOverrideImport(options.overrideImportPath),
+		ConfigWithBadges(options.badges),
+		ConfigWithInternalWarning(options.warnInternal),
+		ConfigWithExampleTitles(options.exampleTitles),
+		ConfigWithExampleOrder(options.exampleOrder),
+		ConfigWithCAPI(options.cAPI),
+		ConfigWithBuildTargets(options.buildTargets),
+		ConfigWithTranslations(options.translations),
+		ConfigWithStringCatalog(options.stringCatalog),
+		ConfigWithImportURLResolver(options.importURLs),
+		ConfigWithPlaygroundSharer(options.playground),
+		ConfigWithFlattenedEmbedding(options.flattenEmbedded),
+		ConfigWithPromotedMethods(options.promotedMethods),
+		ConfigWithSymbolOrder(options.symbolOrder),
+		ConfigWithSymbolIndex(options.symbolIndex, options.outputFile),
+		ConfigWithRoutes(options.routes),
+		ConfigWithImplementerIndex(options.implementers),
+		ConfigWithImplementedInterfaces(options.implemented),
+		ConfigWithImporterIndex(options.importers),
+		ConfigWithSourceImporter(options.sourceImporter),
+		ConfigWithDeprecatedHidden(options.hideDeprecated),
+		ConfigWithTypeLinks(!options.noTypeLinks),
+		ConfigWithIndexedFields(options.indexFields),
+		ConfigWithBuildInfo(options.buildInfo),
+		ConfigWithBenchmarks(options.benchmarks),
+		ConfigWithFuzzTargets(options.fuzzTargets),
+		ConfigWithClassDiagram(options.classDiagram),
+		ConfigWithIotaValues(options.iotaValues),
+		ConfigWithComputedValues(options.computedValues),
+		ConfigWithFuncBodies(options.funcBodies),
+		ConfigWithPlatforms(options.platforms),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	cfg.Pkg, err = getDocPkg(pkg, cfg.FileSet, options.includeUnexported)
+	cfg.Imports = pkg.Imports
+
+	cfg.Pkg, err = getDocPkg(cfg, pkg, options.buildTags, options.includeUnexported, options.testHelpers, options.xtestHelpers, options.symbolAliases, options.overrideImportPath)
 	if err != nil {
 		return nil, err
 	}
 
+	inheritDocs(cfg)
+
+	if filePlatforms != nil {
+		removeDuplicateValues(cfg.Pkg)
+		cfg.SymbolPlatforms = platformSymbols(cfg, filePlatforms)
+	}
+
+	if options.symbolFilter != nil {
+		options.symbolFilter.filterPackage(cfg.Pkg)
+	}
+
+	if len(options.hiddenMethods) > 0 {
+		hideMethods(cfg.Pkg, options.hiddenMethods)
+	}
+
+	if cfg.Pkg.ImportPath == unknownImportPath {
+		log.Warnf("package is not in a Go module and no import path was provided, so its import statement, reference badge and links to its documentation are left out")
+
+		if cfg.Repo == nil {
+			log.Warnf("no repository was found for package, so links to its source code are left out")
+		}
+	}
+
 	sym := PackageSymbols(cfg.Pkg)
 	cfg.Symbols = sym
 
+	if len(cfg.Routes) > 0 {
+		cfg.SymbolSections = symbolSections(cfg.Pkg)
+	}
+
+	if cfg.SymbolOrder == PopularitySymbolOrder {
+		cfg.Usage = symbolUsage(cfg)
+	}
+
+	if options.checkExamples || options.strictExamples {
+		if err := checkExamples(log, pkg, options.strictExamples); err != nil {
+			return nil, err
+		}
+	}
+
 	examples := doc.Examples(cfg.Files...)
 
+	if options.runExamples {
+		outputs, err := runExamples(log, pkg, options.buildTags)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.ExampleOutputs = make(map[*doc.Example]string)
+		for _, ex := range examples {
+			if out, ok := outputs[exampleFuncName(ex)]; ok {
+				cfg.ExampleOutputs[ex] = out
+			}
+		}
+	}
+
+	aliasExamples(examples, options.symbolAliases)
+	sortExamples(examples, cfg.ExampleOrder)
+
 	return NewPackage(cfg, examples), nil
 }
 
@@ -95,6 +242,32 @@ func PackageWithUnexportedIncluded() PackageOption {
 	}
 }
 
+// PackageWithTestHelpers can be used along with the NewPackageFromBuild
+// function to specify that the symbols declared in the _test.go files of the
+// package itself (e.g. test doubles exported for the tests of other packages
+// through an export_test.go file) should be included in the documentation.
+// Tests, benchmarks, examples and fuzz targets are left out, and the included
+// symbols are marked as test only.
+func PackageWithTestHelpers() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.testHelpers = true
+		return nil
+	}
+}
+
+// PackageWithExternalTestHelpers can be used along with the
+// NewPackageFromBuild function to specify that the symbols declared in the
+// _test.go files of the package's external test package (e.g. fixtures in a
+// package named pkg_test) should be included in the documentation. As with
+// PackageWithTestHelpers, tests, benchmarks, examples and fuzz targets are left
+// out, and the included symbols are marked as test only.
+func PackageWithExternalTestHelpers() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.xtestHelpers = true
+		return nil
+	}
+}
+
 // PackageWithRepositoryOverrides can be used along with the NewPackageFromBuild
 // function to define manual overrides to the automatic repository detection
 // logic.
@@ -105,6 +278,19 @@ func PackageWithRepositoryOverrides(repo *Repo) PackageOption {
 	}
 }
 
+// PackageWithPinnedCommit can be used along with the NewPackageFromBuild
+// function to specify that links to the source code of the package should be
+// permalinks to the commit checked out in its repository (i.e. HEAD) rather
+// than links to the default branch, so that their line anchors don't drift as
+// the branch moves on. The commit can also be set manually with the Commit
+// field of the repository overrides.
+func PackageWithPinnedCommit() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.pinCommit = true
+		return nil
+	}
+}
+
 // PackageWithFileFilter can be used along with the NewPackageFromBuild function
 // to specify that only symbols from a specific file should be included in the
 // documentation for the package.
@@ -115,6 +301,468 @@ func PackageWithFileFilter(file string) PackageOption {
 	}
 }
 
+// PackageWithBadges can be used along with the NewPackageFromBuild function to
+// specify that the standard badges (reference, version and documentation
+// coverage) should be generated for the package's documentation.
+func PackageWithBadges() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.badges = true
+		return nil
+	}
+}
+
+// PackageWithInternalWarning can be used along with the NewPackageFromBuild
+// function to specify that the documentation for an internal package should
+// include a warning that the package is not importable from outside of its
+// parent tree.
+func PackageWithInternalWarning() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.warnInternal = true
+		return nil
+	}
+}
+
+// PackageWithExampleTitles can be used along with the NewPackageFromBuild
+// function to specify the style used to generate the titles of examples.
+func PackageWithExampleTitles(style ExampleTitleStyle) PackageOption {
+	return func(opts *PackageOptions) error {
+		switch style {
+		case DefaultExampleTitles, HumanizedExampleTitles, ShortExampleTitles:
+		default:
+			return fmt.Errorf("gomarkdoc: invalid example title style: %s", style)
+		}
+
+		opts.exampleTitles = style
+		return nil
+	}
+}
+
+// PackageWithExampleOrder can be used along with the NewPackageFromBuild
+// function to specify the order in which examples are listed.
+func PackageWithExampleOrder(order ExampleOrder) PackageOption {
+	return func(opts *PackageOptions) error {
+		switch order {
+		case AlphabeticalExampleOrder, SourceExampleOrder:
+		default:
+			return fmt.Errorf("gomarkdoc: invalid example order: %s", order)
+		}
+
+		opts.exampleOrder = order
+		return nil
+	}
+}
+
+// PackageWithSymbolOrder can be used along with the NewPackageFromBuild
+// function to specify the order in which the functions and types of the
+// package are listed.
+func PackageWithSymbolOrder(order SymbolOrder) PackageOption {
+	return func(opts *PackageOptions) error {
+		switch order {
+		case AlphabeticalSymbolOrder, PopularitySymbolOrder:
+		default:
+			return fmt.Errorf("gomarkdoc: invalid symbol order: %s", order)
+		}
+
+		opts.symbolOrder = order
+		return nil
+	}
+}
+
+// PackageWithSymbolIndex can be used along with the NewPackageFromBuild
+// function to specify the index of the packages documented together and the
+// file the package's documentation is written to. Doc links to symbols of the
+// other packages in the index link to their generated documentation. The
+// package should be added to the index once it is created.
+func PackageWithSymbolIndex(idx *SymbolIndex, outputFile string) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.symbolIndex = idx
+		opts.outputFile = outputFile
+		return nil
+	}
+}
+
+// PackageWithImplementers can be used along with the NewPackageFromBuild
+// function to list the types within the package's module which implement each
+// of its interfaces. The index loads the type information of the module once,
+// so it should be shared by all of the packages documented together.
+func PackageWithImplementers(idx *ImplementerIndex) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.implementers = idx
+		return nil
+	}
+}
+
+// PackageWithSourceImporter can be used along with the NewPackageFromBuild
+// function to type check the packages imported by the package with the
+// provided importer. The importer only checks each package once, so it should
+// be shared by all of the packages documented together. Without it, the
+// imports are checked with a new importer for each package.
+func PackageWithSourceImporter(imp *SourceImporter) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.sourceImporter = imp
+		return nil
+	}
+}
+
+// PackageWithImporters can be used along with the NewPackageFromBuild function
+// to list the other packages in the index which import the package, such as
+// the packages of its module documented together with ./... The packages
+// documented together should share the index and be added to it once they are
+// created.
+func PackageWithImporters(idx *ImporterIndex) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.importers = idx
+		return nil
+	}
+}
+
+// PackageWithImplementedInterfaces can be used along with the
+// NewPackageFromBuild function to list the interfaces within the package's
+// module which are implemented by each of its other types. The index can be
+// shared with PackageWithImplementers.
+func PackageWithImplementedInterfaces(idx *ImplementerIndex) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.implemented = idx
+		return nil
+	}
+}
+
+// PackageWithRoutes can be used along with the NewPackageFromBuild function to
+// write the documentation of some sections of the package to separate files
+// (e.g. all of its types to types.md), which are rendered from the views of the
+// package provided by ForSection. The package's own documentation links to the
+// files, and links to the symbols of the routed sections point at the files
+// they are written to.
+func PackageWithRoutes(routes map[Section]string) PackageOption {
+	return func(opts *PackageOptions) error {
+		if err := validateRoutes(routes); err != nil {
+			return err
+		}
+
+		opts.routes = routes
+		return nil
+	}
+}
+
+// PackageWithDeprecatedHidden can be used along with the NewPackageFromBuild
+// function to specify that the functions, types and methods with a
+// "Deprecated:" note should be left out of the package's documentation. They
+// are still provided by DeprecatedTypes and DeprecatedFuncs, so they can be
+// documented separately.
+func PackageWithDeprecatedHidden() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.hideDeprecated = true
+		return nil
+	}
+}
+
+// PackageWithoutTypeLinks can be used along with the NewPackageFromBuild
+// function to specify that the types from other packages referenced by the
+// signatures and declarations of the package (e.g. context.Context) should not
+// be linked to their documentation.
+func PackageWithoutTypeLinks() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.noTypeLinks = true
+		return nil
+	}
+}
+
+// PackageWithIndexedFields can be used along with the NewPackageFromBuild
+// function to specify that the documented fields of struct types should be
+// listed under their types in the index and table of contents, alongside the
+// functions and methods of the types. This is useful for navigating very large
+// APIs.
+func PackageWithIndexedFields() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.indexFields = true
+		return nil
+	}
+}
+
+// PackageWithBenchmarks can be used along with the NewPackageFromBuild function
+// to specify that the benchmark functions declared in the _test.go files of the
+// package should be documented in a Benchmarks section, along with their
+// documentation comments and code.
+func PackageWithBenchmarks() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.benchmarks = true
+		return nil
+	}
+}
+
+// PackageWithFuzzTargets can be used along with the NewPackageFromBuild
+// function to specify that the fuzz targets declared in the _test.go files of
+// the package should be documented in a Fuzzing section, along with their
+// documentation comments and the seed inputs of their corpus.
+func PackageWithFuzzTargets() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.fuzzTargets = true
+		return nil
+	}
+}
+
+// PackageWithClassDiagram can be used along with the NewPackageFromBuild
+// function to specify that a Class Diagram section should follow the index of
+// the package, holding a Mermaid class diagram of its exported types, the types
+// they embed and the interfaces they implement.
+func PackageWithClassDiagram() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.classDiagram = true
+		return nil
+	}
+}
+
+// PackageWithIotaValues can be used along with the NewPackageFromBuild function
+// to specify that const declarations using iota should be followed by a table
+// of the computed value of each of their constants, which are otherwise left
+// implicit in the declaration. Values are computed from type information, so
+// they are left out for packages which can't be type checked.
+func PackageWithIotaValues() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.iotaValues = true
+		return nil
+	}
+}
+
+// PackageWithComputedValues can be used along with the NewPackageFromBuild
+// function to specify that const declarations whose values are expressions
+// rather than literals (e.g. 1 << 20 or time.Second * 30) should be followed by
+// a table of the computed value of each of their constants. This includes the
+// declarations using iota. Like with PackageWithIotaValues, values are left out
+// for packages which can't be type checked.
+func PackageWithComputedValues() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.computedValues = true
+		return nil
+	}
+}
+
+// PackageWithFuncBodies can be used along with the NewPackageFromBuild function
+// to specify that the full declaration of each function and method, including
+// its body, should be included in a collapsible block after its documentation.
+// This is useful for small utility packages, where the implementation is the
+// best documentation. The body of a single function can be included instead
+// with a //gomarkdoc:body directive in its documentation comment.
+func PackageWithFuncBodies() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.funcBodies = true
+		return nil
+	}
+}
+
+// PackageWithBuildInfo can be used along with the NewPackageFromBuild function
+// to specify that the documentation of a main package should end with a build
+// configuration appendix for operators, listing the string variables which can
+// be set with -ldflags "-X" and the packages the command imports along with
+// the versions of the modules providing them. It is found by analyzing the
+// source, so nothing is built. Other packages are not affected.
+func PackageWithBuildInfo() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.buildInfo = true
+		return nil
+	}
+}
+
+// PackageWithExampleCheck can be used along with the NewPackageFromBuild
+// function to specify that the package's examples should be type checked, with
+// a warning logged for each example which doesn't compile.
+func PackageWithExampleCheck() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.checkExamples = true
+		return nil
+	}
+}
+
+// PackageWithStrictExamples can be used along with the NewPackageFromBuild
+// function to specify that the package's examples should be type checked and
+// that an error should be returned if any of them don't compile.
+func PackageWithStrictExamples() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.strictExamples = true
+		return nil
+	}
+}
+
+// PackageWithExampleExecution can be used along with the NewPackageFromBuild
+// function to specify that the package's testable examples should be run with
+// go test, so that the output they actually print is documented in place of
+// their output comments. Examples without an output comment are run as well.
+func PackageWithExampleExecution() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.runExamples = true
+		return nil
+	}
+}
+
+// PackageWithFlattenedEmbedding can be used along with the NewPackageFromBuild
+// function to specify that the fields promoted to struct types from the structs
+// they embed, including those embedded several levels deep, should be listed
+// along with each type and the embedded type they come from.
+func PackageWithFlattenedEmbedding() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.flattenEmbedded = true
+		return nil
+	}
+}
+
+// PackageWithPromotedMethods can be used along with the NewPackageFromBuild
+// function to specify that the methods promoted to types from the types they
+// embed should be listed along with each type and the embedded type they come
+// from, so that the documentation covers the full method set of each type.
+// This relies on type information, so nothing is listed for packages which
+// fail to type check.
+func PackageWithPromotedMethods() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.promotedMethods = true
+		return nil
+	}
+}
+
+// PackageWithCAPI can be used along with the NewPackageFromBuild function to
+// specify that the functions exported to C with //export directives and the
+// documented declarations of the C preamble should be included in a C API
+// section of the package's documentation.
+func PackageWithCAPI() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.cAPI = true
+		return nil
+	}
+}
+
+// PackageWithBuildTags can be used along with the NewPackageFromBuild function
+// to choose the files of the package to document using the provided build tags
+// (e.g. integration) instead of the files selected when the package was
+// loaded, so that symbols behind custom build constraints are documented. The
+// files are matched using the GOOS and GOARCH of the default build context.
+func PackageWithBuildTags(tags ...string) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.buildTags = append([]string{}, tags...)
+		return nil
+	}
+}
+
+// PackageWithPlatforms can be used along with the NewPackageFromBuild function
+// to document the package for each of the provided GOOS/GOARCH platforms (e.g.
+// linux/amd64) at once. The files chosen for any of the platforms are
+// documented together, and the symbols which aren't available on all of the
+// platforms are marked with the platforms they are available on. The files
+// are chosen using the build tags from PackageWithBuildTags, if any.
+func PackageWithPlatforms(platforms ...string) PackageOption {
+	return func(opts *PackageOptions) error {
+		for _, platform := range platforms {
+			if _, _, err := parsePlatform(platform); err != nil {
+				return err
+			}
+		}
+
+		opts.platforms = append([]string{}, platforms...)
+		return nil
+	}
+}
+
+// PackageWithBuildTargets can be used along with the NewPackageFromBuild
+// function to specify that symbols declared in files with build constraints
+// (e.g. //go:build js && wasm or a _windows.go suffix) should be annotated with
+// badges showing the targets they are available for.
+func PackageWithBuildTargets() PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.buildTargets = true
+		return nil
+	}
+}
+
+// PackageWithTranslations can be used along with the NewPackageFromBuild
+// function to substitute translated text for the prose blocks of the package's
+// documentation. The translations are keyed by the ProseID of the original
+// text.
+func PackageWithTranslations(translations map[string]string) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.translations = translations
+		return nil
+	}
+}
+
+// PackageWithStringCatalog can be used along with the NewPackageFromBuild
+// function to record the prose blocks of the package's documentation in the
+// provided catalog as they are rendered, so they can be exported for
+// translation.
+func PackageWithStringCatalog(catalog *StringCatalog) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.stringCatalog = catalog
+		return nil
+	}
+}
+
+// PackageWithSymbolAliases can be used along with the NewPackageFromBuild
+// function to present symbols under different names in the documentation, or
+// to leave them out entirely. The aliases are keyed by the name of a top-level
+// symbol (e.g. "clientImpl") or of a method or field of a type (e.g.
+// "Client.doRequest"), and an empty alias hides the symbol. Symbols which are
+// renamed to exported names are documented even if unexported symbols are not
+// included.
+func PackageWithSymbolAliases(aliases map[string]string) PackageOption {
+	return func(opts *PackageOptions) error {
+		if err := validateSymbolAliases(aliases); err != nil {
+			return err
+		}
+
+		opts.symbolAliases = aliases
+		return nil
+	}
+}
+
+// PackageWithImportURLResolver can be used along with the NewPackageFromBuild
+// function to resolve the urls of links to other packages in the package's
+// documentation using the provided resolver instead of linking to pkg.go.dev.
+func PackageWithImportURLResolver(resolver *ImportURLResolver) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.importURLs = resolver
+		return nil
+	}
+}
+
+// PackageWithPlaygroundLinks can be used along with the NewPackageFromBuild
+// function to specify that self-contained examples should link to a copy of
+// their code on the Go Playground, which is shared using the provided function
+// (e.g. by posting the code to the playground's share api).
+func PackageWithPlaygroundLinks(share PlaygroundSharer) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.playground = share
+		return nil
+	}
+}
+
+// PackageWithSymbolFilter can be used along with the NewPackageFromBuild
+// function to specify that only the symbols kept by the provided filter should
+// be included in the documentation for the package.
+func PackageWithSymbolFilter(filter *SymbolFilter) PackageOption {
+	return func(opts *PackageOptions) error {
+		opts.symbolFilter = filter
+		return nil
+	}
+}
+
+// PackageWithHiddenMethods can be used along with the NewPackageFromBuild
+// function to leave the methods of the types matching any of the provided
+// patterns out of the documentation for the package, while still documenting
+// the types themselves (e.g. generated gRPC servers with many boilerplate
+// methods). Patterns use the same syntax as NewSymbolFilter.
+func PackageWithHiddenMethods(types ...string) PackageOption {
+	return func(opts *PackageOptions) error {
+		patterns, err := compileSymbolPatterns(types)
+		if err != nil {
+			return err
+		}
+
+		opts.hiddenMethods = append(opts.hiddenMethods, patterns...)
+		return nil
+	}
+}
+
+// PackageWithOverrideImport can be used along with the NewPackageFromBuild
+// function to provide the import path of the package instead of deriving it
+// from the package's location. This allows packages outside of a Go module to
+// be documented as if they were imported with the provided import path.
 func PackageWithOverrideImport(importPath string) PackageOption {
 	return func(opts *PackageOptions) error {
 		opts.overrideImportPath = &importPath
@@ -147,14 +795,15 @@ func (pkg *Package) Name() string {
 
 // Import provides the raw text for the import declaration that is used to
 // import code from the package. If your package's documentation is generated
-// from a local path and does not use Go Modules, this will typically print
-// `import "."`.
+// from a local path which does not use Go Modules and no import path was
+// provided, the import path is unknown and the empty string is returned.
 func (pkg *Package) Import() string {
-	if pkg.cfg.OverrideImport != nil {
-		return fmt.Sprintf(`import "%s"`, *pkg.cfg.OverrideImport)
+	importPath := pkg.ImportPath()
+	if importPath == unknownImportPath {
+		return ""
 	}
 
-	return fmt.Sprintf(`import "%s"`, pkg.doc.ImportPath)
+	return fmt.Sprintf(`import "%s"`, importPath)
 }
 
 // ImportPath provides the identifier used for the package when installing or
@@ -168,6 +817,33 @@ func (pkg *Package) ImportPath() string {
 	return pkg.doc.ImportPath
 }
 
+// Internal indicates whether the package is an internal package, which may only
+// be imported by code rooted at the parent of its internal directory.
+func (pkg *Package) Internal() bool {
+	_, ok := internalRoot(pkg.ImportPath())
+	return ok
+}
+
+// InternalWarning provides the warning text to display for an internal
+// package. It is empty unless internal warnings were requested and the package
+// is internal.
+func (pkg *Package) InternalWarning() string {
+	if !pkg.cfg.WarnInternal {
+		return ""
+	}
+
+	root, ok := internalRoot(pkg.ImportPath())
+	if !ok {
+		return ""
+	}
+
+	if root == "" {
+		return "Internal package: it cannot be imported by other modules."
+	}
+
+	return fmt.Sprintf("Internal package: it can only be imported by packages within %s.", root)
+}
+
 // Summary provides the one-sentence summary of the package's documentation
 // comment.
 func (pkg *Package) Summary() string {
@@ -175,9 +851,10 @@ func (pkg *Package) Summary() string {
 }
 
 // Doc provides the structured contents of the documentation comment for the
-// package.
+// package. The "Deprecated:" paragraph is left out, since it is provided by
+// DeprecationNotice.
 func (pkg *Package) Doc() *Doc {
-	val := NewDoc(pkg.cfg.Inc(2), pkg.doc.Doc)
+	val := NewDoc(pkg.cfg.Inc(2), withoutDeprecation(pkg.doc.Doc))
 	if pkg.cfg.FileFilter != nil {
 		if path.Base(*pkg.cfg.FileFilter) != "doc.go" {
 			val.blocks = []*Block{}
@@ -189,6 +866,10 @@ func (pkg *Package) Doc() *Doc {
 
 // Consts lists the top-level constants provided by the package.
 func (pkg *Package) Consts() (consts []*Value) {
+	if !pkg.cfg.documents(ConstSection) {
+		return nil
+	}
+
 	for _, c := range pkg.doc.Consts {
 		val := NewValue(pkg.cfg.Inc(1), c)
 
@@ -207,6 +888,10 @@ func (pkg *Package) Consts() (consts []*Value) {
 
 // Vars lists the top-level variables provided by the package.
 func (pkg *Package) Vars() (vars []*Value) {
+	if !pkg.cfg.documents(VarSection) {
+		return nil
+	}
+
 	for _, v := range pkg.doc.Vars {
 		val := NewValue(pkg.cfg.Inc(1), v)
 
@@ -225,8 +910,12 @@ func (pkg *Package) Vars() (vars []*Value) {
 
 // Funcs lists the top-level functions provided by the package.
 func (pkg *Package) Funcs() (funcs []*Func) {
+	if !pkg.cfg.documents(FuncSection) {
+		return nil
+	}
+
 	for _, fn := range pkg.doc.Funcs {
-		val := NewFunc(pkg.cfg.Inc(1), fn, pkg.examples)
+		val := NewFunc(pkg.cfg.Inc(1), fn, pkg.ungroupedExamples())
 
 		if pkg.cfg.FileFilter != nil {
 			valPath := val.Location().Filepath
@@ -235,16 +924,25 @@ func (pkg *Package) Funcs() (funcs []*Func) {
 			}
 		}
 
+		if pkg.cfg.HideDeprecated && val.IsDeprecated() {
+			continue
+		}
+
 		funcs = append(funcs, val)
 	}
 
+	sortFuncs(pkg.cfg, funcs)
 	return
 }
 
 // Types lists the top-level types provided by the package.
 func (pkg *Package) Types() (types []*Type) {
+	if !pkg.cfg.documents(TypeSection) {
+		return nil
+	}
+
 	for _, typ := range pkg.doc.Types {
-		val := NewType(pkg.cfg.Inc(1), typ, pkg.examples)
+		val := NewType(pkg.cfg.Inc(1), typ, pkg.ungroupedExamples())
 
 		if pkg.cfg.FileFilter != nil {
 			valPath := val.Location().Filepath
@@ -253,17 +951,96 @@ func (pkg *Package) Types() (types []*Type) {
 			}
 		}
 
+		if pkg.cfg.HideDeprecated && val.IsDeprecated() {
+			continue
+		}
+
 		types = append(types, val)
 	}
 
+	sortTypes(pkg.cfg, types)
+	return
+}
+
+// DeprecatedTypes lists the top-level types of the package with a
+// "Deprecated:" note, including their functions and methods. Their headers are
+// nested one level below those of Types so that they can be listed under a
+// header for the package, such as in an appendix of deprecated symbols.
+func (pkg *Package) DeprecatedTypes() (types []*Type) {
+	for _, typ := range pkg.doc.Types {
+		val := NewType(pkg.cfg.Inc(2), typ, pkg.ungroupedExamples())
+		if !val.IsDeprecated() {
+			continue
+		}
+
+		if pkg.cfg.FileFilter != nil {
+			valPath := val.Location().Filepath
+			if *pkg.cfg.FileFilter != valPath {
+				continue
+			}
+		}
+
+		types = append(types, val)
+	}
+
+	sortTypes(pkg.cfg, types)
+	return
+}
+
+// DeprecatedFuncs lists the top-level functions of the package with a
+// "Deprecated:" note, along with the deprecated functions and methods of the
+// types which aren't deprecated themselves. Their headers are nested one level
+// below those of Funcs, like those of DeprecatedTypes.
+func (pkg *Package) DeprecatedFuncs() (funcs []*Func) {
+	var docs []*doc.Func
+	docs = append(docs, pkg.doc.Funcs...)
+	for _, typ := range pkg.doc.Types {
+		if _, ok := deprecation(typ.Doc); ok {
+			continue
+		}
+
+		docs = append(docs, typ.Funcs...)
+		docs = append(docs, typ.Methods...)
+	}
+
+	for _, fn := range docs {
+		val := NewFunc(pkg.cfg.Inc(2), fn, pkg.ungroupedExamples())
+		if !val.IsDeprecated() {
+			continue
+		}
+
+		if pkg.cfg.FileFilter != nil {
+			valPath := val.Location().Filepath
+			if *pkg.cfg.FileFilter != valPath {
+				continue
+			}
+		}
+
+		funcs = append(funcs, val)
+	}
+
 	return
 }
 
 // Examples provides the package-level examples that have been defined. This
 // does not include examples that are associated with symbols contained within
-// the package.
+// the package, except in the view of the package for the ExampleSection.
 func (pkg *Package) Examples() (examples []*Example) {
-	for _, example := range pkg.examples {
+	if !pkg.cfg.documents(ExampleSection) {
+		return nil
+	}
+
+	// The examples of the symbols are listed along with the package's own
+	// when they are routed to a file of their own
+	if pkg.cfg.Section == ExampleSection {
+		for _, example := range pkg.ungroupedExamples() {
+			examples = append(examples, newGroupedExample(pkg.cfg.Inc(1), example))
+		}
+
+		return examples
+	}
+
+	for _, example := range pkg.ungroupedExamples() {
 		var name string
 		switch {
 		case example.Name == "":
@@ -289,6 +1066,63 @@ func (pkg *Package) Examples() (examples []*Example) {
 	return
 }
 
+// ExampleGroups provides the examples of the package and its symbols which
+// have been tagged with a scenario using a //gomarkdoc:group directive, grouped
+// by scenario in the order the scenarios are first seen. Grouped examples are
+// not included in the examples of the package or the symbols they belong to.
+func (pkg *Package) ExampleGroups() []*ExampleGroup {
+	if !pkg.cfg.documents(ExampleSection) {
+		return nil
+	}
+
+	var groups []*ExampleGroup
+	byName := make(map[string]*ExampleGroup)
+	for _, example := range pkg.examples {
+		name := exampleGroup(pkg.cfg, example)
+		if name == "" {
+			continue
+		}
+
+		group, ok := byName[name]
+		if !ok {
+			group = &ExampleGroup{cfg: pkg.cfg.Inc(1), name: name}
+			byName[name] = group
+			groups = append(groups, group)
+		}
+
+		group.examples = append(group.examples, newGroupedExample(group.cfg.Inc(1), example))
+	}
+
+	return groups
+}
+
+// ungroupedExamples provides the examples which are not part of an
+// ExampleGroup.
+func (pkg *Package) ungroupedExamples() []*doc.Example {
+	var examples []*doc.Example
+	for _, example := range pkg.examples {
+		if exampleGroup(pkg.cfg, example) == "" {
+			examples = append(examples, example)
+		}
+	}
+
+	return examples
+}
+
+// internalRoot finds the import path of the tree which is allowed to import the
+// provided import path. The second return value is false if the import path is
+// not internal.
+func internalRoot(importPath string) (string, bool) {
+	parts := strings.Split(filepath.ToSlash(importPath), "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if parts[i] == "internal" {
+			return strings.Join(parts[:i], "/"), true
+		}
+	}
+
+	return "", false
+}
+
 var goModRegex = regexp.MustCompile(`^\s*module ([^\s]+)`)
 
 // findImportPath attempts to find an import path for the contents of the
@@ -356,44 +1190,29 @@ func findFileInParent(dir, filename string, fileIsDir bool) (*os.File, bool) {
 	return nil, false
 }
 
-func getDocPkg(pkg *build.Package, fs *token.FileSet, includeUnexported bool) (*doc.Package, error) {
-	pkgs, err := parser.ParseDir(
-		fs,
-		pkg.Dir,
-		func(info os.FileInfo) bool {
-			for _, name := range pkg.GoFiles {
-				if name == info.Name() {
-					return true
-				}
-			}
-
-			for _, name := range pkg.CgoFiles {
-				if name == info.Name() {
-					return true
-				}
-			}
-
-			return false
-		},
-		parser.ParseComments,
-	)
+// unknownImportPath is the import path of packages which are documented from a
+// local path outside of a Go module.
+const unknownImportPath = "."
 
+func getDocPkg(cfg *Config, pkg *build.Package, tags []string, includeUnexported, includeTestHelpers, includeExternalTestHelpers bool, aliases map[string]string, overrideImportPath *string) (*doc.Package, error) {
+	files, err := loadFiles(cfg, pkg, tags, includeTestHelpers, includeExternalTestHelpers)
 	if err != nil {
-		return nil, fmt.Errorf("gomarkdoc: failed to parse package: %w", err)
+		return nil, err
 	}
 
-	if len(pkgs) == 0 {
+	if len(files) == 0 {
 		return nil, fmt.Errorf("gomarkdoc: no source-code package in directory %s", pkg.Dir)
 	}
 
-	if len(pkgs) > 1 {
-		return nil, fmt.Errorf("gomarkdoc: multiple packages in directory %s", pkg.Dir)
-	}
+	astPkg := &ast.Package{Name: pkg.Name, Files: files}
+	aliasPackage(astPkg, aliases)
 
-	astPkg := pkgs[pkg.Name]
+	if includeTestHelpers || includeExternalTestHelpers {
+		removeTestFuncs(astPkg)
+	}
 
 	if !includeUnexported {
-		ast.PackageExports(astPkg)
+		packageExports(astPkg)
 	}
 
 	importPath := pkg.ImportPath
@@ -401,7 +1220,9 @@ func getDocPkg(pkg *build.Package, fs *token.FileSet, includeUnexported bool) (*
 		importPath = pkg.ImportComment
 	}
 
-	if importPath == "." {
+	if overrideImportPath != nil {
+		importPath = *overrideImportPath
+	} else if importPath == unknownImportPath {
 		if modPath, ok := findImportPath(pkg.Dir); ok {
 			importPath = modPath
 		}
@@ -60,6 +60,94 @@ func TestPackage_dotImport(t *testing.T) {
 	is.Equal(pkg.ImportPath(), `github.com/princjef/gomarkdoc/testData/lang/function`)
 }
 
+func TestPackage_outsideModule(t *testing.T) {
+	is := is.New(t)
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "foo.go"), []byte("// Package foo is outside of a module.\npackage foo\n"), 0o644)
+	is.NoErr(err)
+
+	buildPkg, err := build.ImportDir(dir, build.ImportComment)
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg)
+	is.NoErr(err)
+
+	is.Equal(pkg.ImportPath(), ".")
+	is.Equal(pkg.Import(), "") // import path is unknown
+
+	pkg, err = lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithOverrideImport("example.com/foo"))
+	is.NoErr(err)
+
+	is.Equal(pkg.ImportPath(), "example.com/foo")
+	is.Equal(pkg.Import(), `import "example.com/foo"`)
+}
+
+func TestPackage_symbolOrder(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/popularity")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithSymbolOrder(lang.PopularitySymbolOrder))
+	is.NoErr(err)
+
+	types := pkg.Types()
+	is.Equal(len(types), 2)
+	is.Equal(types[0].Name(), "Client")
+	is.Equal(types[1].Name(), "AbortPolicy")
+
+	funcs := pkg.Funcs()
+	is.Equal(len(funcs), 2)
+	is.Equal(funcs[0].Name(), "Run")
+	is.Equal(funcs[1].Name(), "Apply")
+
+	pkg, err = loadPackage("../testData/lang/popularity")
+	is.NoErr(err)
+
+	is.Equal(pkg.Types()[0].Name(), "AbortPolicy") // alphabetical by default
+	is.Equal(pkg.Funcs()[0].Name(), "Apply")
+
+	_, err = lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithSymbolOrder("random"))
+	is.True(err != nil)
+}
+
+func TestPackage_Internal(t *testing.T) {
+	tests := []struct {
+		importPath string
+		internal   bool
+		warning    string
+	}{
+		{"github.com/foo/bar", false, ""},
+		{"github.com/foo/bar/internal", true, "Internal package: it can only be imported by packages within github.com/foo/bar."},
+		{"github.com/foo/internal/bar", true, "Internal package: it can only be imported by packages within github.com/foo."},
+		{"github.com/foo/internalized", false, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.importPath, func(t *testing.T) {
+			is := is.New(t)
+
+			buildPkg, err := getBuildPackage("../testData/lang/function")
+			is.NoErr(err)
+
+			log := logger.New(logger.ErrorLevel)
+			pkg, err := lang.NewPackageFromBuild(
+				log,
+				buildPkg,
+				lang.PackageWithOverrideImport(test.importPath),
+				lang.PackageWithInternalWarning(),
+			)
+			is.NoErr(err)
+
+			is.Equal(pkg.Internal(), test.internal)
+			is.Equal(pkg.InternalWarning(), test.warning)
+		})
+	}
+}
+
 func TestPackage_strings(t *testing.T) {
 	is := is.New(t)
 
@@ -179,3 +267,126 @@ func loadPackage(dir string) (*lang.Package, error) {
 
 	return pkg, nil
 }
+
+func TestPackage_ClassDiagram(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/implementers")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithClassDiagram())
+	is.NoErr(err)
+
+	diagram := pkg.ClassDiagram()
+	is.True(strings.HasPrefix(diagram, "classDiagram\n"))
+	is.True(strings.Contains(diagram, "    class Shape {\n        <<interface>>\n        +Area() float64\n    }\n"))
+	is.True(strings.Contains(diagram, "    class Point {\n        +X float64\n        +Y float64\n    }\n"))
+	is.True(strings.Contains(diagram, "    Shape <|.. Circle\n")) // implemented by a pointer
+	is.True(strings.HasSuffix(diagram, "    Shape <|.. Square"))
+	is.True(!strings.Contains(diagram, "Any <|..")) // empty interfaces are left out
+
+	buildPkg, err = getBuildPackage("../testData/lang/promoted")
+	is.NoErr(err)
+
+	pkg, err = lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithClassDiagram())
+	is.NoErr(err)
+
+	diagram = pkg.ClassDiagram()
+	is.True(strings.Contains(diagram, "    Base <|-- Client\n"))
+	is.True(strings.Contains(diagram, "    Client <|-- Service"))
+
+	pkg, err = loadPackage("../testData/lang/promoted")
+	is.NoErr(err)
+	is.Equal(pkg.ClassDiagram(), "") // left out by default
+}
+
+func TestPackage_routes(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/function")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(
+		log,
+		buildPkg,
+		lang.PackageWithSymbolIndex(lang.NewSymbolIndex(), "docs/README.md"),
+		lang.PackageWithRoutes(map[lang.Section]string{
+			lang.TypeSection:    "docs/types.md",
+			lang.ExampleSection: "docs/examples/README.md",
+		}),
+	)
+	is.NoErr(err)
+
+	is.Equal(len(pkg.Funcs()), 1) // Standalone
+	is.Equal(len(pkg.Types()), 0)
+	is.Equal(len(pkg.ExampleGroups()), 0)
+
+	routes := pkg.Routes()
+	is.Equal(len(routes), 2)
+	is.Equal(routes[0].Text(), "Types")
+	is.Equal(routes[0].URL(), "types.md")
+	is.Equal(routes[1].Text(), "Examples")
+	is.Equal(routes[1].URL(), "examples/README.md")
+
+	types := pkg.ForSection(lang.TypeSection)
+	is.Equal(types.Section(), lang.TypeSection)
+	is.Equal(len(types.Consts()), 0)
+	is.Equal(len(types.Funcs()), 0)
+	is.Equal(len(types.Types()), 2)
+	is.Equal(len(types.Types()[1].Examples()), 0) // documented with the examples
+	is.Equal(len(types.Routes()), 0)
+
+	examples := pkg.ForSection(lang.ExampleSection)
+	is.True(len(examples.Examples()) > 0)
+	is.Equal(len(examples.Types()), 0)
+
+	is.Equal(pkg.ForSection(lang.FuncSection), nil)
+
+	_, err = lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithRoutes(map[lang.Section]string{"methods": "methods.md"}))
+	is.True(err != nil)
+}
+
+func TestPackage_Lookup(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/lang/function")
+	is.NoErr(err)
+
+	is.Equal(pkg.LookupFunc("Standalone").Name(), "Standalone")
+	is.Equal(pkg.LookupFunc("New").Name(), "New") // constructor of Receiver
+	is.Equal(pkg.LookupFunc("Receiver.WithPtrReceiver").Name(), "WithPtrReceiver")
+	is.Equal(pkg.LookupFunc("Receiver.Missing"), nil)
+	is.Equal(pkg.LookupType("Receiver").Name(), "Receiver")
+	is.Equal(pkg.LookupType("Standalone"), nil)
+	is.Equal(pkg.LookupValue("ConstB").Title(), "const ConstA, ConstB")
+	is.Equal(pkg.LookupValue("Missing"), nil)
+}
+
+func TestPackage_pinnedCommit(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/function")
+	is.NoErr(err)
+
+	overrides := &lang.Repo{
+		Remote:        "https://github.com/princjef/gomarkdoc",
+		DefaultBranch: "master",
+		PathFromRoot:  "/",
+	}
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(
+		log,
+		buildPkg,
+		lang.PackageWithRepositoryOverrides(overrides),
+		lang.PackageWithPinnedCommit(),
+	)
+	is.NoErr(err)
+
+	repo := pkg.Funcs()[0].Location().Repo
+	is.Equal(len(repo.Commit), 40)
+	is.Equal(repo.Ref(), repo.Commit)
+	is.Equal(overrides.Commit, "") // the overrides are left as they are
+}
@@ -0,0 +1,200 @@
+package lang
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type (
+	// PackageTree organizes a set of packages by directory for an index of
+	// the packages of a module or repository. Directories between the packages
+	// which don't hold a package themselves are included so that the nesting
+	// of the packages is kept. If ImportGraph is set, the graph is rendered
+	// after the packages in the index.
+	PackageTree struct {
+		ImportGraph *ImportGraph
+		entries     []*PackageTreeEntry
+	}
+
+	// PackageTreeEntry is a single directory of a PackageTree, which may hold
+	// a package.
+	PackageTreeEntry struct {
+		name     string
+		depth    int
+		pkg      *Package
+		href     string
+		children []*PackageTreeEntry
+	}
+)
+
+// NewPackageTree creates a PackageTree for the provided packages, rooted at
+// the deepest directory containing all of them. The entries of packages link
+// to the files their documentation is written to (as provided to
+// PackageWithSymbolIndex), relative to the index file.
+func NewPackageTree(indexFile string, packages []*Package) *PackageTree {
+	if len(packages) == 0 {
+		return &PackageTree{}
+	}
+
+	dirs := make([]string, len(packages))
+	for i, pkg := range packages {
+		dirs[i] = filepath.Clean(pkg.Dir())
+	}
+
+	root := commonDir(dirs)
+
+	byDir := make(map[string]*Package, len(packages))
+	for i, pkg := range packages {
+		rel, err := filepath.Rel(root, dirs[i])
+		if err != nil {
+			continue
+		}
+
+		byDir[filepath.ToSlash(rel)] = pkg
+	}
+
+	// Directories nest under the package at the root if there is one
+	tree := &PackageTree{}
+	var rootEntry *PackageTreeEntry
+	if pkg, ok := byDir["."]; ok {
+		rootEntry = newPackageTreeEntry(indexFile, pkg.Dirname(), 0, pkg)
+		tree.entries = append(tree.entries, rootEntry)
+	}
+
+	rels := make([]string, 0, len(byDir))
+	for rel := range byDir {
+		if rel != "." {
+			rels = append(rels, rel)
+		}
+	}
+
+	// Sorting by segment keeps the contents of a directory together
+	sort.Slice(rels, func(i, j int) bool {
+		return strings.ReplaceAll(rels[i], "/", "\x00") < strings.ReplaceAll(rels[j], "/", "\x00")
+	})
+
+	entries := make(map[string]*PackageTreeEntry)
+	for _, rel := range rels {
+		segments := strings.Split(rel, "/")
+		parent := rootEntry
+		for i, segment := range segments {
+			dir := strings.Join(segments[:i+1], "/")
+			entry, ok := entries[dir]
+			if !ok {
+				depth := i
+				if rootEntry != nil {
+					depth++
+				}
+
+				entry = newPackageTreeEntry(indexFile, segment, depth, byDir[dir])
+				entries[dir] = entry
+
+				if parent == nil {
+					tree.entries = append(tree.entries, entry)
+				} else {
+					parent.children = append(parent.children, entry)
+				}
+			}
+
+			parent = entry
+		}
+	}
+
+	return tree
+}
+
+func newPackageTreeEntry(indexFile, name string, depth int, pkg *Package) *PackageTreeEntry {
+	entry := &PackageTreeEntry{name: name, depth: depth, pkg: pkg}
+	if pkg != nil && indexFile != "" && pkg.cfg.OutputFile != "" {
+		if href, ok := relativeHref(indexFile, pkg.cfg.OutputFile); ok {
+			entry.href = href
+		}
+	}
+
+	return entry
+}
+
+// Entries lists the top-level directories of the tree in order.
+func (t *PackageTree) Entries() []*PackageTreeEntry {
+	return t.entries
+}
+
+// Name provides the name of the directory, relative to the directory
+// containing it.
+func (e *PackageTreeEntry) Name() string {
+	return e.name
+}
+
+// Depth provides the zero-based nesting depth of the directory in the tree.
+func (e *PackageTreeEntry) Depth() int {
+	return e.depth
+}
+
+// Package provides the package in the directory, or nil if the directory
+// only contains other packages.
+func (e *PackageTreeEntry) Package() *Package {
+	return e.pkg
+}
+
+// Href provides the path of the file holding the package's documentation,
+// relative to the index file. It is empty if the directory doesn't hold a
+// package or the file isn't known.
+func (e *PackageTreeEntry) Href() string {
+	return e.href
+}
+
+// Children lists the directories nested within the directory in order.
+func (e *PackageTreeEntry) Children() []*PackageTreeEntry {
+	return e.children
+}
+
+// Summary provides the summary of the package's documentation, if the
+// directory holds a package.
+func (e *PackageTreeEntry) Summary() string {
+	if e.pkg == nil {
+		return ""
+	}
+
+	return e.pkg.Summary()
+}
+
+// commonDir finds the deepest directory containing all of the provided
+// directories.
+func commonDir(dirs []string) string {
+	root := dirs[0]
+	for _, dir := range dirs[1:] {
+		for root != dir && !strings.HasPrefix(dir, root+string(filepath.Separator)) {
+			parent := filepath.Dir(root)
+			if parent == root {
+				break
+			}
+
+			root = parent
+		}
+	}
+
+	return root
+}
+
+// relativeHref provides the path to the file at to from the file at from,
+// using forward slashes as in a url. The second return value is false if
+// either path can't be resolved.
+func relativeHref(from, to string) (string, bool) {
+	fromAbs, err := filepath.Abs(from)
+	if err != nil {
+		return "", false
+	}
+
+	toAbs, err := filepath.Abs(to)
+	if err != nil {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(filepath.Dir(fromAbs), toAbs)
+	if err != nil {
+		return "", false
+	}
+
+	return filepath.ToSlash(rel), true
+}
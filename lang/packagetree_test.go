@@ -0,0 +1,49 @@
+package lang_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestNewPackageTree(t *testing.T) {
+	is := is.New(t)
+
+	log := logger.New(logger.ErrorLevel)
+
+	var pkgs []*lang.Package
+	for _, dir := range []string{"simple", "lang/function", "lang/deprecated"} {
+		buildPkg, err := getBuildPackage(filepath.Join("../testData", dir))
+		is.NoErr(err)
+
+		pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithSymbolIndex(nil, filepath.Join("docs", dir, "README.md")))
+		is.NoErr(err)
+
+		pkgs = append(pkgs, pkg)
+	}
+
+	tree := lang.NewPackageTree("docs/INDEX.md", pkgs)
+
+	entries := tree.Entries()
+	is.Equal(len(entries), 2)
+
+	is.Equal(entries[0].Name(), "lang")
+	is.Equal(entries[0].Package(), nil) // only holds other packages
+	is.Equal(entries[0].Href(), "")
+	is.Equal(entries[0].Depth(), 0)
+
+	children := entries[0].Children()
+	is.Equal(len(children), 2)
+	is.Equal(children[0].Name(), "deprecated")
+	is.Equal(children[0].Depth(), 1)
+	is.Equal(children[0].Href(), "lang/deprecated/README.md")
+	is.Equal(children[0].Summary(), "Package deprecated exercises the detection of deprecated symbols.")
+	is.Equal(children[1].Name(), "function")
+
+	is.Equal(entries[1].Name(), "simple")
+	is.Equal(entries[1].Href(), "simple/README.md")
+	is.Equal(len(entries[1].Children()), 0)
+}
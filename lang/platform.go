@@ -0,0 +1,257 @@
+package lang
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/doc"
+	"go/token"
+	"io/fs"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Platforms lists the GOOS/GOARCH platforms (e.g. linux/amd64) the function is
+// available on, in the order the platforms were provided. The list is empty
+// unless the package is documented for multiple platforms.
+func (fn *Func) Platforms() []string {
+	return symbolPlatforms(fn.cfg, funcSymbolName(fn.doc))
+}
+
+// Platforms lists the GOOS/GOARCH platforms (e.g. linux/amd64) the type is
+// available on, in the order the platforms were provided. The list is empty
+// unless the package is documented for multiple platforms.
+func (typ *Type) Platforms() []string {
+	return symbolPlatforms(typ.cfg, typ.doc.Name)
+}
+
+// Platforms lists the GOOS/GOARCH platforms (e.g. linux/amd64) any of the
+// names of the value is available on, in the order the platforms were
+// provided. The list is empty unless the package is documented for multiple
+// platforms.
+func (v *Value) Platforms() []string {
+	return symbolPlatforms(v.cfg, v.doc.Names...)
+}
+
+// parsePlatform splits a platform into its GOOS and GOARCH.
+func parsePlatform(platform string) (goos, goarch string, err error) {
+	parts := strings.Split(platform, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("gomarkdoc: invalid platform %s, expected GOOS/GOARCH", platform)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// platformPackage provides a copy of the package holding the files chosen for
+// any of the platforms, using the provided build tags. The platforms each file
+// is chosen for are provided by file name. Platforms the package has no files
+// for are skipped.
+func platformPackage(fsys fs.FS, pkg *build.Package, tags, platforms []string) (*build.Package, map[string][]string, error) {
+	filePlatforms := make(map[string][]string)
+	p := *pkg
+	p.GoFiles, p.CgoFiles, p.TestGoFiles, p.XTestGoFiles = nil, nil, nil, nil
+
+	found := false
+	for _, platform := range platforms {
+		goos, goarch, err := parsePlatform(platform)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ctx := buildContext(fsys)
+		ctx.GOOS = goos
+		ctx.GOARCH = goarch
+		ctx.BuildTags = tags
+
+		platformPkg, err := ctx.ImportDir(pkg.Dir, build.ImportComment)
+		var noGo *build.NoGoError
+		if errors.As(err, &noGo) {
+			continue
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("gomarkdoc: failed to load package in directory %s for %s: %w", pkg.Dir, platform, err)
+		}
+
+		found = true
+		for _, files := range [][]string{platformPkg.GoFiles, platformPkg.CgoFiles} {
+			for _, name := range files {
+				filePlatforms[name] = append(filePlatforms[name], platform)
+			}
+		}
+
+		p.GoFiles = mergeFiles(p.GoFiles, platformPkg.GoFiles)
+		p.CgoFiles = mergeFiles(p.CgoFiles, platformPkg.CgoFiles)
+		p.TestGoFiles = mergeFiles(p.TestGoFiles, platformPkg.TestGoFiles)
+		p.XTestGoFiles = mergeFiles(p.XTestGoFiles, platformPkg.XTestGoFiles)
+	}
+
+	if !found {
+		return nil, nil, fmt.Errorf("gomarkdoc: package in directory %s has no files for any of the platforms %s", pkg.Dir, strings.Join(platforms, ", "))
+	}
+
+	return &p, filePlatforms, nil
+}
+
+// mergeFiles adds the names which are missing from the sorted list of file
+// names, keeping it sorted.
+func mergeFiles(files, names []string) []string {
+	for _, name := range names {
+		i := sort.SearchStrings(files, name)
+		if i < len(files) && files[i] == name {
+			continue
+		}
+
+		files = append(files, "")
+		copy(files[i+1:], files[i:])
+		files[i] = name
+	}
+
+	return files
+}
+
+// platformSymbols finds the platforms each of the top-level symbols of the
+// package is declared for, keyed by symbol name as in Config.Symbols. A symbol
+// declared in several files is available on the platforms of all of them.
+func platformSymbols(cfg *Config, filePlatforms map[string][]string) map[string][]string {
+	symbols := make(map[string][]string)
+	add := func(name string, platforms []string) {
+		symbols[name] = append(symbols[name], platforms...)
+	}
+
+	for _, f := range cfg.Files {
+		platforms, ok := filePlatforms[filepath.Base(cfg.FileSet.Position(f.Pos()).Filename)]
+		if !ok {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				var recv string
+				if decl.Recv != nil && len(decl.Recv.List) > 0 {
+					recv = recvTypeName(decl.Recv.List[0].Type)
+				}
+
+				add(symbolName(recv, decl.Name.Name), platforms)
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						add(spec.Name.Name, platforms)
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							add(name.Name, platforms)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return symbols
+}
+
+// funcSymbolName provides the name of the function as in Config.Symbols, which
+// is qualified by the name of the receiver type for methods.
+func funcSymbolName(fn *doc.Func) string {
+	recv := fn.Recv
+	if i := strings.Index(recv, "["); i >= 0 {
+		recv = recv[:i]
+	}
+
+	return symbolName(recv, fn.Name)
+}
+
+// recvTypeName provides the name of the type of a method receiver, without
+// any pointer or type parameters.
+func recvTypeName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return recvTypeName(expr.X)
+	case *ast.IndexExpr:
+		return recvTypeName(expr.X)
+	case *ast.IndexListExpr:
+		return recvTypeName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	}
+
+	return ""
+}
+
+// symbolPlatforms provides the platforms any of the named symbols is
+// available on, in the order of the platforms of the config.
+func symbolPlatforms(cfg *Config, names ...string) []string {
+	if len(cfg.Platforms) == 0 {
+		return nil
+	}
+
+	available := make(map[string]bool)
+	for _, name := range names {
+		for _, platform := range cfg.SymbolPlatforms[name] {
+			available[platform] = true
+		}
+	}
+
+	var platforms []string
+	for _, platform := range cfg.Platforms {
+		if available[platform] {
+			platforms = append(platforms, platform)
+		}
+	}
+
+	return platforms
+}
+
+// platformBadges provides a badge listing the platforms of the named symbols
+// if they aren't available on all of the platforms the package is documented
+// for.
+func platformBadges(cfg *Config, names ...string) []*Badge {
+	platforms := symbolPlatforms(cfg, names...)
+	if len(platforms) == 0 || len(platforms) == len(cfg.Platforms) {
+		return nil
+	}
+
+	text := strings.Join(platforms, ", ")
+
+	// Dashes and underscores are separators in shields.io badge paths, so they
+	// need to be doubled to appear in the text
+	image := strings.NewReplacer("-", "--", "_", "__").Replace(text)
+
+	return []*Badge{NewBadge(
+		PlatformBadge,
+		fmt.Sprintf("Platforms: %s", text),
+		fmt.Sprintf("https://img.shields.io/badge/platforms-%s-informational", url.PathEscape(image)),
+		"",
+	)}
+}
+
+// removeDuplicateValues removes the constants and variables which are declared
+// again by the files of another platform, keeping the first declaration.
+func removeDuplicateValues(pkg *doc.Package) {
+	seen := make(map[string]bool)
+	dedupe := func(values []*doc.Value, tok token.Token) []*doc.Value {
+		kept := values[:0]
+		for _, v := range values {
+			key := fmt.Sprintf("%s %s", tok, strings.Join(v.Names, ","))
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+			kept = append(kept, v)
+		}
+
+		return kept
+	}
+
+	pkg.Consts = dedupe(pkg.Consts, token.CONST)
+	pkg.Vars = dedupe(pkg.Vars, token.VAR)
+	for _, typ := range pkg.Types {
+		typ.Consts = dedupe(typ.Consts, token.CONST)
+		typ.Vars = dedupe(typ.Vars, token.VAR)
+	}
+}
@@ -0,0 +1,30 @@
+package lang
+
+// PlaygroundSharer shares the source of a program on the Go Playground,
+// providing the url of the shared program.
+type PlaygroundSharer func(code string) (string, error)
+
+// PlaygroundURL provides the url of the example shared on the Go Playground so
+// that readers can run it. It is empty unless playground links have been
+// enabled for the package, or if the example isn't self-contained, since the
+// playground can only run complete programs. Examples which fail to be shared
+// are logged and left without a link.
+func (ex *Example) PlaygroundURL() string {
+	if ex.cfg.Playground == nil || ex.doc.Play == nil {
+		return ""
+	}
+
+	code, err := ex.Code()
+	if err != nil {
+		ex.cfg.Log.Warnf("unable to print example %s for the playground: %s", ex.name, err)
+		return ""
+	}
+
+	u, err := ex.cfg.Playground(code)
+	if err != nil {
+		ex.cfg.Log.Warnf("unable to share example %s on the playground: %s", ex.name, err)
+		return ""
+	}
+
+	return u
+}
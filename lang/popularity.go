@@ -0,0 +1,193 @@
+package lang
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SymbolOrder identifies the order in which the functions and types of a
+// package are listed.
+type SymbolOrder string
+
+const (
+	// AlphabeticalSymbolOrder lists functions and types sorted by name.
+	AlphabeticalSymbolOrder SymbolOrder = "alphabetical"
+
+	// PopularitySymbolOrder lists the functions and types which are referenced
+	// most often within the module's own code and tests first. Symbols with
+	// the same number of references are sorted by name.
+	PopularitySymbolOrder SymbolOrder = "popularity"
+)
+
+// symbolUsage counts the references to the package's top-level functions and
+// types (including the functions associated with types) in the Go files of
+// the module containing the package, including its tests. References within
+// the package are found by name and references from other packages by the
+// selectors on the package's imports. This is a cheap static count, so
+// references through dot imports and shadowed names are not accounted for.
+func symbolUsage(cfg *Config) map[string]int {
+	names := make(map[string]bool)
+	for _, fn := range cfg.Pkg.Funcs {
+		names[fn.Name] = true
+	}
+
+	for _, typ := range cfg.Pkg.Types {
+		names[typ.Name] = true
+		for _, fn := range typ.Funcs {
+			names[fn.Name] = true
+		}
+	}
+
+	root := moduleRoot(cfg.PkgDir)
+	usage := make(map[string]int)
+	fset := token.NewFileSet()
+
+	_ = filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if info.IsDir() {
+			if p != root && skipUsageDir(p, info.Name()) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(p, ".go") {
+			return nil
+		}
+
+		f, err := parser.ParseFile(fset, p, nil, parser.SkipObjectResolution)
+		if err != nil {
+			cfg.Log.Debugf("skipping file %s for usage analysis: %s", p, err)
+			return nil
+		}
+
+		if filepath.Dir(p) == cfg.PkgDir && f.Name.Name == cfg.Pkg.Name {
+			countLocalUsage(f, names, usage)
+		} else if local, ok := importName(f, cfg.Pkg.ImportPath, cfg.Pkg.Name); ok {
+			countImportedUsage(f, local, names, usage)
+		}
+
+		return nil
+	})
+
+	return usage
+}
+
+// moduleRoot provides the directory of the module containing the provided
+// directory, or the directory itself if it is not in a module.
+func moduleRoot(dir string) string {
+	f, ok := findFileInParent(dir, "go.mod", false)
+	if !ok {
+		return dir
+	}
+	defer f.Close()
+
+	return filepath.Dir(f.Name())
+}
+
+// skipUsageDir indicates whether a directory should be left out of the usage
+// analysis, following the directories ignored by the go command. Nested
+// modules are left out as well.
+func skipUsageDir(p, name string) bool {
+	if name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+		return true
+	}
+
+	_, err := os.Stat(filepath.Join(p, "go.mod"))
+	return err == nil
+}
+
+// importName provides the name the file refers to the package with the
+// provided import path by. The second return value is false if the file
+// doesn't import the package or imports it without a name to refer to it by.
+func importName(f *ast.File, importPath, pkgName string) (string, bool) {
+	for _, spec := range f.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || p != importPath {
+			continue
+		}
+
+		if spec.Name == nil {
+			return pkgName, true
+		}
+
+		if spec.Name.Name == "_" || spec.Name.Name == "." {
+			return "", false
+		}
+
+		return spec.Name.Name, true
+	}
+
+	return "", false
+}
+
+func countLocalUsage(f *ast.File, names map[string]bool, usage map[string]int) {
+	// Declarations and selected fields or methods aren't references to the
+	// top-level symbols, even if they share a name
+	skip := make(map[*ast.Ident]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			skip[n.Name] = true
+		case *ast.TypeSpec:
+			skip[n.Name] = true
+		case *ast.SelectorExpr:
+			skip[n.Sel] = true
+		case *ast.Ident:
+			if !skip[n] && names[n.Name] {
+				usage[n.Name]++
+			}
+		}
+
+		return true
+	})
+}
+
+func countImportedUsage(f *ast.File, local string, names map[string]bool, usage map[string]int) {
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		if x, ok := sel.X.(*ast.Ident); ok && x.Name == local && names[sel.Sel.Name] {
+			usage[sel.Sel.Name]++
+		}
+
+		return true
+	})
+}
+
+// sortFuncs sorts the provided functions in place according to the symbol
+// order of the config. Functions are already sorted alphabetically.
+func sortFuncs(cfg *Config, funcs []*Func) {
+	if cfg.SymbolOrder != PopularitySymbolOrder {
+		return
+	}
+
+	sort.SliceStable(funcs, func(i, j int) bool {
+		return cfg.Usage[funcs[i].Name()] > cfg.Usage[funcs[j].Name()]
+	})
+}
+
+// sortTypes sorts the provided types in place according to the symbol order of
+// the config. Types are already sorted alphabetically.
+func sortTypes(cfg *Config, types []*Type) {
+	if cfg.SymbolOrder != PopularitySymbolOrder {
+		return
+	}
+
+	sort.SliceStable(types, func(i, j int) bool {
+		return cfg.Usage[types[i].Name()] > cfg.Usage[types[j].Name()]
+	})
+}
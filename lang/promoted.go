@@ -0,0 +1,266 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// PromotedMethod holds documentation information for a method promoted to a
+// type from one of the types it embeds, which is part of the type's method set
+// without being declared for it.
+type PromotedMethod struct {
+	cfg      *Config
+	typeName string
+	fn       *types.Func
+	from     []string
+}
+
+// Level provides the default level at which headers for the method should be
+// rendered in the final documentation.
+func (m *PromotedMethod) Level() int {
+	return m.cfg.Level
+}
+
+// Name provides the name of the method.
+func (m *PromotedMethod) Name() string {
+	return m.fn.Name()
+}
+
+// Receiver provides the name of the type the method is promoted to.
+func (m *PromotedMethod) Receiver() string {
+	return m.typeName
+}
+
+// Signature provides the raw text representation of the method's signature
+// (e.g. "Read(p []byte) (n int, err error)"). Types from other packages are
+// qualified by the name of their package.
+func (m *PromotedMethod) Signature() string {
+	sig := types.TypeString(m.fn.Type(), m.qualifier)
+	return fmt.Sprintf("%s%s", m.Name(), strings.TrimPrefix(sig, "func"))
+}
+
+// Origin provides the type declaring the method (e.g. "Base" or "io.Reader").
+// Types from other packages are qualified by the name of their package.
+func (m *PromotedMethod) Origin() string {
+	recv := m.fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return ""
+	}
+
+	t := recv.Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	if named, ok := t.(*types.Named); ok {
+		return types.TypeString(named.Origin(), m.qualifier)
+	}
+
+	// Methods of interfaces have the interface as their receiver, which is
+	// the embedded type the method was promoted from
+	if len(m.from) > 0 {
+		return m.from[len(m.from)-1]
+	}
+
+	return types.TypeString(t, m.qualifier)
+}
+
+// EmbeddedFrom provides the path of embedded types the method is promoted
+// through (e.g. "Base.Logger"), starting with the one embedded in the type
+// itself.
+func (m *PromotedMethod) EmbeddedFrom() string {
+	return strings.Join(m.from, ".")
+}
+
+// Summary provides the one-sentence summary of the method's documentation
+// comment.
+func (m *PromotedMethod) Summary() string {
+	return extractSummary(m.docText())
+}
+
+// Doc provides the structured contents of the documentation comment for the
+// method. Documentation is only available for methods declared in the package
+// itself.
+func (m *PromotedMethod) Doc() *Doc {
+	return NewDoc(m.cfg.Inc(1), m.docText())
+}
+
+// Anchor produces anchor text for the method. Promoted methods have no headers
+// of their own, so the anchor is the one of the type they are promoted to.
+func (m *PromotedMethod) Anchor() string {
+	return Symbol{
+		Kind: TypeSymbolKind,
+		Name: m.typeName,
+	}.Anchor()
+}
+
+func (m *PromotedMethod) qualifier(pkg *types.Package) string {
+	return packageQualifier(m.cfg)(pkg)
+}
+
+// packageQualifier qualifies the names of types from packages other than the
+// one being documented with the name of their package.
+func packageQualifier(cfg *Config) types.Qualifier {
+	return func(pkg *types.Package) string {
+		if pkg == cfg.Types {
+			return ""
+		}
+
+		return pkg.Name()
+	}
+}
+
+// docText finds the documentation comment of the method's declaration in the
+// package. The comments of methods are only kept by the documentation of the
+// package, while those of interface methods are kept in the syntax.
+func (m *PromotedMethod) docText() string {
+	if m.fn.Pkg() != m.cfg.Types {
+		return ""
+	}
+
+	pos := m.fn.Pos()
+	for _, typ := range m.cfg.Pkg.Types {
+		for _, fn := range typ.Methods {
+			if fn.Decl != nil && fn.Decl.Name.Pos() == pos {
+				return fn.Doc
+			}
+		}
+	}
+
+	var text string
+	for _, f := range m.cfg.Files {
+		if pos < f.Pos() || pos > f.End() {
+			continue
+		}
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			if field, ok := n.(*ast.Field); ok && len(field.Names) > 0 && field.Names[0].Pos() == pos {
+				text = field.Doc.Text()
+				if text == "" {
+					text = field.Comment.Text()
+				}
+			}
+
+			return text == ""
+		})
+	}
+
+	return text
+}
+
+// PromotedMethods lists the exported methods promoted to the type from the
+// types it embeds, including those embedded several levels deep, along with
+// the embedded types they come from. For interfaces, these are the methods of
+// the embedded interfaces. Methods are only listed when promoted methods are
+// enabled for the package and type information is available for it.
+func (typ *Type) PromotedMethods() []*PromotedMethod {
+	if !typ.cfg.PromotedMethods || typ.cfg.Types == nil {
+		return nil
+	}
+
+	obj, ok := typ.cfg.Types.Scope().Lookup(typ.doc.Name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+
+	// Methods go/doc already lists with the type are left out
+	listed := make(map[string]bool)
+	for _, fn := range typ.doc.Methods {
+		listed[fn.Name] = true
+	}
+
+	var methods []*PromotedMethod
+	if iface, ok := obj.Type().Underlying().(*types.Interface); ok {
+		methods = interfacePromotedMethods(typ.cfg.Inc(1), typ.doc.Name, iface)
+	} else {
+		methods = promotedMethods(typ.cfg.Inc(1), typ.doc.Name, obj.Type())
+	}
+
+	filtered := methods[:0]
+	for _, m := range methods {
+		if !listed[m.Name()] {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return filtered
+}
+
+// promotedMethods finds the methods in the method set of a pointer to the type
+// which are promoted from embedded fields.
+func promotedMethods(cfg *Config, typeName string, t types.Type) []*PromotedMethod {
+	var methods []*PromotedMethod
+	mset := types.NewMethodSet(types.NewPointer(t))
+	for i := 0; i < mset.Len(); i++ {
+		sel := mset.At(i)
+		fn, ok := sel.Obj().(*types.Func)
+		if !ok || len(sel.Index()) < 2 || !token.IsExported(fn.Name()) {
+			continue
+		}
+
+		methods = append(methods, &PromotedMethod{cfg, typeName, fn, embeddedPath(t, sel.Index())})
+	}
+
+	return methods
+}
+
+// embeddedPath provides the names of the embedded fields followed to reach a
+// promoted method through the field indices of its selection.
+func embeddedPath(t types.Type, index []int) []string {
+	var path []string
+	for _, i := range index[:len(index)-1] {
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+
+		st, ok := t.Underlying().(*types.Struct)
+		if !ok {
+			break
+		}
+
+		field := st.Field(i)
+		path = append(path, field.Name())
+		t = field.Type()
+	}
+
+	return path
+}
+
+// interfacePromotedMethods finds the methods of an interface which come from
+// its embedded interfaces rather than being declared by it, along with the
+// embedded interface each of them comes from.
+func interfacePromotedMethods(cfg *Config, typeName string, iface *types.Interface) []*PromotedMethod {
+	explicit := make(map[string]bool)
+	for i := 0; i < iface.NumExplicitMethods(); i++ {
+		explicit[iface.ExplicitMethod(i).Name()] = true
+	}
+
+	var methods []*PromotedMethod
+	for i := 0; i < iface.NumMethods(); i++ {
+		fn := iface.Method(i)
+		if explicit[fn.Name()] || !token.IsExported(fn.Name()) {
+			continue
+		}
+
+		var from []string
+		for j := 0; j < iface.NumEmbeddeds(); j++ {
+			embedded := iface.EmbeddedType(j)
+			if obj, _, _ := types.LookupFieldOrMethod(embedded, false, fn.Pkg(), fn.Name()); obj != nil {
+				from = []string{types.TypeString(embedded, packageQualifier(cfg))}
+				break
+			}
+		}
+
+		methods = append(methods, &PromotedMethod{cfg, typeName, fn, from})
+	}
+
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Name() < methods[j].Name()
+	})
+
+	return methods
+}
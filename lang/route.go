@@ -0,0 +1,188 @@
+package lang
+
+import (
+	"fmt"
+	"go/doc"
+)
+
+// Section identifies a kind of symbol which is documented in its own section
+// of a package's documentation and can be routed to a separate file.
+type Section string
+
+const (
+	// ConstSection holds the top-level constants of a package.
+	ConstSection Section = "consts"
+
+	// VarSection holds the top-level variables of a package.
+	VarSection Section = "vars"
+
+	// FuncSection holds the top-level functions of a package which aren't
+	// associated with one of its types.
+	FuncSection Section = "funcs"
+
+	// TypeSection holds the types of a package, along with their functions,
+	// methods, constants and variables.
+	TypeSection Section = "types"
+
+	// ExampleSection holds the examples of a package and of its symbols.
+	ExampleSection Section = "examples"
+)
+
+// sectionTitles holds the titles used to link to the files sections are
+// routed to, in the order the sections appear in the documentation.
+var sectionTitles = []struct {
+	section Section
+	title   string
+}{
+	{ConstSection, "Constants"},
+	{VarSection, "Variables"},
+	{FuncSection, "Functions"},
+	{TypeSection, "Types"},
+	{ExampleSection, "Examples"},
+}
+
+// validateRoutes checks that each of the routes is for a valid section and has
+// a file to route it to.
+func validateRoutes(routes map[Section]string) error {
+	for section, file := range routes {
+		switch section {
+		case ConstSection, VarSection, FuncSection, TypeSection, ExampleSection:
+		default:
+			return fmt.Errorf("gomarkdoc: invalid section %s, expected one of consts, vars, funcs, types or examples", section)
+		}
+
+		if file == "" {
+			return fmt.Errorf("gomarkdoc: no file provided for section %s", section)
+		}
+	}
+
+	return nil
+}
+
+// symbolSections maps the names of the symbols of the package (as used in the
+// symbols of the config) to the sections they are documented in.
+func symbolSections(pkg *doc.Package) map[string]Section {
+	sections := make(map[string]Section)
+	for _, c := range pkg.Consts {
+		for _, n := range c.Names {
+			sections[n] = ConstSection
+		}
+	}
+
+	for _, v := range pkg.Vars {
+		for _, n := range v.Names {
+			sections[n] = VarSection
+		}
+	}
+
+	for _, fn := range pkg.Funcs {
+		sections[fn.Name] = FuncSection
+	}
+
+	// Everything else in the symbols of the package belongs to a type
+	for name := range PackageSymbols(pkg) {
+		if _, ok := sections[name]; !ok {
+			sections[name] = TypeSection
+		}
+	}
+
+	return sections
+}
+
+// ForSection provides a view of the package for the file its section is
+// routed to, which only documents the symbols of that section. The view of
+// the ExampleSection documents all of the examples of the package, titled with
+// the symbols they belong to. Nil is returned if the section isn't routed.
+func (pkg *Package) ForSection(section Section) *Package {
+	if _, ok := pkg.cfg.Routes[section]; !ok {
+		return nil
+	}
+
+	cfg := *pkg.cfg
+	cfg.Section = section
+
+	return &Package{&cfg, pkg.doc, pkg.examples}
+}
+
+// Section provides the section documented by the view of the package, or an
+// empty string for the package's own documentation file.
+func (pkg *Package) Section() Section {
+	return pkg.cfg.Section
+}
+
+// Routes provides links to the files the sections of the package are routed
+// to, relative to the file being documented, in the order the sections appear
+// in the documentation. Routes are only provided for the package's own
+// documentation file.
+func (pkg *Package) Routes() []*Span {
+	if pkg.cfg.Section != "" {
+		return nil
+	}
+
+	var links []*Span
+	for _, s := range sectionTitles {
+		file, ok := pkg.cfg.Routes[s.section]
+		if !ok {
+			continue
+		}
+
+		if pkg.cfg.currentFile() == "" {
+			continue
+		}
+
+		href, ok := relativeHref(pkg.cfg.currentFile(), file)
+		if !ok {
+			continue
+		}
+
+		links = append(links, NewSpan(pkg.cfg.Inc(0), LinkSpan, s.title, href))
+	}
+
+	return links
+}
+
+// documents checks whether the documentation being generated includes the
+// section, which is the case for the section of a view of the package, or for
+// the package's own documentation file when the section isn't routed
+// elsewhere.
+func (cfg *Config) documents(section Section) bool {
+	if cfg.Section != "" {
+		return cfg.Section == section
+	}
+
+	_, routed := cfg.Routes[section]
+	return !routed
+}
+
+// currentFile provides the file the documentation being generated is written
+// to.
+func (cfg *Config) currentFile() string {
+	if cfg.Section != "" {
+		return cfg.Routes[cfg.Section]
+	}
+
+	return cfg.OutputFile
+}
+
+// symbolHref provides the href of the documentation of the symbol of the
+// package with the provided name, which is an anchor within the current file
+// unless the section of the symbol is documented in another file.
+func symbolHref(cfg *Config, name string, sym Symbol) string {
+	anchor := fmt.Sprintf("#%s", sym.Anchor())
+
+	file, ok := cfg.Routes[cfg.SymbolSections[name]]
+	if !ok {
+		file = cfg.OutputFile
+	}
+
+	if file == "" || file == cfg.currentFile() {
+		return anchor
+	}
+
+	href, ok := relativeHref(cfg.currentFile(), file)
+	if !ok {
+		return anchor
+	}
+
+	return href + anchor
+}
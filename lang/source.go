@@ -0,0 +1,163 @@
+package lang
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"go/build"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"golang.org/x/mod/module"
+)
+
+// NewPackageFromFS creates a representation of a package's documentation from
+// the files in the provided directory of a file system other than the local
+// one, such as a module zip (see ModuleZipFS), a git object store or an
+// in-memory overlay like fstest.MapFS. Paths within the file system are
+// slash-separated as described by the io/fs package. The import path of the
+// package can't be derived from the file system, so it must be provided.
+//
+// Packages loaded from a file system are parsed without type information, and
+// their repository isn't detected, so links to their source code require
+// PackageWithRepositoryOverrides. Options which run the go command or read
+// other files of the package's module, such as PackageWithExampleExecution
+// or PackageWithImplementers, are not supported.
+func NewPackageFromFS(log logger.Logger, fsys fs.FS, dir string, importPath string, opts ...PackageOption) (*Package, error) {
+	ctx := buildContext(fsys)
+	buildPkg, err := ctx.ImportDir(dir, build.ImportComment)
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to load package in directory %s: %w", dir, err)
+	}
+
+	buildPkg.ImportPath = importPath
+
+	opts = append(opts[:len(opts):len(opts)], func(opts *PackageOptions) error {
+		opts.fsys = fsys
+		return nil
+	})
+
+	return NewPackageFromBuild(log, buildPkg, opts...)
+}
+
+// FindPackages lists the directories of the file system under the provided root
+// which hold Go packages, in lexical order. Like the go command, directories
+// named testdata or vendor and those starting with "." or "_" are skipped along
+// with their contents.
+func FindPackages(fsys fs.FS, root string) ([]string, error) {
+	var dirs []string
+	err := fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		name := d.Name()
+		if p != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+			return fs.SkipDir
+		}
+
+		ctx := buildContext(fsys)
+		if _, err := ctx.ImportDir(p, 0); err == nil {
+			dirs = append(dirs, p)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to find packages in %s: %w", root, err)
+	}
+
+	return dirs, nil
+}
+
+// ModuleZipFS provides the contents of a module zip file, as served by module
+// proxies, rooted at the module's directory. The path of the module is
+// provided along with its file system, so the import path of a package is the
+// module path joined with the package's directory.
+func ModuleZipFS(zr *zip.Reader) (fs.FS, string, error) {
+	if len(zr.File) == 0 {
+		return nil, "", errors.New("gomarkdoc: empty module zip")
+	}
+
+	// Every file of a module zip is within the module@version directory,
+	// where the module path is escaped
+	name := zr.File[0].Name
+	at := strings.Index(name, "@")
+	if at < 0 {
+		return nil, "", fmt.Errorf("gomarkdoc: invalid module zip file %s", name)
+	}
+
+	slash := strings.Index(name[at:], "/")
+	if slash < 0 {
+		return nil, "", fmt.Errorf("gomarkdoc: invalid module zip file %s", name)
+	}
+
+	root := name[:at+slash]
+	for _, f := range zr.File {
+		if !strings.HasPrefix(f.Name, root+"/") {
+			return nil, "", fmt.Errorf("gomarkdoc: module zip file %s is outside of %s", f.Name, root)
+		}
+	}
+
+	modPath, err := module.UnescapePath(name[:at])
+	if err != nil {
+		return nil, "", fmt.Errorf("gomarkdoc: invalid module zip directory %s: %w", root, err)
+	}
+
+	sub, err := fs.Sub(zr, root)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return sub, modPath, nil
+}
+
+// buildContext provides the context used to find the files of packages, which
+// reads them from the file system if one is provided.
+func buildContext(fsys fs.FS) build.Context {
+	ctx := build.Default
+	if fsys == nil {
+		return ctx
+	}
+
+	ctx.JoinPath = path.Join
+	ctx.IsAbsPath = func(p string) bool { return false }
+	ctx.IsDir = func(p string) bool {
+		info, err := fs.Stat(fsys, p)
+		return err == nil && info.IsDir()
+	}
+	ctx.HasSubdir = func(root, dir string) (string, bool) {
+		rel := strings.TrimPrefix(dir, root+"/")
+		return rel, rel != dir
+	}
+	ctx.ReadDir = func(dir string) ([]fs.FileInfo, error) {
+		entries, err := fs.ReadDir(fsys, dir)
+		if err != nil {
+			return nil, err
+		}
+
+		infos := make([]fs.FileInfo, 0, len(entries))
+		for _, e := range entries {
+			info, err := e.Info()
+			if err != nil {
+				return nil, err
+			}
+
+			infos = append(infos, info)
+		}
+
+		return infos, nil
+	}
+	ctx.OpenFile = func(p string) (io.ReadCloser, error) {
+		return fsys.Open(p)
+	}
+
+	return ctx
+}
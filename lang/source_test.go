@@ -0,0 +1,79 @@
+package lang_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+var sourceFiles = map[string]string{
+	"go.mod":               "module example.com/shapes\n",
+	"shapes.go":            "// Package shapes has shapes.\npackage shapes\n\n// Shape is a shape.\ntype Shape interface {\n\tArea() float64\n}\n",
+	"square/square.go":     "// Package square has squares.\npackage square\n\n// Square is a square.\ntype Square struct {\n\tSide float64\n}\n\n// Area provides the area of the square.\nfunc (s Square) Area() float64 { return s.Side * s.Side }\n",
+	"square/other_test.go": "package square\n",
+	"testdata/x/x.go":      "package x\n",
+	"docs/README.md":       "# Docs\n",
+}
+
+func TestNewPackageFromFS(t *testing.T) {
+	is := is.New(t)
+
+	fsys := fstest.MapFS{}
+	for name, text := range sourceFiles {
+		fsys[name] = &fstest.MapFile{Data: []byte(text)}
+	}
+
+	dirs, err := lang.FindPackages(fsys, ".")
+	is.NoErr(err)
+	is.Equal(dirs, []string{".", "square"})
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromFS(log, fsys, "square", "example.com/shapes/square")
+	is.NoErr(err)
+
+	is.Equal(pkg.Name(), "square")
+	is.Equal(pkg.ImportPath(), "example.com/shapes/square")
+	is.Equal(pkg.Summary(), "Package square has squares.")
+
+	types := pkg.Types()
+	is.Equal(len(types), 1)
+	is.Equal(types[0].Name(), "Square")
+	is.Equal(types[0].Methods()[0].Name(), "Area")
+
+	_, err = lang.NewPackageFromFS(log, fsys, "docs", "example.com/shapes/docs")
+	is.True(err != nil) // no Go files
+}
+
+func TestModuleZipFS(t *testing.T) {
+	is := is.New(t)
+
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	for name, text := range sourceFiles {
+		w, err := zw.Create("example.com/!my!shapes@v1.2.0/" + name)
+		is.NoErr(err)
+
+		_, err = w.Write([]byte(text))
+		is.NoErr(err)
+	}
+
+	is.NoErr(zw.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	is.NoErr(err)
+
+	fsys, modPath, err := lang.ModuleZipFS(zr)
+	is.NoErr(err)
+	is.Equal(modPath, "example.com/MyShapes")
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromFS(log, fsys, ".", modPath)
+	is.NoErr(err)
+	is.True(strings.HasPrefix(pkg.Summary(), "Package shapes"))
+}
@@ -1,7 +1,6 @@
 package lang
 
 import (
-	"fmt"
 	"go/doc/comment"
 	"regexp"
 	"strings"
@@ -75,7 +74,7 @@ func ParseSpans(cfg *Config, texts []comment.Text) []*Span {
 			if v.ImportPath == "" {
 				name := symbolName(v.Recv, v.Name)
 				if sym, ok := cfg.Symbols[name]; ok {
-					s = append(s, NewSpan(cfg.Inc(0), LinkSpan, str, fmt.Sprintf("#%s", sym.Anchor())))
+					s = append(s, NewSpan(cfg.Inc(0), LinkSpan, str, symbolHref(cfg, name, sym)))
 				} else {
 					cfg.Log.Warnf("Unable to find symbol %s", name)
 					s = append(s, NewSpan(cfg.Inc(0), TextSpan, collapseWhitespace(str), ""))
@@ -83,7 +82,20 @@ func ParseSpans(cfg *Config, texts []comment.Text) []*Span {
 				break
 			}
 
-			s = append(s, NewSpan(cfg.Inc(0), LinkSpan, str, v.DefaultURL("https://pkg.go.dev/")))
+			// Packages documented together link to each other's documentation
+			if href, ok := cfg.SymbolIndex.Resolve(v.ImportPath, symbolName(v.Recv, v.Name), cfg.currentFile()); ok {
+				s = append(s, NewSpan(cfg.Inc(0), LinkSpan, str, href))
+				break
+			}
+
+			url, err := cfg.ImportURLs.Resolve(v.ImportPath, symbolName(v.Recv, v.Name))
+			if err != nil {
+				cfg.Log.Warnf("Unable to resolve url for package %s: %s", v.ImportPath, err)
+				s = append(s, NewSpan(cfg.Inc(0), TextSpan, str, ""))
+				break
+			}
+
+			s = append(s, NewSpan(cfg.Inc(0), LinkSpan, str, url))
 		case *comment.Link:
 			var b strings.Builder
 			printText(&b, v.Text...)
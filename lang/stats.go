@@ -0,0 +1,301 @@
+package lang
+
+import (
+	"go/doc"
+	"sort"
+	"strings"
+)
+
+type (
+	// Stats holds aggregate statistics about the documentation for a set of
+	// packages.
+	Stats struct {
+		packages []*PackageStats
+	}
+
+	// PackageStats holds statistics about the documentation for the symbols
+	// within a single package.
+	PackageStats struct {
+		importPath   string
+		consts       int
+		vars         int
+		funcs        int
+		types        int
+		methods      int
+		documented   int
+		undocumented []string
+		typeStats    []*TypeStats
+	}
+
+	// TypeStats holds statistics about the documentation for a single type
+	// along with its constructors and methods.
+	TypeStats struct {
+		name         string
+		documented   int
+		total        int
+		undocumented []string
+	}
+)
+
+// NewStats computes the aggregate statistics for the provided packages.
+func NewStats(packages []*Package) *Stats {
+	stats := &Stats{packages: make([]*PackageStats, len(packages))}
+	for i, pkg := range packages {
+		stats.packages[i] = pkg.Stats()
+	}
+
+	return stats
+}
+
+// Packages lists the statistics for each of the packages.
+func (s *Stats) Packages() []*PackageStats {
+	return s.packages
+}
+
+// PackageCount provides the number of packages covered by the statistics.
+func (s *Stats) PackageCount() int {
+	return len(s.packages)
+}
+
+// Consts provides the total number of constants across all packages.
+func (s *Stats) Consts() int {
+	return s.sum(func(p *PackageStats) int { return p.consts })
+}
+
+// Vars provides the total number of variables across all packages.
+func (s *Stats) Vars() int {
+	return s.sum(func(p *PackageStats) int { return p.vars })
+}
+
+// Funcs provides the total number of top-level functions across all packages.
+func (s *Stats) Funcs() int {
+	return s.sum(func(p *PackageStats) int { return p.funcs })
+}
+
+// Types provides the total number of types across all packages.
+func (s *Stats) Types() int {
+	return s.sum(func(p *PackageStats) int { return p.types })
+}
+
+// Methods provides the total number of methods across all packages.
+func (s *Stats) Methods() int {
+	return s.sum(func(p *PackageStats) int { return p.methods })
+}
+
+// Total provides the total number of symbols across all packages.
+func (s *Stats) Total() int {
+	return s.sum((*PackageStats).Total)
+}
+
+// Documented provides the total number of symbols with a documentation comment
+// across all packages.
+func (s *Stats) Documented() int {
+	return s.sum((*PackageStats).Documented)
+}
+
+// Coverage provides the percentage of symbols across all packages which have a
+// documentation comment.
+func (s *Stats) Coverage() int {
+	return percentage(s.Documented(), s.Total())
+}
+
+// LargestUndocumented lists the statistics for the packages with the most
+// undocumented symbols, ordered from most to fewest undocumented symbols.
+// Packages with no undocumented symbols are left out. At most limit packages
+// are returned.
+func (s *Stats) LargestUndocumented(limit int) []*PackageStats {
+	var res []*PackageStats
+	for _, p := range s.packages {
+		if len(p.undocumented) > 0 {
+			res = append(res, p)
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return len(res[i].undocumented) > len(res[j].undocumented)
+	})
+
+	if len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res
+}
+
+func (s *Stats) sum(fn func(p *PackageStats) int) int {
+	var total int
+	for _, p := range s.packages {
+		total += fn(p)
+	}
+
+	return total
+}
+
+// Stats computes the documentation statistics for the package.
+func (pkg *Package) Stats() *PackageStats {
+	stats := &PackageStats{importPath: pkg.ImportPath()}
+
+	for _, c := range pkg.doc.Consts {
+		stats.addValue(c, &stats.consts)
+	}
+
+	for _, v := range pkg.doc.Vars {
+		stats.addValue(v, &stats.vars)
+	}
+
+	for _, fn := range pkg.doc.Funcs {
+		stats.funcs++
+		stats.add(fn.Name, fn.Doc)
+	}
+
+	for _, typ := range pkg.doc.Types {
+		ts := &TypeStats{name: typ.Name}
+		stats.typeStats = append(stats.typeStats, ts)
+
+		stats.types++
+		stats.add(typ.Name, typ.Doc)
+		ts.add(typ.Name, typ.Doc)
+
+		for _, c := range typ.Consts {
+			stats.addValue(c, &stats.consts)
+		}
+
+		for _, v := range typ.Vars {
+			stats.addValue(v, &stats.vars)
+		}
+
+		for _, fn := range typ.Funcs {
+			stats.funcs++
+			stats.add(fn.Name, fn.Doc)
+			ts.add(fn.Name, fn.Doc)
+		}
+
+		for _, fn := range typ.Methods {
+			stats.methods++
+			stats.add(symbolName(typ.Name, fn.Name), fn.Doc)
+			ts.add(symbolName(typ.Name, fn.Name), fn.Doc)
+		}
+	}
+
+	return stats
+}
+
+// ImportPath provides the import path of the package.
+func (p *PackageStats) ImportPath() string {
+	return p.importPath
+}
+
+// Consts provides the number of constants in the package.
+func (p *PackageStats) Consts() int {
+	return p.consts
+}
+
+// Vars provides the number of variables in the package.
+func (p *PackageStats) Vars() int {
+	return p.vars
+}
+
+// Funcs provides the number of top-level functions in the package.
+func (p *PackageStats) Funcs() int {
+	return p.funcs
+}
+
+// Types provides the number of types in the package.
+func (p *PackageStats) Types() int {
+	return p.types
+}
+
+// Methods provides the number of methods in the package.
+func (p *PackageStats) Methods() int {
+	return p.methods
+}
+
+// Total provides the number of symbols in the package.
+func (p *PackageStats) Total() int {
+	return p.consts + p.vars + p.funcs + p.types + p.methods
+}
+
+// Documented provides the number of symbols in the package which have a
+// documentation comment.
+func (p *PackageStats) Documented() int {
+	return p.documented
+}
+
+// Coverage provides the percentage of symbols in the package which have a
+// documentation comment.
+func (p *PackageStats) Coverage() int {
+	return percentage(p.documented, p.Total())
+}
+
+// Undocumented lists the names of the symbols in the package which do not have
+// a documentation comment.
+func (p *PackageStats) Undocumented() []string {
+	return p.undocumented
+}
+
+// TypeStats lists the statistics for each of the types in the package, which
+// cover the types along with their constructors and methods.
+func (p *PackageStats) TypeStats() []*TypeStats {
+	return p.typeStats
+}
+
+func (p *PackageStats) addValue(v *doc.Value, counter *int) {
+	for _, n := range v.Names {
+		*counter++
+		p.add(n, v.Doc)
+	}
+}
+
+func (p *PackageStats) add(name, doc string) {
+	if strings.TrimSpace(doc) != "" {
+		p.documented++
+	} else {
+		p.undocumented = append(p.undocumented, name)
+	}
+}
+
+// Name provides the name of the type.
+func (t *TypeStats) Name() string {
+	return t.name
+}
+
+// Total provides the number of symbols covered by the statistics: the type
+// itself, its constructors and its methods.
+func (t *TypeStats) Total() int {
+	return t.total
+}
+
+// Documented provides the number of symbols covered by the statistics which
+// have a documentation comment.
+func (t *TypeStats) Documented() int {
+	return t.documented
+}
+
+// Coverage provides the percentage of symbols covered by the statistics which
+// have a documentation comment.
+func (t *TypeStats) Coverage() int {
+	return percentage(t.documented, t.total)
+}
+
+// Undocumented lists the names of the symbols covered by the statistics which
+// do not have a documentation comment.
+func (t *TypeStats) Undocumented() []string {
+	return t.undocumented
+}
+
+func (t *TypeStats) add(name, doc string) {
+	t.total++
+	if strings.TrimSpace(doc) != "" {
+		t.documented++
+	} else {
+		t.undocumented = append(t.undocumented, name)
+	}
+}
+
+func percentage(n, total int) int {
+	if total == 0 {
+		return 100
+	}
+
+	return n * 100 / total
+}
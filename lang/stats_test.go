@@ -0,0 +1,59 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/matryer/is"
+)
+
+func TestPackage_Stats(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/lang/function")
+	is.NoErr(err)
+
+	stats := pkg.Stats()
+	is.Equal(stats.Consts(), 2)
+	is.Equal(stats.Vars(), 1)
+	is.Equal(stats.Funcs(), 2)
+	is.Equal(stats.Types(), 2)
+	is.Equal(stats.Methods(), 3)
+	is.Equal(stats.Total(), 10)
+	is.Equal(stats.Documented(), 10)
+	is.Equal(stats.Coverage(), 100)
+	is.Equal(len(stats.Undocumented()), 0)
+}
+
+func TestPackageStats_TypeStats(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/lang/function")
+	is.NoErr(err)
+
+	types := pkg.Stats().TypeStats()
+	is.Equal(len(types), 2)
+
+	is.Equal(types[0].Name(), "Generic")
+	is.Equal(types[0].Total(), 2)
+
+	is.Equal(types[1].Name(), "Receiver")
+	is.Equal(types[1].Total(), 4) // Type, constructor and 2 methods
+	is.Equal(types[1].Documented(), 4)
+	is.Equal(types[1].Coverage(), 100)
+	is.Equal(len(types[1].Undocumented()), 0)
+}
+
+func TestStats(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/lang/function")
+	is.NoErr(err)
+
+	stats := lang.NewStats([]*lang.Package{pkg, pkg})
+	is.Equal(stats.PackageCount(), 2)
+	is.Equal(stats.Methods(), 6)
+	is.Equal(stats.Total(), 20)
+	is.Equal(stats.Coverage(), 100)
+	is.Equal(len(stats.LargestUndocumented(10)), 0)
+}
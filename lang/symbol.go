@@ -98,18 +98,28 @@ func typeSymbols(sym map[string]Symbol, t *doc.Type) {
 	}
 
 	for _, s := range t.Decl.Specs {
-		typ, ok := s.(*ast.TypeSpec).Type.(*ast.StructType)
-		if !ok {
-			continue
-		}
-
-		for _, f := range typ.Fields.List {
-			for _, n := range f.Names {
-				sym[symbolName(t.Name, n.String())] = Symbol{
-					Receiver: t.Name,
-					Name:     n.String(),
-					Kind:     FieldSymbolKind,
-					Parent:   &typeSym,
+		switch typ := s.(*ast.TypeSpec).Type.(type) {
+		case *ast.StructType:
+			for _, f := range typ.Fields.List {
+				for _, n := range f.Names {
+					sym[symbolName(t.Name, n.String())] = Symbol{
+						Receiver: t.Name,
+						Name:     n.String(),
+						Kind:     FieldSymbolKind,
+						Parent:   &typeSym,
+					}
+				}
+			}
+		case *ast.InterfaceType:
+			// Interface methods are documented along with their type
+			for _, f := range typ.Methods.List {
+				for _, n := range f.Names {
+					sym[symbolName(t.Name, n.String())] = Symbol{
+						Receiver: t.Name,
+						Name:     n.String(),
+						Kind:     MethodSymbolKind,
+						Parent:   &typeSym,
+					}
 				}
 			}
 		}
@@ -162,7 +172,7 @@ func (s Symbol) Anchor() string {
 
 	switch s.Kind {
 	case MethodSymbolKind, FieldSymbolKind:
-		return fmt.Sprintf("%s.%s", strings.TrimLeft(s.Receiver, "*"), s.Name)
+		return fmt.Sprintf("%s.%s", receiverTypeName(s.Receiver), s.Name)
 	default:
 		return s.Name
 	}
@@ -179,3 +189,15 @@ func symbolName(receiver string, name string) string {
 
 	return fmt.Sprintf("%s.%s", receiver, name)
 }
+
+// receiverTypeName provides the bare name of the type of the provided receiver,
+// removing any pointer indicator and type parameters (e.g. "*Set[T]" becomes
+// "Set").
+func receiverTypeName(receiver string) string {
+	receiver = strings.TrimLeft(receiver, "*")
+	if idx := strings.Index(receiver, "["); idx != -1 {
+		receiver = receiver[:idx]
+	}
+
+	return receiver
+}
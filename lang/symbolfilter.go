@@ -0,0 +1,163 @@
+package lang
+
+import (
+	"fmt"
+	"go/doc"
+	"path"
+	"regexp"
+	"strings"
+)
+
+type (
+	// SymbolFilter selects the symbols of a package to include in its
+	// documentation by matching their names against patterns. Methods are
+	// matched by their qualified name (e.g. Client.Do).
+	SymbolFilter struct {
+		include []symbolPattern
+		exclude []symbolPattern
+	}
+
+	// symbolPattern matches the name of a symbol.
+	symbolPattern func(name string) bool
+)
+
+// NewSymbolFilter creates a filter which keeps the symbols matching at least
+// one of the include patterns (or all symbols if there are none), except those
+// matching one of the exclude patterns. Patterns are globs following the rules
+// of path.Match (e.g. Client.*), or regular expressions when they are enclosed
+// in slashes (e.g. /^Mock/).
+func NewSymbolFilter(include, exclude []string) (*SymbolFilter, error) {
+	var (
+		f   SymbolFilter
+		err error
+	)
+
+	if f.include, err = compileSymbolPatterns(include); err != nil {
+		return nil, err
+	}
+
+	if f.exclude, err = compileSymbolPatterns(exclude); err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+// Match checks whether the symbol with the provided name is kept by the
+// filter.
+func (f *SymbolFilter) Match(name string) bool {
+	if len(f.include) > 0 && !matchesAny(f.include, name) {
+		return false
+	}
+
+	return !matchesAny(f.exclude, name)
+}
+
+// excluded checks whether the symbol with the provided name matches one of the
+// exclude patterns.
+func (f *SymbolFilter) excluded(name string) bool {
+	return matchesAny(f.exclude, name)
+}
+
+// filterPackage removes the symbols which aren't kept by the filter from the
+// documentation of the package. A type is kept along with the members matching
+// the filter when any of them do, so that they can be documented, and all of
+// its members are kept when the type itself matches unless they are excluded.
+// Groups of constants and variables are kept when any of their names match.
+func (f *SymbolFilter) filterPackage(pkg *doc.Package) {
+	pkg.Consts = f.filterValues(pkg.Consts, false)
+	pkg.Vars = f.filterValues(pkg.Vars, false)
+	pkg.Funcs = f.filterFuncs(pkg.Funcs, "", false)
+
+	types := pkg.Types[:0]
+	for _, typ := range pkg.Types {
+		if f.excluded(typ.Name) {
+			continue
+		}
+
+		all := f.Match(typ.Name)
+		typ.Consts = f.filterValues(typ.Consts, all)
+		typ.Vars = f.filterValues(typ.Vars, all)
+		typ.Funcs = f.filterFuncs(typ.Funcs, "", all)
+		typ.Methods = f.filterFuncs(typ.Methods, typ.Name, all)
+
+		if all || len(typ.Consts)+len(typ.Vars)+len(typ.Funcs)+len(typ.Methods) > 0 {
+			types = append(types, typ)
+		}
+	}
+
+	pkg.Types = types
+}
+
+func (f *SymbolFilter) filterValues(values []*doc.Value, all bool) []*doc.Value {
+	var filtered []*doc.Value
+	for _, v := range values {
+		for _, name := range v.Names {
+			if f.Match(name) || all && !f.excluded(name) {
+				filtered = append(filtered, v)
+				break
+			}
+		}
+	}
+
+	return filtered
+}
+
+func (f *SymbolFilter) filterFuncs(funcs []*doc.Func, recv string, all bool) []*doc.Func {
+	var filtered []*doc.Func
+	for _, fn := range funcs {
+		name := symbolName(recv, fn.Name)
+		if f.Match(name) || all && !f.excluded(name) {
+			filtered = append(filtered, fn)
+		}
+	}
+
+	return filtered
+}
+
+// hideMethods removes the methods of the types whose names match any of the
+// patterns from the documentation of the package.
+func hideMethods(pkg *doc.Package, patterns []symbolPattern) {
+	for _, typ := range pkg.Types {
+		if matchesAny(patterns, typ.Name) {
+			typ.Methods = nil
+		}
+	}
+}
+
+func compileSymbolPatterns(patterns []string) ([]symbolPattern, error) {
+	compiled := make([]symbolPattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+			re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+			if err != nil {
+				return nil, fmt.Errorf("gomarkdoc: invalid symbol pattern %s: %w", pattern, err)
+			}
+
+			compiled = append(compiled, re.MatchString)
+			continue
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("gomarkdoc: invalid symbol pattern %s: %w", pattern, err)
+		}
+
+		pattern := pattern
+		compiled = append(compiled, func(name string) bool {
+			ok, _ := path.Match(pattern, name)
+			return ok
+		})
+	}
+
+	return compiled, nil
+}
+
+func matchesAny(patterns []symbolPattern, name string) bool {
+	for _, match := range patterns {
+		if match(name) {
+			return true
+		}
+	}
+
+	return false
+}
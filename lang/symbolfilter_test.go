@@ -0,0 +1,110 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestPackage_symbolFilter(t *testing.T) {
+	is := is.New(t)
+
+	// Methods of an included type are kept unless they are excluded
+	pkg, err := loadFilteredPackage([]string{"Client"}, []string{"/Close$/"})
+	is.NoErr(err)
+
+	is.Equal(len(pkg.Consts()), 0)
+	is.Equal(len(pkg.Funcs()), 0)
+
+	types := pkg.Types()
+	is.Equal(len(types), 1)
+	is.Equal(types[0].Name(), "Client")
+	is.Equal(len(types[0].Funcs()), 1)
+	is.Equal(types[0].Funcs()[0].Name(), "NewClient")
+	is.Equal(len(types[0].Methods()), 1)
+	is.Equal(types[0].Methods()[0].Name(), "Do")
+
+	// Types are kept for their included methods
+	pkg, err = loadFilteredPackage([]string{"*.Serve"}, nil)
+	is.NoErr(err)
+
+	types = pkg.Types()
+	is.Equal(len(types), 1)
+	is.Equal(types[0].Name(), "Server")
+	is.Equal(len(types[0].Methods()), 1)
+
+	// Excluded types are left out with their methods
+	pkg, err = loadFilteredPackage(nil, []string{"/^Mock/"})
+	is.NoErr(err)
+
+	is.Equal(len(pkg.Consts()), 1)
+	is.Equal(len(pkg.Funcs()), 1)
+	is.Equal(pkg.Funcs()[0].Name(), "Ping")
+
+	var names []string
+	for _, typ := range pkg.Types() {
+		names = append(names, typ.Name())
+	}
+
+	is.Equal(names, []string{"Client", "Server"})
+}
+
+func TestNewSymbolFilter(t *testing.T) {
+	is := is.New(t)
+
+	f, err := lang.NewSymbolFilter([]string{"Client.*", "/^New/"}, []string{"*.Close"})
+	is.NoErr(err)
+
+	is.True(f.Match("Client.Do"))
+	is.True(f.Match("NewClient"))
+	is.True(!f.Match("Client.Close"))
+	is.True(!f.Match("Client"))
+	is.True(!f.Match("Ping"))
+
+	_, err = lang.NewSymbolFilter([]string{"/(/"}, nil)
+	is.True(err != nil)
+
+	_, err = lang.NewSymbolFilter(nil, []string{"[a"})
+	is.True(err != nil)
+}
+
+func TestPackage_hiddenMethods(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/symbolfilter")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithHiddenMethods("*Client"))
+	is.NoErr(err)
+
+	types := pkg.Types()
+	is.Equal(len(types), 3)
+	is.Equal(types[0].Name(), "Client")
+	is.Equal(len(types[0].Methods()), 0)
+	is.Equal(len(types[0].Funcs()), 1) // constructors are kept
+	is.Equal(types[1].Name(), "MockClient")
+	is.Equal(len(types[1].Methods()), 0)
+	is.Equal(types[2].Name(), "Server")
+	is.Equal(len(types[2].Methods()), 1)
+
+	_, err = lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithHiddenMethods("/(/"))
+	is.True(err != nil)
+}
+
+func loadFilteredPackage(include, exclude []string) (*lang.Package, error) {
+	buildPkg, err := getBuildPackage("../testData/lang/symbolfilter")
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := lang.NewSymbolFilter(include, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.New(logger.ErrorLevel)
+	return lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithSymbolFilter(filter))
+}
@@ -0,0 +1,97 @@
+package lang
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+type (
+	// SymbolIndex holds the symbols of all of the packages documented together,
+	// along with the files their documentation is written to. Packages sharing
+	// an index link to each other's generated documentation instead of using
+	// their ImportURLResolver. Since links are resolved when the documentation
+	// is rendered, packages can be added to the index after the packages
+	// referencing them are created.
+	SymbolIndex struct {
+		pkgs map[string]indexedPackage
+	}
+
+	indexedPackage struct {
+		file     string
+		symbols  map[string]Symbol
+		routes   map[Section]string
+		sections map[string]Section
+	}
+)
+
+// NewSymbolIndex creates an empty SymbolIndex.
+func NewSymbolIndex() *SymbolIndex {
+	return &SymbolIndex{make(map[string]indexedPackage)}
+}
+
+// Add adds the symbols of the package to the index along with the file its
+// documentation is written to. Symbols of sections routed to other files are
+// resolved to those files. Packages which aren't written to a file can't
+// be linked to, so they are ignored.
+func (idx *SymbolIndex) Add(pkg *Package, file string) {
+	if file == "" || pkg.ImportPath() == unknownImportPath {
+		return
+	}
+
+	idx.pkgs[pkg.ImportPath()] = indexedPackage{file, pkg.cfg.Symbols, pkg.cfg.Routes, pkg.cfg.SymbolSections}
+}
+
+// Resolve provides the href of the documentation of the symbol in the package
+// with the provided import path, relative to the documentation file from
+// which it is linked. If no symbol is provided, the href points at the file
+// holding the package's documentation. The second return value is false if the
+// package or symbol is not in the index, or if no symbol is provided and the
+// package is documented in the same file.
+func (idx *SymbolIndex) Resolve(importPath, symbol, from string) (string, bool) {
+	if idx == nil || from == "" {
+		return "", false
+	}
+
+	pkg, ok := idx.pkgs[importPath]
+	if !ok {
+		return "", false
+	}
+
+	file := pkg.file
+	var anchor string
+	if symbol != "" {
+		sym, ok := pkg.symbols[symbol]
+		if !ok {
+			return "", false
+		}
+
+		anchor = fmt.Sprintf("#%s", sym.Anchor())
+
+		if routed, ok := pkg.routes[pkg.sections[symbol]]; ok {
+			file = routed
+		}
+	}
+
+	fromAbs, err := filepath.Abs(from)
+	if err != nil {
+		return "", false
+	}
+
+	toAbs, err := filepath.Abs(file)
+	if err != nil {
+		return "", false
+	}
+
+	// The package has no header of its own to link to when it is written to
+	// the same file
+	if fromAbs == toAbs {
+		return anchor, anchor != ""
+	}
+
+	rel, err := filepath.Rel(filepath.Dir(fromAbs), toAbs)
+	if err != nil {
+		return "", false
+	}
+
+	return filepath.ToSlash(rel) + anchor, true
+}
@@ -0,0 +1,58 @@
+package lang_test
+
+import (
+	"go/build"
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestSymbolIndex_Resolve(t *testing.T) {
+	is := is.New(t)
+
+	idx := lang.NewSymbolIndex()
+	log := logger.New(logger.ErrorLevel)
+
+	// Packages are loaded by directory so their import paths are derived from
+	// the module, as they are by the command
+	buildPkg, err := build.ImportDir("../testData/lang/crosslinks", build.ImportComment)
+	is.NoErr(err)
+
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithSymbolIndex(idx, "docs/crosslinks/README.md"))
+	is.NoErr(err)
+	idx.Add(pkg, "docs/crosslinks/README.md")
+
+	buildPkg, err = build.ImportDir("../testData/lang/doclinks", build.ImportComment)
+	is.NoErr(err)
+
+	linked, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithSymbolIndex(idx, "docs/doclinks/README.md"))
+	is.NoErr(err)
+	idx.Add(linked, "docs/doclinks/README.md")
+
+	importPath := linked.ImportPath()
+
+	href, ok := idx.Resolve(importPath, "Client.Do_all", "docs/crosslinks/README.md")
+	is.True(ok)
+	is.Equal(href, "../doclinks/README.md#Client.Do_all")
+
+	href, ok = idx.Resolve(importPath, "", "docs/crosslinks/README.md")
+	is.True(ok)
+	is.Equal(href, "../doclinks/README.md")
+
+	href, ok = idx.Resolve(importPath, "Client", "docs/doclinks/README.md")
+	is.True(ok)
+	is.Equal(href, "#Client")
+
+	_, ok = idx.Resolve(importPath, "Missing", "docs/crosslinks/README.md")
+	is.True(!ok)
+
+	_, ok = idx.Resolve("strings", "Builder", "docs/crosslinks/README.md")
+	is.True(!ok)
+
+	// Links are resolved when rendering, so the package can be added last
+	spans := pkg.Doc().Blocks()[0].Spans()
+	is.Equal(spans[1].Kind(), lang.LinkSpan)
+	is.Equal(spans[1].URL(), "../doclinks/README.md#Client")
+}
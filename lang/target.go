@@ -0,0 +1,257 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/build/constraint"
+	"io/fs"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// The GOOS and GOARCH values recognized in file name suffixes, matching the
+// lists used by the go/build package.
+var (
+	knownOS = map[string]bool{
+		"aix": true, "android": true, "darwin": true, "dragonfly": true,
+		"freebsd": true, "hurd": true, "illumos": true, "ios": true, "js": true,
+		"linux": true, "nacl": true, "netbsd": true, "openbsd": true,
+		"plan9": true, "solaris": true, "wasip1": true, "windows": true,
+		"zos": true,
+	}
+
+	knownArch = map[string]bool{
+		"386": true, "amd64": true, "amd64p32": true, "arm": true,
+		"armbe": true, "arm64": true, "arm64be": true, "loong64": true,
+		"mips": true, "mipsle": true, "mips64": true, "mips64le": true,
+		"mips64p32": true, "mips64p32le": true, "ppc": true, "ppc64": true,
+		"ppc64le": true, "riscv": true, "riscv64": true, "s390": true,
+		"s390x": true, "sparc": true, "sparc64": true, "wasm": true,
+	}
+)
+
+// Badges lists the badges for the function. A deprecated badge is produced
+// when the function is deprecated, a test only badge is produced when the
+// function is declared in a _test.go file, a build target badge is produced
+// when build targets have been enabled for the package and the file declaring
+// the function has build constraints, and a platform badge is produced when
+// the function isn't available on all of the platforms the package is
+// documented for.
+func (fn *Func) Badges() []*Badge {
+	var badges []*Badge
+	if fn.IsDeprecated() {
+		badges = append(badges, deprecatedBadge())
+	}
+
+	if fn.IsTestOnly() {
+		badges = append(badges, testOnlyBadge())
+	}
+
+	badges = append(badges, targetBadges(fn.cfg, fn.doc.Decl)...)
+	return append(badges, platformBadges(fn.cfg, funcSymbolName(fn.doc))...)
+}
+
+// BuildConstraint provides the build constraint which must be satisfied for
+// the function to be available, derived from the //go:build line and name of
+// the file declaring it. The empty string is returned if the file has no
+// build constraints.
+func (fn *Func) BuildConstraint() string {
+	return buildConstraint(fn.cfg, fn.doc.Decl)
+}
+
+// Badges lists the badges for the type. A deprecated badge is produced when
+// the type is deprecated, a test only badge is produced when the type is
+// declared in a _test.go file, a build target badge is produced when build
+// targets have been enabled for the package and the file declaring the type
+// has build constraints, and a platform badge is produced when the type isn't
+// available on all of the platforms the package is documented for.
+func (typ *Type) Badges() []*Badge {
+	var badges []*Badge
+	if typ.IsDeprecated() {
+		badges = append(badges, deprecatedBadge())
+	}
+
+	if typ.IsTestOnly() {
+		badges = append(badges, testOnlyBadge())
+	}
+
+	badges = append(badges, targetBadges(typ.cfg, typ.doc.Decl)...)
+	return append(badges, platformBadges(typ.cfg, typ.doc.Name)...)
+}
+
+// BuildConstraint provides the build constraint which must be satisfied for
+// the type to be available, derived from the //go:build line and name of the
+// file declaring it. The empty string is returned if the file has no build
+// constraints.
+func (typ *Type) BuildConstraint() string {
+	return buildConstraint(typ.cfg, typ.doc.Decl)
+}
+
+// Badges lists the badges for the value. A test only badge is produced when
+// the value is declared in a _test.go file, a build target badge is produced
+// when build targets have been enabled for the package and the file declaring
+// the value has build constraints, and a platform badge is produced when the
+// value isn't available on all of the platforms the package is documented for.
+func (v *Value) Badges() []*Badge {
+	var badges []*Badge
+	if v.IsTestOnly() {
+		badges = append(badges, testOnlyBadge())
+	}
+
+	badges = append(badges, targetBadges(v.cfg, v.doc.Decl)...)
+	return append(badges, platformBadges(v.cfg, v.doc.Names...)...)
+}
+
+// BuildConstraint provides the build constraint which must be satisfied for
+// the value to be available, derived from the //go:build line and name of the
+// file declaring it. The empty string is returned if the file has no build
+// constraints.
+func (v *Value) BuildConstraint() string {
+	return buildConstraint(v.cfg, v.doc.Decl)
+}
+
+func targetBadges(cfg *Config, node ast.Node) []*Badge {
+	if !cfg.BuildTargets {
+		return nil
+	}
+
+	expr := buildConstraint(cfg, node)
+	if expr == "" {
+		return nil
+	}
+
+	// Dashes and underscores are separators in shields.io badge paths, so they
+	// need to be doubled to appear in the text
+	text := strings.NewReplacer("-", "--", "_", "__").Replace(expr)
+
+	return []*Badge{NewBadge(
+		TargetBadge,
+		fmt.Sprintf("Build: %s", expr),
+		fmt.Sprintf("https://img.shields.io/badge/build-%s-informational", url.PathEscape(text)),
+		"",
+	)}
+}
+
+// taggedPackage provides a copy of the package whose files are chosen using
+// the provided build tags.
+func taggedPackage(fsys fs.FS, pkg *build.Package, tags []string) (*build.Package, error) {
+	ctx := buildContext(fsys)
+	ctx.BuildTags = tags
+
+	tagged, err := ctx.ImportDir(pkg.Dir, build.ImportComment)
+	if err != nil {
+		return nil, fmt.Errorf("gomarkdoc: failed to apply build tags to package in directory %s: %w", pkg.Dir, err)
+	}
+
+	p := *pkg
+	p.GoFiles = tagged.GoFiles
+	p.CgoFiles = tagged.CgoFiles
+	p.IgnoredGoFiles = tagged.IgnoredGoFiles
+	p.TestGoFiles = tagged.TestGoFiles
+	p.XTestGoFiles = tagged.XTestGoFiles
+	p.Imports = tagged.Imports
+
+	return &p, nil
+}
+
+func buildConstraint(cfg *Config, node ast.Node) string {
+	filename := cfg.FileSet.Position(node.Pos()).Filename
+	if filename == "" {
+		return ""
+	}
+
+	var expr constraint.Expr
+	for _, f := range cfg.Files {
+		if cfg.FileSet.Position(f.Pos()).Filename != filename {
+			continue
+		}
+
+		expr = fileConstraint(f)
+		break
+	}
+
+	for _, tag := range filenameTags(filepath.Base(filename)) {
+		var x constraint.Expr = &constraint.TagExpr{Tag: tag}
+		if expr != nil {
+			x = &constraint.AndExpr{X: expr, Y: x}
+		}
+
+		expr = x
+	}
+
+	if expr == nil {
+		return ""
+	}
+
+	return expr.String()
+}
+
+// fileConstraint parses the build constraint from the header of the file,
+// preferring a //go:build line over legacy // +build lines.
+func fileConstraint(f *ast.File) constraint.Expr {
+	var (
+		goBuild   constraint.Expr
+		plusBuild constraint.Expr
+	)
+
+	for _, group := range f.Comments {
+		// Build constraints must appear before the package clause
+		if group.Pos() >= f.Package {
+			break
+		}
+
+		for _, c := range group.List {
+			switch {
+			case constraint.IsGoBuild(c.Text):
+				if x, err := constraint.Parse(c.Text); err == nil {
+					goBuild = x
+				}
+			case constraint.IsPlusBuild(c.Text):
+				x, err := constraint.Parse(c.Text)
+				if err != nil {
+					continue
+				}
+
+				if plusBuild == nil {
+					plusBuild = x
+				} else {
+					plusBuild = &constraint.AndExpr{X: plusBuild, Y: x}
+				}
+			}
+		}
+	}
+
+	if goBuild != nil {
+		return goBuild
+	}
+
+	return plusBuild
+}
+
+// filenameTags provides the GOOS and GOARCH constraints implied by the suffixes
+// of a file name (e.g. dom_js_wasm.go).
+func filenameTags(name string) []string {
+	name = strings.TrimSuffix(name, ".go")
+	name = strings.TrimSuffix(name, "_test")
+
+	// The first element is never a constraint, even if it matches a known
+	// GOOS or GOARCH (e.g. linux.go)
+	parts := strings.Split(name, "_")
+	if len(parts) < 2 {
+		return nil
+	}
+	parts = parts[1:]
+
+	n := len(parts)
+	if n >= 2 && knownOS[parts[n-2]] && knownArch[parts[n-1]] {
+		return []string{parts[n-2], parts[n-1]}
+	}
+
+	if knownOS[parts[n-1]] || knownArch[parts[n-1]] {
+		return []string{parts[n-1]}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package lang_test
+
+import (
+	"go/build"
+	"os"
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestPackage_buildTargets(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadTargetPackage(lang.PackageWithBuildTargets())
+	is.NoErr(err)
+
+	constraints := make(map[string]string)
+	for _, fn := range pkg.Funcs() {
+		constraints[fn.Name()] = fn.BuildConstraint()
+	}
+
+	is.Equal(constraints["Everywhere"], "")
+	is.Equal(constraints["Alert"], "js")
+	is.Equal(constraints["Memory"], "js && wasm")
+
+	is.Equal(len(pkg.Types()), 1)
+	is.Equal(pkg.Types()[0].BuildConstraint(), "tinygo || baremetal")
+
+	is.Equal(len(pkg.Consts()), 1)
+	badges := pkg.Consts()[0].Badges()
+	is.Equal(len(badges), 1)
+	is.Equal(badges[0].Kind(), lang.TargetBadge)
+	is.Equal(badges[0].Text(), "Build: js && wasm")
+	is.Equal(badges[0].Image(), "https://img.shields.io/badge/build-js%20&&%20wasm-informational")
+}
+
+func TestPackage_buildTargets_disabled(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadTargetPackage()
+	is.NoErr(err)
+
+	for _, fn := range pkg.Funcs() {
+		is.Equal(len(fn.Badges()), 0)
+	}
+}
+
+func TestPackage_buildTags(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/tags")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithBuildTags("tagged"), lang.PackageWithBuildTargets())
+	is.NoErr(err)
+
+	funcs := pkg.Funcs()
+	is.Equal(len(funcs), 2)
+	is.Equal(funcs[0].Name(), "Tagged")
+	is.Equal(funcs[0].BuildConstraint(), "tagged")
+	is.Equal(funcs[1].Name(), "Untagged")
+
+	// The files of the build package are used without the option
+	pkg, err = lang.NewPackageFromBuild(log, buildPkg)
+	is.NoErr(err)
+	is.Equal(len(pkg.Funcs()), 1)
+}
+
+func loadTargetPackage(opts ...lang.PackageOption) (*lang.Package, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := build.Default
+	ctx.GOOS = "js"
+	ctx.GOARCH = "wasm"
+	ctx.BuildTags = []string{"tinygo"}
+
+	buildPkg, err := ctx.Import("../testData/lang/target", wd, build.ImportComment)
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.New(logger.ErrorLevel)
+	return lang.NewPackageFromBuild(log, buildPkg, opts...)
+}
+
+func TestPackage_platforms(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/platform")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithPlatforms("linux/amd64", "darwin/arm64", "windows/amd64"))
+	is.NoErr(err)
+
+	// Constants declared for several platforms are only listed once
+	is.Equal(len(pkg.Consts()), 1)
+	is.Equal(pkg.Consts()[0].Platforms(), []string{"linux/amd64", "windows/amd64"})
+
+	funcs := pkg.Funcs()
+	is.Equal(len(funcs), 1)
+	is.Equal(funcs[0].Name(), "Notify")
+	is.Equal(funcs[0].Platforms(), []string{"linux/amd64", "darwin/arm64"})
+
+	types := pkg.Types()
+	is.Equal(len(types), 2)
+	is.Equal(types[0].Name(), "File")
+	is.Equal(len(types[0].Badges()), 0)
+	is.Equal(len(types[0].Funcs()[0].Badges()), 0)
+
+	methods := make(map[string][]string)
+	for _, m := range types[0].Methods() {
+		methods[m.Name()] = m.Platforms()
+	}
+
+	is.Equal(methods["Close"], []string{"linux/amd64", "darwin/arm64", "windows/amd64"})
+	is.Equal(methods["Fd"], []string{"linux/amd64"})
+	is.Equal(methods["Handle"], []string{"windows/amd64"})
+
+	is.Equal(types[1].Name(), "Signal")
+	badges := types[1].Badges()
+	is.Equal(len(badges), 1)
+	is.Equal(badges[0].Kind(), lang.PlatformBadge)
+	is.Equal(badges[0].Text(), "Platforms: linux/amd64, darwin/arm64")
+
+	_, err = lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithPlatforms("linux"))
+	is.True(err != nil)
+}
@@ -0,0 +1,98 @@
+package lang
+
+import (
+	"go/ast"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// testFuncPrefixes are the prefixes of the names of the functions in _test.go
+// files which are run by go test rather than called by other code.
+var testFuncPrefixes = []string{"Test", "Benchmark", "Example", "Fuzz"}
+
+// IsTestOnly indicates whether the function is declared in a _test.go file,
+// which is only the case when test helpers have been included in the
+// documentation for the package.
+func (fn *Func) IsTestOnly() bool {
+	return isTestOnly(fn.cfg, fn.doc.Decl)
+}
+
+// IsTestOnly indicates whether the type is declared in a _test.go file, which
+// is only the case when test helpers have been included in the documentation
+// for the package.
+func (typ *Type) IsTestOnly() bool {
+	return isTestOnly(typ.cfg, typ.doc.Decl)
+}
+
+// IsTestOnly indicates whether the value is declared in a _test.go file,
+// which is only the case when test helpers have been included in the
+// documentation for the package.
+func (v *Value) IsTestOnly() bool {
+	return isTestOnly(v.cfg, v.doc.Decl)
+}
+
+func isTestOnly(cfg *Config, node ast.Node) bool {
+	if node == nil {
+		return false
+	}
+
+	return strings.HasSuffix(cfg.FileSet.Position(node.Pos()).Filename, "_test.go")
+}
+
+func testOnlyBadge() *Badge {
+	return NewBadge(
+		TestOnlyBadge,
+		"Test only",
+		"https://img.shields.io/badge/scope-test%20only-yellow",
+		"",
+	)
+}
+
+// removeTestFuncs removes the tests, benchmarks, examples and fuzz targets
+// from the _test.go files of the package, leaving the helpers declared
+// alongside them.
+func removeTestFuncs(pkg *ast.Package) {
+	for name, f := range pkg.Files {
+		if !strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		decls := f.Decls[:0]
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && isTestFunc(fn.Name.Name) {
+				continue
+			}
+
+			decls = append(decls, decl)
+		}
+
+		f.Decls = decls
+	}
+}
+
+// isTestFunc checks whether the name of a function marks it as one run by go
+// test, following the same rules as the go command: the prefix must be
+// followed by the end of the name or a character which isn't lower case.
+func isTestFunc(name string) bool {
+	if name == "TestMain" {
+		return true
+	}
+
+	for _, prefix := range testFuncPrefixes {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		if len(name) == len(prefix) {
+			return true
+		}
+
+		r, _ := utf8.DecodeRuneInString(name[len(prefix):])
+		if !unicode.IsLower(r) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestPackage_testHelpers(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/testhelpers")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithTestHelpers())
+	is.NoErr(err)
+
+	types := pkg.Types()
+	is.Equal(len(types), 2)
+	is.Equal(types[0].Name(), "FakeStore")
+	is.True(types[0].IsTestOnly())
+	is.Equal(len(types[0].Badges()), 1)
+	is.Equal(types[0].Badges()[0].Kind(), lang.TestOnlyBadge)
+	is.True(types[0].Methods()[0].IsTestOnly())
+
+	is.Equal(types[1].Name(), "Store")
+	is.True(!types[1].IsTestOnly())
+	is.Equal(len(types[1].Badges()), 0)
+
+	is.Equal(len(pkg.Consts()), 1)
+	is.True(pkg.Consts()[0].IsTestOnly())
+
+	// Tests and benchmarks are left out, but not helpers named like them
+	var names []string
+	for _, fn := range pkg.Funcs() {
+		names = append(names, fn.Name())
+	}
+
+	is.Equal(names, []string{"Lookup", "Testify"})
+	is.True(!pkg.Funcs()[0].IsTestOnly())
+	is.True(pkg.Funcs()[1].IsTestOnly())
+
+	pkg, err = loadPackage("../testData/lang/testhelpers")
+	is.NoErr(err)
+
+	is.Equal(len(pkg.Types()), 1) // left out by default
+	is.Equal(len(pkg.Consts()), 0)
+}
+
+func TestPackage_externalTestHelpers(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/testhelpers")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithExternalTestHelpers())
+	is.NoErr(err)
+
+	// Only the helpers of the external test package are included
+	is.Equal(len(pkg.Types()), 1)
+	is.Equal(len(pkg.Consts()), 0)
+
+	var names []string
+	for _, fn := range pkg.Funcs() {
+		names = append(names, fn.Name())
+	}
+
+	is.Equal(names, []string{"Lookup", "NewFixture"})
+	is.True(!pkg.Funcs()[0].IsTestOnly())
+	is.True(pkg.Funcs()[1].IsTestOnly())
+	is.Equal(pkg.Funcs()[1].Badges()[0].Kind(), lang.TestOnlyBadge)
+
+	pkg, err = lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithTestHelpers(), lang.PackageWithExternalTestHelpers())
+	is.NoErr(err)
+
+	is.Equal(len(pkg.Types()), 2)
+	is.Equal(len(pkg.Funcs()), 3) // Lookup, NewFixture and Testify
+}
@@ -0,0 +1,86 @@
+package lang
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"go/doc/comment"
+	"strings"
+	"sync"
+)
+
+// StringCatalog collects the prose blocks (paragraphs, headers and lists) of
+// the documentation that is rendered, keyed by their ProseID. It can be
+// exported as a starting point for translating the documentation.
+type StringCatalog struct {
+	mu      sync.Mutex
+	strings map[string]string
+}
+
+// NewStringCatalog creates an empty StringCatalog.
+func NewStringCatalog() *StringCatalog {
+	return &StringCatalog{strings: make(map[string]string)}
+}
+
+// Add records the text of a prose block with the provided id.
+func (c *StringCatalog) Add(id, text string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.strings[id] = text
+}
+
+// Strings provides a copy of the collected prose blocks, keyed by id.
+func (c *StringCatalog) Strings() map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := make(map[string]string, len(c.strings))
+	for id, text := range c.strings {
+		res[id] = text
+	}
+
+	return res
+}
+
+// ProseID produces the stable id for a prose block with the provided text, in
+// doc comment syntax. The id is derived from the text itself, so it stays the
+// same as long as the text is unchanged and translations of outdated text are
+// not used.
+func ProseID(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:8])
+}
+
+// translateBlocks records the prose blocks in the config's string catalog and
+// substitutes the translated version of each block that has a translation.
+// Code blocks are left untouched.
+func translateBlocks(cfg *Config, blocks []comment.Block) []comment.Block {
+	if cfg.StringCatalog == nil && len(cfg.Translations) == 0 {
+		return blocks
+	}
+
+	var p comment.Printer
+	res := make([]comment.Block, 0, len(blocks))
+	for _, b := range blocks {
+		if _, ok := b.(*comment.Code); ok {
+			res = append(res, b)
+			continue
+		}
+
+		text := strings.TrimSpace(string(p.Comment(&comment.Doc{Content: []comment.Block{b}})))
+		id := ProseID(text)
+
+		if cfg.StringCatalog != nil {
+			cfg.StringCatalog.Add(id, text)
+		}
+
+		if translated, ok := cfg.Translations[id]; ok {
+			res = append(res, cfg.Pkg.Parser().Parse(normalizeDoc(translated)).Content...)
+			continue
+		}
+
+		res = append(res, b)
+	}
+
+	return res
+}
@@ -0,0 +1,64 @@
+package lang_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
+	"github.com/matryer/is"
+)
+
+func TestStringCatalog(t *testing.T) {
+	is := is.New(t)
+
+	catalog := lang.NewStringCatalog()
+	fn, err := loadTranslatedFunc("Standalone", lang.PackageWithStringCatalog(catalog))
+	is.NoErr(err)
+
+	_ = fn.Doc()
+
+	header := "# Header A"
+	strs := catalog.Strings()
+	is.Equal(strs[lang.ProseID(header)], header)
+	is.Equal(strs[lang.ProseID("This section contains a code block.")], "This section contains a code block.")
+	is.Equal(len(strs), 4) // Code blocks are not included
+}
+
+func TestTranslations(t *testing.T) {
+	is := is.New(t)
+
+	fn, err := loadTranslatedFunc("Standalone", lang.PackageWithTranslations(map[string]string{
+		lang.ProseID("# Header A"):                          "# Überschrift A",
+		lang.ProseID("This section contains a code block."): "Dieser Abschnitt enthält einen Codeblock.",
+	}))
+	is.NoErr(err)
+
+	blocks := fn.Doc().Blocks()
+	is.Equal(len(blocks), 5)
+	is.Equal(blocks[2].Kind(), lang.HeaderBlock)
+	is.Equal(blocks[2].Spans()[0].Text(), "Überschrift A")
+	is.Equal(blocks[3].Kind(), lang.ParagraphBlock)
+	is.Equal(blocks[3].Spans()[0].Text(), "Dieser Abschnitt enthält einen Codeblock.")
+	is.Equal(blocks[4].Kind(), lang.CodeBlock)
+}
+
+func loadTranslatedFunc(name string, opt lang.PackageOption) (*lang.Func, error) {
+	buildPkg, err := getBuildPackage("../testData/lang/function")
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fn := range pkg.Funcs() {
+		if fn.Name() == name {
+			return fn, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -2,6 +2,7 @@ package lang
 
 import (
 	"fmt"
+	"go/ast"
 	"go/doc"
 	"strings"
 )
@@ -32,9 +33,146 @@ func (typ *Type) Name() string {
 }
 
 // Title provides a formatted name suitable for use in a header identifying the
-// type.
+// type, along with its type parameters if it is generic. The title set with a
+// //gomarkdoc:title directive is used instead if there is one.
 func (typ *Type) Title() string {
-	return fmt.Sprintf("type %s", typ.doc.Name)
+	if title := typ.CustomTitle(); title != "" {
+		return title
+	}
+
+	return fmt.Sprintf("type %s%s", typ.doc.Name, typ.TypeParams())
+}
+
+// CustomTitle provides the title set with a //gomarkdoc:title directive in the
+// type's documentation comment, or an empty string if there is none.
+func (typ *Type) CustomTitle() string {
+	ts := typ.typeSpec()
+	if ts == nil {
+		return ""
+	}
+
+	// The comment of the declaration only documents the type if it isn't
+	// part of a group
+	groups := []*ast.CommentGroup{sourceDoc(typ.cfg, ts)}
+	if !typ.doc.Decl.Lparen.IsValid() {
+		groups = append(groups, sourceDoc(typ.cfg, typ.doc.Decl))
+	}
+
+	return directiveValue(titleDirective, groups...)
+}
+
+// TypeParams provides the type parameter list of a generic type, including the
+// surrounding brackets and constraints (e.g. "[K comparable, V any]"). An empty
+// string is returned for types which are not generic.
+func (typ *Type) TypeParams() string {
+	ts := typ.typeSpec()
+	if ts == nil {
+		return ""
+	}
+
+	params, err := printTypeParams(ts.TypeParams)
+	if err != nil {
+		return ""
+	}
+
+	return params
+}
+
+// InterfaceMethods lists the methods declared by an interface type, along with
+// their own documentation. Embedded interfaces and type constraints are not
+// included. Nil is returned for types which are not interfaces.
+func (typ *Type) InterfaceMethods() []*InterfaceMethod {
+	iface := typ.interfaceType()
+	if iface == nil {
+		return nil
+	}
+
+	var methods []*InterfaceMethod
+	for _, field := range iface.Methods.List {
+		if _, ok := field.Type.(*ast.FuncType); !ok || len(field.Names) == 0 {
+			continue
+		}
+
+		methods = append(methods, NewInterfaceMethod(typ.cfg.Inc(1), typ.doc.Name, field))
+	}
+
+	return methods
+}
+
+// Fields lists the fields declared by a struct type, along with their types,
+// tags and documentation. Embedded fields are named after their type. Nil is
+// returned for types which are not structs.
+func (typ *Type) Fields() []*Field {
+	st, ok := structType(typ.typeSpec())
+	if !ok {
+		return nil
+	}
+
+	var fields []*Field
+	for _, field := range st.Fields.List {
+		for _, name := range fieldNames(field) {
+			fields = append(fields, NewField(typ.cfg.Inc(1), typ.doc.Name, name, field, nil))
+		}
+	}
+
+	return fields
+}
+
+// IndexFields lists the fields declared by a struct type which have
+// documentation, for listing in the index and table of contents. Fields are
+// only listed when indexing of fields is enabled for the package.
+func (typ *Type) IndexFields() []*Field {
+	if !typ.cfg.IndexFields {
+		return nil
+	}
+
+	var fields []*Field
+	for _, field := range typ.Fields() {
+		if field.docText() != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// PromotedFields lists the exported fields promoted to a struct type from the
+// structs it embeds, including those embedded several levels deep, along with
+// the embedded types they come from. Fields are only listed when flattening of
+// embedded structs is enabled for the package.
+func (typ *Type) PromotedFields() []*Field {
+	if !typ.cfg.FlattenEmbedded {
+		return nil
+	}
+
+	return promotedFields(typ.cfg.Inc(1), typ.doc.Name)
+}
+
+// typeSpec finds the spec declaring the type within its declaration.
+func (typ *Type) typeSpec() *ast.TypeSpec {
+	if typ.doc.Decl == nil {
+		return nil
+	}
+
+	for _, spec := range typ.doc.Decl.Specs {
+		if ts, ok := spec.(*ast.TypeSpec); ok && ts.Name.Name == typ.doc.Name {
+			return ts
+		}
+	}
+
+	return nil
+}
+
+// interfaceType provides the interface the type is declared as, or nil if it
+// isn't an interface.
+func (typ *Type) interfaceType() *ast.InterfaceType {
+	ts := typ.typeSpec()
+	if ts == nil {
+		return nil
+	}
+
+	iface, _ := ts.Type.(*ast.InterfaceType)
+	return iface
 }
 
 // Location returns a representation of the node's location in a file within a
@@ -50,20 +188,38 @@ func (typ *Type) Summary() string {
 }
 
 // Doc provides the structured contents of the documentation comment for the
-// type.
+// type. The "Deprecated:" paragraph is left out, since it is provided by
+// DeprecationNotice.
 func (typ *Type) Doc() *Doc {
-	return NewDoc(typ.cfg.Inc(1), typ.doc.Doc)
+	return NewDoc(typ.cfg.Inc(1), withoutDeprecation(typ.doc.Doc))
 }
 
 // Decl provides the raw text representation of the code for the type's
-// declaration.
+// declaration. The doc comments of an interface's methods are left out of the
+// declaration, since they are documented individually by InterfaceMethods.
 func (typ *Type) Decl() (string, error) {
+	if typ.interfaceType() != nil {
+		return printNode(stripInterfaceMethodDocs(typ.doc.Decl), typ.cfg.FileSet)
+	}
+
 	return printNode(typ.doc.Decl, typ.cfg.FileSet)
 }
 
+// TypeLinks provides links to the documentation of the types from other
+// packages used in the type's declaration (e.g. the types of its fields), in
+// the order they first appear.
+func (typ *Type) TypeLinks() []*Span {
+	return typeLinks(typ.cfg, typ.doc.Decl)
+}
+
 // Examples lists the examples pertaining to the type from the set provided on
 // initialization.
 func (typ *Type) Examples() (examples []*Example) {
+	// Examples routed to a file of their own are documented there instead
+	if _, ok := typ.cfg.Routes[ExampleSection]; ok {
+		return nil
+	}
+
 	underscorePrefix := fmt.Sprintf("%s_", typ.doc.Name)
 	for _, example := range typ.examples {
 		var name string
@@ -98,24 +254,42 @@ func (typ *Type) isSubexample(exampleName string) bool {
 // Funcs lists the funcs related to the type. This only includes functions which
 // return an instance of the type or its pointer.
 func (typ *Type) Funcs() []*Func {
-	funcs := make([]*Func, len(typ.doc.Funcs))
-	for i, fn := range typ.doc.Funcs {
-		funcs[i] = NewFunc(typ.cfg.Inc(1), fn, typ.examples)
+	funcs := make([]*Func, 0, len(typ.doc.Funcs))
+	for _, fn := range typ.doc.Funcs {
+		val := NewFunc(typ.cfg.Inc(1), fn, typ.examples)
+		if typ.hidden(val) {
+			continue
+		}
+
+		funcs = append(funcs, val)
 	}
 
+	sortFuncs(typ.cfg, funcs)
 	return funcs
 }
 
 // Methods lists the funcs that use the type as a value or pointer receiver.
 func (typ *Type) Methods() []*Func {
-	methods := make([]*Func, len(typ.doc.Methods))
-	for i, fn := range typ.doc.Methods {
-		methods[i] = NewFunc(typ.cfg.Inc(1), fn, typ.examples)
+	methods := make([]*Func, 0, len(typ.doc.Methods))
+	for _, fn := range typ.doc.Methods {
+		val := NewFunc(typ.cfg.Inc(1), fn, typ.examples)
+		if typ.hidden(val) {
+			continue
+		}
+
+		methods = append(methods, val)
 	}
 
 	return methods
 }
 
+// hidden reports whether the function of the type is left out of its
+// documentation because it is deprecated. The functions of deprecated types
+// are kept, since the type is either hidden or documented as a whole.
+func (typ *Type) hidden(fn *Func) bool {
+	return typ.cfg.HideDeprecated && !typ.IsDeprecated() && fn.IsDeprecated()
+}
+
 // Consts lists the const declaration blocks containing values of this type.
 func (typ *Type) Consts() []*Value {
 	consts := make([]*Value, len(typ.doc.Consts))
@@ -2,6 +2,7 @@ package lang_test
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/anthonyme00/gomarkdoc/lang"
@@ -22,14 +23,182 @@ func TestType_Examples(t *testing.T) {
 	is.Equal(ex[1].Name(), "Sub Test")
 }
 
-func loadType(dir, name string) (*lang.Type, error) {
+func TestType_TypeParams(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/generics", "Generic")
+	is.NoErr(err)
+
+	is.Equal(typ.TypeParams(), "[T any]")
+	is.Equal(typ.Title(), "type Generic[T any]")
+
+	typ, err = loadType("../testData/lang/function", "Receiver")
+	is.NoErr(err)
+
+	is.Equal(typ.TypeParams(), "")
+	is.Equal(typ.Title(), "type Receiver")
+}
+
+func TestType_InterfaceMethods(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/lang/interfaces", "Store")
+	is.NoErr(err)
+
+	methods := typ.InterfaceMethods()
+	is.Equal(len(methods), 3) // embedded interfaces are not methods
+
+	is.Equal(methods[0].Name(), "Get")
+	is.Equal(methods[0].Receiver(), "Store")
+	is.Equal(methods[0].Anchor(), "Store")
+	is.Equal(methods[0].Summary(), "Get retrieves the value for the key.")
+
+	sig, err := methods[0].Signature()
+	is.NoErr(err)
+	is.Equal(sig, "Get(key string) (string, error)")
+
+	is.Equal(methods[2].Name(), "Len")
+	is.Equal(len(methods[2].Doc().Blocks()), 0)
+
+	decl, err := typ.Decl()
+	is.NoErr(err)
+	is.True(!strings.Contains(decl, "retrieves")) // method docs are documented separately
+
+	typ, err = loadType("../testData/lang/function", "Receiver")
+	is.NoErr(err)
+
+	is.Equal(len(typ.InterfaceMethods()), 0)
+}
+
+func TestType_Fields(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/lang/embedded", "Options")
+	is.NoErr(err)
+
+	fields := typ.Fields()
+	is.Equal(len(fields), 3)
+
+	is.Equal(fields[0].Name(), "Verbose")
+	is.Equal(fields[0].Tag(), `json:"verbose" yaml:"verbose"`)
+	is.Equal(len(fields[0].Doc().Blocks()), 2)
+	is.True(!fields[0].Promoted())
+
+	fieldType, err := fields[1].Type()
+	is.NoErr(err)
+	is.Equal(fieldType, "[]string")
+	is.Equal(fields[1].Summary(), "Tags holds a | separated list.") // line comment
+
+	is.Equal(fields[2].Name(), "Retries")
+	is.Equal(fields[2].Tag(), "")
+	is.Equal(fields[2].Summary(), "")
+
+	typ, err = loadType("../testData/lang/embedded", "ServerConfig")
+	is.NoErr(err)
+
+	fields = typ.Fields()
+	is.Equal(len(fields), 4)
+	is.Equal(fields[0].Name(), "BaseConfig") // embedded fields are named after their type
+	is.Equal(fields[1].Name(), "Mutex")
+
+	typ, err = loadType("../testData/lang/interfaces", "Store")
+	is.NoErr(err)
+
+	is.Equal(len(typ.Fields()), 0)
+}
+
+func TestType_PromotedFields(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/embedded")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithFlattenedEmbedding())
+	is.NoErr(err)
+
+	var typ *lang.Type
+	for _, t := range pkg.Types() {
+		if t.Name() == "ServerConfig" {
+			typ = t
+		}
+	}
+	is.True(typ != nil)
+
+	fields := typ.PromotedFields()
+	is.Equal(len(fields), 2) // Name is shadowed and unexported fields are left out
+
+	is.Equal(fields[0].Name(), "Timeout")
+	is.Equal(fields[0].EmbeddedFrom(), "BaseConfig")
+	is.Equal(fields[0].Summary(), "Timeout is the timeout in seconds.")
+	is.True(fields[0].Promoted())
+
+	is.Equal(fields[1].Name(), "CertFile")
+	is.Equal(fields[1].EmbeddedFrom(), "BaseConfig.tlsConfig")
+
+	fieldType, err := fields[1].Type()
+	is.NoErr(err)
+	is.Equal(fieldType, "string")
+
+	typ, err = loadType("../testData/lang/embedded", "ServerConfig")
+	is.NoErr(err)
+
+	is.Equal(len(typ.PromotedFields()), 0) // flattening is disabled by default
+}
+
+func TestType_PromotedMethods(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/promoted")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithPromotedMethods())
+	is.NoErr(err)
+
+	types := make(map[string]*lang.Type)
+	for _, t := range pkg.Types() {
+		types[t.Name()] = t
+	}
+
+	methods := types["Service"].PromotedMethods()
+	is.Equal(len(methods), 3) // unexported methods are left out
+
+	is.Equal(methods[0].Name(), "Close")
+	is.Equal(methods[0].Signature(), "Close() error")
+	is.Equal(methods[0].Origin(), "Base")
+	is.Equal(methods[0].EmbeddedFrom(), "Client.Base")
+	is.Equal(methods[0].Summary(), "Close releases the resources of the client.")
+
+	is.Equal(methods[1].Name(), "Do")
+	is.Equal(methods[1].EmbeddedFrom(), "Client")
+
+	is.Equal(methods[2].Signature(), "Log(msg string)")
+	is.Equal(methods[2].Origin(), "Logger")
+	is.Equal(methods[2].EmbeddedFrom(), "Client.Logger")
+
+	methods = types["ReadCloser"].PromotedMethods()
+	is.Equal(len(methods), 1) // Close is declared by the interface itself
+
+	is.Equal(methods[0].Signature(), "Read(p []byte) (n int, err error)")
+	is.Equal(methods[0].Origin(), "io.Reader")
+	is.Equal(methods[0].EmbeddedFrom(), "io.Reader")
+	is.Equal(methods[0].Summary(), "")
+
+	typ, err := loadType("../testData/lang/promoted", "Service")
+	is.NoErr(err)
+
+	is.Equal(len(typ.PromotedMethods()), 0) // promoted methods are disabled by default
+}
+
+func loadType(dir, name string, opts ...lang.PackageOption) (*lang.Type, error) {
 	buildPkg, err := getBuildPackage(dir)
 	if err != nil {
 		return nil, err
 	}
 
 	log := logger.New(logger.ErrorLevel)
-	pkg, err := lang.NewPackageFromBuild(log, buildPkg)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -42,3 +211,135 @@ func loadType(dir, name string) (*lang.Type, error) {
 
 	return nil, errors.New("type not found")
 }
+
+func TestType_TypeSet(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("../testData/lang/typeset")
+	is.NoErr(err)
+
+	types := make(map[string]*lang.Type)
+	for _, typ := range pkg.Types() {
+		types[typ.Name()] = typ
+	}
+
+	unions := types["Number"].TypeSet()
+	is.Equal(len(unions), 1)
+
+	terms := unions[0].Terms()
+	is.Equal(len(terms), 4)
+	is.True(terms[0].Tilde())
+	is.Equal(terms[0].Text(), "int")
+	is.Equal(terms[0].URL(), "")
+	is.True(!terms[3].Tilde())
+	is.Equal(terms[3].Text(), "Celsius")
+	is.Equal(terms[3].URL(), "#Celsius")
+
+	decl, err := types["Number"].Decl()
+	is.NoErr(err)
+	is.Equal(decl, "type Number interface {\n    ~int | ~int64 | ~float64 | Celsius\n}") // not filtered out
+
+	unions = types["Durable"].TypeSet()
+	is.Equal(len(unions), 2)
+	is.Equal(unions[0].Terms()[0].URL(), "https://pkg.go.dev/time#Duration")
+	is.Equal(unions[1].Terms()[0].Text(), "comparable")
+	is.Equal(len(types["Durable"].InterfaceMethods()), 1)
+
+	is.Equal(len(types["Stringer"].TypeSet()), 0) // not a constraint
+}
+
+func TestType_customTitle(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/lang/title", "URL")
+	is.NoErr(err)
+
+	is.Equal(typ.CustomTitle(), "Uniform Resource Locator")
+	is.Equal(typ.Title(), "Uniform Resource Locator")
+	is.Equal(typ.Anchor(), "URL")
+	is.Equal(typ.Summary(), "URL is a uniform resource locator.")
+}
+
+func TestType_IndexFields(t *testing.T) {
+	is := is.New(t)
+
+	typ, err := loadType("../testData/lang/embedded", "Options")
+	is.NoErr(err)
+
+	is.Equal(len(typ.IndexFields()), 0) // fields aren't indexed by default
+
+	buildPkg, err := getBuildPackage("../testData/lang/embedded")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithIndexedFields())
+	is.NoErr(err)
+
+	for _, t := range pkg.Types() {
+		if t.Name() == "Options" {
+			typ = t
+		}
+	}
+
+	fields := typ.IndexFields()
+	is.Equal(len(fields), 2) // Retries has no documentation
+	is.Equal(fields[0].Title(), "field Options.Verbose")
+	is.Equal(fields[1].Title(), "field Options.Tags")
+	is.Equal(fields[1].Anchor(), "Options")
+}
+
+func TestType_Implementers(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/implementers")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithImplementers(lang.NewImplementerIndex(lang.NewSourceImporter())))
+	is.NoErr(err)
+
+	types := make(map[string]*lang.Type)
+	for _, t := range pkg.Types() {
+		types[t.Name()] = t
+	}
+
+	impls := types["Shape"].Implementers()
+	is.Equal(len(impls), 3)
+
+	is.Equal(impls[0].Text(), "*Circle")
+	is.Equal(impls[0].URL(), "#Circle")
+	is.Equal(impls[1].Text(), "Square")
+	is.Equal(impls[2].Text(), "shapes.Triangle")
+	is.Equal(impls[2].URL(), "https://pkg.go.dev/github.com/anthonyme00/gomarkdoc/testData/lang/implementers/shapes#Triangle")
+
+	is.Equal(len(types["Any"].Implementers()), 0)    // empty interfaces are left out
+	is.Equal(len(types["Square"].Implementers()), 0) // only interfaces have implementers
+}
+
+func TestType_Implements(t *testing.T) {
+	is := is.New(t)
+
+	buildPkg, err := getBuildPackage("../testData/lang/implementers")
+	is.NoErr(err)
+
+	log := logger.New(logger.ErrorLevel)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, lang.PackageWithImplementedInterfaces(lang.NewImplementerIndex(lang.NewSourceImporter())))
+	is.NoErr(err)
+
+	types := make(map[string]*lang.Type)
+	for _, t := range pkg.Types() {
+		types[t.Name()] = t
+	}
+
+	ifaces := types["Square"].Implements()
+	is.Equal(len(ifaces), 2) // Any is left out
+
+	is.Equal(ifaces[0].Text(), "Shape")
+	is.Equal(ifaces[0].URL(), "#Shape")
+	is.Equal(ifaces[1].Text(), "shapes.Polygon") // implemented by a pointer
+	is.Equal(ifaces[1].URL(), "https://pkg.go.dev/github.com/anthonyme00/gomarkdoc/testData/lang/implementers/shapes#Polygon")
+
+	is.Equal(len(types["Point"].Implements()), 0)
+	is.Equal(len(types["Shape"].Implements()), 0) // interfaces aren't listed
+	is.Equal(len(types["Shape"].Implementers()), 0)
+}
@@ -0,0 +1,162 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// typeLinks provides links to the documentation of the types from other
+// packages referenced by the declaration (e.g. context.Context), in the order
+// they first appear. Types of packages documented together link to their
+// generated documentation and the rest are resolved by the ImportURLResolver,
+// which links to pkg.go.dev by default.
+func typeLinks(cfg *Config, decl ast.Node) []*Span {
+	if !cfg.TypeLinks || decl == nil {
+		return nil
+	}
+
+	f := declFile(cfg, decl)
+	if f == nil {
+		return nil
+	}
+
+	imports := fileImports(f)
+	if len(imports) == 0 {
+		return nil
+	}
+
+	var (
+		links []*Span
+		seen  = make(map[string]bool)
+	)
+
+	ast.Inspect(decl, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		importPath, ok := imports[ident.Name]
+		if !ok {
+			return true
+		}
+
+		text := fmt.Sprintf("%s.%s", ident.Name, sel.Sel.Name)
+		if seen[text] {
+			return false
+		}
+
+		seen[text] = true
+
+		if url, ok := importedSymbolURL(cfg, importPath, sel.Sel.Name); ok {
+			links = append(links, NewSpan(cfg.Inc(0), LinkSpan, text, url))
+		}
+
+		return false
+	})
+
+	return links
+}
+
+// importedSymbolURL provides the url of the documentation of a symbol from
+// another package. Packages documented together link to their generated
+// documentation and the rest are resolved by the ImportURLResolver.
+func importedSymbolURL(cfg *Config, importPath, symbol string) (string, bool) {
+	if href, ok := cfg.SymbolIndex.Resolve(importPath, symbol, cfg.currentFile()); ok {
+		return href, true
+	}
+
+	url, err := cfg.ImportURLs.Resolve(importPath, symbol)
+	if err != nil {
+		cfg.Log.Warnf("Unable to resolve url for package %s: %s", importPath, err)
+		return "", false
+	}
+
+	return url, true
+}
+
+// declFile finds the parsed file of the package containing the declaration.
+// The declarations of the package documentation are parsed separately from
+// Files, so the file is matched by name rather than position. The names are
+// compared as absolute paths, since the package directory may be relative to
+// the working directory.
+func declFile(cfg *Config, decl ast.Node) *ast.File {
+	name := absFileName(cfg.FileSet.Position(decl.Pos()).Filename)
+	for _, f := range cfg.Files {
+		if absFileName(cfg.FileSet.Position(f.Pos()).Filename) == name {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// absFileName provides the absolute form of the file name, or the name itself
+// if it can't be made absolute.
+func absFileName(name string) string {
+	if abs, err := filepath.Abs(name); err == nil {
+		return abs
+	}
+
+	return name
+}
+
+// fileImports maps the names the file refers to its imported packages by to
+// their import paths. Blank and dot imports are left out.
+func fileImports(f *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range f.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := assumedPackageName(p)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		if name == "_" || name == "." {
+			continue
+		}
+
+		imports[name] = p
+	}
+
+	return imports
+}
+
+// assumedPackageName guesses the name of the package with the provided import
+// path without loading it, following the same conventions as goimports: major
+// version suffixes and a "go-" prefix are dropped, as is anything after the
+// first character which can't be part of an identifier (e.g. gopkg.in/yaml.v3
+// is assumed to be named yaml).
+func assumedPackageName(importPath string) string {
+	base := path.Base(importPath)
+	if strings.HasPrefix(base, "v") {
+		if _, err := strconv.Atoi(base[1:]); err == nil {
+			if dir := path.Dir(importPath); dir != "." {
+				base = path.Base(dir)
+			}
+		}
+	}
+
+	base = strings.TrimPrefix(base, "go-")
+	if i := strings.IndexFunc(base, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}); i >= 0 {
+		base = base[:i]
+	}
+
+	return base
+}
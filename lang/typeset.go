@@ -0,0 +1,206 @@
+package lang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+type (
+	// TypeUnion is a single element of the type set of a constraint interface,
+	// which is satisfied by any of its terms (e.g. ~int | ~float64).
+	TypeUnion struct {
+		terms []*TypeTerm
+	}
+
+	// TypeTerm is a single term of a TypeUnion.
+	TypeTerm struct {
+		tilde bool
+		text  string
+		url   string
+	}
+)
+
+// TypeSet lists the elements of the type set of a constraint interface, one
+// for each line of unions and embedded types in its declaration. A type must
+// satisfy all of the elements, along with any methods of the interface. Nil is
+// returned for types which aren't constraint interfaces, including interfaces
+// which only embed other interfaces.
+func (typ *Type) TypeSet() []*TypeUnion {
+	iface := typ.interfaceType()
+	if iface == nil || !isConstraint(iface) {
+		return nil
+	}
+
+	var imports map[string]string
+	if f := declFile(typ.cfg, typ.doc.Decl); f != nil {
+		imports = fileImports(f)
+	}
+
+	var unions []*TypeUnion
+	for _, field := range iface.Methods.List {
+		if _, ok := field.Type.(*ast.FuncType); ok {
+			continue
+		}
+
+		union := &TypeUnion{}
+		for _, expr := range unionTerms(field.Type) {
+			union.terms = append(union.terms, newTypeTerm(typ.cfg, imports, expr))
+		}
+
+		unions = append(unions, union)
+	}
+
+	return unions
+}
+
+// Terms lists the terms of the union in the order they are declared.
+func (u *TypeUnion) Terms() []*TypeTerm {
+	return u.terms
+}
+
+// Tilde reports whether the term includes all types with the term's type as
+// their underlying type (e.g. ~int).
+func (t *TypeTerm) Tilde() bool {
+	return t.tilde
+}
+
+// Text provides the type of the term as written, without the tilde.
+func (t *TypeTerm) Text() string {
+	return t.text
+}
+
+// URL provides the url of the documentation of the named type of the term, or
+// an empty string if it isn't a named type declared in the package or in an
+// imported package.
+func (t *TypeTerm) URL() string {
+	return t.url
+}
+
+func newTypeTerm(cfg *Config, imports map[string]string, expr ast.Expr) *TypeTerm {
+	term := &TypeTerm{}
+	if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.TILDE {
+		term.tilde = true
+		expr = u.X
+	}
+
+	text, err := printNode(expr, cfg.FileSet)
+	if err != nil {
+		text = fmt.Sprintf("%T", expr)
+	}
+
+	term.text = text
+
+	// Generic types are linked by their name
+	switch v := expr.(type) {
+	case *ast.IndexExpr:
+		expr = v.X
+	case *ast.IndexListExpr:
+		expr = v.X
+	}
+
+	switch v := expr.(type) {
+	case *ast.Ident:
+		if sym, ok := cfg.Symbols[v.Name]; ok {
+			term.url = symbolHref(cfg, v.Name, sym)
+		}
+	case *ast.SelectorExpr:
+		if pkg, ok := v.X.(*ast.Ident); ok {
+			if importPath, ok := imports[pkg.Name]; ok {
+				term.url, _ = importedSymbolURL(cfg, importPath, v.Sel.Name)
+			}
+		}
+	}
+
+	return term
+}
+
+// isConstraint reports whether the interface has elements which can only be
+// used in constraints, such as unions and terms with a tilde.
+func isConstraint(iface *ast.InterfaceType) bool {
+	for _, field := range iface.Methods.List {
+		switch v := field.Type.(type) {
+		case *ast.BinaryExpr:
+			if v.Op == token.OR {
+				return true
+			}
+		case *ast.UnaryExpr:
+			if v.Op == token.TILDE {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// unionTerms flattens a union (e.g. ~int | ~float64 | MyInt) into its terms.
+func unionTerms(expr ast.Expr) []ast.Expr {
+	if b, ok := expr.(*ast.BinaryExpr); ok && b.Op == token.OR {
+		return append(unionTerms(b.X), unionTerms(b.Y)...)
+	}
+
+	return []ast.Expr{expr}
+}
+
+// packageExports trims the package down to its exported declarations like
+// ast.PackageExports, but keeps the type elements of interfaces (e.g.
+// ~int | ~float64 or comparable), which ast.PackageExports drops since they
+// have no exported name even though they are part of the exported API. Go's
+// own documentation keeps them the same way.
+func packageExports(pkg *ast.Package) {
+	type iface struct {
+		node   *ast.InterfaceType
+		fields []*ast.Field
+	}
+
+	var ifaces []iface
+	for _, f := range pkg.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			if it, ok := n.(*ast.InterfaceType); ok && it.Methods != nil {
+				// Filtering reuses the list, so it has to be copied
+				ifaces = append(ifaces, iface{it, append([]*ast.Field(nil), it.Methods.List...)})
+			}
+
+			return true
+		})
+	}
+
+	ast.PackageExports(pkg)
+
+	for _, it := range ifaces {
+		kept := make(map[*ast.Field]bool, len(it.node.Methods.List))
+		for _, field := range it.node.Methods.List {
+			kept[field] = true
+		}
+
+		list := make([]*ast.Field, 0, len(it.fields))
+		for _, field := range it.fields {
+			if kept[field] || isTypeElement(field) {
+				list = append(list, field)
+			}
+		}
+
+		it.node.Methods.List = list
+		it.node.Incomplete = len(list) < len(it.fields)
+	}
+}
+
+// isTypeElement reports whether the interface field is a type element without
+// an exported name, such as a union, a term with a tilde or a predeclared type.
+func isTypeElement(field *ast.Field) bool {
+	if len(field.Names) > 0 {
+		return false
+	}
+
+	switch v := field.Type.(type) {
+	case *ast.Ident:
+		_, ok := types.Universe.Lookup(v.Name).(*types.TypeName)
+		return ok
+	case *ast.SelectorExpr, *ast.StarExpr, *ast.IndexExpr, *ast.IndexListExpr:
+		return false
+	default:
+		return true
+	}
+}
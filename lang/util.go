@@ -1,12 +1,16 @@
 package lang
 
 import (
+	"fmt"
 	"go/ast"
 	"go/printer"
 	"go/token"
 	"regexp"
 	"strings"
 	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
 )
 
 func printNode(node ast.Node, fs *token.FileSet) (string, error) {
@@ -23,6 +27,33 @@ func printNode(node ast.Node, fs *token.FileSet) (string, error) {
 	return out.String(), nil
 }
 
+// printTypeParams renders the provided type parameter list, including the
+// brackets around it (e.g. "[K comparable, V any]"). An empty string is
+// returned if there are no type parameters.
+func printTypeParams(params *ast.FieldList) (string, error) {
+	if params == nil || len(params.List) == 0 {
+		return "", nil
+	}
+
+	fields := make([]string, 0, len(params.List))
+	for _, field := range params.List {
+		// We use a custom FileSet so that we don't inherit multiline formatting
+		constraint, err := printNode(field.Type, token.NewFileSet())
+		if err != nil {
+			return "", err
+		}
+
+		names := make([]string, len(field.Names))
+		for i, name := range field.Names {
+			names[i] = name.Name
+		}
+
+		fields = append(fields, fmt.Sprintf("%s %s", strings.Join(names, ", "), constraint))
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(fields, ", ")), nil
+}
+
 func runeIsUpper(r rune) bool {
 	return r >= 'A' && r <= 'Z'
 }
@@ -76,6 +107,126 @@ func splitCamel(text string) string {
 	return builder.String()
 }
 
+// humanize converts the space-separated words produced by splitCamel to
+// sentence case, preserving words which are entirely uppercase (such as
+// acronyms).
+func humanize(text string) string {
+	words := strings.Fields(text)
+	for i, w := range words {
+		if i == 0 || len(w) > 1 && strings.ToUpper(w) == w {
+			continue
+		}
+
+		words[i] = strings.ToLower(w)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// titleDirective sets the title rendered for a symbol in place of its name,
+// e.g. to spell out an abbreviation. Anchors are still based on the name so
+// that links to the symbol keep working.
+const titleDirective = "//gomarkdoc:title "
+
+// bodyDirective marks a function whose full declaration, including its body,
+// is rendered along with its signature.
+const bodyDirective = "//gomarkdoc:body"
+
+// hasDirective checks whether the comment groups hold the directive, which
+// takes no value.
+func hasDirective(directive string, groups ...*ast.CommentGroup) bool {
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+
+		for _, c := range group.List {
+			if strings.TrimSpace(c.Text) == directive {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// directiveValue finds the value of the first occurrence of the directive in
+// the comment groups, or an empty string if it isn't present. Directives are
+// left out of the text of documentation comments, so they have to be read
+// from the comments themselves.
+func directiveValue(directive string, groups ...*ast.CommentGroup) string {
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+
+		for _, c := range group.List {
+			if strings.HasPrefix(c.Text, directive) {
+				return strings.TrimSpace(strings.TrimPrefix(c.Text, directive))
+			}
+		}
+	}
+
+	return ""
+}
+
+// sourceDoc finds the documentation comment of a declaration, or of a spec
+// within one, in the parsed files of the package. go/doc removes comments from
+// the declarations it provides once it has extracted their text, so they have
+// to be found in the files instead.
+func sourceDoc(cfg *Config, node ast.Node) *ast.CommentGroup {
+	f := declFile(cfg, node)
+	if f == nil {
+		return nil
+	}
+
+	offset := cfg.FileSet.Position(node.Pos()).Offset
+	matches := func(n ast.Node) bool {
+		return cfg.FileSet.Position(n.Pos()).Offset == offset
+	}
+
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if _, ok := node.(*ast.FuncDecl); ok && matches(decl) {
+				return decl.Doc
+			}
+		case *ast.GenDecl:
+			if _, ok := node.(*ast.GenDecl); ok && matches(decl) {
+				return decl.Doc
+			}
+
+			for _, spec := range decl.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok && matches(ts) {
+					if _, ok := node.(*ast.TypeSpec); ok {
+						return ts.Doc
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// summaryWidth is the maximum display width of a summary without a sentence
+// terminator, in columns. Wide characters such as those of Chinese and
+// Japanese take two columns.
+const summaryWidth = 200
+
+// sentenceTerminators end a sentence without needing to be followed by a
+// space, as in Chinese and Japanese text (e.g. 。) and in some other scripts.
+var sentenceTerminators = map[rune]bool{
+	'。': true, // Ideographic full stop
+	'｡': true, // Halfwidth ideographic full stop
+	'．': true, // Fullwidth full stop
+	'！': true, // Fullwidth exclamation mark
+	'？': true, // Fullwidth question mark
+	'।': true, // Devanagari danda
+	'۔': true, // Arabic full stop
+	'؟': true, // Arabic question mark
+}
+
 func extractSummary(doc string) string {
 	firstParagraph := normalizeDoc(doc)
 
@@ -88,6 +239,7 @@ func extractSummary(doc string) string {
 	var lookback1 rune
 	var lookback2 rune
 	var lookback3 rune
+	terminated := false
 	for _, r := range formatDocParagraph(firstParagraph) {
 		// We terminate the sequence if we see a space preceded by a '.' which
 		// does not have exactly one word character before it (to avoid
@@ -95,6 +247,7 @@ func extractSummary(doc string) string {
 		isPeriod := r == ' ' && lookback1 == '.'
 		isInitial := unicode.IsUpper(lookback2) && !unicode.IsLetter(lookback3) && !unicode.IsDigit(lookback3)
 		if isPeriod && !isInitial {
+			terminated = true
 			break
 		}
 
@@ -105,14 +258,77 @@ func extractSummary(doc string) string {
 		lookback3 = lookback2
 		lookback2 = lookback1
 		lookback1 = r
+
+		if sentenceTerminators[r] {
+			terminated = true
+			break
+		}
+	}
+
+	summary := builder.String()
+
+	// Paragraphs without a sentence terminator are cut short at a whole
+	// character once they get too wide
+	if !terminated && lookback1 != '.' {
+		if cut, ok := truncateWidth(summary, summaryWidth); ok {
+			// Avoid cutting words of space-separated text in half
+			last, _ := utf8.DecodeLastRuneInString(cut)
+			if !isWide(last) {
+				if idx := strings.LastIndex(cut, " "); idx != -1 {
+					cut = cut[:idx]
+				}
+			}
+
+			return fmt.Sprintf("%s…", strings.TrimRightFunc(cut, unicode.IsSpace))
+		}
 	}
 
-	// Make the summary end with a period if it is nonempty and doesn't already.
-	if lookback1 != '.' && lookback1 != 0 {
-		builder.WriteRune('.')
+	// Make the summary end with a period if it is nonempty and doesn't already,
+	// using the ideographic full stop for Chinese and Japanese text.
+	switch {
+	case lookback1 == '.' || lookback1 == 0 || sentenceTerminators[lookback1]:
+	case unicode.In(lookback1, unicode.Han, unicode.Hiragana, unicode.Katakana):
+		summary += "。"
+	default:
+		summary += "."
 	}
 
-	return builder.String()
+	return summary
+}
+
+// truncateWidth cuts the text at a whole character so that it takes at most
+// the provided number of columns when displayed. The last return value is
+// false if the text already fits.
+func truncateWidth(text string, columns int) (string, bool) {
+	var used int
+	for i, r := range text {
+		used += runeWidth(r)
+		if used > columns {
+			return text[:i], true
+		}
+	}
+
+	return text, false
+}
+
+// runeWidth provides the number of columns taken by the rune when displayed.
+func runeWidth(r rune) int {
+	if isWide(r) {
+		return 2
+	}
+
+	return 1
+}
+
+// isWide checks whether the rune takes two columns when displayed, like the
+// characters of Chinese, Japanese and Korean text.
+func isWide(r rune) bool {
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return true
+	}
+
+	return false
 }
 
 var crlfRegex = regexp.MustCompile("\r\n")
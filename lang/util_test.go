@@ -0,0 +1,75 @@
+package lang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestExtractSummary(t *testing.T) {
+	tests := map[string]struct {
+		doc     string
+		summary string
+	}{
+		"English": {
+			doc:     "Foo does a thing. It also does another.",
+			summary: "Foo does a thing.",
+		},
+		"English initials": {
+			doc:     "Foo was written by J. Smith. It does a thing.",
+			summary: "Foo was written by J. Smith.",
+		},
+		"English without period": {
+			doc:     "Foo does a thing",
+			summary: "Foo does a thing.",
+		},
+		"Chinese": {
+			doc:     "Foo 用于处理请求。它还会记录日志。",
+			summary: "Foo 用于处理请求。",
+		},
+		"Japanese": {
+			doc:     "Foo はリクエストを処理します！ログも記録します。",
+			summary: "Foo はリクエストを処理します！",
+		},
+		"Chinese without full stop": {
+			doc:     "Foo 用于处理请求",
+			summary: "Foo 用于处理请求。",
+		},
+		"Chinese wrapped lines": {
+			doc:     "Foo 用于处理\n请求。它还会记录日志。",
+			summary: "Foo 用于处理 请求。",
+		},
+		"Hindi": {
+			doc:     "Foo अनुरोधों को संभालता है। यह लॉग भी लिखता है।",
+			summary: "Foo अनुरोधों को संभालता है।",
+		},
+		"Chinese too wide": {
+			doc:     strings.Repeat("很长", 60),
+			summary: strings.Repeat("很长", 50) + "…",
+		},
+		"English too wide": {
+			doc:     strings.Repeat("word ", 50),
+			summary: strings.TrimSpace(strings.Repeat("word ", 40)) + "…",
+		},
+		"English long sentence": {
+			doc:     strings.Repeat("word ", 50) + "end. Another sentence.",
+			summary: strings.Repeat("word ", 50) + "end.",
+		},
+		"English long paragraph": {
+			doc:     strings.Repeat("word ", 50) + "end.",
+			summary: strings.Repeat("word ", 50) + "end.",
+		},
+		"Korean without full stop": {
+			doc:     "Foo 는 요청을 처리합니다",
+			summary: "Foo 는 요청을 처리합니다.",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(extractSummary(test.doc), test.summary)
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package lang
 
 import (
+	"fmt"
 	"go/doc"
+	"strings"
 )
 
 // Value holds documentation for a var or const declaration within a package.
@@ -46,6 +48,13 @@ func (v *Value) Decl() (string, error) {
 	return printNode(v.doc.Decl, v.cfg.FileSet)
 }
 
+// Title provides the kind of the declaration along with the names it declares
+// (e.g. "const A, B"). It is primarily designed for listing the value in
+// tables of contents.
+func (v *Value) Title() string {
+	return fmt.Sprintf("%s %s", v.doc.Decl.Tok, strings.Join(v.doc.Names, ", "))
+}
+
 // Anchor produces anchor text for the value.
 func (v *Value) Anchor() string {
 	var kind SymbolKind
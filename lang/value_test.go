@@ -54,14 +54,62 @@ func TestValue_Location(t *testing.T) {
 	is.True(strings.HasSuffix(loc.Filepath, "value.go"))
 }
 
-func loadValue(dir, name string) (*lang.Value, error) {
+func TestValue_ConstValues(t *testing.T) {
+	is := is.New(t)
+
+	val, err := loadValue("../testData/lang/constvalues", "Read", lang.PackageWithIotaValues())
+	is.NoErr(err)
+
+	values := val.ConstValues()
+	is.Equal(len(values), 2) // the blank constant is left out
+	is.Equal(*values[0], lang.ConstValue{Name: "Read", Value: "1"})
+	is.Equal(*values[1], lang.ConstValue{Name: "Execute", Value: "4"})
+
+	val, err = loadValue("../testData/lang/constvalues", "MaxSize", lang.PackageWithIotaValues())
+	is.NoErr(err)
+	is.Equal(len(val.ConstValues()), 0) // doesn't use iota
+
+	typ, err := loadType("../testData/lang/constvalues", "Weekday", lang.PackageWithIotaValues())
+	is.NoErr(err)
+
+	values = typ.Consts()[0].ConstValues()
+	is.Equal(len(values), 3)
+	is.Equal(*values[2], lang.ConstValue{Name: "Tuesday", Value: "2"})
+
+	val, err = loadValue("../testData/lang/constvalues", "Read")
+	is.NoErr(err)
+	is.Equal(len(val.ConstValues()), 0) // left out by default
+}
+
+func TestValue_ConstValues_computed(t *testing.T) {
+	is := is.New(t)
+
+	val, err := loadValue("../testData/lang/constvalues", "MaxSize", lang.PackageWithComputedValues())
+	is.NoErr(err)
+
+	values := val.ConstValues()
+	is.Equal(len(values), 3)
+	is.Equal(*values[0], lang.ConstValue{Name: "MaxSize", Value: "1048576"})
+	is.Equal(*values[1], lang.ConstValue{Name: "Timeout", Value: "30000000000"})
+	is.Equal(*values[2], lang.ConstValue{Name: "Ratio", Value: "0.25"})
+
+	val, err = loadValue("../testData/lang/constvalues", "Read", lang.PackageWithComputedValues())
+	is.NoErr(err)
+	is.Equal(len(val.ConstValues()), 2) // iota is an expression too
+
+	val, err = loadValue("../testData/lang/constvalues", "DefaultName", lang.PackageWithComputedValues())
+	is.NoErr(err)
+	is.Equal(len(val.ConstValues()), 0) // literals already show their values
+}
+
+func loadValue(dir, name string, opts ...lang.PackageOption) (*lang.Value, error) {
 	buildPkg, err := getBuildPackage(dir)
 	if err != nil {
 		return nil, err
 	}
 
 	log := logger.New(logger.ErrorLevel)
-	pkg, err := lang.NewPackageFromBuild(log, buildPkg)
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg, opts...)
 	if err != nil {
 		return nil, err
 	}
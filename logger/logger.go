@@ -28,7 +28,8 @@ type (
 
 	// options defines options for configuring the logger
 	options struct {
-		fields map[string]interface{}
+		fields   map[string]interface{}
+		progress ProgressFunc
 	}
 )
 
@@ -72,11 +73,16 @@ func New(level Level, opts ...Option) Logger {
 		log.SetLevel(logrus.ErrorLevel)
 	}
 
+	var l Logger = log
 	if options.fields != nil {
-		return log.WithFields(options.fields)
+		l = log.WithFields(options.fields)
 	}
 
-	return log
+	if options.progress != nil {
+		l = &progressLogger{l, options.progress}
+	}
+
+	return l
 }
 
 // WithField sets the provided key/value pair for use on all logs.
@@ -0,0 +1,72 @@
+package logger
+
+type (
+	// ProgressEvent identifies a step of documentation generation reported to
+	// a ProgressFunc.
+	ProgressEvent string
+
+	// Progress describes a step of documentation generation which has just
+	// happened.
+	Progress struct {
+		// Event identifies the step which happened.
+		Event ProgressEvent
+
+		// Package holds the import path of the package the step applies to,
+		// if any.
+		Package string
+
+		// File holds the path of the file the step applies to, if any.
+		File string
+	}
+
+	// ProgressFunc receives the progress of documentation generation, such as
+	// for displaying a progress bar in a tool embedding gomarkdoc. It is
+	// called synchronously, so it should return quickly.
+	ProgressFunc func(p Progress)
+
+	// ProgressReporter is implemented by loggers which report progress.
+	// Loggers created with the WithProgress option implement it, and custom
+	// Logger implementations can implement it to receive progress as well.
+	ProgressReporter interface {
+		Progress(p Progress)
+	}
+
+	progressLogger struct {
+		Logger
+		fn ProgressFunc
+	}
+)
+
+// Progress events reported while generating documentation.
+const (
+	// PackageStartedEvent is reported when a package starts being loaded.
+	PackageStartedEvent ProgressEvent = "packageStarted"
+
+	// PackageRenderedEvent is reported when the documentation of a package
+	// has been rendered.
+	PackageRenderedEvent ProgressEvent = "packageRendered"
+
+	// FileWrittenEvent is reported when a documentation file has been
+	// written.
+	FileWrittenEvent ProgressEvent = "fileWritten"
+)
+
+// WithProgress sets the function receiving the progress reported through the
+// logger.
+func WithProgress(fn ProgressFunc) Option {
+	return func(opts *options) {
+		opts.progress = fn
+	}
+}
+
+// ReportProgress reports progress through the provided logger if it is a
+// ProgressReporter. Otherwise, the progress is dropped.
+func ReportProgress(log Logger, p Progress) {
+	if r, ok := log.(ProgressReporter); ok {
+		r.Progress(p)
+	}
+}
+
+func (l *progressLogger) Progress(p Progress) {
+	l.fn(p)
+}
@@ -3,11 +3,15 @@ package gomarkdoc
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 
 	"github.com/anthonyme00/gomarkdoc/format"
+	"github.com/anthonyme00/gomarkdoc/format/formatcore"
 	"github.com/anthonyme00/gomarkdoc/lang"
+	"github.com/anthonyme00/gomarkdoc/logger"
 )
 
 type (
@@ -19,13 +23,101 @@ type (
 		format            format.Format
 		templateFuncs     map[string]any
 		onlyFile          *string
+		safeTemplates     bool
+		headings          map[string]string
+		importURLs        *lang.ImportURLResolver
+		log               logger.Logger
+		numberedHeadings  bool
+		deepHeadings      DeepHeadingStrategy
+
+		// headingLevels and headingNumbers hold the level and number of the
+		// current heading at each depth below the title of the output rendered
+		// so far, for numbered headings.
+		headingLevels  []int
+		headingNumbers []int
+
+		// rerootOffset holds the number of levels headings are moved up by
+		// while within a re-rooted heading, for RerootDeepHeadings.
+		rerootOffset int
 	}
 
 	// RendererOption configures the renderer's behavior.
 	RendererOption func(renderer *Renderer) error
+
+	// DeepHeadingStrategy defines how headings nested more deeply than the
+	// deepest header level of the format are rendered.
+	DeepHeadingStrategy string
+)
+
+const (
+	// ClampDeepHeadings renders headings nested too deeply as headers of the
+	// deepest level of the format. It's the default.
+	ClampDeepHeadings DeepHeadingStrategy = "clamp"
+
+	// BoldDeepHeadings renders headings nested too deeply as bold labels.
+	BoldDeepHeadings DeepHeadingStrategy = "bold"
+
+	// DefinitionDeepHeadings renders headings nested too deeply as the terms
+	// of definition lists.
+	DefinitionDeepHeadings DeepHeadingStrategy = "definition"
+
+	// RerootDeepHeadings renders a heading nested too deeply as a level 2
+	// heading, and the headings nested within it relative to it, until the
+	// next heading which isn't nested too deeply.
+	RerootDeepHeadings DeepHeadingStrategy = "reroot"
 )
 
 //go:generate ./gentmpl.sh templates templates
+//go:generate ./gentmpl.sh htmlTemplates htmltemplates ./templates/html
+//go:generate ./gentmpl.sh asciiDocTemplates asciidoctemplates ./templates/asciidoc
+//go:generate ./gentmpl.sh confluenceTemplates confluencetemplates ./templates/confluence
+//go:generate ./gentmpl.sh rstTemplates rsttemplates ./templates/rst
+//go:generate ./gentmpl.sh manTemplates mantemplates ./templates/man
+
+// unsafeTemplateFuncs holds the names of the template functions, including
+// text/template builtins, which are disabled in safe template mode because
+// they can invoke arbitrary code or access the filesystem.
+var unsafeTemplateFuncs = []string{"call"}
+
+// sectionHeadings holds the text of the built-in section headings which can be
+// renamed with WithHeadings.
+var sectionHeadings = []string{
+	"Index",
+	"Constants",
+	"Variables",
+	"Output",
+	"C API",
+	"Documentation Statistics",
+	"Coverage by Package",
+	"Largest Undocumented Surfaces",
+	"Deprecated",
+	"Packages",
+	"Contents",
+	"Build Configuration",
+	"Linker Variables",
+	"Dependencies",
+	"Imported By",
+	"Benchmarks",
+	"Fuzzing",
+	"Class Diagram",
+	"Import Graph",
+	"Getting Started",
+	"Implementation",
+}
+
+// tableCellBreakRegex matches line breaks along with the whitespace around
+// them, which can't be included in the cells of a table.
+var tableCellBreakRegex = regexp.MustCompile(`\s*\n\s*`)
+
+// templateVariants holds the variants of the default templates for formats
+// implementing format.TemplateVariant, keyed by variant name.
+var templateVariants = map[string]map[string]string{
+	"html":       htmlTemplates,
+	"asciidoc":   asciiDocTemplates,
+	"confluence": confluenceTemplates,
+	"rst":        rstTemplates,
+	"man":        manTemplates,
+}
 
 // NewRenderer initializes a Renderer configured using the provided options. If
 // nothing special is provided, the created renderer will use the default set of
@@ -35,6 +127,8 @@ func NewRenderer(opts ...RendererOption) (*Renderer, error) {
 		templateOverrides: make(map[string]string),
 		format:            &format.GitHubFlavoredMarkdown{},
 		templateFuncs:     map[string]any{},
+		headings:          make(map[string]string),
+		deepHeadings:      ClampDeepHeadings,
 	}
 
 	for _, opt := range opts {
@@ -43,10 +137,21 @@ func NewRenderer(opts ...RendererOption) (*Renderer, error) {
 		}
 	}
 
+	if _, ok := renderer.format.(format.DeepHeaders); !ok && renderer.deepHeadings != ClampDeepHeadings {
+		return nil, fmt.Errorf("gomarkdoc: deep heading strategy %s is not supported by the format", renderer.deepHeadings)
+	}
+
+	var variants map[string]string
+	if tv, ok := renderer.format.(format.TemplateVariant); ok {
+		variants = templateVariants[tv.TemplateVariant()]
+	}
+
 	for name, tmplStr := range templates {
-		// Use the override if present
+		// Use the override if present, then the format's variant
 		if val, ok := renderer.templateOverrides[name]; ok {
 			tmplStr = val
+		} else if val, ok := variants[name]; ok {
+			tmplStr = val
 		}
 
 		if renderer.tmpl == nil {
@@ -88,6 +193,87 @@ func WithFormat(format format.Format) RendererOption {
 	}
 }
 
+// WithHeadings renames the built-in section headings (e.g. "Index" or
+// "Constants") used by the default templates, keyed by the default heading
+// text. The keys are case insensitive. Links to the renamed sections use the
+// new text as well.
+func WithHeadings(headings map[string]string) RendererOption {
+	return func(renderer *Renderer) error {
+		for name, text := range headings {
+			if !isSectionHeading(name) {
+				return fmt.Errorf(`gomarkdoc: invalid section heading "%s"`, name)
+			}
+
+			renderer.headings[strings.ToLower(name)] = text
+		}
+
+		return nil
+	}
+}
+
+// WithNumberedHeadings prefixes the section and symbol headings with
+// hierarchical numbers (e.g. 2.3.1). Headings at level 1, such as the name of
+// the package, are titles and aren't numbered, and each of them starts the
+// numbering again. Links to the built-in section headings keep working.
+func WithNumberedHeadings() RendererOption {
+	return func(renderer *Renderer) error {
+		renderer.numberedHeadings = true
+		return nil
+	}
+}
+
+// WithDeepHeadings changes how headings nested more deeply than the deepest
+// header level of the format (e.g. H6 in markdown) are rendered, such as the
+// headings of examples of methods in the documentation comment of a type.
+// Strategies other than ClampDeepHeadings are only supported by formats
+// implementing format.DeepHeaders, and headings rendered as labels or terms
+// keep their anchors so that links to them keep working.
+func WithDeepHeadings(strategy DeepHeadingStrategy) RendererOption {
+	return func(renderer *Renderer) error {
+		switch strategy {
+		case ClampDeepHeadings, BoldDeepHeadings, DefinitionDeepHeadings, RerootDeepHeadings:
+		default:
+			return fmt.Errorf("gomarkdoc: invalid deep heading strategy %s, expected clamp, bold, definition or reroot", strategy)
+		}
+
+		renderer.deepHeadings = strategy
+		return nil
+	}
+}
+
+// WithImportURLResolver changes the resolver used by the importURL template
+// function to find the urls of the documentation of other packages. Without
+// it, packages are linked to pkg.go.dev.
+func WithImportURLResolver(resolver *lang.ImportURLResolver) RendererOption {
+	return func(renderer *Renderer) error {
+		renderer.importURLs = resolver
+		return nil
+	}
+}
+
+// WithSafeTemplates restricts the functions available to templates so that
+// templates from untrusted sources (e.g. user-supplied repositories) can be
+// rendered safely. Functions which can invoke arbitrary code or access the
+// filesystem, such as the "call" builtin, fail when used. Functions added with
+// WithTemplateFunc are provided by the caller rather than the templates, so
+// they remain available.
+func WithSafeTemplates() RendererOption {
+	return func(renderer *Renderer) error {
+		renderer.safeTemplates = true
+		return nil
+	}
+}
+
+// WithLogger sets the logger the renderer reports its progress to. A
+// logger.PackageRenderedEvent is reported for each package rendered by File
+// or Package if the logger was created with logger.WithProgress.
+func WithLogger(log logger.Logger) RendererOption {
+	return func(renderer *Renderer) error {
+		renderer.log = log
+		return nil
+	}
+}
+
 // WithTemplateFunc adds the provided function with the given name to the list
 // of functions that can be used by the rendering templates.
 //
@@ -104,16 +290,55 @@ func WithTemplateFunc(name string, fn any) RendererOption {
 
 // File renders a file containing one or more packages to document to a string.
 // You can change the rendering of the file by overriding the "file" template
-// or one of the templates it references.
+// or one of the templates it references. If the renderer's format implements
+// format.FileRenderer, the format renders the file instead of the templates.
 func (out *Renderer) File(file *lang.File) (string, error) {
-	return out.writeTemplate("file", file)
+	var (
+		text string
+		err  error
+	)
+	if fr, ok := out.format.(format.FileRenderer); ok {
+		text, err = fr.RenderFile(file)
+	} else {
+		text, err = out.writeTemplate("file", file)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, pkg := range file.Packages {
+		out.reportRendered(pkg)
+	}
+
+	return text, nil
 }
 
 // Package renders a package's documentation to a string. You can change the
 // rendering of the package by overriding the "package" template or one of the
 // templates it references.
 func (out *Renderer) Package(pkg *lang.Package) (string, error) {
-	return out.writeTemplate("package", pkg)
+	text, err := out.writeTemplate("package", pkg)
+	if err != nil {
+		return "", err
+	}
+
+	out.reportRendered(pkg)
+
+	return text, nil
+}
+
+// reportRendered reports that the package's documentation has been rendered to
+// the renderer's logger, if there is one.
+func (out *Renderer) reportRendered(pkg *lang.Package) {
+	if out.log == nil {
+		return
+	}
+
+	logger.ReportProgress(out.log, logger.Progress{
+		Event:   logger.PackageRenderedEvent,
+		Package: pkg.ImportPath(),
+	})
 }
 
 // Func renders a function's documentation to a string. You can change the
@@ -130,6 +355,13 @@ func (out *Renderer) Type(typ *lang.Type) (string, error) {
 	return out.writeTemplate("type", typ)
 }
 
+// Value renders the documentation of a const or var declaration to a string.
+// You can change the rendering of the declaration by overriding the "value"
+// template or one of the templates it references.
+func (out *Renderer) Value(v *lang.Value) (string, error) {
+	return out.writeTemplate("value", v)
+}
+
 // Example renders an example's documentation to a string. You can change the
 // rendering of the example by overriding the "example" template or one of the
 // templates it references.
@@ -137,10 +369,43 @@ func (out *Renderer) Example(ex *lang.Example) (string, error) {
 	return out.writeTemplate("example", ex)
 }
 
+// Stats renders aggregate documentation statistics for a set of packages to a
+// string. You can change the rendering of the statistics by overriding the
+// "stats" template.
+func (out *Renderer) Stats(stats *lang.Stats) (string, error) {
+	return out.writeTemplate("stats", stats)
+}
+
+// Deprecated renders an appendix of the deprecated functions, types and methods
+// of a set of packages to a string, grouped by package. It is intended to be
+// used along with the lang.PackageWithDeprecatedHidden option. You can change
+// the rendering of the appendix by overriding the "deprecated" template.
+func (out *Renderer) Deprecated(pkgs []*lang.Package) (string, error) {
+	return out.writeTemplate("deprecated", pkgs)
+}
+
+// GettingStarted renders a guided walkthrough of the ordered package-level
+// examples of a set of packages to a string. You can change the rendering of
+// the walkthrough by overriding the "gettingstarted" template.
+func (out *Renderer) GettingStarted(g *lang.GettingStarted) (string, error) {
+	return out.writeTemplate("gettingstarted", g)
+}
+
+// Packages renders an index of a set of packages organized by directory to a
+// string, listing the summary of each package with a link to its
+// documentation. You can change the rendering of the index by overriding the
+// "packages" template, or the "packagetree" template for the list itself and
+// the "importgraph" template for the import graph of the packages.
+func (out *Renderer) Packages(tree *lang.PackageTree) (string, error) {
+	return out.writeTemplate("packages", tree)
+}
+
 // writeTemplate renders the template of the provided name using the provided
 // data object to a string. It uses the set of templates provided to the
 // renderer as a template library.
 func (out *Renderer) writeTemplate(name string, data interface{}) (string, error) {
+	out.headingLevels, out.headingNumbers = nil, nil
+
 	var result strings.Builder
 	if err := out.tmpl.ExecuteTemplate(&result, name, data); err != nil {
 		return "", err
@@ -167,6 +432,27 @@ func (out *Renderer) getTemplate(name string) *template.Template {
 		"hangingIndent": func(s string, n int) string {
 			return strings.ReplaceAll(s, "\n", fmt.Sprintf("\n%s", strings.Repeat(" ", n)))
 		},
+		"tableCell": func(s string) string {
+			s = tableCellBreakRegex.ReplaceAllString(strings.TrimSpace(s), " ")
+
+			// Pipes may already be escaped by the format
+			s = strings.ReplaceAll(s, `\|`, "|")
+			return strings.ReplaceAll(s, "|", `\|`)
+		},
+		"heading": func(name string) string {
+			if text, ok := out.headings[strings.ToLower(name)]; ok {
+				return text
+			}
+
+			return name
+		},
+		"importURL": func(importPath string, symbol ...string) (string, error) {
+			if len(symbol) > 1 {
+				return "", fmt.Errorf("renderer: importURL accepts at most one symbol")
+			}
+
+			return out.importURLs.Resolve(importPath, strings.Join(symbol, ""))
+		},
 		"include": func(name string, data any) (string, error) {
 			var b strings.Builder
 			err := tmpl.ExecuteTemplate(&b, name, data)
@@ -176,6 +462,7 @@ func (out *Renderer) getTemplate(name string) *template.Template {
 
 			return b.String(), nil
 		},
+		"compactSignature": compactSignature,
 		"iter": func(l any) (any, error) {
 			type iter struct {
 				First bool
@@ -204,13 +491,15 @@ func (out *Renderer) getTemplate(name string) *template.Template {
 
 		"bold":                out.format.Bold,
 		"anchor":              out.format.Anchor,
-		"anchorHeader":        out.format.AnchorHeader,
-		"header":              out.format.Header,
-		"rawAnchorHeader":     out.format.RawAnchorHeader,
-		"rawHeader":           out.format.RawHeader,
+		"anchorHeader":        out.anchorHeader,
+		"header":              out.header,
+		"rawAnchorHeader":     out.rawAnchorHeader,
+		"rawHeader":           out.rawHeader,
 		"codeBlock":           out.format.CodeBlock,
 		"link":                out.format.Link,
 		"listEntry":           out.format.ListEntry,
+		"badge":               out.Badge,
+		"comment":             out.Comment,
 		"accordion":           out.format.Accordion,
 		"accordionHeader":     out.format.AccordionHeader,
 		"accordionTerminator": out.format.AccordionTerminator,
@@ -220,6 +509,28 @@ func (out *Renderer) getTemplate(name string) *template.Template {
 		"escape":              out.format.Escape,
 	}
 
+	if out.numberedHeadings {
+		baseTemplateFuncs["header"] = out.numberedHeader
+		baseTemplateFuncs["anchorHeader"] = func(level int, text, anchor string) (string, error) {
+			return out.anchorHeader(level, out.numberHeading(level, text), anchor)
+		}
+		baseTemplateFuncs["rawHeader"] = func(level int, text string) (string, error) {
+			return out.rawHeader(level, out.numberHeading(level, text))
+		}
+		baseTemplateFuncs["rawAnchorHeader"] = func(level int, text, anchor string) (string, error) {
+			return out.rawAnchorHeader(level, out.numberHeading(level, text), anchor)
+		}
+	}
+
+	helpers := HelperFuncs()
+	if out.safeTemplates {
+		helpers = SafeHelperFuncs()
+	}
+
+	for n, f := range helpers {
+		baseTemplateFuncs[n] = f
+	}
+
 	for n, f := range out.templateFuncs {
 		baseTemplateFuncs[n] = f
 	}
@@ -227,3 +538,212 @@ func (out *Renderer) getTemplate(name string) *template.Template {
 	tmpl.Funcs(baseTemplateFuncs)
 	return tmpl
 }
+
+// numberedHeader renders a numbered header. Links to headers are generated from
+// their text, so numbered headers are given an anchor matching the text
+// without the number if the format supports links within the document.
+func (out *Renderer) numberedHeader(level int, text string) (string, error) {
+	numbered := out.numberHeading(level, text)
+	if numbered == text {
+		return out.header(level, text)
+	}
+
+	anchor, err := out.localAnchor(text)
+	if err != nil {
+		return "", err
+	}
+
+	if anchor != "" {
+		return out.anchorHeader(level, numbered, anchor)
+	}
+
+	return out.header(level, numbered)
+}
+
+// header renders a header, applying the deep heading strategy.
+func (out *Renderer) header(level int, text string) (string, error) {
+	level, deep := out.headerLevel(level)
+	if !deep {
+		return out.format.Header(level, text)
+	}
+
+	anchor, err := out.localAnchor(text)
+	if err != nil {
+		return "", err
+	}
+
+	return out.deepHeader(out.format.Escape(text), anchor)
+}
+
+// anchorHeader renders a header with a custom anchor, applying the deep
+// heading strategy.
+func (out *Renderer) anchorHeader(level int, text, anchor string) (string, error) {
+	level, deep := out.headerLevel(level)
+	if !deep {
+		return out.format.AnchorHeader(level, text, anchor)
+	}
+
+	return out.deepHeader(out.format.Escape(text), anchor)
+}
+
+// rawHeader renders a header without escaping its text, applying the deep
+// heading strategy.
+func (out *Renderer) rawHeader(level int, text string) (string, error) {
+	level, deep := out.headerLevel(level)
+	if !deep {
+		return out.format.RawHeader(level, text)
+	}
+
+	anchor, err := out.localAnchor(text)
+	if err != nil {
+		return "", err
+	}
+
+	return out.deepHeader(text, anchor)
+}
+
+// rawAnchorHeader renders a header with a custom anchor without escaping its
+// text, applying the deep heading strategy.
+func (out *Renderer) rawAnchorHeader(level int, text, anchor string) (string, error) {
+	level, deep := out.headerLevel(level)
+	if !deep {
+		return out.format.RawAnchorHeader(level, text, anchor)
+	}
+
+	return out.deepHeader(text, anchor)
+}
+
+// headerLevel provides the level a header at the provided level is rendered
+// at, and whether it is nested too deeply to be rendered as a header at all.
+// Headers which aren't nested too deeply end any re-rooting.
+func (out *Renderer) headerLevel(level int) (int, bool) {
+	deep, ok := out.format.(format.DeepHeaders)
+	if !ok || out.deepHeadings == ClampDeepHeadings {
+		return level, false
+	}
+
+	max := deep.MaxHeaderLevel()
+	if out.deepHeadings != RerootDeepHeadings {
+		return level, level > max
+	}
+
+	switch {
+	case level <= max:
+		out.rerootOffset = 0
+	case out.rerootOffset == 0 || level-out.rerootOffset > max || level-out.rerootOffset < 2:
+		out.rerootOffset = level - 2
+	}
+
+	return level - out.rerootOffset, false
+}
+
+// deepHeader renders the already escaped text of a header nested too deeply to
+// be rendered as a header, preceded by the provided anchor if there is one.
+func (out *Renderer) deepHeader(text, anchor string) (string, error) {
+	deep := out.format.(format.DeepHeaders)
+
+	var (
+		label string
+		err   error
+	)
+	if out.deepHeadings == DefinitionDeepHeadings {
+		label, err = deep.RawDefinitionTerm(text)
+	} else {
+		label, err = deep.RawLabel(text)
+	}
+
+	if err != nil || anchor == "" {
+		return label, err
+	}
+
+	return fmt.Sprintf("%s\n%s", out.format.Anchor(anchor), label), nil
+}
+
+// localAnchor provides the anchor links to the header with the provided text
+// point to, or an empty string if the format doesn't support links within the
+// document.
+func (out *Renderer) localAnchor(text string) (string, error) {
+	href, err := out.format.LocalHref(text)
+	if err != nil {
+		return "", err
+	}
+
+	if anchor := strings.TrimPrefix(href, "#"); anchor != href {
+		return anchor, nil
+	}
+
+	return "", nil
+}
+
+// numberHeading counts a heading at the provided level and prefixes its text
+// with its number. Headings are numbered by how deeply they are nested rather
+// than by their level, so levels skipped between a heading and the ones around
+// it don't leave gaps in the numbers.
+func (out *Renderer) numberHeading(level int, text string) string {
+	if level <= 1 {
+		out.headingLevels, out.headingNumbers = nil, nil
+		return text
+	}
+
+	depth := 0
+	for depth < len(out.headingLevels) && out.headingLevels[depth] < level {
+		depth++
+	}
+
+	if depth < len(out.headingLevels) {
+		// A heading at the same depth as this one continues its numbering,
+		// even if it was at a deeper level
+		out.headingLevels = append(out.headingLevels[:depth], level)
+		out.headingNumbers = out.headingNumbers[:depth+1]
+		out.headingNumbers[depth]++
+	} else {
+		out.headingLevels = append(out.headingLevels, level)
+		out.headingNumbers = append(out.headingNumbers, 1)
+	}
+
+	parts := make([]string, len(out.headingNumbers))
+	for i, n := range out.headingNumbers {
+		parts[i] = strconv.Itoa(n)
+	}
+
+	return fmt.Sprintf("%s %s", strings.Join(parts, "."), text)
+}
+
+// Badge renders a badge image with the provided alt text and image url which
+// links to the provided href, or no link if the href is empty. Formats which
+// can't render badges show the alt text instead. The badges of a package are
+// provided by lang.Package.Badges.
+func (out *Renderer) Badge(text, image, href string) (string, error) {
+	if b, ok := out.format.(format.BadgeRenderer); ok {
+		return b.Badge(text, image, href)
+	}
+
+	return out.format.Escape(text), nil
+}
+
+// Comment renders a comment with the provided text which is not visible in the
+// rendered output. HTML comments are used for formats without their own
+// syntax for comments.
+func (out *Renderer) Comment(text string) (string, error) {
+	if c, ok := out.format.(format.CommentRenderer); ok {
+		return c.Comment(text)
+	}
+
+	return formatcore.Comment(text), nil
+}
+
+func disallowedTemplateFunc(name string) func(args ...any) (string, error) {
+	return func(args ...any) (string, error) {
+		return "", fmt.Errorf("gomarkdoc: template function %s is not allowed in safe template mode", name)
+	}
+}
+
+func isSectionHeading(name string) bool {
+	for _, heading := range sectionHeadings {
+		if strings.EqualFold(heading, name) {
+			return true
+		}
+	}
+
+	return false
+}
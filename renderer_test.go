@@ -1,6 +1,7 @@
 package gomarkdoc_test
 
 import (
+	"encoding/json"
 	"errors"
 	"go/build"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/anthonyme00/gomarkdoc"
+	"github.com/anthonyme00/gomarkdoc/format"
 	"github.com/anthonyme00/gomarkdoc/format/formatcore"
 	"github.com/anthonyme00/gomarkdoc/lang"
 	"github.com/anthonyme00/gomarkdoc/logger"
@@ -40,6 +42,379 @@ func TestWithTemplateFunc(t *testing.T) {
 	is.True(strings.Contains(f2, "FUNC IS PRESENT IN THIS FILE."))
 }
 
+func TestWithFormat_json(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/lang/function")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer(gomarkdoc.WithFormat(format.JSON))
+	is.NoErr(err)
+
+	text, err := r.File(lang.NewFile("", "", []*lang.Package{pkg}))
+	is.NoErr(err)
+
+	var out struct {
+		Packages []struct {
+			Name  string
+			Types []struct {
+				Name    string
+				Methods []struct {
+					Name      string
+					Signature string
+				}
+			}
+		}
+	}
+	is.NoErr(json.Unmarshal([]byte(text), &out))
+
+	is.Equal(len(out.Packages), 1)
+	is.Equal(out.Packages[0].Name, "function")
+	is.Equal(len(out.Packages[0].Types), 2)
+	is.Equal(out.Packages[0].Types[1].Name, "Receiver")
+	is.Equal(len(out.Packages[0].Types[1].Methods), 2)
+	is.Equal(out.Packages[0].Types[1].Methods[0].Signature, "func (r *Receiver) WithPtrReceiver()")
+}
+
+func TestWithFormat_html(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/lang/function")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer(gomarkdoc.WithFormat(format.HTML))
+	is.NoErr(err)
+
+	text, err := r.File(lang.NewFile("", "", []*lang.Package{pkg}))
+	is.NoErr(err)
+
+	is.True(strings.HasPrefix(text, "<!DOCTYPE html>\n"))
+	is.True(strings.Contains(text, "<title>function</title>"))
+	is.True(strings.Contains(text, `<li><a href="#Receiver.WithPtrReceiver">func (r *Receiver) WithPtrReceiver()</a></li>`))
+	is.True(strings.Contains(text, "<p>New is an initializer for Receiver.</p>"))
+	is.True(strings.HasSuffix(text, "</html>\n"))
+}
+
+func TestWithFormat_confluence(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/docs")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer(gomarkdoc.WithFormat(format.Confluence))
+	is.NoErr(err)
+
+	text, err := r.Package(pkg)
+	is.NoErr(err)
+
+	is.True(strings.HasPrefix(text, "h1. docs\n"))
+	is.True(strings.Contains(text, "It also has a numbered list:\n\n# First\n# Second\n# Third\n"))
+	is.True(strings.Contains(text, "Non\\-numbered lists\n\n* First another line\n* Second\n* Third\n"))
+}
+
+func TestWithFormat_rst(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/docs")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer(gomarkdoc.WithFormat(format.RST))
+	is.NoErr(err)
+
+	text, err := r.Package(pkg)
+	is.NoErr(err)
+
+	is.True(strings.HasPrefix(text, "docs\n====\n"))
+	is.True(strings.Contains(text, "It also has a numbered list:\n\n#. First\n#. Second\n#. Third\n"))
+	is.True(strings.Contains(text, "Non-numbered lists\n\n- First another line\n- Second\n- Third\n"))
+}
+
+func TestWithFormat_man(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/docs")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer(gomarkdoc.WithFormat(format.Man))
+	is.NoErr(err)
+
+	text, err := r.Package(pkg)
+	is.NoErr(err)
+
+	is.True(strings.HasPrefix(text, ".TH \"docs\" 3\n"))
+	is.True(strings.Contains(text, ".PP\nIt also has a numbered list:\n.IP 1. 4\nFirst\n.IP 2. 4\nSecond\n.IP 3. 4\nThird\n"))
+	is.True(strings.Contains(text, "a function in the file Func, a type Type,"))
+}
+
+func TestRenderer_fieldsTable(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/lang/embedded")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer()
+	is.NoErr(err)
+
+	text, err := r.Package(pkg)
+	is.NoErr(err)
+
+	is.True(strings.Contains(text, "| Field | Type | Tag | Description |\n| --- | --- | --- | --- |\n"))
+	is.True(strings.Contains(text, "| Verbose | bool | json:\"verbose\" yaml:\"verbose\" | Verbose enables verbose output. Output is written to stderr. It defaults to false. |\n"))
+	is.True(strings.Contains(text, "| Tags | \\[\\]string | json:\"tags,omitempty\" | Tags holds a \\| separated list. |\n"))
+}
+
+func TestRenderer_docLinks(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/lang/doclinks")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer()
+	is.NoErr(err)
+
+	text, err := r.Package(pkg)
+	is.NoErr(err)
+
+	is.True(strings.Contains(text, "[Client](<#Client>)"))
+	is.True(strings.Contains(text, "[\\*Client.Do\\_all](<#Client.Do_all>)")) // escaped only once
+	is.True(strings.Contains(text, "[strings.Builder](<https://pkg.go.dev/strings#Builder>)"))
+
+	r, err = gomarkdoc.NewRenderer(gomarkdoc.WithFormat(format.HTML))
+	is.NoErr(err)
+
+	text, err = r.Package(pkg)
+	is.NoErr(err)
+
+	is.True(strings.Contains(text, `<a href="#Client.Do_all">*Client.Do_all</a>`))
+}
+
+func TestWithFormat_htmlAutolinks(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/lang/structure")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer(gomarkdoc.WithFormat(format.HTML))
+	is.NoErr(err)
+
+	text, err := r.Package(pkg)
+	is.NoErr(err)
+
+	is.True(strings.Contains(text, `<p>See <a href="https://example.com/docs">https://example.com/docs</a> for more.</p>`))
+	is.True(strings.Contains(text, "<ol>\n<li><p>first step</p></li>"))
+}
+
+func TestWithFormat_htmlOverride(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/lang/function")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer(
+		gomarkdoc.WithFormat(format.HTML),
+		gomarkdoc.WithTemplateOverride("file", "{{range .Packages}}{{.Name}}{{end}}"),
+	)
+	is.NoErr(err)
+
+	text, err := r.File(lang.NewFile("", "", []*lang.Package{pkg}))
+	is.NoErr(err)
+	is.Equal(text, "function")
+}
+
+func TestRenderer_Deprecated(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/lang/hidedeprecated")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer()
+	is.NoErr(err)
+
+	text, err := r.Deprecated([]*lang.Package{pkg})
+	is.NoErr(err)
+
+	is.True(strings.Contains(text, "# Deprecated\n"))
+	is.True(strings.Contains(text, "## "+pkg.ImportPath()+"\n"))
+	is.True(strings.Contains(text, "### func Dial\n"))
+	is.True(strings.Contains(text, "### type Client\n"))
+	is.True(strings.Contains(text, "#### func \\(\\*Client\\) Close\n"))
+	is.True(!strings.Contains(text, "func \\(\\*Server\\) Start"))
+}
+
+func TestRenderer_tableOfContents(t *testing.T) {
+	is := is.New(t)
+
+	function, err := loadPackage("./testData/lang/function")
+	is.NoErr(err)
+
+	simple, err := loadPackage("./testData/simple")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer()
+	is.NoErr(err)
+
+	file := lang.NewFile("", "", []*lang.Package{function})
+	file.TableOfContents = true
+
+	text, err := r.File(file)
+	is.NoErr(err)
+	is.True(strings.Contains(text, "**Contents**\n\n- [const ConstA, ConstB](<#ConstA>)\n- [var Variable](<#Variable>)\n"))
+	is.True(strings.Contains(text, "\n  - [func New](<#New>)\n"))
+
+	// Symbols are nested under their package when there are several
+	file = lang.NewFile("", "", []*lang.Package{function, simple})
+	file.TableOfContents = true
+
+	text, err = r.File(file)
+	is.NoErr(err)
+	is.True(strings.Contains(text, "- [function](<#function>)\n  - [const ConstA, ConstB](<#ConstA>)\n"))
+	is.True(strings.Contains(text, "- [simple](<#simple>)\n  - [type Num](<#Num>)\n    - [func AddNums](<#AddNums>)\n"))
+
+	r, err = gomarkdoc.NewRenderer(gomarkdoc.WithFormat(format.HTML))
+	is.NoErr(err)
+
+	text, err = r.File(file)
+	is.NoErr(err)
+	is.True(strings.Contains(text, "<p><strong>Contents</strong></p>"))
+	is.True(strings.Contains(text, `<li><a href="#function">function</a>`))
+}
+
+func TestHelperFuncs(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/lang/function")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer(gomarkdoc.WithTemplateOverride(
+		"file",
+		`{{semverCompare "1.2.0" "v1.10.0"}} {{semverValid "junk"}} {{semverMajor "v2.3.4"}} {{relTime "2000-01-01T00:00:00Z"}}`,
+	))
+	is.NoErr(err)
+
+	text, err := r.File(lang.NewFile("", "", []*lang.Package{pkg}))
+	is.NoErr(err)
+	is.True(strings.HasPrefix(text, "-1 false v2 "))
+	is.True(strings.HasSuffix(text, " years ago"))
+}
+
+func TestCompactSignature(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/lang/embedded")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer(gomarkdoc.WithTemplateOverride(
+		"package",
+		`{{range .Types}}{{if eq .Name "Options"}}{{compactSignature .Decl}}{{end}}{{end}}`,
+	))
+	is.NoErr(err)
+
+	text, err := r.Package(pkg)
+	is.NoErr(err)
+	is.Equal(text, "type Options struct {\n\tVerbose bool     `json:\"verbose\" yaml:\"verbose\"`\n\tTags    []string `json:\"tags,omitempty\"`\n\tRetries int\n}")
+}
+
+func TestWithHeadings(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/lang/function")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer(gomarkdoc.WithHeadings(map[string]string{
+		"Index":     "Contents",
+		"constants": "Named Values",
+	}))
+	is.NoErr(err)
+
+	text, err := r.Package(pkg)
+	is.NoErr(err)
+
+	is.True(strings.Contains(text, "\n## Contents\n"))
+	is.True(strings.Contains(text, "- [Named Values](<#named-values>)\n"))
+	is.True(strings.Contains(text, "\n## Named Values\n"))
+	is.True(strings.Contains(text, "\n## Variables\n"))
+}
+
+func TestWithHeadings_invalid(t *testing.T) {
+	is := is.New(t)
+
+	_, err := gomarkdoc.NewRenderer(gomarkdoc.WithHeadings(map[string]string{
+		"Functions": "Operations",
+	}))
+	is.Equal(err.Error(), `gomarkdoc: invalid section heading "Functions"`)
+}
+
+func TestWithNumberedHeadings(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/lang/function")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer(gomarkdoc.WithNumberedHeadings())
+	is.NoErr(err)
+
+	text, err := r.Package(pkg)
+	is.NoErr(err)
+
+	is.True(strings.HasPrefix(text, "# function\n"))
+	is.True(strings.Contains(text, "- [Constants](<#constants>)\n"))
+	is.True(strings.Contains(text, "<a name=\"constants\"></a>\n## 2 Constants\n"))
+	is.True(strings.Contains(text, "\n### 4.1 Header A\n"))
+	is.True(strings.Contains(text, "\n## 6 type Receiver\n"))
+	is.True(strings.Contains(text, "\n### 6.1 func New\n"))
+
+	// Numbering starts again for each output
+	again, err := r.Package(pkg)
+	is.NoErr(err)
+	is.Equal(again, text)
+}
+
+func TestWithImportURLResolver(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/lang/function")
+	is.NoErr(err)
+
+	resolver, err := lang.NewImportURLResolver(map[string]string{
+		"github.com/anthonyme00/gomarkdoc": "/docs/{{.Path}}",
+	})
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer(
+		gomarkdoc.WithImportURLResolver(resolver),
+		gomarkdoc.WithTemplateOverride("package", `{{importURL "github.com/anthonyme00/gomarkdoc/lang"}} {{importURL "net/http" "Client"}}`),
+	)
+	is.NoErr(err)
+
+	text, err := r.Package(pkg)
+	is.NoErr(err)
+	is.Equal(text, "/docs/lang https://pkg.go.dev/net/http#Client")
+}
+
+func TestWithSafeTemplates(t *testing.T) {
+	is := is.New(t)
+
+	fn, err := loadFunc("./testData/docs", "Func")
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer(gomarkdoc.WithSafeTemplates())
+	is.NoErr(err)
+
+	f, err := r.Func(fn)
+	is.NoErr(err)
+	is.True(strings.Contains(f, "Func is present in this file."))
+
+	r2, err := gomarkdoc.NewRenderer(
+		gomarkdoc.WithSafeTemplates(),
+		gomarkdoc.WithTemplateOverride("func", `{{call .Name}}`),
+	)
+	is.NoErr(err)
+
+	_, err = r2.Func(fn)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "gomarkdoc: template function call is not allowed in safe template mode"))
+}
+
 func getBuildPackage(path string) (*build.Package, error) {
 	wd, err := os.Getwd()
 	if err != nil {
@@ -49,14 +424,18 @@ func getBuildPackage(path string) (*build.Package, error) {
 	return build.Import(path, wd, build.ImportComment)
 }
 
-func loadFunc(dir, name string) (*lang.Func, error) {
+func loadPackage(dir string) (*lang.Package, error) {
 	buildPkg, err := getBuildPackage(dir)
 	if err != nil {
 		return nil, err
 	}
 
 	log := logger.New(logger.ErrorLevel)
-	pkg, err := lang.NewPackageFromBuild(log, buildPkg)
+	return lang.NewPackageFromBuild(log, buildPkg)
+}
+
+func loadFunc(dir, name string) (*lang.Func, error) {
+	pkg, err := loadPackage(dir)
 	if err != nil {
 		return nil, err
 	}
@@ -83,3 +462,115 @@ func loadFunc(dir, name string) (*lang.Func, error) {
 
 	return nil, errors.New("func not found")
 }
+
+func TestRenderer_progress(t *testing.T) {
+	is := is.New(t)
+
+	var events []logger.Progress
+	log := logger.New(logger.ErrorLevel, logger.WithProgress(func(p logger.Progress) {
+		events = append(events, p)
+	}))
+
+	buildPkg, err := getBuildPackage("./testData/simple")
+	is.NoErr(err)
+
+	pkg, err := lang.NewPackageFromBuild(log, buildPkg)
+	is.NoErr(err)
+
+	r, err := gomarkdoc.NewRenderer(gomarkdoc.WithLogger(log))
+	is.NoErr(err)
+
+	_, err = r.File(lang.NewFile("", "", []*lang.Package{pkg}))
+	is.NoErr(err)
+
+	is.Equal(len(events), 2)
+	is.Equal(events[0].Event, logger.PackageStartedEvent)
+	is.Equal(events[1].Event, logger.PackageRenderedEvent)
+	is.Equal(events[1].Package, pkg.ImportPath())
+}
+
+func TestWithDeepHeadings(t *testing.T) {
+	is := is.New(t)
+
+	pkg, err := loadPackage("./testData/lang/function")
+	is.NoErr(err)
+
+	tmpl := `{{- header 1 .Name }}
+
+{{ header 6 "Method" }}
+
+{{ header 7 "Example" }}
+
+{{ header 8 "Output" }}
+
+{{ rawAnchorHeader 7 "Raw *Example*" "raw" }}
+
+{{ header 5 "Next" }}`
+
+	render := func(opts ...gomarkdoc.RendererOption) string {
+		r, err := gomarkdoc.NewRenderer(append(opts, gomarkdoc.WithTemplateOverride("package", tmpl))...)
+		is.NoErr(err)
+
+		text, err := r.Package(pkg)
+		is.NoErr(err)
+
+		return text
+	}
+
+	is.Equal(render(), "# function\n\n###### Method\n\n###### Example\n\n###### Output\n\n<a name=\"raw\"></a>\n###### Raw *Example*\n\n##### Next")
+	is.Equal(render(gomarkdoc.WithDeepHeadings(gomarkdoc.BoldDeepHeadings)), "# function\n\n###### Method\n\n<a name=\"example\"></a>\n**Example**\n\n<a name=\"output\"></a>\n**Output**\n\n<a name=\"raw\"></a>\n**Raw *Example***\n\n##### Next")
+	is.Equal(render(gomarkdoc.WithDeepHeadings(gomarkdoc.DefinitionDeepHeadings)), "# function\n\n###### Method\n\n<a name=\"example\"></a>\n<dl><dt>\n\nExample\n\n</dt></dl>\n\n<a name=\"output\"></a>\n<dl><dt>\n\nOutput\n\n</dt></dl>\n\n<a name=\"raw\"></a>\n<dl><dt>\n\nRaw *Example*\n\n</dt></dl>\n\n##### Next")
+	is.Equal(render(gomarkdoc.WithDeepHeadings(gomarkdoc.RerootDeepHeadings)), "# function\n\n###### Method\n\n## Example\n\n### Output\n\n<a name=\"raw\"></a>\n## Raw *Example*\n\n##### Next")
+}
+
+func TestWithDeepHeadings_unsupported(t *testing.T) {
+	is := is.New(t)
+
+	_, err := gomarkdoc.NewRenderer(gomarkdoc.WithDeepHeadings("nested"))
+	is.Equal(err.Error(), "gomarkdoc: invalid deep heading strategy nested, expected clamp, bold, definition or reroot")
+
+	_, err = gomarkdoc.NewRenderer(gomarkdoc.WithFormat(format.RST), gomarkdoc.WithDeepHeadings(gomarkdoc.BoldDeepHeadings))
+	is.Equal(err.Error(), "gomarkdoc: deep heading strategy bold is not supported by the format")
+}
+
+// basicFormat wraps a format without exposing its optional interfaces, such as
+// format.BadgeRenderer and format.CommentRenderer.
+type basicFormat struct {
+	format.Format
+}
+
+func TestRenderer_Badge(t *testing.T) {
+	is := is.New(t)
+
+	r, err := gomarkdoc.NewRenderer()
+	is.NoErr(err)
+
+	badge, err := r.Badge("Go Reference", "https://pkg.go.dev/badge/example.com/pkg.svg", "https://pkg.go.dev/example.com/pkg")
+	is.NoErr(err)
+	is.Equal(badge, "[![Go Reference](<https://pkg.go.dev/badge/example.com/pkg.svg>)](<https://pkg.go.dev/example.com/pkg>)")
+
+	r, err = gomarkdoc.NewRenderer(gomarkdoc.WithFormat(basicFormat{format.GitHub}))
+	is.NoErr(err)
+
+	badge, err = r.Badge("Docs *Coverage*", "https://img.shields.io/badge/docs-100%25-green", "")
+	is.NoErr(err)
+	is.Equal(badge, `Docs \*Coverage\*`)
+}
+
+func TestRenderer_Comment(t *testing.T) {
+	is := is.New(t)
+
+	r, err := gomarkdoc.NewRenderer(gomarkdoc.WithFormat(format.AsciiDoc))
+	is.NoErr(err)
+
+	comment, err := r.Comment("Code generated by gomarkdoc. DO NOT EDIT")
+	is.NoErr(err)
+	is.Equal(comment, "// Code generated by gomarkdoc. DO NOT EDIT")
+
+	r, err = gomarkdoc.NewRenderer(gomarkdoc.WithFormat(basicFormat{format.AsciiDoc}))
+	is.NoErr(err)
+
+	comment, err = r.Comment("Code generated by gomarkdoc. DO NOT EDIT")
+	is.NoErr(err)
+	is.Equal(comment, "<!-- Code generated by gomarkdoc. DO NOT EDIT -->")
+}
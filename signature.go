@@ -0,0 +1,35 @@
+package gomarkdoc
+
+import (
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// compactSignature strips the comments and blank lines from the code of a
+// declaration (e.g. the Decl of a type or the Signature of a function) and
+// formats what remains with go/format, so that templates can show a compact
+// signature. Parameter lists spread across several lines are joined onto one.
+func compactSignature(decl string) (string, error) {
+	// Comments are dropped by parsing without them, and blank lines by
+	// printing without the positions of the original code
+	f, err := parser.ParseFile(token.NewFileSet(), "", fmt.Sprintf("package p\n\n%s", decl), parser.SkipObjectResolution)
+	if err != nil {
+		return "", fmt.Errorf("gomarkdoc: unable to parse declaration for compact signature: %w", err)
+	}
+
+	var b strings.Builder
+	for i, d := range f.Decls {
+		if i > 0 {
+			b.WriteRune('\n')
+		}
+
+		if err := format.Node(&b, token.NewFileSet(), d); err != nil {
+			return "", fmt.Errorf("gomarkdoc: unable to format compact signature: %w", err)
+		}
+	}
+
+	return b.String(), nil
+}
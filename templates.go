@@ -3,6 +3,139 @@
 package gomarkdoc
 
 var templates = map[string]string{
+	"badges": `{{- range (iter .Badges) -}}
+	{{- badge .Entry.Text .Entry.Image .Entry.URL -}}
+	{{- if (not .Last) -}}{{- inlineSpacer -}}{{- end -}}
+{{- end -}}
+`,
+	"benchmark": `{{- rawHeader .Level (codeHref .Location | link (escape .Name)) -}}
+{{- spacer -}}
+
+{{- if len .Doc.Blocks -}}
+	{{- template "doc" .Doc -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- codeBlock "go" .Code -}}
+`,
+	"benchmarks": `{{- header (add .Level 1) (heading "Benchmarks") -}}
+{{- spacer -}}
+
+{{- range (iter .Benchmarks) -}}
+	{{- template "benchmark" .Entry -}}
+	{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+{{- end -}}
+`,
+	"builddep": `{{- $link := link (escape .ImportPath) (importURL .ImportPath) -}}
+{{- if .IsStandard -}}
+	{{- printf "%s (standard library)" $link | listEntry 0 -}}
+{{- else if .Version -}}
+	{{- printf "%s (%s)" $link (escape (printf "%s %s" .Module .Version)) | listEntry 0 -}}
+{{- else -}}
+	{{- listEntry 0 $link -}}
+{{- end -}}
+`,
+	"buildinfo": `{{- header (add .Level 1) (heading "Build Configuration") -}}
+
+{{- if len .BuildVars -}}
+	{{- spacer -}}
+
+	{{- header (add .Level 2) (heading "Linker Variables") -}}
+	{{- spacer -}}
+
+	{{- range (iter .BuildVars) -}}
+		{{- template "buildvar" .Entry -}}
+		{{- if (not .Last) -}}{{- inlineSpacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if len .BuildDependencies -}}
+	{{- spacer -}}
+
+	{{- header (add .Level 2) (heading "Dependencies") -}}
+	{{- spacer -}}
+
+	{{- range (iter .BuildDependencies) -}}
+		{{- template "builddep" .Entry -}}
+		{{- if (not .Last) -}}{{- inlineSpacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+`,
+	"buildvar": `{{- $text := printf "-X %s=..." .Target -}}
+{{- if .Default -}}{{- $text = printf "%s (default %q)" $text .Default -}}{{- end -}}
+{{- if .Summary -}}{{- $text = printf "%s: %s" $text .Summary -}}{{- end -}}
+{{- escape $text | listEntry 0 -}}
+`,
+	"capi": `{{- header (add .Level 1) (heading "C API") -}}
+{{- spacer -}}
+
+{{- range (iter .CDecls) -}}
+	{{- codeBlock "c" .Entry.Decl -}}
+	{{- spacer -}}
+
+	{{- template "doc" .Entry.Doc -}}
+	{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+{{- end -}}
+
+{{- if and (len .CDecls) (len .CExports) -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- range (iter .CExports) -}}
+	{{- rawAnchorHeader .Entry.Level (codeHref .Entry.Location | link (escape .Entry.Name) | printf "func C.%s") .Entry.Anchor -}}
+	{{- spacer -}}
+
+	{{- codeBlock "go" .Entry.Signature -}}
+
+	{{- if len .Entry.Doc.Blocks -}}
+		{{- spacer -}}
+		{{- template "doc" .Entry.Doc -}}
+	{{- end -}}
+
+	{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+{{- end -}}
+`,
+	"classdiagram": `{{- header (add .Level 1) (heading "Class Diagram") -}}
+{{- spacer -}}
+
+{{- codeBlock "mermaid" .ClassDiagram -}}
+`,
+	"constvalues": `| Constant | Value |{{- inlineSpacer -}}
+| --- | --- |
+{{- range .ConstValues -}}
+	{{- inlineSpacer -}}
+	| {{ tableCell (escape .Name) }} | {{ tableCell (escape .Value) }} |
+{{- end -}}
+`,
+	"deprecated": `{{comment "Code generated by gomarkdoc. DO NOT EDIT"}}
+
+{{header 1 (heading "Deprecated") -}}
+
+{{- range . -}}
+	{{- if or (len .DeprecatedFuncs) (len .DeprecatedTypes) -}}
+		{{- spacer -}}
+
+		{{- if eq .Name "main" -}}
+			{{- header 2 .Dirname -}}
+		{{- else -}}
+			{{- header 2 .ImportPath -}}
+		{{- end -}}
+
+		{{- range .DeprecatedFuncs -}}
+			{{- spacer -}}
+			{{- template "func" . -}}
+		{{- end -}}
+
+		{{- range .DeprecatedTypes -}}
+			{{- spacer -}}
+			{{- template "type" . -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- spacer -}}
+Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
+`,
 	"doc": `{{- range (iter .Blocks) -}}
 	{{- if eq .Entry.Kind "paragraph" -}}
 		{{- template "text" .Entry.Spans -}}
@@ -25,9 +158,14 @@ var templates = map[string]string{
 {{- codeBlock "go" .Code -}}
 {{- spacer -}}
 
+{{- with .PlaygroundURL -}}
+	{{- link (escape "Run in Go Playground") . -}}
+	{{- spacer -}}
+{{- end -}}
+
 {{- if .HasOutput -}}
 
-	{{- header 4 "Output" -}}
+	{{- header 4 (heading "Output") -}}
 	{{- spacer -}}
 
 	{{- codeBlock "" .Output -}}
@@ -38,13 +176,46 @@ var templates = map[string]string{
 {{- accordionTerminator -}}
 
 `,
-	"file": `<!-- Code generated by gomarkdoc. DO NOT EDIT -->
+	"fields": `{{- $tags := false -}}
+{{- range .Fields -}}
+	{{- if .Tag -}}{{- $tags = true -}}{{- end -}}
+{{- end -}}
+{{- range .PromotedFields -}}
+	{{- if .Tag -}}{{- $tags = true -}}{{- end -}}
+{{- end -}}
+
+{{- if $tags -}}
+	| Field | Type | Tag | Description |{{- inlineSpacer -}}
+	| --- | --- | --- | --- |
+{{- else -}}
+	| Field | Type | Description |{{- inlineSpacer -}}
+	| --- | --- | --- |
+{{- end -}}
+
+{{- range .Fields -}}
+	{{- inlineSpacer -}}
+	| {{ tableCell (escape .Name) }} | {{ tableCell (escape .Type) }} |
+	{{- if $tags }} {{ tableCell (escape .Tag) }} |{{- end }} {{ tableCell (include "doc" .Doc) }} |
+{{- end -}}
+
+{{- range .PromotedFields -}}
+	{{- inlineSpacer -}}
+	| {{ tableCell (escape .Name) }} | {{ tableCell (escape .Type) }} |
+	{{- if $tags }} {{ tableCell (escape .Tag) }} |{{- end }} {{ tableCell (printf "%s %s" (printf "(promoted from %s)" .EmbeddedFrom | escape) (include "doc" .Doc)) }} |
+{{- end -}}`,
+	"file": `{{with .FrontMatter}}{{with .Render}}{{.}}
+{{end}}{{end}}{{comment "Code generated by gomarkdoc. DO NOT EDIT"}}
 
 {{if .Header -}}
 	{{- .Header -}}
 	{{- spacer -}}
 {{- end -}}
 
+{{- if .TableOfContents -}}
+	{{- template "toc" . -}}
+	{{- spacer -}}
+{{- end -}}
+
 {{- range .Packages -}}
 	{{- template "package" . -}}
 	{{- spacer -}}
@@ -56,19 +227,54 @@ var templates = map[string]string{
 {{- end -}}
 
 Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
-`,
-	"func": `{{- if .Receiver -}}
-	{{- rawAnchorHeader .Level (codeHref .Location | link (escape .Name) | printf "func \\(%s\\) %s" (escape .Receiver)) .Anchor -}}
+{{with .Metadata}}
+{{range .Entries}}{{comment .}}
+{{end}}{{end}}`,
+	"func": `{{- if .CustomTitle -}}
+	{{- rawAnchorHeader .Level (codeHref .Location | link (escape .CustomTitle)) .Anchor -}}
+{{- else if .Receiver -}}
+	{{- rawAnchorHeader .Level (printf "func %s %s%s" (printf "(%s)" .Receiver | escape) (codeHref .Location | link (escape .Name)) (escape .TypeParams)) .Anchor -}}
 {{- else -}}
-	{{- rawAnchorHeader .Level (codeHref .Location | link (escape .Name) | printf "func %s") .Anchor -}}
+	{{- rawAnchorHeader .Level (printf "func %s%s" (codeHref .Location | link (escape .Name)) (escape .TypeParams)) .Anchor -}}
 {{- end -}}
 {{- spacer -}}
 
+{{- if len .Badges -}}
+	{{- template "badges" . -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if .IsDeprecated -}}
+	{{- bold (printf "Deprecated: %s" .DeprecationNotice) -}}
+	{{- spacer -}}
+{{- end -}}
+
 {{- codeBlock "go" .Signature -}}
 {{- spacer -}}
 
+{{- if len .TypeLinks -}}
+	{{- template "typelinks" .TypeLinks -}}
+	{{- spacer -}}
+{{- end -}}
+
 {{- template "doc" .Doc -}}
 
+{{- with .InheritedFrom -}}
+	{{- spacer -}}
+	{{- escape (printf "Documentation inherited from %s." .) -}}
+{{- end -}}
+
+{{- with .Body -}}
+	{{- spacer -}}
+	{{- accordionHeader (heading "Implementation") -}}
+	{{- spacer -}}
+
+	{{- codeBlock "go" . -}}
+	{{- spacer -}}
+
+	{{- accordionTerminator -}}
+{{- end -}}
+
 {{- if len .Examples -}}
 	{{- spacer -}}
 
@@ -78,18 +284,110 @@ Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
 	{{- end -}}
 {{- end -}}
 
+`,
+	"fuzzing": `{{- header (add .Level 1) (heading "Fuzzing") -}}
+{{- spacer -}}
+
+{{- range (iter .FuzzTargets) -}}
+	{{- template "fuzztarget" .Entry -}}
+	{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+{{- end -}}
+`,
+	"fuzztarget": `{{- rawHeader .Level (codeHref .Location | link (escape .Name)) -}}
+{{- spacer -}}
+
+{{- if len .Doc.Blocks -}}
+	{{- template "doc" .Doc -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- escape (printf "Seed inputs: %d" .Seeds) | listEntry 0 -}}
+{{- inlineSpacer -}}
+{{- if .Corpus -}}
+	{{- escape (printf "Seed corpus files in %s: %d" .Corpus .CorpusSize) | listEntry 0 -}}
+{{- else -}}
+	{{- escape "Seed corpus files: 0" | listEntry 0 -}}
+{{- end -}}
+`,
+	"gettingstarted": `{{comment "Code generated by gomarkdoc. DO NOT EDIT"}}
+
+{{header 1 (heading "Getting Started") -}}
+
+{{- range .Steps -}}
+	{{- spacer -}}
+	{{- header 2 (printf "%d. %s" .Number .Title) -}}
+	{{- spacer -}}
+
+	{{- bold (escape .Package.ImportPath) -}}
+	{{- spacer -}}
+
+	{{- with .Example -}}
+		{{- if .Summary -}}
+			{{- template "doc" .Doc -}}
+			{{- spacer -}}
+		{{- end -}}
+
+		{{- codeBlock "go" .Code -}}
+
+		{{- if .HasOutput -}}
+			{{- spacer -}}
+			{{- header 3 (heading "Output") -}}
+			{{- spacer -}}
+
+			{{- codeBlock "" .Output -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- spacer -}}
+Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
+`,
+	"implementers": `Implemented by: {{ range (iter .) -}}
+	{{- if .Entry.URL -}}
+		{{- link .Entry.Text .Entry.URL -}}
+	{{- else -}}
+		{{- escape .Entry.Text -}}
+	{{- end -}}
+	{{- if (not .Last) -}}, {{ end -}}
+{{- end -}}
+`,
+	"implements": `Implements: {{ range (iter .) -}}
+	{{- if .Entry.URL -}}
+		{{- link .Entry.Text .Entry.URL -}}
+	{{- else -}}
+		{{- escape .Entry.Text -}}
+	{{- end -}}
+	{{- if (not .Last) -}}, {{ end -}}
+{{- end -}}
 `,
 	"import": `{{- codeBlock "go" .Import -}}`,
+	"importers": `{{- header (add .Level 1) (heading "Imported By") -}}
+{{- spacer -}}
+
+{{- range (iter .Importers) -}}
+	{{- if .Entry.URL -}}
+		{{- link .Entry.Text .Entry.URL | listEntry 0 -}}
+	{{- else -}}
+		{{- escape .Entry.Text | listEntry 0 -}}
+	{{- end -}}
+	{{- if (not .Last) -}}{{- inlineSpacer -}}{{- end -}}
+{{- end -}}
+`,
+	"importgraph": `{{- header 2 (heading "Import Graph") -}}
+{{- spacer -}}
+
+{{- codeBlock (print .Format) .Source -}}
+`,
 	"index": `{{- if len .Consts -}}
 
-	{{- localHref "Constants" | link "Constants" | listEntry 0 -}}
+	{{- localHref (heading "Constants") | link (heading "Constants") | listEntry 0 -}}
 	{{- inlineSpacer -}}
 	
 {{- end -}}
 
 {{- if len .Vars -}}
 
-	{{- localHref "Variables" | link "Variables" | listEntry 0 -}}
+	{{- localHref (heading "Variables") | link (heading "Variables") | listEntry 0 -}}
 	{{- inlineSpacer -}}
 
 {{- end -}}
@@ -116,8 +414,29 @@ Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
 		{{- inlineSpacer -}}
 	{{- end -}}
 
+	{{- range .IndexFields -}}
+		{{- (link .Title (rawLocalHref .Anchor)) | listEntry 1 -}}
+		{{- inlineSpacer -}}
+	{{- end -}}
+
+{{- end -}}
+
+{{- range .Routes -}}
+
+	{{- link .Text .URL | listEntry 0 -}}
+	{{- inlineSpacer -}}
+
 {{- end -}}
 `,
+	"interface": `{{- range (iter .) -}}
+	{{- if len .Entry.Doc.Blocks -}}
+		{{- listEntry 0 (hangingIndent (printf "%s%s%s" (bold .Entry.Signature) spacer (include "doc" .Entry.Doc)) 2) -}}
+	{{- else -}}
+		{{- listEntry 0 (bold .Entry.Signature) -}}
+	{{- end -}}
+
+	{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+{{- end -}}`,
 	"list": `{{- range (iter .Items) -}}
     {{- if eq .Entry.Kind "ordered" -}}
         {{- .Entry.Number -}}. {{ hangingIndent (include "doc" .Entry) 2 -}}
@@ -141,10 +460,27 @@ Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
 {{- end -}}
 {{- spacer -}}
 
-{{- template "import" . -}}
-{{- spacer -}}
+{{- if and (not .Section) .InternalWarning -}}
+	{{- bold .InternalWarning -}}
+	{{- spacer -}}
+{{- end -}}
 
-{{- if len .Doc.Blocks -}}
+{{- if and (not .Section) (len .Badges) -}}
+	{{- template "badges" . -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if and (not .Section) .IsDeprecated -}}
+	{{- bold (printf "Deprecated: %s" .DeprecationNotice) -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if and (not .Section) .Import -}}
+	{{- template "import" . -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if and (not .Section) (len .Doc.Blocks) -}}
 	{{- template "doc" .Doc -}}
 	{{- spacer -}}
 {{- end -}}
@@ -154,15 +490,23 @@ Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
 	{{- spacer -}}
 {{- end -}}
 
-{{- header (add .Level 1) "Index" -}}
-{{- spacer -}}
+{{- if ne .Section "examples" -}}
+	{{- header (add .Level 1) (heading "Index") -}}
+	{{- spacer -}}
 
-{{- template "index" . -}}
+	{{- template "index" . -}}
+{{- end -}}
+
+{{- if and (not .Section) .ClassDiagram -}}
+	{{- spacer -}}
+
+	{{- template "classdiagram" . -}}
+{{- end -}}
 
 {{- if len .Consts -}}
 	{{- spacer -}}
 
-	{{- header (add .Level 1) "Constants" -}}
+	{{- header (add .Level 1) (heading "Constants") -}}
 	{{- spacer -}}
 
 	{{- range (iter .Consts) -}}
@@ -175,7 +519,7 @@ Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
 {{- if len .Vars -}}
 	{{- spacer -}}
 
-	{{- header (add .Level 1) "Variables" -}}
+	{{- header (add .Level 1) (heading "Variables") -}}
 	{{- spacer -}}
 
 	{{- range (iter .Vars) -}}
@@ -202,6 +546,139 @@ Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
 		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
 	{{- end -}}
 {{- end -}}
+
+{{- range .ExampleGroups -}}
+	{{- spacer -}}
+
+	{{- header .Level .Title -}}
+	{{- spacer -}}
+
+	{{- range (iter .Examples) -}}
+		{{- template "example" .Entry -}}
+		{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- if and (not .Section) (or (len .CDecls) (len .CExports)) -}}
+	{{- spacer -}}
+
+	{{- template "capi" . -}}
+{{- end -}}
+
+{{- if and (not .Section) (or (len .BuildVars) (len .BuildDependencies)) -}}
+	{{- spacer -}}
+
+	{{- template "buildinfo" . -}}
+{{- end -}}
+
+{{- if and (not .Section) (len .Importers) -}}
+	{{- spacer -}}
+
+	{{- template "importers" . -}}
+{{- end -}}
+
+{{- if and (not .Section) (len .Benchmarks) -}}
+	{{- spacer -}}
+
+	{{- template "benchmarks" . -}}
+{{- end -}}
+
+{{- if and (not .Section) (len .FuzzTargets) -}}
+	{{- spacer -}}
+
+	{{- template "fuzzing" . -}}
+{{- end -}}
+`,
+	"packages": `{{comment "Code generated by gomarkdoc. DO NOT EDIT"}}
+
+{{header 1 (heading "Packages") -}}
+{{- spacer -}}
+
+{{- template "packagetree" .Entries -}}
+{{- spacer -}}
+
+{{- if .ImportGraph -}}
+	{{- template "importgraph" .ImportGraph -}}
+	{{- spacer -}}
+{{- end -}}
+
+Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
+`,
+	"packagetree": `{{- range (iter .) -}}
+	{{- with .Entry -}}
+		{{- $name := escape .Name -}}
+		{{- if .Href -}}{{- $name = link .Name .Href -}}{{- end -}}
+
+		{{- if .Summary -}}
+			{{- printf "%s: %s" $name (escape .Summary) | listEntry .Depth -}}
+		{{- else -}}
+			{{- listEntry .Depth $name -}}
+		{{- end -}}
+
+		{{- if len .Children -}}
+			{{- inlineSpacer -}}
+			{{- template "packagetree" .Children -}}
+		{{- end -}}
+	{{- end -}}
+	{{- if (not .Last) -}}{{- inlineSpacer -}}{{- end -}}
+{{- end -}}`,
+	"promotedmethods": `{{- range (iter .) -}}
+	{{- $entry := printf "%s %s" (bold .Entry.Signature) (escape (printf "(promoted from %s)" .Entry.EmbeddedFrom)) -}}
+	{{- if len .Entry.Doc.Blocks -}}
+		{{- listEntry 0 (hangingIndent (printf "%s%s%s" $entry spacer (include "doc" .Entry.Doc)) 2) -}}
+	{{- else -}}
+		{{- listEntry 0 $entry -}}
+	{{- end -}}
+
+	{{- if (not .Last) -}}{{- spacer -}}{{- end -}}
+{{- end -}}
+`,
+	"stats": `{{comment "Code generated by gomarkdoc. DO NOT EDIT"}}
+
+{{header 1 (heading "Documentation Statistics") -}}
+{{- spacer -}}
+
+{{- printf "Packages: %d" .PackageCount | listEntry 0 -}}
+{{- inlineSpacer -}}
+{{- printf "Constants: %d" .Consts | listEntry 0 -}}
+{{- inlineSpacer -}}
+{{- printf "Variables: %d" .Vars | listEntry 0 -}}
+{{- inlineSpacer -}}
+{{- printf "Functions: %d" .Funcs | listEntry 0 -}}
+{{- inlineSpacer -}}
+{{- printf "Types: %d" .Types | listEntry 0 -}}
+{{- inlineSpacer -}}
+{{- printf "Methods: %d" .Methods | listEntry 0 -}}
+{{- inlineSpacer -}}
+{{- printf "Documentation coverage: %d%% (%d of %d symbols)" .Coverage .Documented .Total | listEntry 0 -}}
+{{- spacer -}}
+
+{{- header 2 (heading "Coverage by Package") -}}
+{{- spacer -}}
+
+{{- range (iter .Packages) -}}
+	{{- printf "%s: %d%% (%d of %d symbols)" .Entry.ImportPath .Entry.Coverage .Entry.Documented .Entry.Total | escape | listEntry 0 -}}
+	{{- if (not .Last) -}}{{- inlineSpacer -}}{{- end -}}
+{{- end -}}
+
+{{- with (.LargestUndocumented 10) -}}
+	{{- spacer -}}
+
+	{{- header 2 (heading "Largest Undocumented Surfaces") -}}
+	{{- spacer -}}
+
+	{{- range (iter .) -}}
+		{{- printf "%s: %d undocumented" .Entry.ImportPath (len .Entry.Undocumented) | escape | listEntry 0 -}}
+		{{- range .Entry.Undocumented -}}
+			{{- inlineSpacer -}}
+			{{- escape . | listEntry 1 -}}
+		{{- end -}}
+		{{- if (not .Last) -}}{{- inlineSpacer -}}{{- end -}}
+	{{- end -}}
+{{- end -}}
+
+{{- spacer -}}
+Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
 `,
 	"text": `{{- range . -}}
 	{{- if eq .Kind "text" -}}
@@ -211,17 +688,131 @@ Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
 	{{- else if eq .Kind "autolink" -}}
 		{{- .Text -}}
 	{{- else if eq .Kind "link" -}}
-		{{- link (escape .Text) .URL -}}
+		{{- link .Text .URL -}}
 	{{- end -}}
 {{- end -}}`,
-	"type": `{{- rawAnchorHeader .Level (codeHref .Location | link (escape .Name) | printf "type %s") .Anchor -}}
+	"toc": `{{- bold (heading "Contents") -}}
 {{- spacer -}}
 
+{{- $nested := gt (len .Packages) 1 -}}
+{{- range .Packages -}}
+	{{- $depth := 0 -}}
+	{{- if $nested -}}
+		{{- $name := .Name -}}
+		{{- if eq .Name "main" -}}{{- $name = .Dirname -}}{{- end -}}
+		{{- localHref $name | link $name | listEntry 0 -}}
+		{{- inlineSpacer -}}
+		{{- $depth = 1 -}}
+	{{- end -}}
+
+	{{- range .Consts -}}
+		{{- link .Title (rawLocalHref .Anchor) | listEntry $depth -}}
+		{{- inlineSpacer -}}
+	{{- end -}}
+
+	{{- range .Vars -}}
+		{{- link .Title (rawLocalHref .Anchor) | listEntry $depth -}}
+		{{- inlineSpacer -}}
+	{{- end -}}
+
+	{{- range .Funcs -}}
+		{{- link .Title (rawLocalHref .Anchor) | listEntry $depth -}}
+		{{- inlineSpacer -}}
+	{{- end -}}
+
+	{{- range .Types -}}
+		{{- link .Title (rawLocalHref .Anchor) | listEntry $depth -}}
+		{{- inlineSpacer -}}
+
+		{{- range .Consts -}}
+			{{- link .Title (rawLocalHref .Anchor) | listEntry (add $depth 1) -}}
+			{{- inlineSpacer -}}
+		{{- end -}}
+
+		{{- range .Vars -}}
+			{{- link .Title (rawLocalHref .Anchor) | listEntry (add $depth 1) -}}
+			{{- inlineSpacer -}}
+		{{- end -}}
+
+		{{- range .Funcs -}}
+			{{- link .Title (rawLocalHref .Anchor) | listEntry (add $depth 1) -}}
+			{{- inlineSpacer -}}
+		{{- end -}}
+
+		{{- range .Methods -}}
+			{{- link .Title (rawLocalHref .Anchor) | listEntry (add $depth 1) -}}
+			{{- inlineSpacer -}}
+		{{- end -}}
+
+		{{- range .IndexFields -}}
+			{{- link .Title (rawLocalHref .Anchor) | listEntry (add $depth 1) -}}
+			{{- inlineSpacer -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}`,
+	"type": `{{- if .CustomTitle -}}
+	{{- rawAnchorHeader .Level (codeHref .Location | link (escape .CustomTitle)) .Anchor -}}
+{{- else -}}
+	{{- rawAnchorHeader .Level (printf "type %s%s" (codeHref .Location | link (escape .Name)) (escape .TypeParams)) .Anchor -}}
+{{- end -}}
+{{- spacer -}}
+
+{{- if len .Badges -}}
+	{{- template "badges" . -}}
+	{{- spacer -}}
+{{- end -}}
+
+{{- if .IsDeprecated -}}
+	{{- bold (printf "Deprecated: %s" .DeprecationNotice) -}}
+	{{- spacer -}}
+{{- end -}}
+
 {{- template "doc" .Doc -}}
 {{- spacer -}}
 
 {{- codeBlock "go" .Decl -}}
 
+{{- if len .TypeLinks -}}
+	{{- spacer -}}
+
+	{{- template "typelinks" .TypeLinks -}}
+{{- end -}}
+
+{{- if len .TypeSet -}}
+	{{- spacer -}}
+
+	{{- template "typeset" .TypeSet -}}
+{{- end -}}
+
+{{- if len .InterfaceMethods -}}
+	{{- spacer -}}
+
+	{{- template "interface" .InterfaceMethods -}}
+{{- end -}}
+
+{{- if len .Implementers -}}
+	{{- spacer -}}
+
+	{{- template "implementers" .Implementers -}}
+{{- end -}}
+
+{{- if len .Implements -}}
+	{{- spacer -}}
+
+	{{- template "implements" .Implements -}}
+{{- end -}}
+
+{{- $documentedFields := false -}}
+{{- range .Fields -}}
+	{{- if .Summary -}}{{- $documentedFields = true -}}{{- end -}}
+{{- end -}}
+
+{{- if or $documentedFields (len .PromotedFields) -}}
+	{{- spacer -}}
+
+	{{- template "fields" . -}}
+{{- end -}}
+
 {{- if len .Consts -}}
 	{{- spacer -}}
 
@@ -267,12 +858,59 @@ Generated by {{link "gomarkdoc" "https://github.com/princjef/gomarkdoc"}}
 	{{- end -}}
 {{- end -}}
 
+{{- if len .PromotedMethods -}}
+	{{- spacer -}}
+
+	{{- template "promotedmethods" .PromotedMethods -}}
+{{- end -}}
+
 `,
+	"typelinks": `Uses: {{ range (iter .) -}}
+	{{- link .Entry.Text .Entry.URL -}}
+	{{- if (not .Last) -}}, {{ end -}}
+{{- end -}}`,
+	"typeset": `{{- if gt (len .) 1 -}}
+	{{- "Type set, satisfying each of:" -}}
+{{- else -}}
+	{{- "Type set:" -}}
+{{- end -}}
+{{- spacer -}}
+
+{{- $nested := gt (len .) 1 -}}
+{{- range (iter .) -}}
+	{{- $depth := 0 -}}
+	{{- if and $nested (gt (len .Entry.Terms) 1) -}}
+		{{- listEntry 0 "one of:" -}}
+		{{- inlineSpacer -}}
+		{{- $depth = 1 -}}
+	{{- end -}}
+
+	{{- range (iter .Entry.Terms) -}}
+		{{- with .Entry -}}
+			{{- $text := escape .Text -}}
+			{{- if .URL -}}{{- $text = link .Text .URL -}}{{- end -}}
+			{{- if .Tilde -}}{{- $text = printf "%s%s" (escape "~") $text -}}{{- end -}}
+			{{- listEntry $depth $text -}}
+		{{- end -}}
+		{{- if (not .Last) -}}{{- inlineSpacer -}}{{- end -}}
+	{{- end -}}
+
+	{{- if (not .Last) -}}{{- inlineSpacer -}}{{- end -}}
+{{- end -}}`,
 	"value": `{{- anchor .Anchor -}}
+{{- if len .Badges -}}
+	{{- template "badges" . -}}
+	{{- spacer -}}
+{{- end -}}
 {{- template "doc" .Doc -}}
 {{- spacer -}}
 
 {{- codeBlock "go" .Decl -}}
 
+{{- if len .ConstValues -}}
+	{{- spacer -}}
+	{{- template "constvalues" . -}}
+{{- end -}}
+
 `,
 }
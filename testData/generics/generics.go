@@ -13,7 +13,23 @@ func NewGeneric[T any](param T) Generic[T] {
 // Method is a method of a generic type.
 func (g Generic[T]) Method() {}
 
+// Set is a method of a generic type with a pointer receiver. It complements
+// [Generic.Method].
+func (g *Generic[T]) Set(v T) {
+	g.Field = v
+}
+
 // Func is a generic function.
 func Func[S int | float64](s S) S {
 	return s
 }
+
+// Map applies f to each element of s, producing a slice of the results.
+func Map[T, U any](s []T, f func(T) U) []U {
+	res := make([]U, len(s))
+	for i, v := range s {
+		res[i] = f(v)
+	}
+
+	return res
+}
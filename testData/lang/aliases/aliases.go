@@ -0,0 +1,32 @@
+// Package aliases holds symbols with internal names used to exercise symbol
+// aliasing.
+package aliases
+
+// clientImpl sends requests. Use newClientImpl to create a clientImpl.
+type clientImpl struct {
+	// Endpoint holds the address requests are sent to.
+	Endpoint string
+
+	// Secret holds the credentials of the client.
+	Secret string
+}
+
+// newClientImpl creates a clientImpl for the endpoint.
+func newClientImpl(endpoint string) *clientImpl {
+	return &clientImpl{Endpoint: endpoint}
+}
+
+// doRequest sends a request. See clientImpl.doRequest for details.
+func (c *clientImpl) doRequest(body string) error { return nil }
+
+// Debug dumps the client's state.
+func (c *clientImpl) Debug() {}
+
+// Legacy is no longer part of the published API.
+func Legacy() {}
+
+// Exported values, one of which is hidden.
+const (
+	Visible = 1
+	Hidden  = 2
+)
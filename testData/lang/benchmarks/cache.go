@@ -0,0 +1,10 @@
+// Package benchmarks exercises the documentation of benchmarks.
+package benchmarks
+
+// Cache holds values by key.
+type Cache map[string]int
+
+// Get retrieves the value for the key.
+func (c Cache) Get(key string) int {
+	return c[key]
+}
@@ -0,0 +1,29 @@
+package benchmarks
+
+import "testing"
+
+// BenchmarkGet measures lookups of a key which is present.
+func BenchmarkGet(b *testing.B) {
+	c := Cache{"key": 1}
+
+	for i := 0; i < b.N; i++ {
+		// Lookups don't allocate
+		c.Get("key")
+	}
+}
+
+func BenchmarkGetMissing(b *testing.B) {
+	c := Cache{}
+	for i := 0; i < b.N; i++ {
+		c.Get("key")
+	}
+}
+
+// Benchmarked isn't a benchmark despite its name.
+func Benchmarked() {}
+
+func TestGet(t *testing.T) {
+	if (Cache{"key": 1}).Get("key") != 1 {
+		t.Fail()
+	}
+}
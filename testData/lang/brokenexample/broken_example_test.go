@@ -0,0 +1,7 @@
+//go:build brokenexample
+
+package brokenexample
+
+func ExampleGreet_renamed() {
+	_ = Welcome("gopher")
+}
@@ -0,0 +1,7 @@
+// Package brokenexample exercises the type checking of examples.
+package brokenexample
+
+// Greet greets the provided name.
+func Greet(name string) string {
+	return "Hello, " + name
+}
@@ -0,0 +1,7 @@
+package brokenexample
+
+import "strings"
+
+func ExampleGreet() {
+	_ = strings.ToUpper(Greet("gopher"))
+}
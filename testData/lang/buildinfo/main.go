@@ -0,0 +1,31 @@
+// Command buildinfo exercises the build configuration of main packages.
+package main
+
+import (
+	"fmt"
+
+	"github.com/anthonyme00/gomarkdoc/testData/simple"
+	"github.com/spf13/cobra"
+)
+
+// version is the released version of the command.
+var version = "dev"
+
+var (
+	// commit is the commit the command was built from.
+	commit string
+
+	// date is when the command was built.
+	date, builtBy = "", "unknown"
+
+	// count can't be set by the linker since it isn't a string.
+	count = 1
+
+	// started can't be set by the linker since it isn't a literal.
+	started = fmt.Sprint(count)
+)
+
+func main() {
+	cmd := &cobra.Command{Use: "buildinfo"}
+	fmt.Println(version, commit, date, builtBy, started, simple.Num(1), cmd.Use)
+}
@@ -0,0 +1,44 @@
+// Package cgo exposes a small API to C consumers.
+package cgo
+
+/*
+#include <stdint.h>
+
+// Point is a location on a two-dimensional grid.
+typedef struct {
+	int32_t x;
+	int32_t y;
+} Point;
+
+// distance computes the squared distance between two points.
+static inline int64_t distance(Point a, Point b) {
+	int64_t dx = a.x - b.x;
+	int64_t dy = a.y - b.y;
+	return dx*dx + dy*dy;
+}
+
+static int undocumented(void) { return 0; }
+*/
+import "C"
+
+// Add sums two integers.
+//
+// The result wraps on overflow.
+//
+//export Add
+func Add(a, b C.int) C.int {
+	return a + b
+}
+
+//export Origin
+func Origin() C.Point {
+	return C.Point{}
+}
+
+// Distance is only available from Go.
+func Distance(x1, y1, x2, y2 int32) int64 {
+	return int64(C.distance(
+		C.Point{x: C.int32_t(x1), y: C.int32_t(y1)},
+		C.Point{x: C.int32_t(x2), y: C.int32_t(y2)},
+	))
+}
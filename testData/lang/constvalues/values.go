@@ -0,0 +1,35 @@
+// Package constvalues has constants whose values are computed by the compiler.
+package constvalues
+
+import "time"
+
+// Weekday is a day of the week.
+type Weekday int
+
+// Days of the week.
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+)
+
+// Permissions which can be combined.
+const (
+	Read = 1 << iota
+	_
+	Execute
+)
+
+// Limits of the client.
+const (
+	MaxSize = 1 << 20
+	Timeout = time.Second * 30
+	Ratio   = 1.0 / 4
+)
+
+// Defaults of the client.
+const (
+	DefaultName = "client"
+	DefaultPort = 8080
+	MinOffset   = -1
+)
@@ -0,0 +1,4 @@
+// Package crosslinks exercises links to the documentation of packages which
+// are documented together, such as
+// [github.com/anthonyme00/gomarkdoc/testData/lang/doclinks.Client].
+package crosslinks
@@ -0,0 +1,32 @@
+// Package deprecated exercises the detection of deprecated symbols.
+//
+// Deprecated: Use the
+// replacement package instead.
+package deprecated
+
+// Client is a deprecated client.
+//
+// Deprecated: Use NewClient instead.
+type Client struct {
+	// Addr is the address of the server.
+	Addr string
+
+	// Timeout is the timeout in seconds.
+	//
+	// Deprecated: Use Deadline instead.
+	Timeout int
+}
+
+// Dial connects to the server.
+//
+// Deprecated: Use Connect instead.
+//
+// Dial will be removed in the next major version.
+func Dial(addr string) (*Client, error) {
+	return &Client{Addr: addr}, nil
+}
+
+// Connect connects to the server.
+func Connect(addr string) (*Client, error) {
+	return &Client{Addr: addr}, nil
+}
@@ -0,0 +1,9 @@
+// Package doclinks exercises the rendering of doc links such as [Client],
+// [*Client.Do_all] and [strings.Builder].
+package doclinks
+
+// Client is a client.
+type Client struct{}
+
+// Do_all does everything.
+func (c *Client) Do_all() {}
@@ -0,0 +1,45 @@
+// Package embedded exercises the flattening of fields promoted from embedded
+// structs.
+package embedded
+
+import "sync"
+
+// ServerConfig configures a server.
+type ServerConfig struct {
+	BaseConfig
+	sync.Mutex
+
+	// Port is the port the server listens on.
+	Port int
+
+	// Name overrides the name of the base configuration.
+	Name string
+}
+
+// BaseConfig holds the configuration shared by all components.
+type BaseConfig struct {
+	*tlsConfig
+
+	// Name is the name of the component.
+	Name string
+
+	Timeout int // Timeout is the timeout in seconds.
+
+	internal bool
+}
+
+type tlsConfig struct {
+	// CertFile is the path to the certificate.
+	CertFile string
+}
+
+// Options is a struct with tagged fields.
+type Options struct {
+	// Verbose enables verbose output. Output is written to stderr.
+	//
+	// It defaults to false.
+	Verbose bool `json:"verbose" yaml:"verbose"`
+
+	Tags    []string `json:"tags,omitempty"` // Tags holds a | separated list.
+	Retries int
+}
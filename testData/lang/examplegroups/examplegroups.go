@@ -0,0 +1,12 @@
+// Package examplegroups holds examples tagged with scenarios used to exercise
+// example grouping.
+package examplegroups
+
+// Client is a type with examples in several scenarios.
+type Client struct{}
+
+// Login authenticates the client.
+func (c *Client) Login(token string) {}
+
+// List lists a page of items.
+func (c *Client) List(page int) []string { return nil }
@@ -0,0 +1,29 @@
+package examplegroups_test
+
+import "github.com/anthonyme00/gomarkdoc/testData/lang/examplegroups"
+
+func ExampleClient() {
+	var c examplegroups.Client
+	c.Login("token")
+}
+
+// Logging in with a token.
+//
+//gomarkdoc:group authentication
+func ExampleClient_Login() {
+	var c examplegroups.Client
+	c.Login("token")
+}
+
+//gomarkdoc:group pagination
+func ExampleClient_List_nextPage() {
+	var c examplegroups.Client
+	c.List(2)
+}
+
+//gomarkdoc:group authentication
+func Example_sessionReuse() {
+	var c examplegroups.Client
+	c.Login("token")
+	c.List(1)
+}
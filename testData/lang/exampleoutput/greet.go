@@ -0,0 +1,10 @@
+package exampleoutput
+
+import "fmt"
+
+// Greet prints a greeting for each of the names.
+func Greet(names ...string) {
+	for _, name := range names {
+		fmt.Printf("Hello, %s!\n", name)
+	}
+}
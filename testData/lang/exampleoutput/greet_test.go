@@ -0,0 +1,14 @@
+package exampleoutput
+
+func ExampleGreet() {
+	// Greet a single person
+	Greet("Gopher")
+	// Output: Hello, Gopher!
+}
+
+func ExampleGreet_many() {
+	Greet("Alice", "Bob")
+	// Unordered output:
+	// Hello, Bob!
+	// Hello, Alice!
+}
@@ -0,0 +1,10 @@
+package examplerun
+
+import "fmt"
+
+// Count prints the numbers from 1 to n.
+func Count(n int) {
+	for i := 1; i <= n; i++ {
+		fmt.Println(i)
+	}
+}
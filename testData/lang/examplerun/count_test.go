@@ -0,0 +1,18 @@
+package examplerun_test
+
+import "github.com/anthonyme00/gomarkdoc/testData/lang/examplerun"
+
+func ExampleCount() {
+	examplerun.Count(2)
+	// Output:
+	// 1
+	// 2
+}
+
+func ExampleCount_missing() {
+	examplerun.Count(3)
+}
+
+func ExampleCount_silent() {
+	examplerun.Count(0)
+}
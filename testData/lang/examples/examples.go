@@ -0,0 +1,9 @@
+// Package examples holds symbols with examples used to exercise example
+// naming and ordering.
+package examples
+
+// Client is a type with several examples.
+type Client struct{}
+
+// Do performs a request.
+func (c *Client) Do() {}
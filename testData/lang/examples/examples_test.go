@@ -0,0 +1,19 @@
+package examples_test
+
+import "github.com/anthonyme00/gomarkdoc/testData/lang/examples"
+
+func ExampleClient() {
+	var c examples.Client
+	c.Do()
+}
+
+func ExampleClient_withRetry() {
+	var c examples.Client
+	c.Do()
+	c.Do()
+}
+
+func ExampleClient_usingHTTPProxy() {
+	var c examples.Client
+	c.Do()
+}
@@ -0,0 +1,28 @@
+// Package funcbody has small functions whose implementation is the
+// documentation.
+package funcbody
+
+// Clamp limits v to the range [lo, hi].
+//
+//gomarkdoc:body
+func Clamp(v, lo, hi int) int {
+	// Values below the range are raised first
+	if v < lo {
+		return lo
+	}
+
+	if v > hi {
+		return hi
+	}
+
+	return v
+}
+
+// Abs provides the absolute value of v.
+func Abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
@@ -0,0 +1,9 @@
+package fuzz
+
+import "strconv"
+
+// Parse parses a positive number.
+func Parse(s string) (int, bool) {
+	n, err := strconv.Atoi(s)
+	return n, err == nil && n > 0
+}
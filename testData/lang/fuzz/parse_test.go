@@ -0,0 +1,24 @@
+package fuzz
+
+import "testing"
+
+// FuzzParse checks that parsed numbers are positive.
+func FuzzParse(f *testing.F) {
+	f.Add("1")
+	f.Add("-1")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		if n, ok := Parse(s); ok && n <= 0 {
+			t.Errorf("parsed %d from %q", n, s)
+		}
+	})
+}
+
+func FuzzParseEmpty(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string) {
+		Parse(s)
+	})
+}
+
+// Fuzzy isn't a fuzz target despite its name.
+func Fuzzy() {}
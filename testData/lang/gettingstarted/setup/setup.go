@@ -0,0 +1,10 @@
+// Package setup creates clients.
+package setup
+
+// Client talks to the server.
+type Client struct{}
+
+// New creates a client.
+func New() *Client {
+	return &Client{}
+}
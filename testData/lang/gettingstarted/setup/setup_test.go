@@ -0,0 +1,22 @@
+package setup_test
+
+import (
+	"fmt"
+
+	"github.com/anthonyme00/gomarkdoc/testData/lang/gettingstarted/setup"
+)
+
+// Create a client.
+//
+//gomarkdoc:order 1
+func Example() {
+	c := setup.New()
+	fmt.Println(c != nil)
+	// Output: true
+}
+
+//gomarkdoc:order 3
+func Example_reuse() {
+	c := setup.New()
+	_ = c
+}
@@ -0,0 +1,7 @@
+// Package usage sends queries with clients.
+package usage
+
+// Query sends a query.
+func Query(q string) string {
+	return q
+}
@@ -0,0 +1,24 @@
+package usage_test
+
+import (
+	"fmt"
+
+	"github.com/anthonyme00/gomarkdoc/testData/lang/gettingstarted/usage"
+)
+
+// Send a query.
+//
+//gomarkdoc:order 2
+func Example_query() {
+	fmt.Println(usage.Query("hello"))
+	// Output: hello
+}
+
+func Example_unordered() {
+	usage.Query("ignored")
+}
+
+//gomarkdoc:order 2
+func ExampleQuery() {
+	usage.Query("symbol examples are left out")
+}
@@ -0,0 +1,45 @@
+// Package hidedeprecated exercises leaving deprecated symbols out of the
+// documentation.
+package hidedeprecated
+
+// Client is a deprecated client.
+//
+// Deprecated: Use Server instead.
+type Client struct{}
+
+// NewClient creates a client.
+func NewClient() *Client {
+	return &Client{}
+}
+
+// Close closes the client.
+func (c *Client) Close() error {
+	return nil
+}
+
+// Server serves requests.
+type Server struct{}
+
+// Start starts the server.
+func (s *Server) Start() error {
+	return nil
+}
+
+// Run runs the server.
+//
+// Deprecated: Use Start instead.
+func (s *Server) Run() error {
+	return nil
+}
+
+// Connect connects to the server.
+func Connect(addr string) (*Server, error) {
+	return &Server{}, nil
+}
+
+// Dial connects to the server.
+//
+// Deprecated: Use Connect instead.
+func Dial(addr string) (*Server, error) {
+	return &Server{}, nil
+}
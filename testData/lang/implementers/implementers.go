@@ -0,0 +1,42 @@
+// Package implementers has an interface implemented by types in several
+// packages.
+package implementers
+
+// Shape is a two dimensional shape.
+type Shape interface {
+	// Area provides the area of the shape.
+	Area() float64
+}
+
+// Any is implemented by every type.
+type Any interface{}
+
+// Square is a square.
+type Square struct {
+	Side float64
+}
+
+// Area provides the area of the square.
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+// Circle is a circle.
+type Circle struct {
+	Radius float64
+}
+
+// Area provides the area of the circle.
+func (c *Circle) Area() float64 {
+	return 3 * c.Radius * c.Radius
+}
+
+// Point isn't a shape.
+type Point struct {
+	X, Y float64
+}
+
+// Sides provides the number of sides of the square.
+func (s *Square) Sides() int {
+	return 4
+}
@@ -0,0 +1,26 @@
+// Package shapes has more shapes.
+package shapes
+
+// Triangle is a right triangle.
+type Triangle struct {
+	Base, Height float64
+}
+
+// Area provides the area of the triangle.
+func (t Triangle) Area() float64 {
+	return t.Base * t.Height / 2
+}
+
+// Polygon is a shape with straight sides.
+type Polygon interface {
+	// Area provides the area of the polygon.
+	Area() float64
+
+	// Sides provides the number of sides of the polygon.
+	Sides() int
+}
+
+// Sides provides the number of sides of the triangle.
+func (t Triangle) Sides() int {
+	return 3
+}
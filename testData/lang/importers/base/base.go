@@ -0,0 +1,5 @@
+// Package base is imported by the other packages.
+package base
+
+// Name is the name shared by the other packages.
+const Name = "base"
@@ -0,0 +1,7 @@
+// Package client imports base.
+package client
+
+import "github.com/anthonyme00/gomarkdoc/testData/lang/importers/base"
+
+// Name is the name of the client.
+const Name = base.Name + " client"
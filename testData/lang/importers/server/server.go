@@ -0,0 +1,10 @@
+// Package server imports base and client.
+package server
+
+import (
+	"github.com/anthonyme00/gomarkdoc/testData/lang/importers/base"
+	"github.com/anthonyme00/gomarkdoc/testData/lang/importers/client"
+)
+
+// Name is the name of the server, which talks to the client.
+const Name = base.Name + " server for " + client.Name
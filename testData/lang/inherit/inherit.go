@@ -0,0 +1,29 @@
+// Package inherit has wrappers inheriting their documentation.
+package inherit
+
+import "strings"
+
+// Builder builds strings.
+type Builder struct {
+	b strings.Builder
+}
+
+// Write appends the contents of p to the builder.
+func (b *Builder) Write(p []byte) (int, error) {
+	return b.b.Write(p)
+}
+
+//gomarkdoc:inherit Builder.Write
+func (b *Builder) Append(p []byte) (int, error) {
+	return b.Write(p)
+}
+
+// Len is a wrapper.
+//
+//gomarkdoc:inherit strings.Builder.Len
+func (b *Builder) Len() int {
+	return b.b.Len()
+}
+
+//gomarkdoc:inherit strings.Missing
+func Missing() {}
@@ -0,0 +1,18 @@
+// Package interfaces exercises the documentation of interface methods.
+package interfaces
+
+import "io"
+
+// Store is an interface with documented methods.
+type Store interface {
+	io.Closer
+
+	// Get retrieves the value for the key. It returns an error if there is
+	// no value for the key.
+	Get(key string) (string, error)
+
+	// Set stores the value for the key.
+	Set(key, value string) error
+
+	Len() int
+}
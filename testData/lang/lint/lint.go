@@ -0,0 +1,39 @@
+// This package exercises the checks of documentation comments, with comments
+// breaking each of the conventions.
+package lint
+
+// Client connects to the server. Use [Client.Dial] to connect and [Client.Send]
+// to send a message.
+type Client struct{}
+
+// A Message is sent to the server.
+type Message struct{}
+
+// opens a connection to the server.
+func (c *Client) Dial() {}
+
+// Close closes the connection.
+// Deprecated: Connections are closed automatically.
+func (c *Client) Close() {}
+
+// Connect creates a client.
+//
+// DEPRECATED: use [NewClient] instead.
+func Connect() *Client { return nil }
+
+// Timeout is the time to wait for a response.
+const Timeout = 10
+
+// Limits of the size of messages.
+const (
+	MinSize = 1
+	MaxSize = 100
+)
+
+// Retries holds the number of retries.
+var Retries = 3
+
+// Run runs the client.
+func Run() {}
+
+func Undocumented() {}
@@ -0,0 +1,9 @@
+package platform
+
+// MaxPath is the maximum length of a path.
+const MaxPath = 4096
+
+// Fd provides the file descriptor of the file.
+func (f *File) Fd() uintptr {
+	return 0
+}
@@ -0,0 +1,9 @@
+package platform
+
+// MaxPath is the maximum length of a path.
+const MaxPath = 260
+
+// Handle provides the handle of the file.
+func (f *File) Handle() uintptr {
+	return 0
+}
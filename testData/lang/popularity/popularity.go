@@ -0,0 +1,21 @@
+// Package popularity exercises the ordering of symbols by usage.
+package popularity
+
+// AbortPolicy is rarely used.
+type AbortPolicy int
+
+// Client is used often.
+type Client struct{}
+
+// NewClient creates a client.
+func NewClient() *Client {
+	return &Client{}
+}
+
+// Apply is rarely used.
+func Apply() {
+	Run(NewClient())
+}
+
+// Run is used often.
+func Run(c *Client) {}
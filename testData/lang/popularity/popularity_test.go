@@ -0,0 +1,12 @@
+package popularity_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/testData/lang/popularity"
+)
+
+func TestRun(t *testing.T) {
+	popularity.Run(popularity.NewClient())
+	popularity.Run(&popularity.Client{})
+}
@@ -0,0 +1,41 @@
+package promoted
+
+import "io"
+
+// Base holds the state shared by clients.
+type Base struct{}
+
+// Close releases the resources of the client.
+func (Base) Close() error { return nil }
+
+// Logger logs messages.
+type Logger struct{}
+
+// Log writes the message to the log.
+func (*Logger) Log(msg string) {}
+
+func (*Logger) flush() {}
+
+// Client sends requests.
+type Client struct {
+	Base
+	*Logger
+
+	Name string
+}
+
+// Do sends a request.
+func (c *Client) Do() {}
+
+// Service wraps a client.
+type Service struct {
+	Client
+}
+
+// ReadCloser reads data until it is closed.
+type ReadCloser interface {
+	io.Reader
+
+	// Close stops reading.
+	Close() error
+}
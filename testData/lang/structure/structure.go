@@ -0,0 +1,18 @@
+// Package structure exercises the block structure of doc comments.
+//
+// # Usage
+//
+// Steps:
+//  1. first step
+//  2. second step
+//
+// Points:
+//   - alpha
+//   - beta
+//
+// See https://example.com/docs for more.
+//
+// Old Style Heading
+//
+// Text.
+package structure
@@ -0,0 +1,37 @@
+// Package symbolfilter exercises the symbol include/exclude filters.
+package symbolfilter
+
+// DefaultTimeout is the default timeout of a Client in seconds.
+const DefaultTimeout = 30
+
+// Client sends requests.
+type Client struct{}
+
+// NewClient creates a Client.
+func NewClient() *Client {
+	return &Client{}
+}
+
+// Do sends a request.
+func (c *Client) Do() {}
+
+// Close releases the resources of the client.
+func (c *Client) Close() {}
+
+// Server handles requests.
+type Server struct{}
+
+// Serve handles requests until the server is closed.
+func (s *Server) Serve() {}
+
+// MockClient is a Client for tests.
+type MockClient struct{}
+
+// Do records the request.
+func (m *MockClient) Do() {}
+
+// MockRequest creates a request for tests.
+func MockRequest() {}
+
+// Ping checks the connection to the server.
+func Ping() {}
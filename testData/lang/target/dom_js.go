@@ -0,0 +1,4 @@
+package target
+
+// Alert shows a message in the browser.
+func Alert(msg string) {}
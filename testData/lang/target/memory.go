@@ -0,0 +1,11 @@
+//go:build js && wasm
+
+package target
+
+// PageSize is the size of a page of WebAssembly memory.
+const PageSize = 65536
+
+// Memory provides the size of the linear memory in pages.
+func Memory() int {
+	return 0
+}
@@ -0,0 +1,6 @@
+//go:build tinygo || baremetal
+
+package target
+
+// Pin identifies a GPIO pin on a microcontroller.
+type Pin uint8
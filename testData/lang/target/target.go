@@ -0,0 +1,6 @@
+// Package target contains symbols which are only available for some build
+// targets.
+package target
+
+// Everywhere is available for all build targets.
+func Everywhere() {}
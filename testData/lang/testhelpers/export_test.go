@@ -0,0 +1,13 @@
+package testhelpers
+
+// FakeStore is an in-memory Store for tests.
+type FakeStore map[string]string
+
+// Get retrieves the value stored for the key.
+func (s FakeStore) Get(key string) (string, bool) {
+	v, ok := s[key]
+	return v, ok
+}
+
+// DefaultKey is the key used by tests when none is provided.
+const DefaultKey = "key"
@@ -0,0 +1,18 @@
+package testhelpers_test
+
+import (
+	"testing"
+
+	"github.com/anthonyme00/gomarkdoc/testData/lang/testhelpers"
+)
+
+// NewFixture creates a store holding a value for the default key.
+func NewFixture() testhelpers.FakeStore {
+	return testhelpers.FakeStore{testhelpers.DefaultKey: "value"}
+}
+
+func TestFixture(t *testing.T) {
+	if testhelpers.Lookup(NewFixture(), testhelpers.DefaultKey) != "value" {
+		t.Fail()
+	}
+}
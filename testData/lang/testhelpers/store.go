@@ -0,0 +1,16 @@
+// Package testhelpers exercises the documentation of the helpers declared in
+// the _test.go files of a package.
+package testhelpers
+
+// Store persists values by key.
+type Store interface {
+	// Get retrieves the value stored for the key.
+	Get(key string) (string, bool)
+}
+
+// Lookup retrieves the value stored for the key, or the empty string if there
+// is none.
+func Lookup(s Store, key string) string {
+	v, _ := s.Get(key)
+	return v
+}
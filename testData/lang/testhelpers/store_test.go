@@ -0,0 +1,18 @@
+package testhelpers
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	if Lookup(FakeStore{DefaultKey: "value"}, DefaultKey) != "value" {
+		t.Fail()
+	}
+}
+
+func BenchmarkLookup(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Lookup(FakeStore{}, DefaultKey)
+	}
+}
+
+// Testify is a helper whose name only looks like a test.
+func Testify() {}
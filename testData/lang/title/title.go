@@ -0,0 +1,19 @@
+// Package title exercises titles overridden with a directive.
+package title
+
+// NewURL creates a URL.
+//
+//gomarkdoc:title New Uniform Resource Locator
+func NewURL() *URL {
+	return &URL{}
+}
+
+// URL is a uniform resource locator.
+//
+//gomarkdoc:title Uniform Resource Locator
+type URL struct{}
+
+// String formats the URL.
+func (u *URL) String() string {
+	return ""
+}
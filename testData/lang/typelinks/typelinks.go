@@ -0,0 +1,33 @@
+// Package typelinks exercises linking the types of other packages used in
+// signatures and declarations.
+package typelinks
+
+import (
+	"context"
+	stdio "io"
+	"net/http"
+)
+
+// Server serves requests.
+type Server struct {
+	// Handler handles the requests.
+	Handler http.Handler
+
+	// Log receives the log output of the server.
+	Log stdio.Writer
+}
+
+// Serve serves requests until the context is done.
+func (s *Server) Serve(ctx context.Context, h http.Handler) error {
+	return nil
+}
+
+// Copy copies from src to dst.
+func Copy(dst stdio.Writer, src stdio.Reader) (int64, error) {
+	return 0, nil
+}
+
+// Add adds two numbers.
+func Add(a, b int) int {
+	return a + b
+}
@@ -0,0 +1,27 @@
+// Package typeset exercises the documentation of the type sets of constraint
+// interfaces.
+package typeset
+
+import "time"
+
+// Celsius is a temperature in degrees Celsius.
+type Celsius float32
+
+// Number is satisfied by numeric types.
+type Number interface {
+	~int | ~int64 | ~float64 | Celsius
+}
+
+// Durable is satisfied by durations with a string representation.
+type Durable interface {
+	time.Duration | ~int32
+	comparable
+
+	// String describes the value.
+	String() string
+}
+
+// Stringer is a basic interface, so it has no type set to document.
+type Stringer interface {
+	String() string
+}
@@ -0,0 +1,5 @@
+// Package api is a module of the workspace.
+package api
+
+// Version is the version of the api.
+const Version = "v1"
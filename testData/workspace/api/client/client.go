@@ -0,0 +1,5 @@
+// Package client is a module nested within another module of the workspace.
+package client
+
+// Client calls the api.
+type Client struct{}
@@ -0,0 +1,3 @@
+module example.com/api/client
+
+go 1.18
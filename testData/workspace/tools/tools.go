@@ -0,0 +1,5 @@
+// Package tools is another module of the workspace.
+package tools
+
+// Run runs the tools.
+func Run() {}